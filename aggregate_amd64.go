@@ -6,6 +6,10 @@ package syndrdbsimd
 // Processes 4 int64 values per iteration (256-bit AVX2 register).
 func sumInt64AVX2(values *int64, length int) int64
 
+// sumInt64AVX512 computes sum using AVX-512 SIMD instructions.
+// Processes 16 int64 values per iteration across two 512-bit accumulators.
+func sumInt64AVX512(values *int64, length int) int64
+
 // minInt64AVX2 finds minimum using AVX2 SIMD instructions.
 // Processes 4 int64 values per iteration.
 func minInt64AVX2(values *int64, length int) int64
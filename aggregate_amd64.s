@@ -22,6 +22,7 @@ TEXT ·sumInt64AVX2(SB), NOSPLIT, $0-24
 	
 	// Initialize accumulator: 4 int64 sums in YMM0
 	VPXOR   Y0, Y0, Y0            // Y0 = [0, 0, 0, 0]
+	XORQ    R8, R8                // R8 = scalar sum for remainder (stays 0 if none)
 	
 	// Calculate number of full 4-element chunks
 	MOVQ    CX, DX
@@ -45,8 +46,6 @@ remainder:
 	ANDQ     $3, DX                // DX = length % 4
 	JZ       horizontal_sum        // No remainder
 	
-	XORQ     R8, R8                // R8 = scalar sum for remainder
-	
 remainder_loop:
 	ADDQ     0(SI), R8             // R8 += *SI
 	ADDQ     $8, SI
@@ -350,3 +349,55 @@ return_count:
 return_zero_count:
 	MOVQ     $0, ret+24(FP)
 	RET
+
+// func sumInt64AVX512(values *int64, length int) int64
+//
+// Computes the sum of int64 values using AVX-512.
+// Same algorithm as sumInt64AVX2 with 8 lanes per iteration. Two accumulators
+// are used so consecutive VPADDQs do not depend on each other.
+//
+TEXT ·sumInt64AVX512(SB), NOSPLIT, $0-24
+	MOVQ    values+0(FP), SI       // SI = pointer to values array
+	MOVQ    length+8(FP), CX       // CX = length
+	XORQ    AX, AX                 // AX = running scalar sum
+	
+	CMPQ    CX, $16
+	JL      sum512_remainder
+	
+	VPXORQ  Z0, Z0, Z0             // Z0, Z1 = 8-lane accumulators
+	VPXORQ  Z1, Z1, Z1
+	
+sum512_loop:
+	VPADDQ  0(SI), Z0, Z0          // Z0 += values[0:8]
+	VPADDQ  64(SI), Z1, Z1         // Z1 += values[8:16]
+	
+	ADDQ    $128, SI
+	SUBQ    $16, CX
+	CMPQ    CX, $16
+	JGE     sum512_loop
+	
+	// Horizontal sum: 2x512 -> 512 -> 256 -> 128 -> 64 bits
+	VPADDQ  Z1, Z0, Z0
+	VEXTRACTI64X4 $1, Z0, Y1
+	VPADDQ  Y1, Y0, Y0
+	VEXTRACTI128 $1, Y0, X1
+	VPADDQ  X1, X0, X0
+	VPSHUFD $0xEE, X0, X1
+	VPADDQ  X1, X0, X0
+	VMOVQ   X0, AX
+	VZEROUPPER
+	
+sum512_remainder:
+	// Handle remaining elements (0-15)
+	TESTQ   CX, CX
+	JZ      sum512_done
+	
+sum512_remainder_loop:
+	ADDQ    0(SI), AX
+	ADDQ    $8, SI
+	DECQ    CX
+	JNZ     sum512_remainder_loop
+	
+sum512_done:
+	MOVQ    AX, ret+16(FP)
+	RET
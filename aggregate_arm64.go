@@ -6,6 +6,10 @@ package syndrdbsimd
 // Processes 2 int64 values per iteration (128-bit NEON register).
 func sumInt64NEON(values *int64, length int) int64
 
+// sumInt64SVE computes sum using SVE predicated loops.
+// Processes one full vector per iteration with no scalar remainder.
+func sumInt64SVE(values *int64, length int) int64
+
 // minInt64NEON finds minimum using NEON SIMD instructions.
 // Processes 2 int64 values per iteration.
 func minInt64NEON(values *int64, length int) int64
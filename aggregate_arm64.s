@@ -282,3 +282,41 @@ return_count:
 return_zero_count:
 	MOVD    $0, ret+24(FP)
 	RET
+
+// ============================================================================
+// SVE kernels
+// ============================================================================
+//
+// SVE instructions are emitted as WORD directives because Go's ARM64 assembler
+// has no SVE mnemonics; see compare_arm64.s for the loop structure.
+
+// func sumInt64SVE(values *int64, length int) int64
+//
+// Accumulates per-lane sums and reduces them with UADDV at the end.
+// Inactive lanes load as zero, so the last partial vector needs no special case.
+// Overflow wraps, matching the scalar implementation.
+//
+TEXT ·sumInt64SVE(SB), NOSPLIT, $0-24
+    MOVD    values+0(FP), R0        // R0 = pointer to values array
+    MOVD    length+8(FP), R4        // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+    MOVD    $0, R5                  // R5 = result
+
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BEQ     sum_done
+    WORD    $0x25d8e3e1             // ptrue p1.d
+    WORD    $0x25f8c002             // mov z2.d, #0; Z2 = per-lane sums
+
+sum_loop:
+    WORD    $0xa5e34000             // ld1d {z0.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x04e00042             // add z2.d, z2.d, z0.d
+    WORD    $0x04f0e3e3             // incd x3
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     sum_loop
+
+    WORD    $0x04c12440             // uaddv d0, p1, z2.d; Horizontal sum of all lanes
+    FMOVD   F0, R5
+
+sum_done:
+    MOVD    R5, ret+16(FP)
+    RET
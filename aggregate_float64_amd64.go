@@ -0,0 +1,12 @@
+//go:build amd64
+
+package syndrdbsimd
+
+// AVX2 kernels for float64 aggregates.
+// See aggregate_float64_amd64.s for the NaN handling and length requirements.
+
+//go:noescape
+func minFloat64AVX2(values *float64, length int) float64
+
+//go:noescape
+func maxFloat64AVX2(values *float64, length int) float64
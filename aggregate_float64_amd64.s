@@ -0,0 +1,46 @@
+#include "textflag.h"
+
+// ============================================================================
+// Float64 min/max
+// ============================================================================
+
+// MINMAX_FLOAT64_AVX2 defines a kernel with the signature
+// func(values *float64, length int) float64
+// that reduces length values (a multiple of 8) with op, starting from identity.
+//
+// VMINPD and VMAXPD return their second source operand when either input is NaN.
+// The accumulators are passed as that operand, so NaN values are skipped and the
+// accumulators never hold NaN.
+#define MINMAX_FLOAT64_AVX2(name, op, identity) \
+TEXT name(SB), NOSPLIT, $0-24 \
+    MOVQ    values+0(FP), SI \
+    MOVQ    length+8(FP), CX \
+    MOVQ    identity, AX \
+    MOVQ    AX, X0 \
+    VBROADCASTSD X0, Y0                 /* Y0, Y1 = per-lane results */ \
+    VMOVAPD Y0, Y1 \
+    SHRQ    $3, CX \
+    JZ      reduce \
+loop: \
+    VMOVUPD (SI), Y2 \
+    VMOVUPD 32(SI), Y3 \
+    op      Y0, Y2, Y0                  /* Y0 = Y2 op Y0, keeping Y0 for NaN lanes */ \
+    op      Y1, Y3, Y1 \
+    ADDQ    $64, SI \
+    DECQ    CX \
+    JNZ     loop \
+reduce: \
+    op      Y1, Y0, Y0 \
+    VEXTRACTF128 $1, Y0, X1 \
+    op      X1, X0, X0 \
+    VPERMILPD $1, X0, X1                /* Swap the two remaining lanes */ \
+    op      X1, X0, X0 \
+    VZEROUPPER \
+    MOVSD   X0, ret+16(FP) \
+    RET
+
+// func minFloat64AVX2(values *float64, length int) float64
+MINMAX_FLOAT64_AVX2(·minFloat64AVX2, VMINPD, $0x7FF0000000000000)
+
+// func maxFloat64AVX2(values *float64, length int) float64
+MINMAX_FLOAT64_AVX2(·maxFloat64AVX2, VMAXPD, $0xFFF0000000000000)
@@ -0,0 +1,12 @@
+//go:build arm64
+
+package syndrdbsimd
+
+// NEON kernels for float64 aggregates.
+// See aggregate_float64_arm64.s for the NaN handling and length requirements.
+
+//go:noescape
+func minFloat64NEON(values *float64, length int) float64
+
+//go:noescape
+func maxFloat64NEON(values *float64, length int) float64
@@ -0,0 +1,49 @@
+#include "textflag.h"
+
+// Vector floating-point instructions (FMINNM/FMAXNM and their pairwise forms) are
+// emitted as WORD directives because older Go ARM64 assemblers have no mnemonics
+// for them. Each is annotated with its disassembly.
+
+// ============================================================================
+// Float64 min/max
+// ============================================================================
+
+// MINMAX_FLOAT64_NEON defines a kernel with the signature
+// func(values *float64, length int) float64
+// that reduces length values (a multiple of 4) into V16 and V17, starting from
+// identity. FMINNM and FMAXNM return the other operand when one input is a quiet
+// NaN, so NaN values are skipped and the accumulators never hold NaN.
+#define MINMAX_FLOAT64_NEON(name, identity, op1, op2, combine, across) \
+TEXT name(SB), NOSPLIT, $0-24 \
+    MOVD    values+0(FP), R0 \
+    MOVD    length+8(FP), R1 \
+    MOVD    identity, R4 \
+    VDUP    R4, V16.D2 \
+    VDUP    R4, V17.D2 \
+    LSR     $2, R1, R1 \
+    CBZ     R1, reduce \
+loop: \
+    VLD1.P  32(R0), [V1.D2, V2.D2] \
+    WORD    op1 \
+    WORD    op2 \
+    SUB     $1, R1, R1 \
+    CBNZ    R1, loop \
+reduce: \
+    WORD    combine \
+    WORD    across \
+    FMOVD   F0, ret+16(FP) \
+    RET
+
+// func minFloat64NEON(values *float64, length int) float64
+//   fminnm  v16.2d, v16.2d, v1.2d
+//   fminnm  v17.2d, v17.2d, v2.2d
+//   fminnm  v16.2d, v16.2d, v17.2d
+//   fminnmp d0, v16.2d
+MINMAX_FLOAT64_NEON(·minFloat64NEON, $0x7FF0000000000000, $0x4ee1c610, $0x4ee2c631, $0x4ef1c610, $0x7ef0ca00)
+
+// func maxFloat64NEON(values *float64, length int) float64
+//   fmaxnm  v16.2d, v16.2d, v1.2d
+//   fmaxnm  v17.2d, v17.2d, v2.2d
+//   fmaxnm  v16.2d, v16.2d, v17.2d
+//   fmaxnmp d0, v16.2d
+MINMAX_FLOAT64_NEON(·maxFloat64NEON, $0xFFF0000000000000, $0x4e61c610, $0x4e62c631, $0x4e71c610, $0x7e70ca00)
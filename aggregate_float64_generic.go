@@ -0,0 +1,27 @@
+package syndrdbsimd
+
+import "math"
+
+// minFloat64Generic finds the minimum float64 value using scalar operations.
+// NaN values are skipped. Returns +Inf if the slice is empty or all NaN.
+func minFloat64Generic(values []float64) float64 {
+	min := math.Inf(1)
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// maxFloat64Generic finds the maximum float64 value using scalar operations.
+// NaN values are skipped. Returns -Inf if the slice is empty or all NaN.
+func maxFloat64Generic(values []float64) float64 {
+	max := math.Inf(-1)
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
@@ -1,7 +1,7 @@
 // Package syndrdbsimd provides high-performance SIMD operations for SyndrDB.
 //
 // This package implements database-critical operations using SIMD instructions
-// (AVX2 on x86-64, NEON on ARM64) with automatic fallback to scalar implementations
+// (AVX2 and AVX-512 on x86-64, NEON and SVE on ARM64) with automatic fallback to scalar implementations
 // when SIMD is not available.
 //
 // Phase 1 includes:
@@ -13,182 +13,118 @@
 //   - AVX2: ~4x speedup on comparisons, ~4x on bitmap operations
 //   - NEON: ~2x speedup on comparisons, ~2x on bitmap operations
 //   - Automatic CPU feature detection and optimal implementation selection
+//   - SetISA and WithGlobalISA cap the selection for the whole process, and a
+//     Kernels value from NewKernels caps it for the calls made through it;
+//     ActiveKernels reports which implementation each operation uses
 package syndrdbsimd
 
 // CmpEqInt64 compares int64 values for equality against a threshold.
 // Returns a slice of booleans where result[i] == true if values[i] == threshold.
 //
 // This function automatically selects the best implementation:
+//   - AVX-512 on x86-64 processors that support it (8 elements per operation)
 //   - AVX2 on x86-64 processors (4 elements per operation)
+//   - SVE on ARM64 processors that support it (full hardware vector, no scalar tail)
 //   - NEON on ARM64 processors (2 elements per operation)
 //   - Scalar fallback on other architectures
 func CmpEqInt64(values []int64, threshold int64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	// TODO: I could add a heuristic to skip SIMD for very small arrays (< 8 elements)
-	// where the overhead might not be worth it
-
-	return cmpEqInt64Impl(values, threshold)
+	return Kernels{}.CmpEqInt64(values, threshold)
 }
 
 // CmpEqInt64Mask compares int64 values for equality and returns a bitmask.
 // Returns a slice of uint64 where bit i in result[j] is set if values[j*64+i] == threshold.
 // This is more memory-efficient than CmpEqInt64 for large datasets.
 func CmpEqInt64Mask(values []int64, threshold int64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpEqInt64MaskImpl(values, threshold)
+	return Kernels{}.CmpEqInt64Mask(values, threshold)
 }
 
 // CmpNeInt64 compares int64 values for inequality against a threshold.
 // Returns a slice of booleans where result[i] == true if values[i] != threshold.
 func CmpNeInt64(values []int64, threshold int64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpNeInt64Impl(values, threshold)
+	return Kernels{}.CmpNeInt64(values, threshold)
 }
 
 // CmpNeInt64Mask compares int64 values for inequality and returns a bitmask.
 func CmpNeInt64Mask(values []int64, threshold int64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpNeInt64MaskImpl(values, threshold)
+	return Kernels{}.CmpNeInt64Mask(values, threshold)
 }
 
 // CmpGtInt64 compares int64 values for greater-than against a threshold.
 // Returns a slice of booleans where result[i] == true if values[i] > threshold.
 func CmpGtInt64(values []int64, threshold int64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpGtInt64Impl(values, threshold)
+	return Kernels{}.CmpGtInt64(values, threshold)
 }
 
 // CmpGtInt64Mask compares int64 values for greater-than and returns a bitmask.
 func CmpGtInt64Mask(values []int64, threshold int64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpGtInt64MaskImpl(values, threshold)
+	return Kernels{}.CmpGtInt64Mask(values, threshold)
 }
 
 // CmpLtInt64 compares int64 values for less-than against a threshold.
 // Returns a slice of booleans where result[i] == true if values[i] < threshold.
 func CmpLtInt64(values []int64, threshold int64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpLtInt64Impl(values, threshold)
+	return Kernels{}.CmpLtInt64(values, threshold)
 }
 
 // CmpLtInt64Mask compares int64 values for less-than and returns a bitmask.
 func CmpLtInt64Mask(values []int64, threshold int64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpLtInt64MaskImpl(values, threshold)
+	return Kernels{}.CmpLtInt64Mask(values, threshold)
 }
 
 // CmpGeInt64 compares int64 values for greater-than-or-equal against a threshold.
 // Returns a slice of booleans where result[i] == true if values[i] >= threshold.
 func CmpGeInt64(values []int64, threshold int64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpGeInt64Impl(values, threshold)
+	return Kernels{}.CmpGeInt64(values, threshold)
 }
 
 // CmpGeInt64Mask compares int64 values for greater-than-or-equal and returns a bitmask.
 func CmpGeInt64Mask(values []int64, threshold int64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpGeInt64MaskImpl(values, threshold)
+	return Kernels{}.CmpGeInt64Mask(values, threshold)
 }
 
 // CmpLeInt64 compares int64 values for less-than-or-equal against a threshold.
 // Returns a slice of booleans where result[i] == true if values[i] <= threshold.
 func CmpLeInt64(values []int64, threshold int64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpLeInt64Impl(values, threshold)
+	return Kernels{}.CmpLeInt64(values, threshold)
 }
 
 // CmpLeInt64Mask compares int64 values for less-than-or-equal and returns a bitmask.
 func CmpLeInt64Mask(values []int64, threshold int64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpLeInt64MaskImpl(values, threshold)
+	return Kernels{}.CmpLeInt64Mask(values, threshold)
 }
 
 // AndBitmap performs bitwise AND on two uint64 bitmaps.
 // Returns a new bitmap where result[i] = a[i] & b[i].
-// Uses SIMD when available for ~4x (AVX2) or ~2x (NEON) speedup.
+// Uses SIMD when available for ~4x (AVX2) or ~2x (NEON) speedup; AVX-512 processes
+// 8 words per operation.
 func AndBitmap(a, b []uint64) []uint64 {
-	if len(a) == 0 || len(b) == 0 {
-		return []uint64{}
-	}
-
-	return andBitmapImpl(a, b)
+	return Kernels{}.AndBitmap(a, b)
 }
 
 // OrBitmap performs bitwise OR on two uint64 bitmaps.
 // Returns a new bitmap where result[i] = a[i] | b[i].
 func OrBitmap(a, b []uint64) []uint64 {
-	if len(a) == 0 || len(b) == 0 {
-		return []uint64{}
-	}
-
-	return orBitmapImpl(a, b)
+	return Kernels{}.OrBitmap(a, b)
 }
 
 // XorBitmap performs bitwise XOR on two uint64 bitmaps.
 // Returns a new bitmap where result[i] = a[i] ^ b[i].
 func XorBitmap(a, b []uint64) []uint64 {
-	if len(a) == 0 || len(b) == 0 {
-		return []uint64{}
-	}
-
-	return xorBitmapImpl(a, b)
+	return Kernels{}.XorBitmap(a, b)
 }
 
 // NotBitmap performs bitwise NOT on a uint64 bitmap.
 // Returns a new bitmap where result[i] = ^a[i].
 func NotBitmap(a []uint64) []uint64 {
-	if len(a) == 0 {
-		return []uint64{}
-	}
-
-	return notBitmapImpl(a)
+	return Kernels{}.NotBitmap(a)
 }
 
 // PopCount counts the number of set bits (1s) in a bitmap.
 // This is extremely useful for counting matching rows in database queries.
-// Uses SIMD when available for significant speedup.
+// Uses SIMD when available for significant speedup, including the vector
+// VPOPCNTQ instruction on CPUs with AVX512VPOPCNTDQ.
 func PopCount(bitmap []uint64) int {
-	if len(bitmap) == 0 {
-		return 0
-	}
-
-	return popCountImpl(bitmap)
+	return Kernels{}.PopCount(bitmap)
 }
 
 // BoolsToBitmask converts a boolean slice to a compact bitmask representation.
@@ -209,15 +145,13 @@ func BoolsToBitmask(bools []bool) []uint64 {
 // Infinity values are compared normally (e.g., +Inf > any finite number is true).
 //
 // This function automatically selects the best implementation:
+//   - AVX-512 on x86-64 processors that support it (8 elements per operation)
 //   - AVX2 on x86-64 processors (4 elements per operation)
+//   - SVE on ARM64 processors that support it (full hardware vector, no scalar tail)
 //   - NEON on ARM64 processors (2 elements per operation)
 //   - Scalar fallback on other architectures
 func CmpGtFloat64(values []float64, threshold float64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpGtFloat64Impl(values, threshold)
+	return Kernels{}.CmpGtFloat64(values, threshold)
 }
 
 // CmpGtFloat64Mask compares float64 values for greater-than and returns a bitmask.
@@ -226,11 +160,7 @@ func CmpGtFloat64(values []float64, threshold float64) []bool {
 //
 // NaN comparisons always return false per IEEE 754.
 func CmpGtFloat64Mask(values []float64, threshold float64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpGtFloat64MaskImpl(values, threshold)
+	return Kernels{}.CmpGtFloat64Mask(values, threshold)
 }
 
 // CmpGeFloat64 compares float64 values for greater-than-or-equal against a threshold.
@@ -238,20 +168,12 @@ func CmpGtFloat64Mask(values []float64, threshold float64) []uint64 {
 //
 // NaN comparisons always return false per IEEE 754.
 func CmpGeFloat64(values []float64, threshold float64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpGeFloat64Impl(values, threshold)
+	return Kernels{}.CmpGeFloat64(values, threshold)
 }
 
 // CmpGeFloat64Mask compares float64 values for greater-than-or-equal and returns a bitmask.
 func CmpGeFloat64Mask(values []float64, threshold float64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpGeFloat64MaskImpl(values, threshold)
+	return Kernels{}.CmpGeFloat64Mask(values, threshold)
 }
 
 // CmpLtFloat64 compares float64 values for less-than against a threshold.
@@ -259,20 +181,12 @@ func CmpGeFloat64Mask(values []float64, threshold float64) []uint64 {
 //
 // NaN comparisons always return false per IEEE 754.
 func CmpLtFloat64(values []float64, threshold float64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpLtFloat64Impl(values, threshold)
+	return Kernels{}.CmpLtFloat64(values, threshold)
 }
 
 // CmpLtFloat64Mask compares float64 values for less-than and returns a bitmask.
 func CmpLtFloat64Mask(values []float64, threshold float64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpLtFloat64MaskImpl(values, threshold)
+	return Kernels{}.CmpLtFloat64Mask(values, threshold)
 }
 
 // CmpLeFloat64 compares float64 values for less-than-or-equal against a threshold.
@@ -280,20 +194,12 @@ func CmpLtFloat64Mask(values []float64, threshold float64) []uint64 {
 //
 // NaN comparisons always return false per IEEE 754.
 func CmpLeFloat64(values []float64, threshold float64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpLeFloat64Impl(values, threshold)
+	return Kernels{}.CmpLeFloat64(values, threshold)
 }
 
 // CmpLeFloat64Mask compares float64 values for less-than-or-equal and returns a bitmask.
 func CmpLeFloat64Mask(values []float64, threshold float64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpLeFloat64MaskImpl(values, threshold)
+	return Kernels{}.CmpLeFloat64Mask(values, threshold)
 }
 
 // CmpEqFloat64 compares float64 values for equality against a threshold.
@@ -301,20 +207,12 @@ func CmpLeFloat64Mask(values []float64, threshold float64) []uint64 {
 //
 // NaN comparisons always return false per IEEE 754 (even NaN == NaN is false).
 func CmpEqFloat64(values []float64, threshold float64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpEqFloat64Impl(values, threshold)
+	return Kernels{}.CmpEqFloat64(values, threshold)
 }
 
 // CmpEqFloat64Mask compares float64 values for equality and returns a bitmask.
 func CmpEqFloat64Mask(values []float64, threshold float64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpEqFloat64MaskImpl(values, threshold)
+	return Kernels{}.CmpEqFloat64Mask(values, threshold)
 }
 
 // CmpNeFloat64 compares float64 values for inequality against a threshold.
@@ -322,20 +220,12 @@ func CmpEqFloat64Mask(values []float64, threshold float64) []uint64 {
 //
 // NaN != x returns true for all x per IEEE 754 (including NaN != NaN).
 func CmpNeFloat64(values []float64, threshold float64) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpNeFloat64Impl(values, threshold)
+	return Kernels{}.CmpNeFloat64(values, threshold)
 }
 
 // CmpNeFloat64Mask compares float64 values for inequality and returns a bitmask.
 func CmpNeFloat64Mask(values []float64, threshold float64) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	return cmpNeFloat64MaskImpl(values, threshold)
+	return Kernels{}.CmpNeFloat64Mask(values, threshold)
 }
 
 // BitmaskToBools converts a bitmask back to a boolean slice.
@@ -354,140 +244,128 @@ func BitmaskToBools(mask []uint64, length int) []bool {
 // Uses adaptive SIMD based on string count and average length. Configure thresholds
 // via SetStringSIMDThreshold.
 func CmpEqString(values []string, threshold string) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	byteValues := stringsToBytes(values)
-	byteThreshold := []byte(threshold)
-	return cmpEqStringImpl(byteValues, byteThreshold)
+	return Kernels{}.CmpEqString(values, threshold)
 }
 
 // CmpEqStringMask compares string values for equality and returns a bitmask.
 func CmpEqStringMask(values []string, threshold string) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	byteValues := stringsToBytes(values)
-	byteThreshold := []byte(threshold)
-	return cmpEqStringMaskImpl(byteValues, byteThreshold)
+	return Kernels{}.CmpEqStringMask(values, threshold)
 }
 
 // CmpNeString compares string values for inequality against a threshold.
 // Returns a slice of booleans where result[i] == true if values[i] != threshold.
 func CmpNeString(values []string, threshold string) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	byteValues := stringsToBytes(values)
-	byteThreshold := []byte(threshold)
-	return cmpNeStringImpl(byteValues, byteThreshold)
+	return Kernels{}.CmpNeString(values, threshold)
 }
 
 // CmpNeStringMask compares string values for inequality and returns a bitmask.
 func CmpNeStringMask(values []string, threshold string) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
+	return Kernels{}.CmpNeStringMask(values, threshold)
+}
 
-	byteValues := stringsToBytes(values)
-	byteThreshold := []byte(threshold)
-	return cmpNeStringMaskImpl(byteValues, byteThreshold)
+// CmpGtString compares string values lexicographically against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] > threshold,
+// ordering by bytes as Go's string comparison does.
+func CmpGtString(values []string, threshold string) []bool {
+	return Kernels{}.CmpGtString(values, threshold)
+}
+
+// CmpGtStringMask compares string values with > and returns a bitmask.
+func CmpGtStringMask(values []string, threshold string) []uint64 {
+	return Kernels{}.CmpGtStringMask(values, threshold)
+}
+
+// CmpGeString compares string values lexicographically against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] >= threshold,
+// ordering by bytes as Go's string comparison does.
+func CmpGeString(values []string, threshold string) []bool {
+	return Kernels{}.CmpGeString(values, threshold)
+}
+
+// CmpGeStringMask compares string values with >= and returns a bitmask.
+func CmpGeStringMask(values []string, threshold string) []uint64 {
+	return Kernels{}.CmpGeStringMask(values, threshold)
+}
+
+// CmpLtString compares string values lexicographically against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] < threshold,
+// ordering by bytes as Go's string comparison does.
+func CmpLtString(values []string, threshold string) []bool {
+	return Kernels{}.CmpLtString(values, threshold)
+}
+
+// CmpLtStringMask compares string values with < and returns a bitmask.
+func CmpLtStringMask(values []string, threshold string) []uint64 {
+	return Kernels{}.CmpLtStringMask(values, threshold)
+}
+
+// CmpLeString compares string values lexicographically against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] <= threshold,
+// ordering by bytes as Go's string comparison does.
+func CmpLeString(values []string, threshold string) []bool {
+	return Kernels{}.CmpLeString(values, threshold)
+}
+
+// CmpLeStringMask compares string values with <= and returns a bitmask.
+func CmpLeStringMask(values []string, threshold string) []uint64 {
+	return Kernels{}.CmpLeStringMask(values, threshold)
+}
+
+// CmpBetweenString checks whether string values lie in a lexicographic range.
+// Returns a slice of booleans where result[i] == true if lo <= values[i] <= hi,
+// matching SQL's inclusive BETWEEN.
+func CmpBetweenString(values []string, lo, hi string) []bool {
+	return Kernels{}.CmpBetweenString(values, lo, hi)
+}
+
+// CmpBetweenStringMask checks the range lo <= values[i] <= hi and returns a bitmask.
+func CmpBetweenStringMask(values []string, lo, hi string) []uint64 {
+	return Kernels{}.CmpBetweenStringMask(values, lo, hi)
 }
 
 // CmpHasPrefixString checks if string values start with a given prefix.
 // Returns a slice of booleans where result[i] == true if values[i] starts with prefix.
 func CmpHasPrefixString(values []string, prefix string) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	byteValues := stringsToBytes(values)
-	bytePrefix := []byte(prefix)
-	return cmpHasPrefixStringImpl(byteValues, bytePrefix)
+	return Kernels{}.CmpHasPrefixString(values, prefix)
 }
 
 // CmpHasPrefixStringMask checks for prefix match and returns a bitmask.
 func CmpHasPrefixStringMask(values []string, prefix string) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	byteValues := stringsToBytes(values)
-	bytePrefix := []byte(prefix)
-	return cmpHasPrefixStringMaskImpl(byteValues, bytePrefix)
+	return Kernels{}.CmpHasPrefixStringMask(values, prefix)
 }
 
 // CmpHasSuffixString checks if string values end with a given suffix.
 // Returns a slice of booleans where result[i] == true if values[i] ends with suffix.
 func CmpHasSuffixString(values []string, suffix string) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	byteValues := stringsToBytes(values)
-	byteSuffix := []byte(suffix)
-	return cmpHasSuffixStringImpl(byteValues, byteSuffix)
+	return Kernels{}.CmpHasSuffixString(values, suffix)
 }
 
 // CmpHasSuffixStringMask checks for suffix match and returns a bitmask.
 func CmpHasSuffixStringMask(values []string, suffix string) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	byteValues := stringsToBytes(values)
-	byteSuffix := []byte(suffix)
-	return cmpHasSuffixStringMaskImpl(byteValues, byteSuffix)
+	return Kernels{}.CmpHasSuffixStringMask(values, suffix)
 }
 
 // CmpContainsString checks if string values contain a substring.
 // Returns a slice of booleans where result[i] == true if values[i] contains substr.
 func CmpContainsString(values []string, substr string) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	byteValues := stringsToBytes(values)
-	byteSubstr := []byte(substr)
-	return cmpContainsStringImpl(byteValues, byteSubstr)
+	return Kernels{}.CmpContainsString(values, substr)
 }
 
 // CmpContainsStringMask checks for substring match and returns a bitmask.
 func CmpContainsStringMask(values []string, substr string) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	byteValues := stringsToBytes(values)
-	byteSubstr := []byte(substr)
-	return cmpContainsStringMaskImpl(byteValues, byteSubstr)
+	return Kernels{}.CmpContainsStringMask(values, substr)
 }
 
 // CmpEqStringIgnoreCase compares string values for equality (case-insensitive ASCII).
 // Returns a slice of booleans where result[i] == true if values[i] equals threshold
 // ignoring case for ASCII characters (A-Z, a-z). Non-ASCII bytes are compared directly.
 func CmpEqStringIgnoreCase(values []string, threshold string) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	byteValues := stringsToBytes(values)
-	byteThreshold := []byte(threshold)
-	return cmpEqStringIgnoreCaseImpl(byteValues, byteThreshold)
+	return Kernels{}.CmpEqStringIgnoreCase(values, threshold)
 }
 
 // CmpEqStringIgnoreCaseMask compares strings case-insensitively and returns a bitmask.
 func CmpEqStringIgnoreCaseMask(values []string, threshold string) []uint64 {
-	if len(values) == 0 {
-		return []uint64{}
-	}
-
-	byteValues := stringsToBytes(values)
-	byteThreshold := []byte(threshold)
-	return cmpEqStringIgnoreCaseMaskImpl(byteValues, byteThreshold)
+	return Kernels{}.CmpEqStringIgnoreCaseMask(values, threshold)
 }
 
 // CmpLikeString performs SQL LIKE pattern matching on string values.
@@ -503,24 +381,12 @@ func CmpEqStringIgnoreCaseMask(values []string, threshold string) []uint64 {
 //
 // For repeated pattern usage, consider using CmpLikeStringCompiled for better performance.
 func CmpLikeString(values []string, pattern string) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	compiled, err := CompilePatternAuto(pattern)
-	if err != nil {
-		// Return all false on invalid pattern
-		results := make([]bool, len(values))
-		return results
-	}
-
-	return cmpLikeStringCompiled(values, compiled)
+	return Kernels{}.CmpLikeString(values, pattern)
 }
 
 // CmpLikeStringMask performs SQL LIKE pattern matching and returns a bitmask.
 func CmpLikeStringMask(values []string, pattern string) []uint64 {
-	bools := CmpLikeString(values, pattern)
-	return boolsToBitmask(bools)
+	return Kernels{}.CmpLikeStringMask(values, pattern)
 }
 
 // CmpLikeStringCompiled performs SQL LIKE pattern matching using a pre-compiled pattern.
@@ -533,39 +399,115 @@ func CmpLikeStringMask(values []string, pattern string) []uint64 {
 //	results1 := CmpLikeStringCompiled(values1, pattern)
 //	results2 := CmpLikeStringCompiled(values2, pattern)
 func CmpLikeStringCompiled(values []string, pattern *CompiledPattern) []bool {
-	if len(values) == 0 {
-		return []bool{}
-	}
-
-	return cmpLikeStringCompiled(values, pattern)
+	return Kernels{}.CmpLikeStringCompiled(values, pattern)
 }
 
 // CmpLikeStringCompiledMask performs compiled LIKE matching and returns a bitmask.
 func CmpLikeStringCompiledMask(values []string, pattern *CompiledPattern) []uint64 {
-	bools := CmpLikeStringCompiled(values, pattern)
-	return boolsToBitmask(bools)
+	return Kernels{}.CmpLikeStringCompiledMask(values, pattern)
+}
+
+// CmpNotLikeString performs SQL NOT LIKE pattern matching on string values.
+// Returns a slice of booleans where result[i] == true if values[i] does not match
+// pattern. An invalid pattern returns the compile error: unlike the all-false
+// result of CmpLikeString, an all-false NOT LIKE result would read as every row
+// matching.
+func CmpNotLikeString(values []string, pattern string) ([]bool, error) {
+	return Kernels{}.CmpNotLikeString(values, pattern)
+}
+
+// CmpNotLikeStringMask performs SQL NOT LIKE pattern matching and returns a bitmask.
+func CmpNotLikeStringMask(values []string, pattern string) ([]uint64, error) {
+	return Kernels{}.CmpNotLikeStringMask(values, pattern)
+}
+
+// CmpNotLikeStringCompiled negates CmpLikeStringCompiled. It also serves
+// NOT ILIKE and NOT SIMILAR TO with patterns from CompilePatternIgnoreCase and
+// CompileSimilarTo.
+func CmpNotLikeStringCompiled(values []string, pattern *CompiledPattern) []bool {
+	return Kernels{}.CmpNotLikeStringCompiled(values, pattern)
+}
+
+// CmpNotLikeStringCompiledMask performs compiled NOT LIKE matching and returns a bitmask.
+func CmpNotLikeStringCompiledMask(values []string, pattern *CompiledPattern) []uint64 {
+	return Kernels{}.CmpNotLikeStringCompiledMask(values, pattern)
+}
+
+// CmpILikeString performs SQL ILIKE pattern matching on string values: LIKE
+// with ASCII letters compared regardless of case. Pattern types route as in
+// CmpLikeString, to the case-insensitive SIMD equality, prefix and suffix
+// compares or, for contains and wildcard patterns, to the LIKE matchers over
+// SIMD-lowercased values.
+func CmpILikeString(values []string, pattern string) []bool {
+	return Kernels{}.CmpILikeString(values, pattern)
+}
+
+// CmpILikeStringMask performs SQL ILIKE pattern matching and returns a bitmask.
+func CmpILikeStringMask(values []string, pattern string) []uint64 {
+	return Kernels{}.CmpILikeStringMask(values, pattern)
 }
 
 // cmpLikeStringCompiled is the internal implementation of LIKE matching.
-func cmpLikeStringCompiled(values []string, pattern *CompiledPattern) []bool {
+func cmpLikeStringCompiled(ks *kernelSet, values []string, pattern *CompiledPattern) []bool {
 	byteValues := stringsToBytes(values)
+	if pattern.IgnoreCase {
+		return cmpILikeStringCompiled(ks, byteValues, pattern)
+	}
 
 	switch pattern.Type {
 	case PatternExact:
 		// Exact match
-		return cmpEqStringImpl(byteValues, pattern.Segments[0])
+		return cmpEqStringImpl(ks, byteValues, pattern.Segments[0])
 	case PatternPrefix:
 		// Prefix match
-		return cmpHasPrefixStringImpl(byteValues, pattern.Segments[0])
+		return cmpHasPrefixStringImpl(ks, byteValues, pattern.Segments[0])
 	case PatternSuffix:
 		// Suffix match
-		return cmpHasSuffixStringImpl(byteValues, pattern.Segments[0])
+		return cmpHasSuffixStringImpl(ks, byteValues, pattern.Segments[0])
 	case PatternContains:
 		// Substring match
-		return cmpContainsStringImpl(byteValues, pattern.Segments[0])
+		return cmpContainsStringImpl(ks, byteValues, pattern.Segments[0])
 	case PatternWildcard:
 		// Complex wildcard matching
-		return cmpMatchWildcardImpl(byteValues, []byte(pattern.OriginalPattern))
+		if pattern.program == nil {
+			// Built by hand rather than by CompilePattern
+			return cmpMatchWildcardImpl(ks, byteValues, []byte(pattern.OriginalPattern))
+		}
+		return pattern.program.matchAll(ks, byteValues)
+	case PatternRegex:
+		// SIMILAR TO with regular expression features
+		results := make([]bool, len(values))
+		for i, v := range byteValues {
+			results[i] = pattern.regex.Match(v)
+		}
+		return results
+	default:
+		// Unknown pattern type - return all false
+		results := make([]bool, len(values))
+		return results
+	}
+}
+
+// cmpILikeStringCompiled is the internal implementation of ILIKE matching.
+// The pattern's segments are already lowercase.
+func cmpILikeStringCompiled(ks *kernelSet, values [][]byte, pattern *CompiledPattern) []bool {
+	switch pattern.Type {
+	case PatternExact:
+		return cmpEqStringIgnoreCaseImpl(ks, values, pattern.Segments[0])
+	case PatternPrefix:
+		return cmpHasPrefixStringIgnoreCaseImpl(ks, values, pattern.Segments[0])
+	case PatternSuffix:
+		return cmpHasSuffixStringIgnoreCaseImpl(ks, values, pattern.Segments[0])
+	case PatternContains:
+		substr := pattern.Segments[0]
+		return matchLowered(ks, values, func(v []byte) bool { return strIndexImpl(ks, v, substr) >= 0 })
+	case PatternWildcard:
+		// % and _ are not letters, so lowering the pattern keeps its wildcards
+		prog := pattern.program
+		if prog == nil {
+			prog = compileLikeProgram(parseLikePattern(pattern.OriginalPattern)).lowered()
+		}
+		return matchLowered(ks, values, func(v []byte) bool { return prog.match(ks, v) })
 	default:
 		// Unknown pattern type - return all false
 		results := make([]bool, len(values))
@@ -573,6 +515,19 @@ func cmpLikeStringCompiled(values []string, pattern *CompiledPattern) []bool {
 	}
 }
 
+// matchLowered applies match to a lowercase copy of each value. The copies share
+// one scratch buffer, so match must not retain its argument.
+func matchLowered(ks *kernelSet, values [][]byte, match func([]byte) bool) []bool {
+	results := make([]bool, len(values))
+	var scratch []byte
+	for i, v := range values {
+		scratch = append(scratch[:0], v...)
+		strToLowerImpl(ks, scratch)
+		results[i] = match(scratch)
+	}
+	return results
+}
+
 // ============================================================================
 // Phase 2: Aggregation Operations
 // ============================================================================
@@ -581,17 +536,15 @@ func cmpLikeStringCompiled(values []string, pattern *CompiledPattern) []bool {
 // Returns 0 for empty arrays.
 //
 // This function automatically selects the best implementation:
+//   - AVX-512 on x86-64 processors that support it (8 elements per operation)
 //   - AVX2 on x86-64 processors (4 elements per operation)
+//   - SVE on ARM64 processors that support it (full hardware vector, no scalar tail)
 //   - NEON on ARM64 processors (2 elements per operation)
 //   - Scalar fallback on other architectures
 //
 // Performance: ~4-6x speedup with SIMD on large arrays.
 func SumInt64(values []int64) int64 {
-	if len(values) == 0 {
-		return 0
-	}
-
-	return sumInt64Impl(values)
+	return Kernels{}.SumInt64(values)
 }
 
 // MinInt64 finds the minimum int64 value in the array.
@@ -604,11 +557,7 @@ func SumInt64(values []int64) int64 {
 //
 // Performance: ~4-6x speedup with SIMD on large arrays.
 func MinInt64(values []int64) int64 {
-	if len(values) == 0 {
-		return 1<<63 - 1 // math.MaxInt64
-	}
-
-	return minInt64Impl(values)
+	return Kernels{}.MinInt64(values)
 }
 
 // MaxInt64 finds the maximum int64 value in the array.
@@ -621,11 +570,7 @@ func MinInt64(values []int64) int64 {
 //
 // Performance: ~4-6x speedup with SIMD on large arrays.
 func MaxInt64(values []int64) int64 {
-	if len(values) == 0 {
-		return -1 << 63 // math.MinInt64
-	}
-
-	return maxInt64Impl(values)
+	return Kernels{}.MaxInt64(values)
 }
 
 // CountNonNull counts the number of non-null values in the array.
@@ -639,11 +584,7 @@ func MaxInt64(values []int64) int64 {
 //
 // Performance: ~2-4x speedup with SIMD on large arrays.
 func CountNonNull(values []int64, nullBitmap []uint64) int64 {
-	if len(values) == 0 {
-		return 0
-	}
-
-	return countNonNullImpl(values, nullBitmap)
+	return Kernels{}.CountNonNull(values, nullBitmap)
 }
 
 // AvgInt64 computes the average of all int64 values in the array.
@@ -651,12 +592,7 @@ func CountNonNull(values []int64, nullBitmap []uint64) int64 {
 //
 // Note: This uses SumInt64 internally, so it benefits from SIMD acceleration.
 func AvgInt64(values []int64) float64 {
-	if len(values) == 0 {
-		return 0
-	}
-
-	sum := SumInt64(values)
-	return float64(sum) / float64(len(values))
+	return Kernels{}.AvgInt64(values)
 }
 
 // ========================================
@@ -674,11 +610,7 @@ func AvgInt64(values []int64) float64 {
 // FNV-1a is a fast, simple hash suitable for hash table operations.
 // Performance: ~2-4x speedup with SIMD on large arrays.
 func HashInt64(values []int64, output []uint64) {
-	if len(values) == 0 || len(output) != len(values) {
-		return
-	}
-
-	hashInt64Impl(values, output)
+	Kernels{}.HashInt64(values, output)
 }
 
 // CRC32 computes the CRC32 checksum of a byte slice using the IEEE polynomial.
@@ -697,35 +629,29 @@ func CRC32(data []byte) uint32 {
 //
 // Performance: ~2-3x speedup with hardware CRC32C instructions.
 func CRC32Int64(values []int64, output []uint32) {
-	if len(values) == 0 || len(output) != len(values) {
-		return
-	}
-
-	crc32Int64Impl(values, output)
+	Kernels{}.CRC32Int64(values, output)
 }
 
 // XXHash64 computes XXHash64 hashes for int64 values.
 // The output slice must be pre-allocated with the same length as values.
 //
 // This function automatically selects the best implementation:
+//   - AVX-512 on x86-64 processors with AVX512DQ (8 elements per operation)
 //   - AVX2 on x86-64 processors (4 elements per operation)
+//   - SVE on ARM64 processors that support it (full hardware vector, no scalar tail)
 //   - NEON on ARM64 processors (2 elements per operation)
 //   - Scalar fallback on other architectures
 //
 // XXHash64 is a fast, high-quality non-cryptographic hash.
 // Performance: ~3-5x speedup with SIMD on large arrays.
 func XXHash64(values []int64, output []uint64) {
-	if len(values) == 0 || len(output) != len(values) {
-		return
-	}
-
-	xxhash64Impl(values, output)
+	Kernels{}.XXHash64(values, output)
 }
 
 // XXHash64Bytes computes the XXHash64 hash of a byte slice.
 // This is useful for hashing variable-length keys in hash tables.
 func XXHash64Bytes(data []byte) uint64 {
-	return xxhash64BytesGeneric(data)
+	return Kernels{}.XXHash64Bytes(data)
 }
 
 // ========================================
@@ -741,7 +667,7 @@ func XXHash64Bytes(data []byte) uint64 {
 //
 // This is equivalent to bytes.Compare but can use SIMD for acceleration.
 func StrCmp(a, b []byte) int {
-	return strCmpImpl(a, b)
+	return Kernels{}.StrCmp(a, b)
 }
 
 // StrLen returns the length of a byte slice.
@@ -761,15 +687,24 @@ func StrLen(s []byte) int {
 // Equivalent to bytes.HasPrefix but can use SIMD for acceleration.
 // Performance: ~2-4x speedup with SIMD on long prefixes.
 func StrPrefixCmp(str, prefix []byte) bool {
-	return strPrefixCmpImpl(str, prefix)
+	return Kernels{}.StrPrefixCmp(str, prefix)
 }
 
 // StrContains checks if str contains substr.
 // Returns true if substr is found in str, false otherwise.
 //
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (32 start positions per operation)
+//   - NEON on ARM64 processors (16 start positions per operation)
+//   - Scalar fallback on other architectures
+//
+// The SIMD kernels compare the first and last byte of substr at every start
+// position at once and verify the full substring only where both match, so
+// haystacks rarely fall back to byte-by-byte work.
+//
 // Equivalent to bytes.Contains.
 func StrContains(str, substr []byte) bool {
-	return strContainsGeneric(str, substr)
+	return Kernels{}.StrContains(str, substr)
 }
 
 // StrEq checks if two byte slices are equal.
@@ -783,7 +718,7 @@ func StrContains(str, substr []byte) bool {
 // Equivalent to bytes.Equal but can use SIMD for acceleration.
 // Performance: ~3-5x speedup with SIMD on long strings.
 func StrEq(a, b []byte) bool {
-	return strEqImpl(a, b)
+	return Kernels{}.StrEq(a, b)
 }
 
 // StrToLower converts a byte slice to lowercase (ASCII only).
@@ -796,7 +731,7 @@ func StrEq(a, b []byte) bool {
 //
 // Performance: ~4-6x speedup with SIMD on long strings.
 func StrToLower(s []byte) {
-	strToLowerImpl(s)
+	Kernels{}.StrToLower(s)
 }
 
 // StrToUpper converts a byte slice to uppercase (ASCII only).
@@ -809,11 +744,1190 @@ func StrToLower(s []byte) {
 //
 // Performance: ~4-6x speedup with SIMD on long strings.
 func StrToUpper(s []byte) {
-	strToUpperImpl(s)
+	Kernels{}.StrToUpper(s)
 }
 
 // StrEqIgnoreCase checks if two byte slices are equal, ignoring case (ASCII only).
 // Returns true if equal (case-insensitive), false otherwise.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (32 bytes per operation)
+//   - NEON on ARM64 processors (16 bytes per operation)
+//   - Scalar fallback on other architectures
+//
+// Both inputs are lowercased in registers before the compare, so only ASCII
+// letters fold; other bytes, including non-ASCII ones, must match exactly.
 func StrEqIgnoreCase(a, b []byte) bool {
-	return strEqIgnoreCaseGeneric(a, b)
+	return Kernels{}.StrEqIgnoreCase(a, b)
+}
+
+// ========================================
+// Selection Materialization
+// ========================================
+
+// CompressInt64 copies the values selected by a bitmask into dst, preserving order.
+// Bit i in mask[i/64] selects src[i], matching the layout produced by the Cmp*Mask
+// functions. Bits beyond len(src) are ignored. Returns the number of values written.
+//
+// dst should have room for PopCount(mask) values (len(src) is always enough);
+// if it is shorter, compression stops once dst is full.
+//
+// This function automatically selects the best implementation:
+//   - AVX-512 on x86-64 processors that support it (VPCOMPRESSQ, 8 elements per operation)
+//   - AVX2 on x86-64 processors (permutation tables, 4 elements per operation)
+//   - NEON on ARM64 processors (TBL shuffles, 2 elements per operation)
+//   - Scalar fallback on other architectures
+//
+// Example:
+//
+//	mask := CmpGtInt64Mask(prices, 100)
+//	out := make([]int64, len(prices))
+//	out = out[:CompressInt64(out, prices, mask)]
+func CompressInt64(dst, src []int64, mask []uint64) int {
+	return Kernels{}.CompressInt64(dst, src, mask)
+}
+
+// CompressFloat64 copies the values selected by a bitmask into dst, preserving order.
+// It has the same contract as CompressInt64. Values are copied bit-for-bit, so NaN
+// payloads and negative zero are preserved.
+func CompressFloat64(dst, src []float64, mask []uint64) int {
+	return Kernels{}.CompressFloat64(dst, src, mask)
+}
+
+// CompressStrings copies the strings selected by a bitmask into dst, preserving order.
+// It has the same contract as CompressInt64. Only string headers are copied; the
+// selected strings share their bytes with src.
+func CompressStrings(dst, src []string, mask []uint64) int {
+	return Kernels{}.CompressStrings(dst, src, mask)
+}
+
+// GatherInt64 loads values by position: dst[i] = src[indices[i]].
+// The dst slice must be pre-allocated with at least len(indices) elements.
+// Panics if any index is out of range for src, like ordinary slice indexing.
+//
+// This function automatically selects the best implementation:
+//   - AVX-512 on x86-64 processors that support it (8 elements per operation)
+//   - AVX2 on x86-64 processors (VPGATHERDQ, 4 elements per operation)
+//   - Scalar fallback on other architectures
+func GatherInt64(dst, src []int64, indices []uint32) {
+	Kernels{}.GatherInt64(dst, src, indices)
+}
+
+// ScatterInt64 stores values by position: dst[indices[i]] = src[i].
+// The src slice must have at least len(indices) elements. When an index repeats,
+// the value that appears last in src wins.
+// Panics if any index is out of range for dst, like ordinary slice indexing.
+//
+// This function automatically selects the best implementation:
+//   - AVX-512 on x86-64 processors that support it (VPSCATTERDQ, 8 elements per operation)
+//   - Scalar fallback elsewhere (AVX2 and NEON have no scatter instruction)
+func ScatterInt64(dst, src []int64, indices []uint32) {
+	Kernels{}.ScatterInt64(dst, src, indices)
+}
+
+// ============================================================================
+// Narrow Integer Operations
+// ============================================================================
+//
+// The Int8/Int16/Int32 and Uint8/Uint16/Uint32 families mirror the int64 API for
+// dictionary codes and small-range columns, so they can be filtered without
+// widening. AVX2 packs 32, 16 or 8 lanes per register for these types.
+
+// Int8 Operations
+
+// CmpEqInt8 compares int8 values for equality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] == threshold.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (32 elements per operation)
+//   - Scalar fallback on other architectures
+func CmpEqInt8(values []int8, threshold int8) []bool {
+	return Kernels{}.CmpEqInt8(values, threshold)
+}
+
+// CmpEqInt8Mask compares int8 values for equality and returns a bitmask.
+// Returns a slice of uint64 where bit i in result[j] is set if values[j*64+i] == threshold.
+// This is more memory-efficient than CmpEqInt8 for large datasets.
+func CmpEqInt8Mask(values []int8, threshold int8) []uint64 {
+	return Kernels{}.CmpEqInt8Mask(values, threshold)
+}
+
+// CmpNeInt8 compares int8 values for inequality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] != threshold.
+func CmpNeInt8(values []int8, threshold int8) []bool {
+	return Kernels{}.CmpNeInt8(values, threshold)
+}
+
+// CmpNeInt8Mask compares int8 values for inequality and returns a bitmask.
+func CmpNeInt8Mask(values []int8, threshold int8) []uint64 {
+	return Kernels{}.CmpNeInt8Mask(values, threshold)
+}
+
+// CmpGtInt8 compares int8 values for greater-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] > threshold.
+func CmpGtInt8(values []int8, threshold int8) []bool {
+	return Kernels{}.CmpGtInt8(values, threshold)
+}
+
+// CmpGtInt8Mask compares int8 values for greater-than and returns a bitmask.
+func CmpGtInt8Mask(values []int8, threshold int8) []uint64 {
+	return Kernels{}.CmpGtInt8Mask(values, threshold)
+}
+
+// CmpLtInt8 compares int8 values for less-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] < threshold.
+func CmpLtInt8(values []int8, threshold int8) []bool {
+	return Kernels{}.CmpLtInt8(values, threshold)
+}
+
+// CmpLtInt8Mask compares int8 values for less-than and returns a bitmask.
+func CmpLtInt8Mask(values []int8, threshold int8) []uint64 {
+	return Kernels{}.CmpLtInt8Mask(values, threshold)
+}
+
+// CmpGeInt8 compares int8 values for greater-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] >= threshold.
+func CmpGeInt8(values []int8, threshold int8) []bool {
+	return Kernels{}.CmpGeInt8(values, threshold)
+}
+
+// CmpGeInt8Mask compares int8 values for greater-than-or-equal and returns a bitmask.
+func CmpGeInt8Mask(values []int8, threshold int8) []uint64 {
+	return Kernels{}.CmpGeInt8Mask(values, threshold)
+}
+
+// CmpLeInt8 compares int8 values for less-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] <= threshold.
+func CmpLeInt8(values []int8, threshold int8) []bool {
+	return Kernels{}.CmpLeInt8(values, threshold)
+}
+
+// CmpLeInt8Mask compares int8 values for less-than-or-equal and returns a bitmask.
+func CmpLeInt8Mask(values []int8, threshold int8) []uint64 {
+	return Kernels{}.CmpLeInt8Mask(values, threshold)
+}
+
+// SumInt8 computes the sum of all int8 values in the array, accumulated in int64
+// so it cannot overflow for any realistic column length.
+// Returns 0 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (widening 16 elements per iteration)
+//   - Scalar fallback on other architectures
+func SumInt8(values []int8) int64 {
+	return Kernels{}.SumInt8(values)
+}
+
+// MinInt8 finds the minimum int8 value in the array.
+// Returns math.MaxInt8 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (32 elements per operation)
+//   - Scalar fallback on other architectures
+func MinInt8(values []int8) int8 {
+	return Kernels{}.MinInt8(values)
+}
+
+// MaxInt8 finds the maximum int8 value in the array.
+// Returns math.MinInt8 for empty arrays.
+func MaxInt8(values []int8) int8 {
+	return Kernels{}.MaxInt8(values)
+}
+
+// XXHash64Int8 computes XXHash64 hashes for int8 values.
+// The output slice must be pre-allocated with the same length as values.
+//
+// Each value is hashed as its int64 widening, so output[i] equals the XXHash64 hash
+// of int64(values[i]) and join keys hash identically across column widths.
+// Uses the XXHash64 kernels, including their SIMD paths.
+func XXHash64Int8(values []int8, output []uint64) {
+	Kernels{}.XXHash64Int8(values, output)
+}
+
+// Int16 Operations
+
+// CmpEqInt16 compares int16 values for equality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] == threshold.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (16 elements per operation)
+//   - Scalar fallback on other architectures
+func CmpEqInt16(values []int16, threshold int16) []bool {
+	return Kernels{}.CmpEqInt16(values, threshold)
+}
+
+// CmpEqInt16Mask compares int16 values for equality and returns a bitmask.
+// Returns a slice of uint64 where bit i in result[j] is set if values[j*64+i] == threshold.
+// This is more memory-efficient than CmpEqInt16 for large datasets.
+func CmpEqInt16Mask(values []int16, threshold int16) []uint64 {
+	return Kernels{}.CmpEqInt16Mask(values, threshold)
+}
+
+// CmpNeInt16 compares int16 values for inequality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] != threshold.
+func CmpNeInt16(values []int16, threshold int16) []bool {
+	return Kernels{}.CmpNeInt16(values, threshold)
+}
+
+// CmpNeInt16Mask compares int16 values for inequality and returns a bitmask.
+func CmpNeInt16Mask(values []int16, threshold int16) []uint64 {
+	return Kernels{}.CmpNeInt16Mask(values, threshold)
+}
+
+// CmpGtInt16 compares int16 values for greater-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] > threshold.
+func CmpGtInt16(values []int16, threshold int16) []bool {
+	return Kernels{}.CmpGtInt16(values, threshold)
+}
+
+// CmpGtInt16Mask compares int16 values for greater-than and returns a bitmask.
+func CmpGtInt16Mask(values []int16, threshold int16) []uint64 {
+	return Kernels{}.CmpGtInt16Mask(values, threshold)
+}
+
+// CmpLtInt16 compares int16 values for less-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] < threshold.
+func CmpLtInt16(values []int16, threshold int16) []bool {
+	return Kernels{}.CmpLtInt16(values, threshold)
+}
+
+// CmpLtInt16Mask compares int16 values for less-than and returns a bitmask.
+func CmpLtInt16Mask(values []int16, threshold int16) []uint64 {
+	return Kernels{}.CmpLtInt16Mask(values, threshold)
+}
+
+// CmpGeInt16 compares int16 values for greater-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] >= threshold.
+func CmpGeInt16(values []int16, threshold int16) []bool {
+	return Kernels{}.CmpGeInt16(values, threshold)
+}
+
+// CmpGeInt16Mask compares int16 values for greater-than-or-equal and returns a bitmask.
+func CmpGeInt16Mask(values []int16, threshold int16) []uint64 {
+	return Kernels{}.CmpGeInt16Mask(values, threshold)
+}
+
+// CmpLeInt16 compares int16 values for less-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] <= threshold.
+func CmpLeInt16(values []int16, threshold int16) []bool {
+	return Kernels{}.CmpLeInt16(values, threshold)
+}
+
+// CmpLeInt16Mask compares int16 values for less-than-or-equal and returns a bitmask.
+func CmpLeInt16Mask(values []int16, threshold int16) []uint64 {
+	return Kernels{}.CmpLeInt16Mask(values, threshold)
+}
+
+// SumInt16 computes the sum of all int16 values in the array, accumulated in int64
+// so it cannot overflow for any realistic column length.
+// Returns 0 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (widening 16 elements per iteration)
+//   - Scalar fallback on other architectures
+func SumInt16(values []int16) int64 {
+	return Kernels{}.SumInt16(values)
+}
+
+// MinInt16 finds the minimum int16 value in the array.
+// Returns math.MaxInt16 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (16 elements per operation)
+//   - Scalar fallback on other architectures
+func MinInt16(values []int16) int16 {
+	return Kernels{}.MinInt16(values)
+}
+
+// MaxInt16 finds the maximum int16 value in the array.
+// Returns math.MinInt16 for empty arrays.
+func MaxInt16(values []int16) int16 {
+	return Kernels{}.MaxInt16(values)
+}
+
+// XXHash64Int16 computes XXHash64 hashes for int16 values.
+// The output slice must be pre-allocated with the same length as values.
+//
+// Each value is hashed as its int64 widening, so output[i] equals the XXHash64 hash
+// of int64(values[i]) and join keys hash identically across column widths.
+// Uses the XXHash64 kernels, including their SIMD paths.
+func XXHash64Int16(values []int16, output []uint64) {
+	Kernels{}.XXHash64Int16(values, output)
+}
+
+// Int32 Operations
+
+// CmpEqInt32 compares int32 values for equality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] == threshold.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (8 elements per operation)
+//   - Scalar fallback on other architectures
+func CmpEqInt32(values []int32, threshold int32) []bool {
+	return Kernels{}.CmpEqInt32(values, threshold)
+}
+
+// CmpEqInt32Mask compares int32 values for equality and returns a bitmask.
+// Returns a slice of uint64 where bit i in result[j] is set if values[j*64+i] == threshold.
+// This is more memory-efficient than CmpEqInt32 for large datasets.
+func CmpEqInt32Mask(values []int32, threshold int32) []uint64 {
+	return Kernels{}.CmpEqInt32Mask(values, threshold)
+}
+
+// CmpNeInt32 compares int32 values for inequality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] != threshold.
+func CmpNeInt32(values []int32, threshold int32) []bool {
+	return Kernels{}.CmpNeInt32(values, threshold)
+}
+
+// CmpNeInt32Mask compares int32 values for inequality and returns a bitmask.
+func CmpNeInt32Mask(values []int32, threshold int32) []uint64 {
+	return Kernels{}.CmpNeInt32Mask(values, threshold)
+}
+
+// CmpGtInt32 compares int32 values for greater-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] > threshold.
+func CmpGtInt32(values []int32, threshold int32) []bool {
+	return Kernels{}.CmpGtInt32(values, threshold)
+}
+
+// CmpGtInt32Mask compares int32 values for greater-than and returns a bitmask.
+func CmpGtInt32Mask(values []int32, threshold int32) []uint64 {
+	return Kernels{}.CmpGtInt32Mask(values, threshold)
+}
+
+// CmpLtInt32 compares int32 values for less-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] < threshold.
+func CmpLtInt32(values []int32, threshold int32) []bool {
+	return Kernels{}.CmpLtInt32(values, threshold)
+}
+
+// CmpLtInt32Mask compares int32 values for less-than and returns a bitmask.
+func CmpLtInt32Mask(values []int32, threshold int32) []uint64 {
+	return Kernels{}.CmpLtInt32Mask(values, threshold)
+}
+
+// CmpGeInt32 compares int32 values for greater-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] >= threshold.
+func CmpGeInt32(values []int32, threshold int32) []bool {
+	return Kernels{}.CmpGeInt32(values, threshold)
+}
+
+// CmpGeInt32Mask compares int32 values for greater-than-or-equal and returns a bitmask.
+func CmpGeInt32Mask(values []int32, threshold int32) []uint64 {
+	return Kernels{}.CmpGeInt32Mask(values, threshold)
+}
+
+// CmpLeInt32 compares int32 values for less-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] <= threshold.
+func CmpLeInt32(values []int32, threshold int32) []bool {
+	return Kernels{}.CmpLeInt32(values, threshold)
+}
+
+// CmpLeInt32Mask compares int32 values for less-than-or-equal and returns a bitmask.
+func CmpLeInt32Mask(values []int32, threshold int32) []uint64 {
+	return Kernels{}.CmpLeInt32Mask(values, threshold)
+}
+
+// SumInt32 computes the sum of all int32 values in the array, accumulated in int64
+// so it cannot overflow for any realistic column length.
+// Returns 0 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (widening 16 elements per iteration)
+//   - Scalar fallback on other architectures
+func SumInt32(values []int32) int64 {
+	return Kernels{}.SumInt32(values)
+}
+
+// MinInt32 finds the minimum int32 value in the array.
+// Returns math.MaxInt32 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (8 elements per operation)
+//   - Scalar fallback on other architectures
+func MinInt32(values []int32) int32 {
+	return Kernels{}.MinInt32(values)
+}
+
+// MaxInt32 finds the maximum int32 value in the array.
+// Returns math.MinInt32 for empty arrays.
+func MaxInt32(values []int32) int32 {
+	return Kernels{}.MaxInt32(values)
+}
+
+// XXHash64Int32 computes XXHash64 hashes for int32 values.
+// The output slice must be pre-allocated with the same length as values.
+//
+// Each value is hashed as its int64 widening, so output[i] equals the XXHash64 hash
+// of int64(values[i]) and join keys hash identically across column widths.
+// Uses the XXHash64 kernels, including their SIMD paths.
+func XXHash64Int32(values []int32, output []uint64) {
+	Kernels{}.XXHash64Int32(values, output)
+}
+
+// Uint8 Operations
+
+// CmpEqUint8 compares uint8 values for equality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] == threshold.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (32 elements per operation)
+//   - Scalar fallback on other architectures
+func CmpEqUint8(values []uint8, threshold uint8) []bool {
+	return Kernels{}.CmpEqUint8(values, threshold)
+}
+
+// CmpEqUint8Mask compares uint8 values for equality and returns a bitmask.
+// Returns a slice of uint64 where bit i in result[j] is set if values[j*64+i] == threshold.
+// This is more memory-efficient than CmpEqUint8 for large datasets.
+func CmpEqUint8Mask(values []uint8, threshold uint8) []uint64 {
+	return Kernels{}.CmpEqUint8Mask(values, threshold)
+}
+
+// CmpNeUint8 compares uint8 values for inequality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] != threshold.
+func CmpNeUint8(values []uint8, threshold uint8) []bool {
+	return Kernels{}.CmpNeUint8(values, threshold)
+}
+
+// CmpNeUint8Mask compares uint8 values for inequality and returns a bitmask.
+func CmpNeUint8Mask(values []uint8, threshold uint8) []uint64 {
+	return Kernels{}.CmpNeUint8Mask(values, threshold)
+}
+
+// CmpGtUint8 compares uint8 values for greater-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] > threshold.
+func CmpGtUint8(values []uint8, threshold uint8) []bool {
+	return Kernels{}.CmpGtUint8(values, threshold)
+}
+
+// CmpGtUint8Mask compares uint8 values for greater-than and returns a bitmask.
+func CmpGtUint8Mask(values []uint8, threshold uint8) []uint64 {
+	return Kernels{}.CmpGtUint8Mask(values, threshold)
+}
+
+// CmpLtUint8 compares uint8 values for less-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] < threshold.
+func CmpLtUint8(values []uint8, threshold uint8) []bool {
+	return Kernels{}.CmpLtUint8(values, threshold)
+}
+
+// CmpLtUint8Mask compares uint8 values for less-than and returns a bitmask.
+func CmpLtUint8Mask(values []uint8, threshold uint8) []uint64 {
+	return Kernels{}.CmpLtUint8Mask(values, threshold)
+}
+
+// CmpGeUint8 compares uint8 values for greater-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] >= threshold.
+func CmpGeUint8(values []uint8, threshold uint8) []bool {
+	return Kernels{}.CmpGeUint8(values, threshold)
+}
+
+// CmpGeUint8Mask compares uint8 values for greater-than-or-equal and returns a bitmask.
+func CmpGeUint8Mask(values []uint8, threshold uint8) []uint64 {
+	return Kernels{}.CmpGeUint8Mask(values, threshold)
+}
+
+// CmpLeUint8 compares uint8 values for less-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] <= threshold.
+func CmpLeUint8(values []uint8, threshold uint8) []bool {
+	return Kernels{}.CmpLeUint8(values, threshold)
+}
+
+// CmpLeUint8Mask compares uint8 values for less-than-or-equal and returns a bitmask.
+func CmpLeUint8Mask(values []uint8, threshold uint8) []uint64 {
+	return Kernels{}.CmpLeUint8Mask(values, threshold)
+}
+
+// SumUint8 computes the sum of all uint8 values in the array, accumulated in uint64
+// so it cannot overflow for any realistic column length.
+// Returns 0 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (widening 16 elements per iteration)
+//   - Scalar fallback on other architectures
+func SumUint8(values []uint8) uint64 {
+	return Kernels{}.SumUint8(values)
+}
+
+// MinUint8 finds the minimum uint8 value in the array.
+// Returns math.MaxUint8 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (32 elements per operation)
+//   - Scalar fallback on other architectures
+func MinUint8(values []uint8) uint8 {
+	return Kernels{}.MinUint8(values)
+}
+
+// MaxUint8 finds the maximum uint8 value in the array.
+// Returns 0 for empty arrays.
+func MaxUint8(values []uint8) uint8 {
+	return Kernels{}.MaxUint8(values)
+}
+
+// XXHash64Uint8 computes XXHash64 hashes for uint8 values.
+// The output slice must be pre-allocated with the same length as values.
+//
+// Each value is hashed as its int64 widening, so output[i] equals the XXHash64 hash
+// of int64(values[i]) and join keys hash identically across column widths.
+// Uses the XXHash64 kernels, including their SIMD paths.
+func XXHash64Uint8(values []uint8, output []uint64) {
+	Kernels{}.XXHash64Uint8(values, output)
+}
+
+// Uint16 Operations
+
+// CmpEqUint16 compares uint16 values for equality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] == threshold.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (16 elements per operation)
+//   - Scalar fallback on other architectures
+func CmpEqUint16(values []uint16, threshold uint16) []bool {
+	return Kernels{}.CmpEqUint16(values, threshold)
+}
+
+// CmpEqUint16Mask compares uint16 values for equality and returns a bitmask.
+// Returns a slice of uint64 where bit i in result[j] is set if values[j*64+i] == threshold.
+// This is more memory-efficient than CmpEqUint16 for large datasets.
+func CmpEqUint16Mask(values []uint16, threshold uint16) []uint64 {
+	return Kernels{}.CmpEqUint16Mask(values, threshold)
+}
+
+// CmpNeUint16 compares uint16 values for inequality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] != threshold.
+func CmpNeUint16(values []uint16, threshold uint16) []bool {
+	return Kernels{}.CmpNeUint16(values, threshold)
+}
+
+// CmpNeUint16Mask compares uint16 values for inequality and returns a bitmask.
+func CmpNeUint16Mask(values []uint16, threshold uint16) []uint64 {
+	return Kernels{}.CmpNeUint16Mask(values, threshold)
+}
+
+// CmpGtUint16 compares uint16 values for greater-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] > threshold.
+func CmpGtUint16(values []uint16, threshold uint16) []bool {
+	return Kernels{}.CmpGtUint16(values, threshold)
+}
+
+// CmpGtUint16Mask compares uint16 values for greater-than and returns a bitmask.
+func CmpGtUint16Mask(values []uint16, threshold uint16) []uint64 {
+	return Kernels{}.CmpGtUint16Mask(values, threshold)
+}
+
+// CmpLtUint16 compares uint16 values for less-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] < threshold.
+func CmpLtUint16(values []uint16, threshold uint16) []bool {
+	return Kernels{}.CmpLtUint16(values, threshold)
+}
+
+// CmpLtUint16Mask compares uint16 values for less-than and returns a bitmask.
+func CmpLtUint16Mask(values []uint16, threshold uint16) []uint64 {
+	return Kernels{}.CmpLtUint16Mask(values, threshold)
+}
+
+// CmpGeUint16 compares uint16 values for greater-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] >= threshold.
+func CmpGeUint16(values []uint16, threshold uint16) []bool {
+	return Kernels{}.CmpGeUint16(values, threshold)
+}
+
+// CmpGeUint16Mask compares uint16 values for greater-than-or-equal and returns a bitmask.
+func CmpGeUint16Mask(values []uint16, threshold uint16) []uint64 {
+	return Kernels{}.CmpGeUint16Mask(values, threshold)
+}
+
+// CmpLeUint16 compares uint16 values for less-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] <= threshold.
+func CmpLeUint16(values []uint16, threshold uint16) []bool {
+	return Kernels{}.CmpLeUint16(values, threshold)
+}
+
+// CmpLeUint16Mask compares uint16 values for less-than-or-equal and returns a bitmask.
+func CmpLeUint16Mask(values []uint16, threshold uint16) []uint64 {
+	return Kernels{}.CmpLeUint16Mask(values, threshold)
+}
+
+// SumUint16 computes the sum of all uint16 values in the array, accumulated in uint64
+// so it cannot overflow for any realistic column length.
+// Returns 0 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (widening 16 elements per iteration)
+//   - Scalar fallback on other architectures
+func SumUint16(values []uint16) uint64 {
+	return Kernels{}.SumUint16(values)
+}
+
+// MinUint16 finds the minimum uint16 value in the array.
+// Returns math.MaxUint16 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (16 elements per operation)
+//   - Scalar fallback on other architectures
+func MinUint16(values []uint16) uint16 {
+	return Kernels{}.MinUint16(values)
+}
+
+// MaxUint16 finds the maximum uint16 value in the array.
+// Returns 0 for empty arrays.
+func MaxUint16(values []uint16) uint16 {
+	return Kernels{}.MaxUint16(values)
+}
+
+// XXHash64Uint16 computes XXHash64 hashes for uint16 values.
+// The output slice must be pre-allocated with the same length as values.
+//
+// Each value is hashed as its int64 widening, so output[i] equals the XXHash64 hash
+// of int64(values[i]) and join keys hash identically across column widths.
+// Uses the XXHash64 kernels, including their SIMD paths.
+func XXHash64Uint16(values []uint16, output []uint64) {
+	Kernels{}.XXHash64Uint16(values, output)
+}
+
+// Uint32 Operations
+
+// CmpEqUint32 compares uint32 values for equality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] == threshold.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (8 elements per operation)
+//   - Scalar fallback on other architectures
+func CmpEqUint32(values []uint32, threshold uint32) []bool {
+	return Kernels{}.CmpEqUint32(values, threshold)
+}
+
+// CmpEqUint32Mask compares uint32 values for equality and returns a bitmask.
+// Returns a slice of uint64 where bit i in result[j] is set if values[j*64+i] == threshold.
+// This is more memory-efficient than CmpEqUint32 for large datasets.
+func CmpEqUint32Mask(values []uint32, threshold uint32) []uint64 {
+	return Kernels{}.CmpEqUint32Mask(values, threshold)
+}
+
+// CmpNeUint32 compares uint32 values for inequality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] != threshold.
+func CmpNeUint32(values []uint32, threshold uint32) []bool {
+	return Kernels{}.CmpNeUint32(values, threshold)
+}
+
+// CmpNeUint32Mask compares uint32 values for inequality and returns a bitmask.
+func CmpNeUint32Mask(values []uint32, threshold uint32) []uint64 {
+	return Kernels{}.CmpNeUint32Mask(values, threshold)
+}
+
+// CmpGtUint32 compares uint32 values for greater-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] > threshold.
+func CmpGtUint32(values []uint32, threshold uint32) []bool {
+	return Kernels{}.CmpGtUint32(values, threshold)
+}
+
+// CmpGtUint32Mask compares uint32 values for greater-than and returns a bitmask.
+func CmpGtUint32Mask(values []uint32, threshold uint32) []uint64 {
+	return Kernels{}.CmpGtUint32Mask(values, threshold)
+}
+
+// CmpLtUint32 compares uint32 values for less-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] < threshold.
+func CmpLtUint32(values []uint32, threshold uint32) []bool {
+	return Kernels{}.CmpLtUint32(values, threshold)
+}
+
+// CmpLtUint32Mask compares uint32 values for less-than and returns a bitmask.
+func CmpLtUint32Mask(values []uint32, threshold uint32) []uint64 {
+	return Kernels{}.CmpLtUint32Mask(values, threshold)
+}
+
+// CmpGeUint32 compares uint32 values for greater-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] >= threshold.
+func CmpGeUint32(values []uint32, threshold uint32) []bool {
+	return Kernels{}.CmpGeUint32(values, threshold)
+}
+
+// CmpGeUint32Mask compares uint32 values for greater-than-or-equal and returns a bitmask.
+func CmpGeUint32Mask(values []uint32, threshold uint32) []uint64 {
+	return Kernels{}.CmpGeUint32Mask(values, threshold)
+}
+
+// CmpLeUint32 compares uint32 values for less-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] <= threshold.
+func CmpLeUint32(values []uint32, threshold uint32) []bool {
+	return Kernels{}.CmpLeUint32(values, threshold)
+}
+
+// CmpLeUint32Mask compares uint32 values for less-than-or-equal and returns a bitmask.
+func CmpLeUint32Mask(values []uint32, threshold uint32) []uint64 {
+	return Kernels{}.CmpLeUint32Mask(values, threshold)
+}
+
+// SumUint32 computes the sum of all uint32 values in the array, accumulated in uint64
+// so it cannot overflow for any realistic column length.
+// Returns 0 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (widening 16 elements per iteration)
+//   - Scalar fallback on other architectures
+func SumUint32(values []uint32) uint64 {
+	return Kernels{}.SumUint32(values)
+}
+
+// MinUint32 finds the minimum uint32 value in the array.
+// Returns math.MaxUint32 for empty arrays.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (8 elements per operation)
+//   - Scalar fallback on other architectures
+func MinUint32(values []uint32) uint32 {
+	return Kernels{}.MinUint32(values)
+}
+
+// MaxUint32 finds the maximum uint32 value in the array.
+// Returns 0 for empty arrays.
+func MaxUint32(values []uint32) uint32 {
+	return Kernels{}.MaxUint32(values)
+}
+
+// XXHash64Uint32 computes XXHash64 hashes for uint32 values.
+// The output slice must be pre-allocated with the same length as values.
+//
+// Each value is hashed as its int64 widening, so output[i] equals the XXHash64 hash
+// of int64(values[i]) and join keys hash identically across column widths.
+// Uses the XXHash64 kernels, including their SIMD paths.
+func XXHash64Uint32(values []uint32, output []uint64) {
+	Kernels{}.XXHash64Uint32(values, output)
+}
+
+// ============================================================================
+// Float32 Operations
+// ============================================================================
+//
+// The Float32 family covers sensor readings and embeddings stored as float32.
+// Comparisons follow the same IEEE 754 NaN semantics as the Float64 family.
+
+// CmpEqFloat32 compares float32 values for equality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] == threshold.
+//
+// NaN comparisons always return false per IEEE 754 (even NaN == NaN is false).
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (8 elements per operation)
+//   - NEON on ARM64 processors (4 elements per operation)
+//   - Scalar fallback on other architectures
+func CmpEqFloat32(values []float32, threshold float32) []bool {
+	return Kernels{}.CmpEqFloat32(values, threshold)
+}
+
+// CmpEqFloat32Mask compares float32 values for equality and returns a bitmask.
+// Returns a slice of uint64 where bit i in result[j] is set if values[j*64+i] == threshold.
+// This is more memory-efficient than CmpEqFloat32 for large datasets.
+func CmpEqFloat32Mask(values []float32, threshold float32) []uint64 {
+	return Kernels{}.CmpEqFloat32Mask(values, threshold)
+}
+
+// CmpNeFloat32 compares float32 values for inequality against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] != threshold.
+//
+// NaN != x returns true for all x per IEEE 754 (including NaN != NaN).
+func CmpNeFloat32(values []float32, threshold float32) []bool {
+	return Kernels{}.CmpNeFloat32(values, threshold)
+}
+
+// CmpNeFloat32Mask compares float32 values for inequality and returns a bitmask.
+func CmpNeFloat32Mask(values []float32, threshold float32) []uint64 {
+	return Kernels{}.CmpNeFloat32Mask(values, threshold)
+}
+
+// CmpGtFloat32 compares float32 values for greater-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] > threshold.
+//
+// NaN comparisons always return false per IEEE 754.
+// Infinity values are compared normally (e.g., +Inf > any finite number is true).
+func CmpGtFloat32(values []float32, threshold float32) []bool {
+	return Kernels{}.CmpGtFloat32(values, threshold)
+}
+
+// CmpGtFloat32Mask compares float32 values for greater-than and returns a bitmask.
+func CmpGtFloat32Mask(values []float32, threshold float32) []uint64 {
+	return Kernels{}.CmpGtFloat32Mask(values, threshold)
+}
+
+// CmpGeFloat32 compares float32 values for greater-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] >= threshold.
+//
+// NaN comparisons always return false per IEEE 754.
+func CmpGeFloat32(values []float32, threshold float32) []bool {
+	return Kernels{}.CmpGeFloat32(values, threshold)
+}
+
+// CmpGeFloat32Mask compares float32 values for greater-than-or-equal and returns a bitmask.
+func CmpGeFloat32Mask(values []float32, threshold float32) []uint64 {
+	return Kernels{}.CmpGeFloat32Mask(values, threshold)
+}
+
+// CmpLtFloat32 compares float32 values for less-than against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] < threshold.
+//
+// NaN comparisons always return false per IEEE 754.
+func CmpLtFloat32(values []float32, threshold float32) []bool {
+	return Kernels{}.CmpLtFloat32(values, threshold)
+}
+
+// CmpLtFloat32Mask compares float32 values for less-than and returns a bitmask.
+func CmpLtFloat32Mask(values []float32, threshold float32) []uint64 {
+	return Kernels{}.CmpLtFloat32Mask(values, threshold)
+}
+
+// CmpLeFloat32 compares float32 values for less-than-or-equal against a threshold.
+// Returns a slice of booleans where result[i] == true if values[i] <= threshold.
+//
+// NaN comparisons always return false per IEEE 754.
+func CmpLeFloat32(values []float32, threshold float32) []bool {
+	return Kernels{}.CmpLeFloat32(values, threshold)
+}
+
+// CmpLeFloat32Mask compares float32 values for less-than-or-equal and returns a bitmask.
+func CmpLeFloat32Mask(values []float32, threshold float32) []uint64 {
+	return Kernels{}.CmpLeFloat32Mask(values, threshold)
+}
+
+// SumFloat32 computes the sum of all float32 values in the array, accumulated in
+// float64 to limit rounding error. Returns 0 for empty arrays.
+//
+// The SIMD paths add in several interleaved accumulators, so the result can differ
+// from a sequential loop in the last bits. NaN or ±Inf inputs propagate as usual.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (widening 8 elements per operation)
+//   - NEON on ARM64 processors (widening 4 elements per operation)
+//   - Scalar fallback on other architectures
+func SumFloat32(values []float32) float64 {
+	return Kernels{}.SumFloat32(values)
+}
+
+// MinFloat32 finds the minimum float32 value in the array.
+// NaN values are skipped. Returns +Inf for empty arrays or if every value is NaN.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (8 elements per operation)
+//   - NEON on ARM64 processors (4 elements per operation)
+//   - Scalar fallback on other architectures
+func MinFloat32(values []float32) float32 {
+	return Kernels{}.MinFloat32(values)
+}
+
+// MaxFloat32 finds the maximum float32 value in the array.
+// NaN values are skipped. Returns -Inf for empty arrays or if every value is NaN.
+func MaxFloat32(values []float32) float32 {
+	return Kernels{}.MaxFloat32(values)
+}
+
+// AvgFloat32 computes the average of float32 values in float64.
+// Returns 0 for empty arrays.
+func AvgFloat32(values []float32) float64 {
+	return Kernels{}.AvgFloat32(values)
+}
+
+// Float32ToFloat64 widens src into dst, like copy with a conversion.
+// Converts min(len(dst), len(src)) values and returns that count.
+// Widening is exact; NaN and ±Inf are preserved.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (4 elements per operation)
+//   - NEON on ARM64 processors (2 elements per operation)
+//   - Scalar fallback on other architectures
+func Float32ToFloat64(dst []float64, src []float32) int {
+	return Kernels{}.Float32ToFloat64(dst, src)
+}
+
+// Float64ToFloat32 narrows src into dst, like copy with a conversion.
+// Converts min(len(dst), len(src)) values and returns that count.
+// Values round to the nearest float32 exactly as float32(v) does; values beyond
+// the float32 range become ±Inf.
+func Float64ToFloat32(dst []float32, src []float64) int {
+	return Kernels{}.Float64ToFloat32(dst, src)
+}
+
+// ============================================================================
+// Arithmetic Operations
+// ============================================================================
+//
+// Element-wise arithmetic for projections such as SELECT price * qty + tax.
+// Column-column functions combine a[i] with b[i] over min(len(a), len(b))
+// elements; column-scalar functions combine every a[i] with b. The Into variants
+// write into a caller-provided dst, which may be a or b itself, and return the number
+// of elements written.
+//
+// Int64 results wrap like Go's integer arithmetic, and every int64 function also
+// reports whether any element overflowed so a query can raise an error.
+// Float64 results follow IEEE 754: overflow gives ±Inf and 0/0 gives NaN.
+
+// AddInt64 computes result[i] = a[i] + b[i] over min(len(a), len(b)) elements.
+// overflow reports whether any element overflowed; the result wraps.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (4 elements per operation)
+//   - NEON on ARM64 processors (2 elements per operation)
+//   - Scalar fallback on other architectures
+func AddInt64(a, b []int64) (result []int64, overflow bool) {
+	return Kernels{}.AddInt64(a, b)
+}
+
+// AddInt64Into computes dst[i] = a[i] + b[i] over min(len(dst), len(a), len(b))
+// elements and returns that count and whether any element overflowed.
+func AddInt64Into(dst, a, b []int64) (n int, overflow bool) {
+	return Kernels{}.AddInt64Into(dst, a, b)
+}
+
+// AddInt64Scalar computes result[i] = a[i] + b and reports whether any element overflowed.
+func AddInt64Scalar(a []int64, b int64) (result []int64, overflow bool) {
+	return Kernels{}.AddInt64Scalar(a, b)
+}
+
+// AddInt64ScalarInto computes dst[i] = a[i] + b over min(len(dst), len(a)) elements
+// and returns that count and whether any element overflowed.
+func AddInt64ScalarInto(dst, a []int64, b int64) (n int, overflow bool) {
+	return Kernels{}.AddInt64ScalarInto(dst, a, b)
+}
+
+// SubInt64 computes result[i] = a[i] - b[i] over min(len(a), len(b)) elements.
+// overflow reports whether any element overflowed; the result wraps.
+func SubInt64(a, b []int64) (result []int64, overflow bool) {
+	return Kernels{}.SubInt64(a, b)
+}
+
+// SubInt64Into computes dst[i] = a[i] - b[i] over min(len(dst), len(a), len(b))
+// elements and returns that count and whether any element overflowed.
+func SubInt64Into(dst, a, b []int64) (n int, overflow bool) {
+	return Kernels{}.SubInt64Into(dst, a, b)
+}
+
+// SubInt64Scalar computes result[i] = a[i] - b and reports whether any element overflowed.
+func SubInt64Scalar(a []int64, b int64) (result []int64, overflow bool) {
+	return Kernels{}.SubInt64Scalar(a, b)
+}
+
+// SubInt64ScalarInto computes dst[i] = a[i] - b over min(len(dst), len(a)) elements
+// and returns that count and whether any element overflowed.
+func SubInt64ScalarInto(dst, a []int64, b int64) (n int, overflow bool) {
+	return Kernels{}.SubInt64ScalarInto(dst, a, b)
+}
+
+// MulInt64 computes result[i] = a[i] * b[i] over min(len(a), len(b)) elements.
+// overflow reports whether any element overflowed; the result wraps.
+// Always scalar: AVX2 and NEON have no 64-bit multiply-high for overflow detection.
+func MulInt64(a, b []int64) (result []int64, overflow bool) {
+	result = make([]int64, min(len(a), len(b)))
+	_, overflow = MulInt64Into(result, a, b)
+	return result, overflow
+}
+
+// MulInt64Into computes dst[i] = a[i] * b[i] over min(len(dst), len(a), len(b))
+// elements and returns that count and whether any element overflowed.
+func MulInt64Into(dst, a, b []int64) (n int, overflow bool) {
+	n = min(len(dst), len(a), len(b))
+	if n == 0 {
+		return 0, false
+	}
+
+	return n, mulInt64Generic(dst[:n], a[:n], b[:n])
+}
+
+// MulInt64Scalar computes result[i] = a[i] * b and reports whether any element overflowed.
+func MulInt64Scalar(a []int64, b int64) (result []int64, overflow bool) {
+	result = make([]int64, len(a))
+	_, overflow = MulInt64ScalarInto(result, a, b)
+	return result, overflow
+}
+
+// MulInt64ScalarInto computes dst[i] = a[i] * b over min(len(dst), len(a)) elements
+// and returns that count and whether any element overflowed.
+func MulInt64ScalarInto(dst, a []int64, b int64) (n int, overflow bool) {
+	n = min(len(dst), len(a))
+	if n == 0 {
+		return 0, false
+	}
+
+	return n, mulInt64ScalarGeneric(dst[:n], a[:n], b)
+}
+
+// DivInt64 computes result[i] = a[i] / b[i] over min(len(a), len(b)) elements.
+// overflow reports whether any element overflowed; the result wraps.
+// Division truncates toward zero. A zero divisor yields 0 and is reported as an
+// overflow, as is MinInt64 / -1.
+// Always scalar: AVX2 and NEON have no integer division.
+func DivInt64(a, b []int64) (result []int64, overflow bool) {
+	result = make([]int64, min(len(a), len(b)))
+	_, overflow = DivInt64Into(result, a, b)
+	return result, overflow
+}
+
+// DivInt64Into computes dst[i] = a[i] / b[i] over min(len(dst), len(a), len(b))
+// elements and returns that count and whether any element overflowed.
+func DivInt64Into(dst, a, b []int64) (n int, overflow bool) {
+	n = min(len(dst), len(a), len(b))
+	if n == 0 {
+		return 0, false
+	}
+
+	return n, divInt64Generic(dst[:n], a[:n], b[:n])
+}
+
+// DivInt64Scalar computes result[i] = a[i] / b and reports whether any element overflowed.
+func DivInt64Scalar(a []int64, b int64) (result []int64, overflow bool) {
+	result = make([]int64, len(a))
+	_, overflow = DivInt64ScalarInto(result, a, b)
+	return result, overflow
+}
+
+// DivInt64ScalarInto computes dst[i] = a[i] / b over min(len(dst), len(a)) elements
+// and returns that count and whether any element overflowed.
+func DivInt64ScalarInto(dst, a []int64, b int64) (n int, overflow bool) {
+	n = min(len(dst), len(a))
+	if n == 0 {
+		return 0, false
+	}
+
+	return n, divInt64ScalarGeneric(dst[:n], a[:n], b)
+}
+
+// NegInt64 computes result[i] = -a[i] and reports whether any element overflowed.
+// MinInt64 is the only value that overflows.
+func NegInt64(a []int64) (result []int64, overflow bool) {
+	return Kernels{}.NegInt64(a)
+}
+
+// NegInt64Into computes dst[i] = -a[i] over min(len(dst), len(a)) elements
+// and returns that count and whether any element overflowed.
+func NegInt64Into(dst, a []int64) (n int, overflow bool) {
+	return Kernels{}.NegInt64Into(dst, a)
+}
+
+// AbsInt64 computes result[i] = |a[i]| and reports whether any element overflowed.
+// MinInt64 is the only value that overflows.
+func AbsInt64(a []int64) (result []int64, overflow bool) {
+	return Kernels{}.AbsInt64(a)
+}
+
+// AbsInt64Into computes dst[i] = |a[i]| over min(len(dst), len(a)) elements
+// and returns that count and whether any element overflowed.
+func AbsInt64Into(dst, a []int64) (n int, overflow bool) {
+	return Kernels{}.AbsInt64Into(dst, a)
+}
+
+// AddFloat64 computes result[i] = a[i] + b[i] over min(len(a), len(b)) elements.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (4 elements per operation)
+//   - NEON on ARM64 processors (2 elements per operation)
+//   - Scalar fallback on other architectures
+func AddFloat64(a, b []float64) []float64 {
+	return Kernels{}.AddFloat64(a, b)
+}
+
+// AddFloat64Into computes dst[i] = a[i] + b[i] over min(len(dst), len(a), len(b))
+// elements and returns that count.
+func AddFloat64Into(dst, a, b []float64) int {
+	return Kernels{}.AddFloat64Into(dst, a, b)
+}
+
+// AddFloat64Scalar computes result[i] = a[i] + b.
+func AddFloat64Scalar(a []float64, b float64) []float64 {
+	return Kernels{}.AddFloat64Scalar(a, b)
+}
+
+// AddFloat64ScalarInto computes dst[i] = a[i] + b over min(len(dst), len(a)) elements
+// and returns that count.
+func AddFloat64ScalarInto(dst, a []float64, b float64) int {
+	return Kernels{}.AddFloat64ScalarInto(dst, a, b)
+}
+
+// SubFloat64 computes result[i] = a[i] - b[i] over min(len(a), len(b)) elements.
+func SubFloat64(a, b []float64) []float64 {
+	return Kernels{}.SubFloat64(a, b)
+}
+
+// SubFloat64Into computes dst[i] = a[i] - b[i] over min(len(dst), len(a), len(b))
+// elements and returns that count.
+func SubFloat64Into(dst, a, b []float64) int {
+	return Kernels{}.SubFloat64Into(dst, a, b)
+}
+
+// SubFloat64Scalar computes result[i] = a[i] - b.
+func SubFloat64Scalar(a []float64, b float64) []float64 {
+	return Kernels{}.SubFloat64Scalar(a, b)
+}
+
+// SubFloat64ScalarInto computes dst[i] = a[i] - b over min(len(dst), len(a)) elements
+// and returns that count.
+func SubFloat64ScalarInto(dst, a []float64, b float64) int {
+	return Kernels{}.SubFloat64ScalarInto(dst, a, b)
+}
+
+// MulFloat64 computes result[i] = a[i] * b[i] over min(len(a), len(b)) elements.
+func MulFloat64(a, b []float64) []float64 {
+	return Kernels{}.MulFloat64(a, b)
+}
+
+// MulFloat64Into computes dst[i] = a[i] * b[i] over min(len(dst), len(a), len(b))
+// elements and returns that count.
+func MulFloat64Into(dst, a, b []float64) int {
+	return Kernels{}.MulFloat64Into(dst, a, b)
+}
+
+// MulFloat64Scalar computes result[i] = a[i] * b.
+func MulFloat64Scalar(a []float64, b float64) []float64 {
+	return Kernels{}.MulFloat64Scalar(a, b)
+}
+
+// MulFloat64ScalarInto computes dst[i] = a[i] * b over min(len(dst), len(a)) elements
+// and returns that count.
+func MulFloat64ScalarInto(dst, a []float64, b float64) int {
+	return Kernels{}.MulFloat64ScalarInto(dst, a, b)
+}
+
+// DivFloat64 computes result[i] = a[i] / b[i] over min(len(a), len(b)) elements.
+// Division by zero gives ±Inf, or NaN for 0/0.
+func DivFloat64(a, b []float64) []float64 {
+	return Kernels{}.DivFloat64(a, b)
+}
+
+// DivFloat64Into computes dst[i] = a[i] / b[i] over min(len(dst), len(a), len(b))
+// elements and returns that count.
+func DivFloat64Into(dst, a, b []float64) int {
+	return Kernels{}.DivFloat64Into(dst, a, b)
+}
+
+// DivFloat64Scalar computes result[i] = a[i] / b.
+func DivFloat64Scalar(a []float64, b float64) []float64 {
+	return Kernels{}.DivFloat64Scalar(a, b)
+}
+
+// DivFloat64ScalarInto computes dst[i] = a[i] / b over min(len(dst), len(a)) elements
+// and returns that count.
+func DivFloat64ScalarInto(dst, a []float64, b float64) int {
+	return Kernels{}.DivFloat64ScalarInto(dst, a, b)
+}
+
+// NegFloat64 computes result[i] = -a[i]. Only the sign bit changes, so NaN stays NaN.
+func NegFloat64(a []float64) []float64 {
+	return Kernels{}.NegFloat64(a)
+}
+
+// NegFloat64Into computes dst[i] = -a[i] over min(len(dst), len(a)) elements
+// and returns that count.
+func NegFloat64Into(dst, a []float64) int {
+	return Kernels{}.NegFloat64Into(dst, a)
+}
+
+// AbsFloat64 computes result[i] = |a[i]|. The sign bit is cleared, so -0 becomes +0.
+func AbsFloat64(a []float64) []float64 {
+	return Kernels{}.AbsFloat64(a)
+}
+
+// AbsFloat64Into computes dst[i] = |a[i]| over min(len(dst), len(a)) elements
+// and returns that count.
+func AbsFloat64Into(dst, a []float64) int {
+	return Kernels{}.AbsFloat64Into(dst, a)
 }
@@ -0,0 +1,54 @@
+//go:build amd64
+
+package syndrdbsimd
+
+// AVX2 kernels for element-wise arithmetic.
+// See arith_amd64.s for the overflow detection and length requirements.
+
+//go:noescape
+func addInt64AVX2(dst, a, b *int64, length int) bool
+
+//go:noescape
+func addInt64ScalarAVX2(dst, a *int64, b int64, length int) bool
+
+//go:noescape
+func subInt64AVX2(dst, a, b *int64, length int) bool
+
+//go:noescape
+func subInt64ScalarAVX2(dst, a *int64, b int64, length int) bool
+
+//go:noescape
+func negInt64AVX2(dst, a *int64, length int) bool
+
+//go:noescape
+func absInt64AVX2(dst, a *int64, length int) bool
+
+//go:noescape
+func addFloat64AVX2(dst, a, b *float64, length int)
+
+//go:noescape
+func addFloat64ScalarAVX2(dst, a *float64, b float64, length int)
+
+//go:noescape
+func subFloat64AVX2(dst, a, b *float64, length int)
+
+//go:noescape
+func subFloat64ScalarAVX2(dst, a *float64, b float64, length int)
+
+//go:noescape
+func mulFloat64AVX2(dst, a, b *float64, length int)
+
+//go:noescape
+func mulFloat64ScalarAVX2(dst, a *float64, b float64, length int)
+
+//go:noescape
+func divFloat64AVX2(dst, a, b *float64, length int)
+
+//go:noescape
+func divFloat64ScalarAVX2(dst, a *float64, b float64, length int)
+
+//go:noescape
+func negFloat64AVX2(dst, a *float64, length int)
+
+//go:noescape
+func absFloat64AVX2(dst, a *float64, length int)
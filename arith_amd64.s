@@ -0,0 +1,212 @@
+#include "textflag.h"
+
+// ============================================================================
+// Int64 arithmetic
+// ============================================================================
+//
+// Every kernel processes 8 elements per iteration; length must be a multiple of 8.
+// Results wrap like Go's integer arithmetic and each kernel returns true if any
+// element overflowed. Overflow lanes are ORed into Y0 with the sign bit set, and
+// VMOVMSKPD collects the sign bits at the end.
+//
+// The column-scalar kernels pass VPBROADCASTQ and the scalar argument as the
+// load instruction and address of the second operand, so both forms share a macro.
+//
+// Register usage:
+//   SI: a, DX: b, DI: dst, CX: remaining iterations, Y0: overflow accumulator
+
+// INT64_ARITH_STEP computes r = a op b for the 4 elements at off and records
+// overflow. combine turns x = a^r and y = b^r into a value whose sign bit is set
+// on overflow: for addition x & y (both operands differ in sign from r), for
+// subtraction x &^ y (a differs in sign from both r and b).
+#define INT64_ARITH_STEP(op, combine, ld, baddr, off) \
+    VMOVDQU off(SI), Y1 \
+    ld      baddr, Y2 \
+    op      Y2, Y1, Y3                  /* Y3 = a op b */ \
+    VPXOR   Y1, Y3, Y4                  /* Y4 = a ^ r */ \
+    VPXOR   Y2, Y3, Y5                  /* Y5 = b ^ r */ \
+    combine Y4, Y5, Y6 \
+    VPOR    Y6, Y0, Y0 \
+    VMOVDQU Y3, off(DI)
+
+// INT64_ARITH_AVX2 defines a kernel with the signature
+// func(dst, a, b *int64, length int) bool, or with b int64 for the scalar form.
+#define INT64_ARITH_AVX2(name, op, combine, ld, baddr0, baddr1) \
+TEXT name(SB), NOSPLIT, $0-33 \
+    MOVQ    dst+0(FP), DI \
+    MOVQ    a+8(FP), SI \
+    MOVQ    b+16(FP), DX \
+    MOVQ    length+24(FP), CX \
+    VPXOR   Y0, Y0, Y0 \
+    SHRQ    $3, CX \
+    JZ      done \
+loop: \
+    INT64_ARITH_STEP(op, combine, ld, baddr0, 0) \
+    INT64_ARITH_STEP(op, combine, ld, baddr1, 32) \
+    ADDQ    $64, SI \
+    ADDQ    $64, DX \
+    ADDQ    $64, DI \
+    DECQ    CX \
+    JNZ     loop \
+done: \
+    VMOVMSKPD Y0, AX \
+    VZEROUPPER \
+    TESTL   AX, AX \
+    SETNE   ret+32(FP) \
+    RET
+
+// func addInt64AVX2(dst, a, b *int64, length int) bool
+INT64_ARITH_AVX2(·addInt64AVX2, VPADDQ, VPAND, VMOVDQU, 0(DX), 32(DX))
+
+// func addInt64ScalarAVX2(dst, a *int64, b int64, length int) bool
+INT64_ARITH_AVX2(·addInt64ScalarAVX2, VPADDQ, VPAND, VPBROADCASTQ, b+16(FP), b+16(FP))
+
+// func subInt64AVX2(dst, a, b *int64, length int) bool
+INT64_ARITH_AVX2(·subInt64AVX2, VPSUBQ, VPANDN, VMOVDQU, 0(DX), 32(DX))
+
+// func subInt64ScalarAVX2(dst, a *int64, b int64, length int) bool
+INT64_ARITH_AVX2(·subInt64ScalarAVX2, VPSUBQ, VPANDN, VPBROADCASTQ, b+16(FP), b+16(FP))
+
+// INT64_NEG_STEP computes r = 0 - a for the 4 elements at off. Only MinInt64
+// overflows, and it is the only value for which a and r are both negative.
+#define INT64_NEG_STEP(off) \
+    VMOVDQU off(SI), Y1 \
+    VPSUBQ  Y1, Y7, Y3                  /* Y3 = 0 - a */ \
+    VPAND   Y1, Y3, Y4 \
+    VPOR    Y4, Y0, Y0 \
+    VMOVDQU Y3, off(DI)
+
+// INT64_ABS_STEP computes r = (a ^ s) - s for the 4 elements at off, where s is
+// all ones for negative a. Only MinInt64 overflows, leaving r negative.
+#define INT64_ABS_STEP(off) \
+    VMOVDQU off(SI), Y1 \
+    VPCMPGTQ Y1, Y7, Y2                 /* Y2 = a < 0 ? -1 : 0 */ \
+    VPXOR   Y2, Y1, Y3 \
+    VPSUBQ  Y2, Y3, Y3 \
+    VPOR    Y3, Y0, Y0 \
+    VMOVDQU Y3, off(DI)
+
+// INT64_UNARY_AVX2 defines a kernel with the signature
+// func(dst, a *int64, length int) bool
+#define INT64_UNARY_AVX2(name, step) \
+TEXT name(SB), NOSPLIT, $0-25 \
+    MOVQ    dst+0(FP), DI \
+    MOVQ    a+8(FP), SI \
+    MOVQ    length+16(FP), CX \
+    VPXOR   Y0, Y0, Y0 \
+    VPXOR   Y7, Y7, Y7                  /* Y7 = 0 */ \
+    SHRQ    $3, CX \
+    JZ      done \
+loop: \
+    step(0) \
+    step(32) \
+    ADDQ    $64, SI \
+    ADDQ    $64, DI \
+    DECQ    CX \
+    JNZ     loop \
+done: \
+    VMOVMSKPD Y0, AX \
+    VZEROUPPER \
+    TESTL   AX, AX \
+    SETNE   ret+24(FP) \
+    RET
+
+// func negInt64AVX2(dst, a *int64, length int) bool
+INT64_UNARY_AVX2(·negInt64AVX2, INT64_NEG_STEP)
+
+// func absInt64AVX2(dst, a *int64, length int) bool
+INT64_UNARY_AVX2(·absInt64AVX2, INT64_ABS_STEP)
+
+// ============================================================================
+// Float64 arithmetic
+// ============================================================================
+//
+// Same layout as the int64 kernels, without overflow tracking: results follow
+// IEEE 754, so overflow gives ±Inf and 0/0 gives NaN, exactly as in Go.
+
+// FLOAT64_ARITH_AVX2 defines a kernel with the signature
+// func(dst, a, b *float64, length int), or with b float64 for the scalar form.
+#define FLOAT64_ARITH_AVX2(name, op, ld, baddr0, baddr1) \
+TEXT name(SB), NOSPLIT, $0-32 \
+    MOVQ    dst+0(FP), DI \
+    MOVQ    a+8(FP), SI \
+    MOVQ    b+16(FP), DX \
+    MOVQ    length+24(FP), CX \
+    SHRQ    $3, CX \
+    JZ      done \
+loop: \
+    VMOVUPD (SI), Y1 \
+    VMOVUPD 32(SI), Y3 \
+    ld      baddr0, Y2 \
+    ld      baddr1, Y4 \
+    op      Y2, Y1, Y1                  /* Y1 = a op b */ \
+    op      Y4, Y3, Y3 \
+    VMOVUPD Y1, (DI) \
+    VMOVUPD Y3, 32(DI) \
+    ADDQ    $64, SI \
+    ADDQ    $64, DX \
+    ADDQ    $64, DI \
+    DECQ    CX \
+    JNZ     loop \
+    VZEROUPPER \
+done: \
+    RET
+
+// func addFloat64AVX2(dst, a, b *float64, length int)
+FLOAT64_ARITH_AVX2(·addFloat64AVX2, VADDPD, VMOVUPD, 0(DX), 32(DX))
+
+// func addFloat64ScalarAVX2(dst, a *float64, b float64, length int)
+FLOAT64_ARITH_AVX2(·addFloat64ScalarAVX2, VADDPD, VBROADCASTSD, b+16(FP), b+16(FP))
+
+// func subFloat64AVX2(dst, a, b *float64, length int)
+FLOAT64_ARITH_AVX2(·subFloat64AVX2, VSUBPD, VMOVUPD, 0(DX), 32(DX))
+
+// func subFloat64ScalarAVX2(dst, a *float64, b float64, length int)
+FLOAT64_ARITH_AVX2(·subFloat64ScalarAVX2, VSUBPD, VBROADCASTSD, b+16(FP), b+16(FP))
+
+// func mulFloat64AVX2(dst, a, b *float64, length int)
+FLOAT64_ARITH_AVX2(·mulFloat64AVX2, VMULPD, VMOVUPD, 0(DX), 32(DX))
+
+// func mulFloat64ScalarAVX2(dst, a *float64, b float64, length int)
+FLOAT64_ARITH_AVX2(·mulFloat64ScalarAVX2, VMULPD, VBROADCASTSD, b+16(FP), b+16(FP))
+
+// func divFloat64AVX2(dst, a, b *float64, length int)
+FLOAT64_ARITH_AVX2(·divFloat64AVX2, VDIVPD, VMOVUPD, 0(DX), 32(DX))
+
+// func divFloat64ScalarAVX2(dst, a *float64, b float64, length int)
+FLOAT64_ARITH_AVX2(·divFloat64ScalarAVX2, VDIVPD, VBROADCASTSD, b+16(FP), b+16(FP))
+
+// FLOAT64_UNARY_AVX2 defines a kernel with the signature
+// func(dst, a *float64, length int)
+// that applies op with a broadcast bit mask: XOR with the sign bit negates,
+// AND with everything but the sign bit takes the absolute value.
+#define FLOAT64_UNARY_AVX2(name, op, bits) \
+TEXT name(SB), NOSPLIT, $0-24 \
+    MOVQ    dst+0(FP), DI \
+    MOVQ    a+8(FP), SI \
+    MOVQ    length+16(FP), CX \
+    SHRQ    $3, CX \
+    JZ      done \
+    MOVQ    bits, AX \
+    MOVQ    AX, X0 \
+    VPBROADCASTQ X0, Y0 \
+loop: \
+    VMOVUPD (SI), Y1 \
+    VMOVUPD 32(SI), Y2 \
+    op      Y0, Y1, Y1 \
+    op      Y0, Y2, Y2 \
+    VMOVUPD Y1, (DI) \
+    VMOVUPD Y2, 32(DI) \
+    ADDQ    $64, SI \
+    ADDQ    $64, DI \
+    DECQ    CX \
+    JNZ     loop \
+    VZEROUPPER \
+done: \
+    RET
+
+// func negFloat64AVX2(dst, a *float64, length int)
+FLOAT64_UNARY_AVX2(·negFloat64AVX2, VXORPD, $0x8000000000000000)
+
+// func absFloat64AVX2(dst, a *float64, length int)
+FLOAT64_UNARY_AVX2(·absFloat64AVX2, VANDPD, $0x7FFFFFFFFFFFFFFF)
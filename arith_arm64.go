@@ -0,0 +1,54 @@
+//go:build arm64
+
+package syndrdbsimd
+
+// NEON kernels for element-wise arithmetic.
+// See arith_arm64.s for the overflow detection and length requirements.
+
+//go:noescape
+func addInt64NEON(dst, a, b *int64, length int) bool
+
+//go:noescape
+func addInt64ScalarNEON(dst, a *int64, b int64, length int) bool
+
+//go:noescape
+func subInt64NEON(dst, a, b *int64, length int) bool
+
+//go:noescape
+func subInt64ScalarNEON(dst, a *int64, b int64, length int) bool
+
+//go:noescape
+func negInt64NEON(dst, a *int64, length int) bool
+
+//go:noescape
+func absInt64NEON(dst, a *int64, length int) bool
+
+//go:noescape
+func addFloat64NEON(dst, a, b *float64, length int)
+
+//go:noescape
+func addFloat64ScalarNEON(dst, a *float64, b float64, length int)
+
+//go:noescape
+func subFloat64NEON(dst, a, b *float64, length int)
+
+//go:noescape
+func subFloat64ScalarNEON(dst, a *float64, b float64, length int)
+
+//go:noescape
+func mulFloat64NEON(dst, a, b *float64, length int)
+
+//go:noescape
+func mulFloat64ScalarNEON(dst, a *float64, b float64, length int)
+
+//go:noescape
+func divFloat64NEON(dst, a, b *float64, length int)
+
+//go:noescape
+func divFloat64ScalarNEON(dst, a *float64, b float64, length int)
+
+//go:noescape
+func negFloat64NEON(dst, a *float64, length int)
+
+//go:noescape
+func absFloat64NEON(dst, a *float64, length int)
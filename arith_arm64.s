@@ -0,0 +1,208 @@
+#include "textflag.h"
+
+// Vector floating-point instructions and the vector NEG/ABS forms are emitted as
+// WORD directives because older Go ARM64 assemblers have no mnemonics for them.
+// Each is annotated with its disassembly.
+//
+// Every kernel processes 4 elements per iteration; length must be a multiple of 4.
+//
+// Register usage:
+//   R0: dst, R1: a, R2: b, R3: remaining iterations
+//   V0-V1: a, V2-V3: b, V4-V5: results, V30: broadcast scalar b, V31: overflow lanes
+
+// LOAD_B_COLUMN and LOAD_B_SCALAR fill V2-V3 with the next 4 values of b, from the
+// b column or from the broadcast scalar, so each kernel macro serves both forms.
+#define LOAD_B_COLUMN VLD1.P 32(R2), [V2.D2, V3.D2]
+#define LOAD_B_SCALAR VORR V30.B16, V30.B16, V2.B16; VORR V30.B16, V30.B16, V3.B16
+
+// ============================================================================
+// Int64 arithmetic
+// ============================================================================
+//
+// Results wrap like Go's integer arithmetic and each kernel returns true if any
+// element overflowed. Overflow lanes are ORed into V31 with the sign bit set.
+
+// ADD_OVERFLOW and SUB_OVERFLOW compute y in V6-V7 such that (a^r) & y has the
+// sign bit set on overflow: y = b^r for addition, y = a^b for subtraction.
+#define ADD_OVERFLOW VEOR V2.B16, V4.B16, V6.B16; VEOR V3.B16, V5.B16, V7.B16
+#define SUB_OVERFLOW VEOR V2.B16, V0.B16, V6.B16; VEOR V3.B16, V1.B16, V7.B16
+
+// INT64_ARITH_NEON defines a kernel with the signature
+// func(dst, a, b *int64, length int) bool, or with b int64 for the scalar form.
+#define INT64_ARITH_NEON(name, op, overflow, loadb) \
+TEXT name(SB), NOSPLIT, $0-33 \
+    MOVD    dst+0(FP), R0 \
+    MOVD    a+8(FP), R1 \
+    MOVD    b+16(FP), R2 \
+    MOVD    length+24(FP), R3 \
+    VDUP    R2, V30.D2                  /* Only used by the scalar form */ \
+    VEOR    V31.B16, V31.B16, V31.B16 \
+    LSR     $2, R3, R3 \
+    CBZ     R3, done \
+loop: \
+    VLD1.P  32(R1), [V0.D2, V1.D2] \
+    loadb \
+    op      V2.D2, V0.D2, V4.D2         /* r = a op b */ \
+    op      V3.D2, V1.D2, V5.D2 \
+    overflow \
+    VEOR    V0.B16, V4.B16, V16.B16     /* a ^ r */ \
+    VEOR    V1.B16, V5.B16, V17.B16 \
+    VAND    V16.B16, V6.B16, V6.B16 \
+    VAND    V17.B16, V7.B16, V7.B16 \
+    VORR    V6.B16, V31.B16, V31.B16 \
+    VORR    V7.B16, V31.B16, V31.B16 \
+    VST1.P  [V4.D2, V5.D2], 32(R0) \
+    SUB     $1, R3, R3 \
+    CBNZ    R3, loop \
+done: \
+    VMOV    V31.D[0], R4 \
+    VMOV    V31.D[1], R5 \
+    ORR     R5, R4, R4 \
+    LSR     $63, R4, R4 \
+    MOVB    R4, ret+32(FP) \
+    RET
+
+// func addInt64NEON(dst, a, b *int64, length int) bool
+INT64_ARITH_NEON(·addInt64NEON, VADD, ADD_OVERFLOW, LOAD_B_COLUMN)
+
+// func addInt64ScalarNEON(dst, a *int64, b int64, length int) bool
+INT64_ARITH_NEON(·addInt64ScalarNEON, VADD, ADD_OVERFLOW, LOAD_B_SCALAR)
+
+// func subInt64NEON(dst, a, b *int64, length int) bool
+INT64_ARITH_NEON(·subInt64NEON, VSUB, SUB_OVERFLOW, LOAD_B_COLUMN)
+
+// func subInt64ScalarNEON(dst, a *int64, b int64, length int) bool
+INT64_ARITH_NEON(·subInt64ScalarNEON, VSUB, SUB_OVERFLOW, LOAD_B_SCALAR)
+
+// NEG_OVERFLOW and ABS_OVERFLOW merge the overflow lanes into V31. Only MinInt64
+// overflows: negation leaves a and r both negative, abs leaves r negative.
+#define NEG_OVERFLOW VAND V0.B16, V4.B16, V6.B16; VAND V1.B16, V5.B16, V7.B16; VORR V6.B16, V31.B16, V31.B16; VORR V7.B16, V31.B16, V31.B16
+#define ABS_OVERFLOW VORR V4.B16, V31.B16, V31.B16; VORR V5.B16, V31.B16, V31.B16
+
+// INT64_UNARY_NEON defines a kernel with the signature
+// func(dst, a *int64, length int) bool
+// where c1 and c2 compute V4-V5 from V0-V1.
+#define INT64_UNARY_NEON(name, c1, c2, overflow) \
+TEXT name(SB), NOSPLIT, $0-25 \
+    MOVD    dst+0(FP), R0 \
+    MOVD    a+8(FP), R1 \
+    MOVD    length+16(FP), R3 \
+    VEOR    V31.B16, V31.B16, V31.B16 \
+    LSR     $2, R3, R3 \
+    CBZ     R3, done \
+loop: \
+    VLD1.P  32(R1), [V0.D2, V1.D2] \
+    WORD    c1 \
+    WORD    c2 \
+    overflow \
+    VST1.P  [V4.D2, V5.D2], 32(R0) \
+    SUB     $1, R3, R3 \
+    CBNZ    R3, loop \
+done: \
+    VMOV    V31.D[0], R4 \
+    VMOV    V31.D[1], R5 \
+    ORR     R5, R4, R4 \
+    LSR     $63, R4, R4 \
+    MOVB    R4, ret+24(FP) \
+    RET
+
+// func negInt64NEON(dst, a *int64, length int) bool
+//   neg v4.2d, v0.2d
+//   neg v5.2d, v1.2d
+INT64_UNARY_NEON(·negInt64NEON, $0x6ee0b804, $0x6ee0b825, NEG_OVERFLOW)
+
+// func absInt64NEON(dst, a *int64, length int) bool
+//   abs v4.2d, v0.2d
+//   abs v5.2d, v1.2d
+INT64_UNARY_NEON(·absInt64NEON, $0x4ee0b804, $0x4ee0b825, ABS_OVERFLOW)
+
+// ============================================================================
+// Float64 arithmetic
+// ============================================================================
+//
+// Results follow IEEE 754, so overflow gives ±Inf and 0/0 gives NaN, exactly as in Go.
+
+// FLOAT64_ARITH_NEON defines a kernel with the signature
+// func(dst, a, b *float64, length int), or with b float64 for the scalar form,
+// where c1 and c2 compute V4-V5 from V0-V1 and V2-V3.
+#define FLOAT64_ARITH_NEON(name, c1, c2, loadb) \
+TEXT name(SB), NOSPLIT, $0-32 \
+    MOVD    dst+0(FP), R0 \
+    MOVD    a+8(FP), R1 \
+    MOVD    b+16(FP), R2 \
+    MOVD    length+24(FP), R3 \
+    VDUP    R2, V30.D2                  /* Only used by the scalar form */ \
+    LSR     $2, R3, R3 \
+    CBZ     R3, done \
+loop: \
+    VLD1.P  32(R1), [V0.D2, V1.D2] \
+    loadb \
+    WORD    c1 \
+    WORD    c2 \
+    VST1.P  [V4.D2, V5.D2], 32(R0) \
+    SUB     $1, R3, R3 \
+    CBNZ    R3, loop \
+done: \
+    RET
+
+// func addFloat64NEON(dst, a, b *float64, length int)
+//   fadd v4.2d, v0.2d, v2.2d
+//   fadd v5.2d, v1.2d, v3.2d
+FLOAT64_ARITH_NEON(·addFloat64NEON, $0x4e62d404, $0x4e63d425, LOAD_B_COLUMN)
+
+// func addFloat64ScalarNEON(dst, a *float64, b float64, length int)
+FLOAT64_ARITH_NEON(·addFloat64ScalarNEON, $0x4e62d404, $0x4e63d425, LOAD_B_SCALAR)
+
+// func subFloat64NEON(dst, a, b *float64, length int)
+//   fsub v4.2d, v0.2d, v2.2d
+//   fsub v5.2d, v1.2d, v3.2d
+FLOAT64_ARITH_NEON(·subFloat64NEON, $0x4ee2d404, $0x4ee3d425, LOAD_B_COLUMN)
+
+// func subFloat64ScalarNEON(dst, a *float64, b float64, length int)
+FLOAT64_ARITH_NEON(·subFloat64ScalarNEON, $0x4ee2d404, $0x4ee3d425, LOAD_B_SCALAR)
+
+// func mulFloat64NEON(dst, a, b *float64, length int)
+//   fmul v4.2d, v0.2d, v2.2d
+//   fmul v5.2d, v1.2d, v3.2d
+FLOAT64_ARITH_NEON(·mulFloat64NEON, $0x6e62dc04, $0x6e63dc25, LOAD_B_COLUMN)
+
+// func mulFloat64ScalarNEON(dst, a *float64, b float64, length int)
+FLOAT64_ARITH_NEON(·mulFloat64ScalarNEON, $0x6e62dc04, $0x6e63dc25, LOAD_B_SCALAR)
+
+// func divFloat64NEON(dst, a, b *float64, length int)
+//   fdiv v4.2d, v0.2d, v2.2d
+//   fdiv v5.2d, v1.2d, v3.2d
+FLOAT64_ARITH_NEON(·divFloat64NEON, $0x6e62fc04, $0x6e63fc25, LOAD_B_COLUMN)
+
+// func divFloat64ScalarNEON(dst, a *float64, b float64, length int)
+FLOAT64_ARITH_NEON(·divFloat64ScalarNEON, $0x6e62fc04, $0x6e63fc25, LOAD_B_SCALAR)
+
+// FLOAT64_UNARY_NEON defines a kernel with the signature
+// func(dst, a *float64, length int)
+// where c1 and c2 compute V4-V5 from V0-V1.
+#define FLOAT64_UNARY_NEON(name, c1, c2) \
+TEXT name(SB), NOSPLIT, $0-24 \
+    MOVD    dst+0(FP), R0 \
+    MOVD    a+8(FP), R1 \
+    MOVD    length+16(FP), R3 \
+    LSR     $2, R3, R3 \
+    CBZ     R3, done \
+loop: \
+    VLD1.P  32(R1), [V0.D2, V1.D2] \
+    WORD    c1 \
+    WORD    c2 \
+    VST1.P  [V4.D2, V5.D2], 32(R0) \
+    SUB     $1, R3, R3 \
+    CBNZ    R3, loop \
+done: \
+    RET
+
+// func negFloat64NEON(dst, a *float64, length int)
+//   fneg v4.2d, v0.2d
+//   fneg v5.2d, v1.2d
+FLOAT64_UNARY_NEON(·negFloat64NEON, $0x6ee0f804, $0x6ee0f825)
+
+// func absFloat64NEON(dst, a *float64, length int)
+//   fabs v4.2d, v0.2d
+//   fabs v5.2d, v1.2d
+FLOAT64_UNARY_NEON(·absFloat64NEON, $0x4ee0f804, $0x4ee0f825)
@@ -0,0 +1,212 @@
+package syndrdbsimd
+
+import (
+	"math"
+	"math/bits"
+)
+
+// The int64 functions below wrap like Go's integer arithmetic and return true if
+// any element overflowed. dst must be at least as long as a, and b for the
+// column-column forms.
+
+// addInt64Generic computes dst[i] = a[i] + b[i].
+func addInt64Generic(dst, a, b []int64) bool {
+	overflow := int64(0)
+	for i, x := range a {
+		r := x + b[i]
+		overflow |= (x ^ r) & (b[i] ^ r)
+		dst[i] = r
+	}
+	return overflow < 0
+}
+
+// addInt64ScalarGeneric computes dst[i] = a[i] + b.
+func addInt64ScalarGeneric(dst, a []int64, b int64) bool {
+	overflow := int64(0)
+	for i, x := range a {
+		r := x + b
+		overflow |= (x ^ r) & (b ^ r)
+		dst[i] = r
+	}
+	return overflow < 0
+}
+
+// subInt64Generic computes dst[i] = a[i] - b[i].
+func subInt64Generic(dst, a, b []int64) bool {
+	overflow := int64(0)
+	for i, x := range a {
+		r := x - b[i]
+		overflow |= (x ^ r) & (x ^ b[i])
+		dst[i] = r
+	}
+	return overflow < 0
+}
+
+// subInt64ScalarGeneric computes dst[i] = a[i] - b.
+func subInt64ScalarGeneric(dst, a []int64, b int64) bool {
+	overflow := int64(0)
+	for i, x := range a {
+		r := x - b
+		overflow |= (x ^ r) & (x ^ b)
+		dst[i] = r
+	}
+	return overflow < 0
+}
+
+// mulInt64Generic computes dst[i] = a[i] * b[i].
+func mulInt64Generic(dst, a, b []int64) bool {
+	overflow := false
+	for i, x := range a {
+		dst[i] = x * b[i]
+		overflow = overflow || mulOverflows(x, b[i])
+	}
+	return overflow
+}
+
+// mulInt64ScalarGeneric computes dst[i] = a[i] * b.
+func mulInt64ScalarGeneric(dst, a []int64, b int64) bool {
+	overflow := false
+	for i, x := range a {
+		dst[i] = x * b
+		overflow = overflow || mulOverflows(x, b)
+	}
+	return overflow
+}
+
+// mulOverflows reports whether x * y overflows an int64.
+func mulOverflows(x, y int64) bool {
+	hi, lo := bits.Mul64(uint64(x), uint64(y))
+	// Correct the unsigned high word to the signed product's high word
+	h := int64(hi) - (x>>63)&y - (y>>63)&x
+	return h != int64(lo)>>63
+}
+
+// divInt64Generic computes dst[i] = a[i] / b[i], truncating toward zero.
+// A zero divisor yields 0 and counts as an overflow, as does MinInt64 / -1.
+func divInt64Generic(dst, a, b []int64) bool {
+	overflow := false
+	for i, x := range a {
+		r, ok := divInt64(x, b[i])
+		dst[i] = r
+		overflow = overflow || !ok
+	}
+	return overflow
+}
+
+// divInt64ScalarGeneric computes dst[i] = a[i] / b, truncating toward zero.
+func divInt64ScalarGeneric(dst, a []int64, b int64) bool {
+	if b == 0 {
+		clear(dst[:len(a)])
+		return len(a) > 0
+	}
+	overflow := false
+	for i, x := range a {
+		dst[i] = x / b
+		overflow = overflow || (b == -1 && x == math.MinInt64)
+	}
+	return overflow
+}
+
+// divInt64 divides x by y, reporting false for a zero divisor or MinInt64 / -1.
+func divInt64(x, y int64) (int64, bool) {
+	if y == 0 {
+		return 0, false
+	}
+	return x / y, y != -1 || x != math.MinInt64
+}
+
+// negInt64Generic computes dst[i] = -a[i]. Only MinInt64 overflows.
+func negInt64Generic(dst, a []int64) bool {
+	overflow := int64(0)
+	for i, x := range a {
+		r := -x
+		overflow |= x & r
+		dst[i] = r
+	}
+	return overflow < 0
+}
+
+// absInt64Generic computes dst[i] = |a[i]|. Only MinInt64 overflows.
+func absInt64Generic(dst, a []int64) bool {
+	overflow := int64(0)
+	for i, x := range a {
+		s := x >> 63
+		r := (x ^ s) - s
+		overflow |= r
+		dst[i] = r
+	}
+	return overflow < 0
+}
+
+// The float64 functions below follow IEEE 754: overflow gives ±Inf and invalid
+// operations such as 0/0 give NaN.
+
+// addFloat64Generic computes dst[i] = a[i] + b[i].
+func addFloat64Generic(dst, a, b []float64) {
+	for i, x := range a {
+		dst[i] = x + b[i]
+	}
+}
+
+// addFloat64ScalarGeneric computes dst[i] = a[i] + b.
+func addFloat64ScalarGeneric(dst, a []float64, b float64) {
+	for i, x := range a {
+		dst[i] = x + b
+	}
+}
+
+// subFloat64Generic computes dst[i] = a[i] - b[i].
+func subFloat64Generic(dst, a, b []float64) {
+	for i, x := range a {
+		dst[i] = x - b[i]
+	}
+}
+
+// subFloat64ScalarGeneric computes dst[i] = a[i] - b.
+func subFloat64ScalarGeneric(dst, a []float64, b float64) {
+	for i, x := range a {
+		dst[i] = x - b
+	}
+}
+
+// mulFloat64Generic computes dst[i] = a[i] * b[i].
+func mulFloat64Generic(dst, a, b []float64) {
+	for i, x := range a {
+		dst[i] = x * b[i]
+	}
+}
+
+// mulFloat64ScalarGeneric computes dst[i] = a[i] * b.
+func mulFloat64ScalarGeneric(dst, a []float64, b float64) {
+	for i, x := range a {
+		dst[i] = x * b
+	}
+}
+
+// divFloat64Generic computes dst[i] = a[i] / b[i].
+func divFloat64Generic(dst, a, b []float64) {
+	for i, x := range a {
+		dst[i] = x / b[i]
+	}
+}
+
+// divFloat64ScalarGeneric computes dst[i] = a[i] / b.
+func divFloat64ScalarGeneric(dst, a []float64, b float64) {
+	for i, x := range a {
+		dst[i] = x / b
+	}
+}
+
+// negFloat64Generic computes dst[i] = -a[i].
+func negFloat64Generic(dst, a []float64) {
+	for i, x := range a {
+		dst[i] = -x
+	}
+}
+
+// absFloat64Generic computes dst[i] = |a[i]| by clearing the sign bit.
+func absFloat64Generic(dst, a []float64) {
+	for i, x := range a {
+		dst[i] = math.Abs(x)
+	}
+}
@@ -0,0 +1,358 @@
+package syndrdbsimd
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// ============================================================================
+// Int64 Arithmetic Tests
+// ============================================================================
+
+func TestArithInt64_HappyPath(t *testing.T) {
+	price := []int64{100, 250, -40, 7}
+	qty := []int64{3, 2, 5, 0}
+
+	total, overflow := MulInt64(price, qty)
+	checkEqual(t, 4, "MulInt64", total, []int64{300, 500, -200, 0})
+	if overflow {
+		t.Error("MulInt64: unexpected overflow")
+	}
+
+	withTax, _ := AddInt64Scalar(total, 15)
+	checkEqual(t, 4, "AddInt64Scalar", withTax, []int64{315, 515, -185, 15})
+
+	diff, _ := SubInt64(price, qty)
+	checkEqual(t, 4, "SubInt64", diff, []int64{97, 248, -45, 7})
+
+	neg, _ := NegInt64(price)
+	checkEqual(t, 4, "NegInt64", neg, []int64{-100, -250, 40, -7})
+
+	abs, _ := AbsInt64(neg)
+	checkEqual(t, 4, "AbsInt64", abs, []int64{100, 250, 40, 7})
+}
+
+// Each operation reports overflow for exactly the inputs that wrap
+func TestArithInt64_Overflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func() bool
+		overflow bool
+	}{
+		{"add max+1", func() bool { _, o := AddInt64([]int64{math.MaxInt64}, []int64{1}); return o }, true},
+		{"add min+max", func() bool { _, o := AddInt64([]int64{math.MinInt64}, []int64{math.MaxInt64}); return o }, false},
+		{"add scalar min-1", func() bool { _, o := AddInt64Scalar([]int64{math.MinInt64}, -1); return o }, true},
+		{"sub min-1", func() bool { _, o := SubInt64([]int64{math.MinInt64}, []int64{1}); return o }, true},
+		{"sub 0-min", func() bool { _, o := SubInt64Scalar([]int64{0}, math.MinInt64); return o }, true},
+		{"sub -1-min", func() bool { _, o := SubInt64Scalar([]int64{-1}, math.MinInt64); return o }, false},
+		{"mul 2^32*2^31", func() bool { _, o := MulInt64Scalar([]int64{1 << 32}, 1<<31); return o }, true},
+		{"mul -2^32*2^31", func() bool { _, o := MulInt64Scalar([]int64{-1 << 32}, 1<<31); return o }, false},
+		{"mul min*-1", func() bool { _, o := MulInt64([]int64{math.MinInt64}, []int64{-1}); return o }, true},
+		{"div min/-1", func() bool { _, o := DivInt64([]int64{math.MinInt64}, []int64{-1}); return o }, true},
+		{"div by zero", func() bool { _, o := DivInt64Scalar([]int64{5}, 0); return o }, true},
+		{"neg min", func() bool { _, o := NegInt64([]int64{math.MinInt64}); return o }, true},
+		{"abs min+1", func() bool { _, o := AbsInt64([]int64{math.MinInt64 + 1}); return o }, false},
+		{"abs min", func() bool { _, o := AbsInt64([]int64{math.MinInt64}); return o }, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.run(); got != tt.overflow {
+			t.Errorf("%s: overflow = %v, want %v", tt.name, got, tt.overflow)
+		}
+	}
+}
+
+// mulOverflows must agree with an exact big.Int product
+func TestMulOverflows(t *testing.T) {
+	rng := rand.New(rand.NewSource(37))
+	edges := []int64{0, 1, -1, 2, -2, 3037000499, 3037000500, -3037000500, math.MaxInt64, math.MinInt64, 1 << 32, -1 << 31}
+	check := func(x, y int64) {
+		p := new(big.Int).Mul(big.NewInt(x), big.NewInt(y))
+		if want := !p.IsInt64(); mulOverflows(x, y) != want {
+			t.Errorf("mulOverflows(%d, %d) = %v, want %v", x, y, !want, want)
+		}
+	}
+	for _, x := range edges {
+		for _, y := range edges {
+			check(x, y)
+		}
+	}
+	for i := 0; i < 10000; i++ {
+		check(rng.Int63()>>rng.Intn(63)-rng.Int63()>>rng.Intn(63), rng.Int63()>>rng.Intn(63)-rng.Int63()>>rng.Intn(63))
+	}
+}
+
+func TestDivInt64_ZeroDivisor(t *testing.T) {
+	result, overflow := DivInt64([]int64{10, 7, -9}, []int64{3, 0, 2})
+	checkEqual(t, 3, "DivInt64", result, []int64{3, 0, -4})
+	if !overflow {
+		t.Error("Expected overflow for a zero divisor")
+	}
+
+	result, _ = DivInt64Scalar([]int64{10, -7}, 0)
+	checkEqual(t, 2, "DivInt64Scalar", result, []int64{0, 0})
+}
+
+// ============================================================================
+// Float64 Arithmetic Tests
+// ============================================================================
+
+func TestArithFloat64_HappyPath(t *testing.T) {
+	a := []float64{1.5, -2, 0, 8}
+	b := []float64{0.5, 4, 0, -2}
+
+	checkEqual(t, 4, "AddFloat64", AddFloat64(a, b), []float64{2, 2, 0, 6})
+	checkEqual(t, 4, "SubFloat64Scalar", SubFloat64Scalar(a, 1), []float64{0.5, -3, -1, 7})
+	checkEqual(t, 4, "MulFloat64Scalar", MulFloat64Scalar(a, 2), []float64{3, -4, 0, 16})
+	checkEqual(t, 4, "AbsFloat64", AbsFloat64(a), []float64{1.5, 2, 0, 8})
+
+	quotient := DivFloat64(a, b)
+	if quotient[0] != 3 || quotient[1] != -0.5 || !math.IsNaN(quotient[2]) || quotient[3] != -4 {
+		t.Errorf("DivFloat64 = %v, want [3 -0.5 NaN -4]", quotient)
+	}
+	if got := DivFloat64Scalar([]float64{1, -1}, 0); !math.IsInf(got[0], 1) || !math.IsInf(got[1], -1) {
+		t.Errorf("DivFloat64Scalar by zero = %v, want [+Inf -Inf]", got)
+	}
+	if got := NegFloat64([]float64{0}); !math.Signbit(got[0]) {
+		t.Error("NegFloat64(0): expected -0")
+	}
+}
+
+// ============================================================================
+// Kernel Equivalence Tests
+// ============================================================================
+
+// randomArithInt64 returns values of mixed magnitude, with the int64 extremes
+// sprinkled in so every kernel sees overflowing lanes.
+func randomArithInt64(rng *rand.Rand, size int) []int64 {
+	values := make([]int64, size)
+	for i := range values {
+		switch rng.Intn(8) {
+		case 0:
+			values[i] = math.MaxInt64 - rng.Int63n(4)
+		case 1:
+			values[i] = math.MinInt64 + rng.Int63n(4)
+		default:
+			values[i] = rng.Int63n(2001) - 1000
+		}
+	}
+	return values
+}
+
+// Every ISA must match the generic implementations, including overflow flags
+// for overflows in the vector part and in the scalar tail
+func TestArith_MatchGeneric(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+	cfg := DefaultConfig()
+	cfg.MinArith = 1
+	if err := SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	sizes := []int{1, 3, 4, 5, 7, 8, 9, 16, 17, 100, 1000}
+	intBinary := []struct {
+		name    string
+		impl    func(ks *kernelSet, dst, a, b []int64) bool
+		generic func(dst, a, b []int64) bool
+	}{
+		{"add", addInt64Impl, addInt64Generic},
+		{"sub", subInt64Impl, subInt64Generic},
+	}
+	intScalar := []struct {
+		name    string
+		impl    func(ks *kernelSet, dst, a []int64, b int64) bool
+		generic func(dst, a []int64, b int64) bool
+	}{
+		{"addScalar", addInt64ScalarImpl, addInt64ScalarGeneric},
+		{"subScalar", subInt64ScalarImpl, subInt64ScalarGeneric},
+	}
+	intUnary := []struct {
+		name    string
+		impl    func(ks *kernelSet, dst, a []int64) bool
+		generic func(dst, a []int64) bool
+	}{
+		{"neg", negInt64Impl, negInt64Generic},
+		{"abs", absInt64Impl, absInt64Generic},
+	}
+	floatBinary := []struct {
+		name    string
+		impl    func(ks *kernelSet, dst, a, b []float64)
+		generic func(dst, a, b []float64)
+	}{
+		{"add", addFloat64Impl, addFloat64Generic},
+		{"sub", subFloat64Impl, subFloat64Generic},
+		{"mul", mulFloat64Impl, mulFloat64Generic},
+		{"div", divFloat64Impl, divFloat64Generic},
+	}
+	floatScalar := []struct {
+		name    string
+		impl    func(ks *kernelSet, dst, a []float64, b float64)
+		generic func(dst, a []float64, b float64)
+	}{
+		{"addScalar", addFloat64ScalarImpl, addFloat64ScalarGeneric},
+		{"subScalar", subFloat64ScalarImpl, subFloat64ScalarGeneric},
+		{"mulScalar", mulFloat64ScalarImpl, mulFloat64ScalarGeneric},
+		{"divScalar", divFloat64ScalarImpl, divFloat64ScalarGeneric},
+	}
+	floatUnary := []struct {
+		name    string
+		impl    func(ks *kernelSet, dst, a []float64)
+		generic func(dst, a []float64)
+	}{
+		{"neg", negFloat64Impl, negFloat64Generic},
+		{"abs", absFloat64Impl, absFloat64Generic},
+	}
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(370))
+				for _, size := range sizes {
+					a, b := randomArithInt64(rng, size), randomArithInt64(rng, size)
+					got, want := make([]int64, size), make([]int64, size)
+					for _, op := range intBinary {
+						if o1, o2 := op.impl(currentKernels(), got, a, b), op.generic(want, a, b); o1 != o2 {
+							t.Errorf("size=%d %s: overflow = %v, want %v", size, op.name, o1, o2)
+						}
+						checkEqual(t, size, op.name, got, want)
+					}
+					for _, op := range intScalar {
+						for _, s := range []int64{7, -1, math.MaxInt64, math.MinInt64} {
+							if o1, o2 := op.impl(currentKernels(), got, a, s), op.generic(want, a, s); o1 != o2 {
+								t.Errorf("size=%d %s(%d): overflow = %v, want %v", size, op.name, s, o1, o2)
+							}
+							checkEqual(t, size, op.name, got, want)
+						}
+					}
+					for _, op := range intUnary {
+						if o1, o2 := op.impl(currentKernels(), got, a), op.generic(want, a); o1 != o2 {
+							t.Errorf("size=%d %s: overflow = %v, want %v", size, op.name, o1, o2)
+						}
+						checkEqual(t, size, op.name, got, want)
+					}
+
+					// Small values never overflow, whichever lane they land in
+					small := make([]int64, size)
+					for i := range small {
+						small[i] = int64(i) - 50
+					}
+					if _, overflow := AddInt64(small, small); overflow {
+						t.Errorf("size=%d: unexpected overflow", size)
+					}
+
+					fa, fb := make([]float64, size), make([]float64, size)
+					for i := range fa {
+						fa[i], fb[i] = rng.NormFloat64()*1e3, rng.NormFloat64()
+					}
+					fb[0] = 0
+					fgot, fwant := make([]float64, size), make([]float64, size)
+					for _, op := range floatBinary {
+						op.impl(currentKernels(), fgot, fa, fb)
+						op.generic(fwant, fa, fb)
+						checkFloat64Bits(t, size, op.name, fgot, fwant)
+					}
+					for _, op := range floatScalar {
+						op.impl(currentKernels(), fgot, fa, 0.25)
+						op.generic(fwant, fa, 0.25)
+						checkFloat64Bits(t, size, op.name, fgot, fwant)
+					}
+					for _, op := range floatUnary {
+						op.impl(currentKernels(), fgot, fa)
+						op.generic(fwant, fa)
+						checkFloat64Bits(t, size, op.name, fgot, fwant)
+					}
+				}
+			})
+		})
+	}
+}
+
+// checkFloat64Bits compares results bit for bit, so NaN and -0 must match too.
+func checkFloat64Bits(t *testing.T, size int, op string, got, want []float64) {
+	t.Helper()
+	for i := range want {
+		if math.Float64bits(got[i]) != math.Float64bits(want[i]) {
+			t.Errorf("size=%d %s: result[%d] = %v, want %v", size, op, i, got[i], want[i])
+			return
+		}
+	}
+}
+
+// ============================================================================
+// Length Handling Tests
+// ============================================================================
+
+func TestArith_Lengths(t *testing.T) {
+	a := []int64{1, 2, 3, 4, 5}
+	b := []int64{10, 20, 30}
+
+	sum, _ := AddInt64(a, b)
+	checkEqual(t, 3, "AddInt64", sum, []int64{11, 22, 33})
+
+	dst := make([]int64, 2)
+	if n, _ := AddInt64Into(dst, a, b); n != 2 {
+		t.Errorf("AddInt64Into: expected 2 values, got %d", n)
+	}
+	checkEqual(t, 2, "AddInt64Into", dst, []int64{11, 22})
+
+	if n := MulFloat64Into(nil, []float64{1}, []float64{2}); n != 0 {
+		t.Errorf("MulFloat64Into: expected 0 values, got %d", n)
+	}
+	if result, overflow := NegInt64(nil); len(result) != 0 || overflow {
+		t.Error("Expected empty result without overflow for empty input")
+	}
+}
+
+// dst may be one of the operands, which is how chained projections reuse buffers
+func TestArith_InPlace(t *testing.T) {
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i)
+	}
+
+	MulFloat64ScalarInto(values, values, 2)
+	AddFloat64Into(values, values, values)
+
+	for i, v := range values {
+		if v != float64(4*i) {
+			t.Fatalf("values[%d] = %v, want %v", i, v, float64(4*i))
+		}
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+func BenchmarkAddInt64Into(b *testing.B) {
+	a := make([]int64, 4096)
+	c := make([]int64, 4096)
+	dst := make([]int64, 4096)
+	for i := range a {
+		a[i], c[i] = int64(i), int64(i*3)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		AddInt64Into(dst, a, c)
+	}
+}
+
+func BenchmarkMulFloat64Into(b *testing.B) {
+	a := make([]float64, 4096)
+	c := make([]float64, 4096)
+	dst := make([]float64, 4096)
+	for i := range a {
+		a[i], c[i] = float64(i), 1.5
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MulFloat64Into(dst, a, c)
+	}
+}
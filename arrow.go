@@ -0,0 +1,340 @@
+package syndrdbsimd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// ErrArrowBuffer is returned when Arrow buffers are too short or misaligned.
+var ErrArrowBuffer = errors.New("invalid Arrow buffer")
+
+// hostLittleEndian reports whether the host stores integers little-endian, as
+// Arrow buffers do; the views below reinterpret buffers in place.
+var hostLittleEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}()
+
+// The adapters below accept the raw buffers of an Arrow array, as found in a
+// record batch or the C data interface, without depending on an Arrow library:
+//
+//   - validity: the LSB-ordered validity bitmap, or nil if the array has no nulls
+//   - values: the little-endian data buffer of a fixed-width array
+//   - offsets, data: the int32 offsets and the UTF-8 bytes of a String (Utf8) array
+//   - offset, length: the array's slot offset into its buffers and its length
+//
+// Buffers are shared, not copied, so they must stay alive and unchanged while
+// the results are in use. The one exception is a validity bitmap whose offset is
+// not a multiple of 64 or whose buffer is not 8-byte aligned: it is copied into
+// words, which takes one bit per row.
+
+// arrowValidity returns the validity of slots [offset, offset+length) as words.
+func arrowValidity(bitmap []byte, offset, length int) ([]uint64, error) {
+	if bitmap == nil {
+		return nil, nil
+	}
+	if need := (offset + length + 7) / 8; len(bitmap) < need {
+		return nil, fmt.Errorf("%w: validity bitmap holds %d bytes, need %d", ErrArrowBuffer, len(bitmap), need)
+	}
+
+	words := (length + 63) / 64
+	if offset%64 == 0 {
+		b := bitmap[offset/8:]
+		if len(b) >= words*8 && (words == 0 || uintptr(unsafe.Pointer(&b[0]))%8 == 0) {
+			return unsafe.Slice((*uint64)(unsafe.Pointer(unsafe.SliceData(b))), words), nil
+		}
+	}
+
+	validity := make([]uint64, words)
+	for i := 0; i < length; i++ {
+		j := offset + i
+		if bitmap[j/8]&(1<<uint(j%8)) != 0 {
+			validity[i/64] |= 1 << uint(i%64)
+		}
+	}
+	return validity, nil
+}
+
+// arrowValues returns the length 8-byte values of a fixed-width buffer from slot offset on.
+func arrowValues[T int64 | float64](values []byte, offset, length int) ([]T, error) {
+	if !hostLittleEndian {
+		return nil, fmt.Errorf("%w: big-endian hosts are not supported", ErrArrowBuffer)
+	}
+	if offset < 0 || length < 0 {
+		return nil, fmt.Errorf("%w: negative offset %d or length %d", ErrArrowBuffer, offset, length)
+	}
+	if need := (offset + length) * 8; len(values) < need {
+		return nil, fmt.Errorf("%w: values buffer holds %d bytes, need %d", ErrArrowBuffer, len(values), need)
+	}
+	if length == 0 {
+		return []T{}, nil
+	}
+	b := values[offset*8:]
+	if uintptr(unsafe.Pointer(&b[0]))%8 != 0 {
+		return nil, fmt.Errorf("%w: values buffer is not 8-byte aligned", ErrArrowBuffer)
+	}
+	return unsafe.Slice((*T)(unsafe.Pointer(&b[0])), length), nil
+}
+
+// Int64ColumnFromArrow returns an Int64Column over the buffers of an Arrow Int64
+// array (also Timestamp, Date64, Time64 and Duration arrays), so its compares,
+// aggregates and hashes run on the buffers in place.
+//
+// Returns an error wrapping ErrArrowBuffer if a buffer is too short, the values
+// buffer is not 8-byte aligned, or the host is big-endian.
+func Int64ColumnFromArrow(validity, values []byte, offset, length int) (Int64Column, error) {
+	v, err := arrowValues[int64](values, offset, length)
+	if err != nil {
+		return Int64Column{}, err
+	}
+	bitmap, err := arrowValidity(validity, offset, length)
+	if err != nil {
+		return Int64Column{}, err
+	}
+	return Int64Column{Values: v, Validity: bitmap}, nil
+}
+
+// Float64ColumnFromArrow returns a Float64Column over the buffers of an Arrow
+// Float64 (Double) array. Errors are those of Int64ColumnFromArrow.
+func Float64ColumnFromArrow(validity, values []byte, offset, length int) (Float64Column, error) {
+	v, err := arrowValues[float64](values, offset, length)
+	if err != nil {
+		return Float64Column{}, err
+	}
+	bitmap, err := arrowValidity(validity, offset, length)
+	if err != nil {
+		return Float64Column{}, err
+	}
+	return Float64Column{Values: v, Validity: bitmap}, nil
+}
+
+// ArrowStringArray is a view of an Arrow String (Utf8) array that evaluates
+// predicates, aggregates and hashes directly over the offsets and data buffers.
+//
+// Null semantics are those of StringColumn: comparisons are false for null rows,
+// aggregates skip them and hashes map them to 0. Hashes equal those of
+// StringColumn.XXHash64 for the same strings, so both can feed one hash table.
+type ArrowStringArray struct {
+	offsets  []int32 // length+1 offsets into data
+	data     []byte
+	validity []uint64
+}
+
+// NewArrowStringArray returns a view of the buffers of an Arrow String array.
+// offsets must hold at least offset+length+1 little-endian int32 values.
+//
+// Returns an error wrapping ErrArrowBuffer if a buffer is too short, the offsets
+// buffer is not 4-byte aligned, the offsets decrease or point past data, or the
+// host is big-endian.
+func NewArrowStringArray(validity, offsets, data []byte, offset, length int) (*ArrowStringArray, error) {
+	if !hostLittleEndian {
+		return nil, fmt.Errorf("%w: big-endian hosts are not supported", ErrArrowBuffer)
+	}
+	if offset < 0 || length < 0 {
+		return nil, fmt.Errorf("%w: negative offset %d or length %d", ErrArrowBuffer, offset, length)
+	}
+	if need := (offset + length + 1) * 4; len(offsets) < need {
+		return nil, fmt.Errorf("%w: offsets buffer holds %d bytes, need %d", ErrArrowBuffer, len(offsets), need)
+	}
+	b := offsets[offset*4:]
+	if uintptr(unsafe.Pointer(&b[0]))%4 != 0 {
+		return nil, fmt.Errorf("%w: offsets buffer is not 4-byte aligned", ErrArrowBuffer)
+	}
+	offs := unsafe.Slice((*int32)(unsafe.Pointer(&b[0])), length+1)
+
+	// Every slice taken later is then in bounds, including for null rows
+	if offs[0] < 0 {
+		return nil, fmt.Errorf("%w: negative offset %d", ErrArrowBuffer, offs[0])
+	}
+	for i := 0; i < length; i++ {
+		if offs[i+1] < offs[i] {
+			return nil, fmt.Errorf("%w: offsets decrease at slot %d", ErrArrowBuffer, offset+i)
+		}
+	}
+	if int(offs[length]) > len(data) {
+		return nil, fmt.Errorf("%w: offset %d past data buffer of %d bytes", ErrArrowBuffer, offs[length], len(data))
+	}
+
+	bitmap, err := arrowValidity(validity, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return &ArrowStringArray{offsets: offs, data: data, validity: bitmap}, nil
+}
+
+// Len returns the number of rows, including nulls.
+func (a *ArrowStringArray) Len() int {
+	return len(a.offsets) - 1
+}
+
+// IsNull reports whether row i is null.
+func (a *ArrowStringArray) IsNull(i int) bool {
+	return isNull(a.validity, i)
+}
+
+// Count returns the number of non-null rows, like SQL COUNT(column).
+func (a *ArrowStringArray) Count() int {
+	return validCount(a.validity, a.Len())
+}
+
+// NullCount returns the number of null rows.
+func (a *ArrowStringArray) NullCount() int {
+	return a.Len() - a.Count()
+}
+
+// bytes returns the bytes of row i, sharing the data buffer.
+func (a *ArrowStringArray) bytes(i int) []byte {
+	return a.data[a.offsets[i]:a.offsets[i+1]]
+}
+
+// Value returns row i as a string sharing the data buffer, or "" for null rows.
+func (a *ArrowStringArray) Value(i int) string {
+	if a.IsNull(i) {
+		return ""
+	}
+	b := a.bytes(i)
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(&b[0], len(b))
+}
+
+// Column returns a StringColumn whose strings share the data buffer. Only the
+// string headers are allocated; use it to reach the functions that take []string.
+func (a *ArrowStringArray) Column() StringColumn {
+	values := make([]string, a.Len())
+	for i := range values {
+		values[i] = a.Value(i)
+	}
+	return StringColumn{Values: values, Validity: a.validity}
+}
+
+// matchMask returns the bitmask of the non-null rows for which match returns true.
+func (a *ArrowStringArray) matchMask(match func([]byte) bool) []uint64 {
+	mask := make([]uint64, (a.Len()+63)/64)
+	for i := 0; i < a.Len(); i++ {
+		if !isNull(a.validity, i) && match(a.bytes(i)) {
+			mask[i/64] |= 1 << uint(i%64)
+		}
+	}
+	return mask
+}
+
+// CompareMask compares each row against threshold with the operator op, ordering
+// strings by bytes as Go does, and returns a bitmask in which the bits of null rows
+// are clear. Panics if op is invalid.
+func (a *ArrowStringArray) CompareMask(op Op, threshold string) []uint64 {
+	checkOp(op)
+	t := stringToBytes(threshold)
+	ks := currentKernels()
+	switch op {
+	case OpEq, OpNe:
+		eq := op == OpEq
+		mask := make([]uint64, (a.Len()+63)/64)
+		for i := 0; i < a.Len(); i++ {
+			if strEqImpl(ks, a.bytes(i), t) == eq && !isNull(a.validity, i) {
+				mask[i/64] |= 1 << uint(i%64)
+			}
+		}
+		return mask
+	default:
+		return a.matchMask(func(b []byte) bool {
+			return orderMatches(op, strCmpImpl(ks, b, t))
+		})
+	}
+}
+
+// HasPrefixMask returns a bitmask of the non-null rows that start with prefix.
+func (a *ArrowStringArray) HasPrefixMask(prefix string) []uint64 {
+	p := stringToBytes(prefix)
+	ks := currentKernels()
+	return a.matchMask(func(b []byte) bool { return strPrefixCmpImpl(ks, b, p) })
+}
+
+// HasSuffixMask returns a bitmask of the non-null rows that end with suffix.
+func (a *ArrowStringArray) HasSuffixMask(suffix string) []uint64 {
+	s := stringToBytes(suffix)
+	ks := currentKernels()
+	return a.matchMask(func(b []byte) bool {
+		return len(b) >= len(s) && strEqImpl(ks, b[len(b)-len(s):], s)
+	})
+}
+
+// ContainsMask returns a bitmask of the non-null rows that contain substr.
+//
+// Rather than searching row by row, it searches the data buffer as a whole with
+// the same SIMD substring search as StrContains and maps each occurrence to its
+// row, skipping to the next row after a match. Rare substrings therefore cost
+// little more than one scan of the data.
+func (a *ArrowStringArray) ContainsMask(substr string) []uint64 {
+	mask := containsRows(a.offsets, a.data, stringToBytes(substr))
+	return applyValidity(mask, a.validity)
+}
+
+// LikeMask returns a bitmask of the non-null rows that match the SQL LIKE pattern.
+// Like CmpLikeStringMask, an invalid pattern matches nothing.
+func (a *ArrowStringArray) LikeMask(pattern string) []uint64 {
+	compiled, err := CompilePatternAuto(pattern)
+	if err != nil {
+		return make([]uint64, (a.Len()+63)/64)
+	}
+
+	switch compiled.Type {
+	case PatternExact:
+		return a.CompareMask(OpEq, string(compiled.Segments[0]))
+	case PatternPrefix:
+		return a.HasPrefixMask(string(compiled.Segments[0]))
+	case PatternSuffix:
+		return a.HasSuffixMask(string(compiled.Segments[0]))
+	case PatternContains:
+		return a.ContainsMask(string(compiled.Segments[0]))
+	default:
+		ks := currentKernels()
+		return a.matchMask(func(b []byte) bool { return compiled.program.match(ks, b) })
+	}
+}
+
+// Min returns a copy of the smallest non-null value in byte order.
+// ok is false if every row is null.
+func (a *ArrowStringArray) Min() (m string, ok bool) {
+	return a.extreme(-1)
+}
+
+// Max returns a copy of the largest non-null value in byte order.
+// ok is false if every row is null.
+func (a *ArrowStringArray) Max() (m string, ok bool) {
+	return a.extreme(1)
+}
+
+// extreme returns the smallest (sign -1) or largest (sign 1) non-null value.
+func (a *ArrowStringArray) extreme(sign int) (string, bool) {
+	best := -1
+	for i := 0; i < a.Len(); i++ {
+		if !isNull(a.validity, i) && (best < 0 || bytes.Compare(a.bytes(i), a.bytes(best)) == sign) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+	return strings.Clone(a.Value(best)), true
+}
+
+// XXHash64 computes the XXHash64Bytes hash of each row into output, with 0 for
+// null rows. It does nothing unless output has the same length as the array.
+func (a *ArrowStringArray) XXHash64(output []uint64) {
+	if a.Len() == 0 || len(output) != a.Len() {
+		return
+	}
+	ks := currentKernels()
+	for i := range output {
+		if isNull(a.validity, i) {
+			output[i] = 0
+		} else {
+			output[i] = xxhash64BytesImpl(ks, a.bytes(i))
+		}
+	}
+}
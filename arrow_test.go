@@ -0,0 +1,258 @@
+package syndrdbsimd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// ============================================================================
+// Arrow Adapter Tests
+// ============================================================================
+
+// arrowBuffer returns the bytes of words, so the buffer is 8-byte aligned like
+// the buffers of an Arrow record batch.
+func arrowBuffer[T int64 | float64 | uint64](words []T) []byte {
+	if len(words) == 0 {
+		return []byte{}
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), len(words)*8)
+}
+
+// arrowStrings returns the validity, offsets and data buffers of an Arrow String
+// array holding values, with the rows listed in nulls set to null.
+func arrowStrings(values []string, nulls ...int) (validity, offsets, data []byte) {
+	offs := make([]int32, len(values)+1)
+	for i, s := range values {
+		data = append(data, s...)
+		offs[i+1] = int32(len(data))
+	}
+	offsets = unsafe.Slice((*byte)(unsafe.Pointer(&offs[0])), len(offs)*4)
+	return arrowBuffer(validityOf(len(values), nulls...)), offsets, data
+}
+
+func TestInt64ColumnFromArrow(t *testing.T) {
+	values := []int64{10, 20, 30, 40, 50, 60, 70}
+	validity := arrowBuffer(validityOf(len(values), 2))
+
+	c, err := Int64ColumnFromArrow(validity, arrowBuffer(values), 0, len(values))
+	if err != nil {
+		t.Fatalf("Int64ColumnFromArrow failed: %v", err)
+	}
+	if &c.Values[0] != &values[0] || &c.Validity[0] != (*uint64)(unsafe.Pointer(&validity[0])) {
+		t.Error("Int64ColumnFromArrow copied a buffer")
+	}
+	if sum, _ := c.Sum(); sum != 250 {
+		t.Errorf("Sum() = %d, want 250", sum)
+	}
+
+	// A sliced array: slots 1-5, with the null slot 2 becoming row 1
+	c, err = Int64ColumnFromArrow(validity, arrowBuffer(values), 1, 5)
+	if err != nil {
+		t.Fatalf("Int64ColumnFromArrow failed: %v", err)
+	}
+	if &c.Values[0] != &values[1] || c.Len() != 5 {
+		t.Error("sliced Int64ColumnFromArrow does not view slots 1-5")
+	}
+	checkEqual(t, 5, "CompareMask", c.CompareMask(OpGe, 30), []uint64{0b1_1100})
+	checkEqual(t, 5, "Compare", c.Compare(OpLt, 40), []bool{true, false, false, false, false})
+
+	// No bitmap means no nulls
+	c, err = Int64ColumnFromArrow(nil, arrowBuffer(values), 0, len(values))
+	if err != nil || c.Validity != nil || c.Count() != len(values) {
+		t.Errorf("Int64ColumnFromArrow without bitmap: %v, Count() = %d", err, c.Count())
+	}
+}
+
+func TestFloat64ColumnFromArrow(t *testing.T) {
+	values := make([]float64, 200)
+	for i := range values {
+		values[i] = float64(i) / 2
+	}
+	validity := arrowBuffer(validityOf(len(values), 70, 150))
+
+	// Slot offset 64 keeps the bitmap word aligned, so it is shared too
+	c, err := Float64ColumnFromArrow(validity, arrowBuffer(values), 64, 100)
+	if err != nil {
+		t.Fatalf("Float64ColumnFromArrow failed: %v", err)
+	}
+	if &c.Validity[0] != (*uint64)(unsafe.Pointer(&validity[8])) {
+		t.Error("Float64ColumnFromArrow copied a word-aligned bitmap")
+	}
+	if c.Count() != 98 || !c.IsNull(6) || !c.IsNull(86) {
+		t.Errorf("Count() = %d, IsNull(6) = %v, want 98, true", c.Count(), c.IsNull(6))
+	}
+	if m, _ := c.Max(); m != 81.5 {
+		t.Errorf("Max() = %v, want 81.5", m)
+	}
+}
+
+func TestColumnFromArrow_Errors(t *testing.T) {
+	values := arrowBuffer([]int64{1, 2, 3, 4})
+	long := arrowBuffer(make([]int64, 16))
+	validity := []byte{0xFF}
+	tests := []struct {
+		name           string
+		validity, vals []byte
+		offset, length int
+	}{
+		{"short values", nil, values, 1, 4},
+		{"short validity", validity, long, 0, 9},
+		{"misaligned values", nil, values[4:], 0, 3},
+		{"negative offset", nil, values, -1, 2},
+	}
+	for _, tt := range tests {
+		if _, err := Int64ColumnFromArrow(tt.validity, tt.vals, tt.offset, tt.length); !errors.Is(err, ErrArrowBuffer) {
+			t.Errorf("%s: err = %v, want ErrArrowBuffer", tt.name, err)
+		}
+	}
+	if _, err := Float64ColumnFromArrow(nil, values, 0, 5); !errors.Is(err, ErrArrowBuffer) {
+		t.Errorf("Float64ColumnFromArrow short values: err = %v, want ErrArrowBuffer", err)
+	}
+	if _, err := Int64ColumnFromArrow([]byte{0x0F}, values, 3, 1); err != nil {
+		t.Errorf("Int64ColumnFromArrow within one bitmap byte: %v", err)
+	}
+}
+
+func TestArrowStringArray_MatchStringColumn(t *testing.T) {
+	values := []string{"skip", "apple", "banana", "", "apricot", "cherry", "apple", "band", "nab", "ban", "ana"}
+	validity, offsets, data := arrowStrings(values, 3, 6)
+
+	// Slot 0 lies before the array's offset
+	a, err := NewArrowStringArray(validity, offsets, data, 1, len(values)-1)
+	if err != nil {
+		t.Fatalf("NewArrowStringArray failed: %v", err)
+	}
+	c := a.Column()
+	n := a.Len()
+	if n != 10 || a.Count() != 8 || a.NullCount() != 2 || !a.IsNull(5) {
+		t.Errorf("Len, Count, NullCount = %d, %d, %d, want 10, 8, 2", n, a.Count(), a.NullCount())
+	}
+	if a.Value(0) != "apple" || a.Value(2) != "" || c.Values[1] != "banana" {
+		t.Errorf("Value(0), Value(2) = %q, %q", a.Value(0), a.Value(2))
+	}
+
+	for _, op := range allOps {
+		for _, s := range []string{"apple", "b", "", "zzz"} {
+			checkEqual(t, n, "CompareMask "+op.String()+" "+s, a.CompareMask(op, s), c.CompareMask(op, s))
+		}
+	}
+	for _, s := range []string{"ap", "ban", "", "x"} {
+		checkEqual(t, n, "HasPrefixMask "+s, a.HasPrefixMask(s), c.HasPrefixMask(s))
+		checkEqual(t, n, "HasSuffixMask "+s, a.HasSuffixMask(s), c.HasSuffixMask(s))
+	}
+
+	// "ab" and "na" span row boundaries in the data buffer
+	for _, s := range []string{"an", "ab", "na", "nab", "e", "", "apple", "q"} {
+		checkEqual(t, n, "ContainsMask "+s, a.ContainsMask(s), c.ContainsMask(s))
+	}
+	for _, p := range []string{"ban%", "%an%", "%e", "apple", "b_n%", "%a%a%", "_"} {
+		checkEqual(t, n, "LikeMask "+p, a.LikeMask(p), c.LikeMask(p))
+	}
+
+	if m, _ := a.Min(); m != "ana" {
+		t.Errorf("Min() = %q, want %q", m, "ana")
+	}
+	if m, _ := a.Max(); m != "nab" {
+		t.Errorf("Max() = %q, want %q", m, "nab")
+	}
+
+	got, want := make([]uint64, n), make([]uint64, n)
+	a.XXHash64(got)
+	c.XXHash64(want)
+	checkEqual(t, n, "XXHash64", got, want)
+}
+
+// Rows and thresholds of 32 bytes or more reach the SIMD equality and prefix kernels
+func TestArrowStringArray_LongStrings(t *testing.T) {
+	long := strings.Repeat("syndrdb-", 6)
+	values := []string{long, long + "!", "!" + long, long[:40], long[8:], long, "short"}
+	validity, offsets, data := arrowStrings(values, 5)
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				a, err := NewArrowStringArray(validity, offsets, data, 0, len(values))
+				if err != nil {
+					t.Fatalf("NewArrowStringArray failed: %v", err)
+				}
+				c := a.Column()
+				n := a.Len()
+				for _, s := range []string{long, long[:40], long[8:], long + "!"} {
+					checkEqual(t, n, "CompareMask = "+s, a.CompareMask(OpEq, s), c.CompareMask(OpEq, s))
+					checkEqual(t, n, "CompareMask != "+s, a.CompareMask(OpNe, s), c.CompareMask(OpNe, s))
+					checkEqual(t, n, "HasPrefixMask "+s, a.HasPrefixMask(s), c.HasPrefixMask(s))
+					checkEqual(t, n, "HasSuffixMask "+s, a.HasSuffixMask(s), c.HasSuffixMask(s))
+				}
+
+				got, want := make([]uint64, n), make([]uint64, n)
+				a.XXHash64(got)
+				c.XXHash64(want)
+				checkEqual(t, n, "XXHash64", got, want)
+			})
+		})
+	}
+}
+
+func TestArrowStringArray_AllNullAndEmpty(t *testing.T) {
+	validity, offsets, data := arrowStrings([]string{"a", "b"}, 0, 1)
+	a, err := NewArrowStringArray(validity, offsets, data, 0, 2)
+	if err != nil {
+		t.Fatalf("NewArrowStringArray failed: %v", err)
+	}
+	if _, ok := a.Max(); ok {
+		t.Error("Max() ok on an all-null array")
+	}
+	checkEqual(t, 2, "ContainsMask", a.ContainsMask("a"), []uint64{0})
+
+	a, err = NewArrowStringArray(nil, offsets, data, 2, 0)
+	if err != nil || a.Len() != 0 {
+		t.Fatalf("NewArrowStringArray of an empty slice: %v", err)
+	}
+	checkEqual(t, 0, "empty CompareMask", a.CompareMask(OpEq, ""), []uint64{})
+}
+
+func TestNewArrowStringArray_Errors(t *testing.T) {
+	_, offsets, data := arrowStrings([]string{"ab", "cd", "ef"})
+	offs := unsafe.Slice((*int32)(unsafe.Pointer(&offsets[0])), 4)
+
+	if _, err := NewArrowStringArray(nil, offsets[:12], data, 0, 3); !errors.Is(err, ErrArrowBuffer) {
+		t.Errorf("short offsets: err = %v, want ErrArrowBuffer", err)
+	}
+	if _, err := NewArrowStringArray(nil, offsets, data[:5], 0, 3); !errors.Is(err, ErrArrowBuffer) {
+		t.Errorf("short data: err = %v, want ErrArrowBuffer", err)
+	}
+	if _, err := NewArrowStringArray(nil, offsets[2:], data, 0, 2); !errors.Is(err, ErrArrowBuffer) {
+		t.Errorf("misaligned offsets: err = %v, want ErrArrowBuffer", err)
+	}
+	offs[2] = 1
+	if _, err := NewArrowStringArray(nil, offsets, data, 0, 3); !errors.Is(err, ErrArrowBuffer) {
+		t.Errorf("decreasing offsets: err = %v, want ErrArrowBuffer", err)
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+func BenchmarkArrowStringArrayContains(b *testing.B) {
+	values := make([]string, 65536)
+	for i := range values {
+		values[i] = strings.Repeat("x", i%32) + "-row"
+	}
+	values[40000] = "the needle"
+	validity, offsets, data := arrowStrings(values)
+	a, err := NewArrowStringArray(validity, offsets, data, 0, len(values))
+	if err != nil {
+		b.Fatalf("NewArrowStringArray failed: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.ContainsMask("needle")
+	}
+}
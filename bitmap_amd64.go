@@ -31,3 +31,33 @@ func notBitmapAVX2(dst, src *uint64, length int)
 //
 //go:noescape
 func popCountAVX2(bitmap *uint64, length int) int
+
+// andBitmapAVX512 performs bitwise AND on bitmaps using AVX-512.
+// Processes 8 uint64 values (512 bits) at a time.
+//
+//go:noescape
+func andBitmapAVX512(dst, a, b *uint64, length int)
+
+// orBitmapAVX512 performs bitwise OR on bitmaps using AVX-512.
+// Processes 8 uint64 values (512 bits) at a time.
+//
+//go:noescape
+func orBitmapAVX512(dst, a, b *uint64, length int)
+
+// xorBitmapAVX512 performs bitwise XOR on bitmaps using AVX-512.
+// Processes 8 uint64 values (512 bits) at a time.
+//
+//go:noescape
+func xorBitmapAVX512(dst, a, b *uint64, length int)
+
+// notBitmapAVX512 performs bitwise NOT on a bitmap using AVX-512.
+// Processes 8 uint64 values (512 bits) at a time.
+//
+//go:noescape
+func notBitmapAVX512(dst, src *uint64, length int)
+
+// popCountAVX512 counts set bits in a bitmap using AVX512VPOPCNTDQ.
+// Returns the total number of 1 bits across all uint64 values.
+//
+//go:noescape
+func popCountAVX512(bitmap *uint64, length int) int
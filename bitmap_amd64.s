@@ -252,9 +252,8 @@ not_done:
 // 1. Use scalar POPCNT on each uint64 (available since SSE4.2)
 // 2. Accumulate results in a register
 //
-// TODO: I could optimize this further with lookup tables or AVX512 VPOPCNT
-// when AVX512VPOPCNTDQ is available. For now, this provides good performance
-// by unrolling the loop and processing multiple elements between accumulations.
+// Unrolling the loop keeps several POPCNTs in flight between accumulations.
+// CPUs with AVX512VPOPCNTDQ use popCountAVX512 instead.
 //
 TEXT ·popCountAVX2(SB), NOSPLIT, $0-24
     MOVQ    bitmap+0(FP), SI        // SI = bitmap pointer
@@ -307,3 +306,153 @@ pop_done:
     // Return accumulated count
     MOVQ    AX, ret+16(FP)
     RET
+
+// ============================================================================
+// AVX-512 kernels
+// ============================================================================
+//
+// The AVX-512 bitmap kernels process 8 uint64 values (512 bits) per iteration and
+// finish the remaining 0-7 words with scalar code, like their AVX2 counterparts.
+
+// BITMAP_BINARY_AVX512 defines a kernel with the signature
+// func(dst, a, b *uint64, length int) computing dst[i] = a[i] op b[i].
+#define BITMAP_BINARY_AVX512(name, vop, sop) \
+TEXT name(SB), NOSPLIT, $0-32 \
+    MOVQ    dst+0(FP), SI \
+    MOVQ    a+8(FP), DI \
+    MOVQ    b+16(FP), DX \
+    MOVQ    length+24(FP), CX \
+    CMPQ    CX, $8 \
+    JL      remainder \
+loop: \
+    VMOVDQU64 (DI), Z0 \
+    VMOVDQU64 (DX), Z1 \
+    vop     Z1, Z0, Z2 \
+    VMOVDQU64 Z2, (SI) \
+    ADDQ    $64, SI \
+    ADDQ    $64, DI \
+    ADDQ    $64, DX \
+    SUBQ    $8, CX \
+    CMPQ    CX, $8 \
+    JGE     loop \
+    VZEROUPPER \
+remainder: \
+    TESTQ   CX, CX \
+    JZ      done \
+remainder_loop: \
+    MOVQ    (DI), AX \
+    sop     (DX), AX \
+    MOVQ    AX, (SI) \
+    ADDQ    $8, SI \
+    ADDQ    $8, DI \
+    ADDQ    $8, DX \
+    DECQ    CX \
+    JNZ     remainder_loop \
+done: \
+    RET
+
+// func andBitmapAVX512(dst, a, b *uint64, length int)
+BITMAP_BINARY_AVX512(·andBitmapAVX512, VPANDQ, ANDQ)
+
+// func orBitmapAVX512(dst, a, b *uint64, length int)
+BITMAP_BINARY_AVX512(·orBitmapAVX512, VPORQ, ORQ)
+
+// func xorBitmapAVX512(dst, a, b *uint64, length int)
+BITMAP_BINARY_AVX512(·xorBitmapAVX512, VPXORQ, XORQ)
+
+// func notBitmapAVX512(dst, src *uint64, length int)
+//
+// VPTERNLOGQ with truth table 0x55 computes NOT of its third operand directly,
+// so no all-ones constant register is needed.
+//
+TEXT ·notBitmapAVX512(SB), NOSPLIT, $0-24
+    MOVQ    dst+0(FP), SI           // SI = destination pointer
+    MOVQ    src+8(FP), DI           // DI = source pointer
+    MOVQ    length+16(FP), CX       // CX = number of elements
+    
+    CMPQ    CX, $8
+    JL      not_remainder
+    
+not_loop:
+    VMOVDQU64 (DI), Z0              // Load 8 uint64 values
+    VPTERNLOGQ $0x55, Z0, Z0, Z0    // Z0 = ~Z0
+    VMOVDQU64 Z0, (SI)
+    
+    ADDQ    $64, SI
+    ADDQ    $64, DI
+    SUBQ    $8, CX
+    
+    CMPQ    CX, $8
+    JGE     not_loop
+    VZEROUPPER
+    
+not_remainder:
+    TESTQ   CX, CX
+    JZ      not_done
+    
+not_remainder_loop:
+    MOVQ    (DI), AX
+    NOTQ    AX
+    MOVQ    AX, (SI)
+    
+    ADDQ    $8, SI
+    ADDQ    $8, DI
+    DECQ    CX
+    JNZ     not_remainder_loop
+    
+not_done:
+    RET
+
+// func popCountAVX512(bitmap *uint64, length int) int
+//
+// Counts set bits using AVX512VPOPCNTDQ.
+// VPOPCNTQ counts the bits of 8 uint64 values in one instruction; the per-lane
+// counts are accumulated in Z1 and reduced horizontally once at the end.
+// Each lane gains at most 64 per iteration, so the accumulators cannot overflow.
+//
+TEXT ·popCountAVX512(SB), NOSPLIT, $0-24
+    MOVQ    bitmap+0(FP), SI        // SI = bitmap pointer
+    MOVQ    length+8(FP), CX        // CX = number of uint64 elements
+    XORQ    AX, AX                  // AX = scalar accumulator
+    
+    CMPQ    CX, $8
+    JL      pop_remainder
+    
+    VPXORQ  Z1, Z1, Z1              // Z1 = 8 lane-wise counts
+    
+pop_loop:
+    VPOPCNTQ (SI), Z0               // Z0[i] = popcount(bitmap[i])
+    VPADDQ  Z0, Z1, Z1
+    
+    ADDQ    $64, SI
+    SUBQ    $8, CX
+    
+    CMPQ    CX, $8
+    JGE     pop_loop
+    
+    // Reduce the 8 lane counts: 512 -> 256 -> 128 -> 64 bits
+    VEXTRACTI64X4 $1, Z1, Y0
+    VPADDQ  Y0, Y1, Y1
+    VEXTRACTI128 $1, Y1, X0
+    VPADDQ  X0, X1, X1
+    VPSHUFD $0xEE, X1, X0
+    VPADDQ  X0, X1, X1
+    VMOVQ   X1, AX
+    VZEROUPPER
+    
+pop_remainder:
+    TESTQ   CX, CX
+    JZ      pop_done
+    
+pop_remainder_loop:
+    MOVQ    (SI), BX
+    POPCNTQ BX, BX
+    ADDQ    BX, AX
+    
+    ADDQ    $8, SI
+    DECQ    CX
+    JNZ     pop_remainder_loop
+    
+pop_done:
+    MOVQ    AX, ret+16(FP)
+    RET
@@ -31,3 +31,33 @@ func notBitmapNEON(dst, src *uint64, length int)
 //
 //go:noescape
 func popCountNEON(bitmap *uint64, length int) int
+
+// andBitmapSVE performs bitwise AND on bitmaps using SVE.
+// Processes one full vector per iteration, whatever the hardware vector length.
+//
+//go:noescape
+func andBitmapSVE(dst, a, b *uint64, length int)
+
+// orBitmapSVE performs bitwise OR on bitmaps using SVE.
+// Processes one full vector per iteration, whatever the hardware vector length.
+//
+//go:noescape
+func orBitmapSVE(dst, a, b *uint64, length int)
+
+// xorBitmapSVE performs bitwise XOR on bitmaps using SVE.
+// Processes one full vector per iteration, whatever the hardware vector length.
+//
+//go:noescape
+func xorBitmapSVE(dst, a, b *uint64, length int)
+
+// notBitmapSVE performs bitwise NOT on a bitmap using SVE.
+// Processes one full vector per iteration, whatever the hardware vector length.
+//
+//go:noescape
+func notBitmapSVE(dst, src *uint64, length int)
+
+// popCountSVE counts set bits in a bitmap using SVE CNT.
+// Returns the total number of 1 bits across all uint64 values.
+//
+//go:noescape
+func popCountSVE(bitmap *uint64, length int) int
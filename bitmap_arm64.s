@@ -297,3 +297,140 @@ pop_done:
     // Return accumulated count
     MOVD    R4, ret+16(FP)
     RET
+
+// ============================================================================
+// SVE kernels
+// ============================================================================
+//
+// SVE instructions are emitted as WORD directives because Go's ARM64 assembler
+// has no SVE mnemonics; see compare_arm64.s for the loop structure.
+
+// func andBitmapSVE(dst, a, b *uint64, length int)
+//
+// Computes dst[i] = a[i] & b[i] one full vector per iteration.
+//
+TEXT ·andBitmapSVE(SB), NOSPLIT, $0-32
+    MOVD    dst+0(FP), R0           // R0 = destination pointer
+    MOVD    a+8(FP), R1             // R1 = first source pointer
+    MOVD    b+16(FP), R2            // R2 = second source pointer
+    MOVD    length+24(FP), R4       // R4 = number of uint64 elements
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BEQ     and_done
+
+and_loop:
+    WORD    $0xa5e34020             // ld1d {z0.d}, p0/z, [x1, x3, lsl #3]
+    WORD    $0xa5e34041             // ld1d {z1.d}, p0/z, [x2, x3, lsl #3]
+    WORD    $0x04213000             // and z0.d, z0.d, z1.d
+    WORD    $0xe5e34000             // st1d {z0.d}, p0, [x0, x3, lsl #3]
+    WORD    $0x04f0e3e3             // incd x3
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     and_loop
+
+and_done:
+    RET
+
+// func orBitmapSVE(dst, a, b *uint64, length int)
+//
+// Computes dst[i] = a[i] | b[i] one full vector per iteration.
+//
+TEXT ·orBitmapSVE(SB), NOSPLIT, $0-32
+    MOVD    dst+0(FP), R0           // R0 = destination pointer
+    MOVD    a+8(FP), R1             // R1 = first source pointer
+    MOVD    b+16(FP), R2            // R2 = second source pointer
+    MOVD    length+24(FP), R4       // R4 = number of uint64 elements
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BEQ     or_done
+
+or_loop:
+    WORD    $0xa5e34020             // ld1d {z0.d}, p0/z, [x1, x3, lsl #3]
+    WORD    $0xa5e34041             // ld1d {z1.d}, p0/z, [x2, x3, lsl #3]
+    WORD    $0x04613000             // orr z0.d, z0.d, z1.d
+    WORD    $0xe5e34000             // st1d {z0.d}, p0, [x0, x3, lsl #3]
+    WORD    $0x04f0e3e3             // incd x3
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     or_loop
+
+or_done:
+    RET
+
+// func xorBitmapSVE(dst, a, b *uint64, length int)
+//
+// Computes dst[i] = a[i] ^ b[i] one full vector per iteration.
+//
+TEXT ·xorBitmapSVE(SB), NOSPLIT, $0-32
+    MOVD    dst+0(FP), R0           // R0 = destination pointer
+    MOVD    a+8(FP), R1             // R1 = first source pointer
+    MOVD    b+16(FP), R2            // R2 = second source pointer
+    MOVD    length+24(FP), R4       // R4 = number of uint64 elements
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BEQ     xor_done
+
+xor_loop:
+    WORD    $0xa5e34020             // ld1d {z0.d}, p0/z, [x1, x3, lsl #3]
+    WORD    $0xa5e34041             // ld1d {z1.d}, p0/z, [x2, x3, lsl #3]
+    WORD    $0x04a13000             // eor z0.d, z0.d, z1.d
+    WORD    $0xe5e34000             // st1d {z0.d}, p0, [x0, x3, lsl #3]
+    WORD    $0x04f0e3e3             // incd x3
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     xor_loop
+
+xor_done:
+    RET
+
+// func notBitmapSVE(dst, src *uint64, length int)
+TEXT ·notBitmapSVE(SB), NOSPLIT, $0-24
+    MOVD    dst+0(FP), R0           // R0 = destination pointer
+    MOVD    src+8(FP), R1           // R1 = source pointer
+    MOVD    length+16(FP), R4       // R4 = number of uint64 elements
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BEQ     not_done
+
+not_loop:
+    WORD    $0xa5e34020             // ld1d {z0.d}, p0/z, [x1, x3, lsl #3]
+    WORD    $0x04dea000             // not z0.d, p0/m, z0.d
+    WORD    $0xe5e34000             // st1d {z0.d}, p0, [x0, x3, lsl #3]
+    WORD    $0x04f0e3e3             // incd x3
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     not_loop
+
+not_done:
+    RET
+
+// func popCountSVE(bitmap *uint64, length int) int
+//
+// CNT counts the bits of every lane; the counts are accumulated per lane and
+// reduced with UADDV once at the end. Inactive lanes load as zero and add nothing.
+//
+TEXT ·popCountSVE(SB), NOSPLIT, $0-24
+    MOVD    bitmap+0(FP), R0        // R0 = bitmap pointer
+    MOVD    length+8(FP), R4        // R4 = number of uint64 elements
+    MOVD    $0, R3                  // R3 = element index
+    MOVD    $0, R5                  // R5 = result
+
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BEQ     pop_done
+    WORD    $0x25d8e3e1             // ptrue p1.d
+    WORD    $0x25f8c002             // mov z2.d, #0; Z2 = per-lane bit counts
+
+pop_loop:
+    WORD    $0xa5e34000             // ld1d {z0.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x04daa000             // cnt z0.d, p0/m, z0.d
+    WORD    $0x04e00042             // add z2.d, z2.d, z0.d
+    WORD    $0x04f0e3e3             // incd x3
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     pop_loop
+
+    WORD    $0x04c12440             // uaddv d0, p1, z2.d; Sum the lane counts
+    FMOVD   F0, R5
+
+pop_done:
+    MOVD    R5, ret+16(FP)
+    RET
@@ -0,0 +1,343 @@
+package syndrdbsimd
+
+import (
+	"fmt"
+	"time"
+)
+
+// calibrationSizes are the input sizes measured for each operation group.
+// The crossover chosen by Calibrate is always one of these sizes.
+var calibrationSizes = []int{1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024}
+
+// calibrationStringLen is the string length used while calibrating MinStrings.
+// It is long enough to clear any sensible AvgByteThreshold.
+const calibrationStringLen = 64
+
+// calibrationStringCount is the number of strings used while calibrating AvgByteThreshold.
+const calibrationStringCount = 256
+
+// calibrationSink keeps benchmark results alive so the calls are not optimized away.
+var calibrationSink interface{}
+
+// calibration measures one Config field: generic and SIMD run the scalar and
+// vector paths on an input of size n, prepared outside the timed loop. SIMD
+// dispatches through ks, whose cutovers send every size to the kernels.
+type calibration struct {
+	op      string // Operation group in kernelOperations, used to skip generic-only groups
+	field   func(c *Config) *int
+	generic func(n int) func()
+	simd    func(ks *kernelSet, n int) func()
+}
+
+var calibrations = []calibration{
+	{
+		op:    "CmpInt64",
+		field: func(c *Config) *int { return &c.MinCmpInt64 },
+		generic: func(n int) func() {
+			values := calibrationInt64s(n)
+			return func() { calibrationSink = cmpGtInt64Generic(values, 0) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			values := calibrationInt64s(n)
+			return func() { calibrationSink = cmpGtInt64Impl(ks, values, 0) }
+		},
+	},
+	{
+		op:    "CmpFloat64",
+		field: func(c *Config) *int { return &c.MinCmpFloat64 },
+		generic: func(n int) func() {
+			values := calibrationFloat64s(n)
+			return func() { calibrationSink = cmpGtFloat64Generic(values, 0) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			values := calibrationFloat64s(n)
+			return func() { calibrationSink = cmpGtFloat64Impl(ks, values, 0) }
+		},
+	},
+	{
+		op:    "NarrowInt",
+		field: func(c *Config) *int { return &c.MinNarrow },
+		generic: func(n int) func() {
+			values := calibrationInt16s(n)
+			return func() { calibrationSink = cmpNumberMaskGeneric(values, OpGt, 0) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			values := calibrationInt16s(n)
+			return func() { calibrationSink = cmpNarrowMaskImpl(ks, values, OpGt, 0) }
+		},
+	},
+	{
+		op:    "Float32",
+		field: func(c *Config) *int { return &c.MinFloat32 },
+		generic: func(n int) func() {
+			values := calibrationFloat32s(n)
+			return func() { calibrationSink = cmpNumberMaskGeneric(values, OpGt, 0) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			values := calibrationFloat32s(n)
+			return func() { calibrationSink = cmpFloat32MaskImpl(ks, values, OpGt, 0) }
+		},
+	},
+	{
+		op:    "Arith",
+		field: func(c *Config) *int { return &c.MinArith },
+		generic: func(n int) func() {
+			values, dst := calibrationFloat64s(n), make([]float64, n)
+			return func() { mulFloat64Generic(dst, values, values) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			values, dst := calibrationFloat64s(n), make([]float64, n)
+			return func() { mulFloat64Impl(ks, dst, values, values) }
+		},
+	},
+	{
+		op:    "Bitmap",
+		field: func(c *Config) *int { return &c.MinBitmapWords },
+		generic: func(n int) func() {
+			a, b := calibrationWords(n), calibrationWords(n)
+			return func() { calibrationSink = andBitmapGeneric(a, b) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			a, b := calibrationWords(n), calibrationWords(n)
+			return func() { calibrationSink = andBitmapImpl(ks, a, b) }
+		},
+	},
+	{
+		op:    "PopCount",
+		field: func(c *Config) *int { return &c.MinPopCountWords },
+		generic: func(n int) func() {
+			bitmap := calibrationWords(n)
+			return func() { calibrationSink = popCountGeneric(bitmap) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			bitmap := calibrationWords(n)
+			return func() { calibrationSink = popCountImpl(ks, bitmap) }
+		},
+	},
+	{
+		op:    "SumInt64",
+		field: func(c *Config) *int { return &c.MinAggregate },
+		generic: func(n int) func() {
+			values := calibrationInt64s(n)
+			return func() { calibrationSink = sumInt64Generic(values) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			values := calibrationInt64s(n)
+			return func() { calibrationSink = sumInt64Impl(ks, values) }
+		},
+	},
+	{
+		op:    "XXHash64",
+		field: func(c *Config) *int { return &c.MinHash },
+		generic: func(n int) func() {
+			values, output := calibrationInt64s(n), make([]uint64, n)
+			return func() { xxhash64SliceGeneric(values, output) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			values, output := calibrationInt64s(n), make([]uint64, n)
+			return func() { xxhash64Impl(ks, values, output) }
+		},
+	},
+	{
+		op:    "Compress",
+		field: func(c *Config) *int { return &c.MinCompress },
+		generic: func(n int) func() {
+			src, dst := calibrationInt64s(n), make([]int64, n)
+			mask := cmpGtInt64MaskGeneric(src, 0)
+			return func() { calibrationSink = compressInt64Generic(dst, src, mask) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			src, dst := calibrationInt64s(n), make([]int64, n)
+			mask := cmpGtInt64MaskGeneric(src, 0)
+			return func() { calibrationSink = compressInt64Impl(ks, dst, src, mask) }
+		},
+	},
+	{
+		op:    "String",
+		field: func(c *Config) *int { return &c.MinStrings },
+		generic: func(n int) func() {
+			values := calibrationStrings(n, calibrationStringLen)
+			return func() { calibrationSink = cmpEqStringGeneric(values, values[0]) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			values := calibrationStrings(n, calibrationStringLen)
+			return func() { calibrationSink = cmpEqStringImpl(ks, values, values[0]) }
+		},
+	},
+	{
+		// Here n is the string length rather than the number of strings
+		op:    "String",
+		field: func(c *Config) *int { return &c.AvgByteThreshold },
+		generic: func(n int) func() {
+			values := calibrationStrings(calibrationStringCount, n)
+			return func() { calibrationSink = cmpEqStringGeneric(values, values[0]) }
+		},
+		simd: func(ks *kernelSet, n int) func() {
+			values := calibrationStrings(calibrationStringCount, n)
+			return func() { calibrationSink = cmpEqStringImpl(ks, values, values[0]) }
+		},
+	},
+}
+
+// Calibrate micro-benchmarks the generic and SIMD paths of each operation group on
+// this machine and installs the measured crossover sizes in the active Config.
+// Returns the installed configuration, which can be saved with encoding/json and
+// shipped to identical machines instead of calibrating each one:
+//
+//	cfg, _ := syndrdbsimd.Calibrate(100 * time.Millisecond)
+//	profile, _ := json.Marshal(cfg)
+//
+//	// On the rest of the fleet
+//	var cfg syndrdbsimd.Config
+//	if err := json.Unmarshal(profile, &cfg); err == nil {
+//		syndrdbsimd.SetConfig(cfg)
+//	}
+//
+// budget is the approximate total measurement time; 100ms gives stable results
+// on most machines. Groups that dispatch to generic code under the current ISA selection
+// keep their current thresholds. If SIMD never beats the generic path for a group,
+// its threshold is set above the largest measured size.
+//
+// Calibrate measures through its own thresholds, so other goroutines keep the
+// installed configuration until the result replaces it at the end. Fields set
+// by SetConfig while Calibrate runs are kept, except the calibrated ones.
+//
+// Returns an error if budget <= 0.
+func Calibrate(budget time.Duration) (Config, error) {
+	if budget <= 0 {
+		return Config{}, fmt.Errorf("calibration budget must be > 0, got %v", budget)
+	}
+
+	// Force the SIMD paths for every size while measuring
+	alwaysSIMD := Config{
+		MinCmpInt64:      1,
+		MinCmpFloat64:    1,
+		MinBitmapWords:   1,
+		MinPopCountWords: 1,
+		MinAggregate:     1,
+		MinHash:          1,
+		MinCompress:      1,
+		MinNarrow:        1,
+		MinFloat32:       1,
+		MinArith:         1,
+		MinStrings:       1,
+		AvgByteThreshold: 1,
+	}
+	base := currentKernels()
+	ks := withCutovers(base, &alwaysSIMD)
+
+	var measured Config
+	var calibrated []calibration
+	slice := budget / time.Duration(2*len(calibrations)*len(calibrationSizes))
+	for _, c := range calibrations {
+		if kernelISA(base, c.op) == ISAGeneric {
+			continue
+		}
+
+		genericNs := make([]float64, len(calibrationSizes))
+		simdNs := make([]float64, len(calibrationSizes))
+		for i, n := range calibrationSizes {
+			genericNs[i] = timePerCall(c.generic(n), slice)
+			simdNs[i] = timePerCall(c.simd(ks, n), slice)
+		}
+		*c.field(&measured) = crossover(calibrationSizes, genericNs, simdNs)
+		calibrated = append(calibrated, c)
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+	config := *simdConfig.Load()
+	for _, c := range calibrated {
+		*c.field(&config) = *c.field(&measured)
+	}
+	simdConfig.Store(&config)
+	return config, nil
+}
+
+// timePerCall runs fn in doubling batches for at least d and returns the mean nanoseconds per call.
+func timePerCall(fn func(), d time.Duration) float64 {
+	fn() // Warm up caches and the branch predictor
+
+	calls := 0
+	batch := 1
+	start := time.Now()
+	for {
+		for i := 0; i < batch; i++ {
+			fn()
+		}
+		calls += batch
+
+		elapsed := time.Since(start)
+		if elapsed >= d {
+			return float64(elapsed) / float64(calls)
+		}
+		batch *= 2
+	}
+}
+
+// crossover returns the smallest size from which the SIMD path is faster at every
+// measured size. Requiring a win at all larger sizes keeps a single noisy
+// measurement from pulling the threshold down.
+func crossover(sizes []int, genericNs, simdNs []float64) int {
+	threshold := sizes[len(sizes)-1] * 2
+	for i := len(sizes) - 1; i >= 0; i-- {
+		if simdNs[i] >= genericNs[i] {
+			break
+		}
+		threshold = sizes[i]
+	}
+	return threshold
+}
+
+func calibrationInt64s(n int) []int64 {
+	values := make([]int64, n)
+	for i := range values {
+		values[i] = int64(i%7) - 3
+	}
+	return values
+}
+
+func calibrationInt16s(n int) []int16 {
+	values := make([]int16, n)
+	for i := range values {
+		values[i] = int16(i%7) - 3
+	}
+	return values
+}
+
+func calibrationFloat64s(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(i%7) - 3
+	}
+	return values
+}
+
+func calibrationFloat32s(n int) []float32 {
+	values := make([]float32, n)
+	for i := range values {
+		values[i] = float32(i%7) - 3
+	}
+	return values
+}
+
+func calibrationWords(n int) []uint64 {
+	words := make([]uint64, n)
+	for i := range words {
+		words[i] = uint64(i) * 0x9E3779B97F4A7C15
+	}
+	return words
+}
+
+// calibrationStrings returns count equal strings of length n, so equality checks
+// compare every byte.
+func calibrationStrings(count, n int) [][]byte {
+	values := make([][]byte, count)
+	for i := range values {
+		values[i] = make([]byte, n)
+		for j := range values[i] {
+			values[i][j] = 'a' + byte(j%26)
+		}
+	}
+	return values
+}
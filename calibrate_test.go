@@ -0,0 +1,200 @@
+package syndrdbsimd
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Calibrate Tests
+// ============================================================================
+
+func TestCalibrate_InstallsValidConfig(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+
+	cfg, err := Calibrate(20 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("Calibrate failed: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Calibrate returned an invalid config: %v", err)
+	}
+	if got := GetConfig(); got != cfg {
+		t.Errorf("Expected installed config %+v, got %+v", cfg, got)
+	}
+
+	// Every threshold is a measured size, just past the largest, or unchanged
+	limit := calibrationSizes[len(calibrationSizes)-1] * 2
+	for _, c := range calibrations {
+		if v := *c.field(&cfg); v > limit {
+			t.Errorf("%s: threshold %d exceeds %d", c.op, v, limit)
+		}
+	}
+}
+
+// Calibrate measures with its own thresholds; callers running meanwhile keep
+// the installed ones
+func TestCalibrate_ConfigUnchangedWhileMeasuring(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+	before := GetConfig()
+
+	done := make(chan error)
+	go func() {
+		_, err := Calibrate(30 * time.Millisecond)
+		done <- err
+	}()
+
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Calibrate failed: %v", err)
+			}
+			return
+		default:
+		}
+		if got := GetConfig(); got != before {
+			// The only change allowed is the final install
+			if err := <-done; err != nil {
+				t.Fatalf("Calibrate failed: %v", err)
+			}
+			if final := GetConfig(); got != final {
+				t.Fatalf("Config was %+v while Calibrate was measuring, then %+v", got, final)
+			}
+			return
+		}
+	}
+}
+
+func TestCalibrate_SkipsGenericGroups(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+
+	custom := DefaultConfig()
+	custom.MinCmpInt64 = 3
+	custom.MinStrings = 5
+	if err := SetConfig(custom); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	var cfg Config
+	err := WithGlobalISA(ISAGeneric, func() {
+		var calErr error
+		cfg, calErr = Calibrate(time.Millisecond)
+		if calErr != nil {
+			t.Fatalf("Calibrate failed: %v", calErr)
+		}
+	})
+	if err != nil {
+		t.Fatalf("WithGlobalISA failed: %v", err)
+	}
+
+	if cfg != custom {
+		t.Errorf("Expected thresholds unchanged under ISAGeneric: %+v, got %+v", custom, cfg)
+	}
+}
+
+func TestCalibrate_InvalidBudget(t *testing.T) {
+	before := GetConfig()
+	for _, budget := range []time.Duration{0, -time.Second} {
+		if _, err := Calibrate(budget); err == nil {
+			t.Errorf("Calibrate(%v): expected error", budget)
+		}
+	}
+	if GetConfig() != before {
+		t.Errorf("Rejected Calibrate changed the config to %+v", GetConfig())
+	}
+}
+
+func TestCrossover(t *testing.T) {
+	sizes := []int{1, 2, 4, 8}
+	tests := []struct {
+		name      string
+		genericNs []float64
+		simdNs    []float64
+		expected  int
+	}{
+		{"SIMD always faster", []float64{2, 4, 8, 16}, []float64{1, 2, 3, 4}, 1},
+		{"SIMD faster from 4", []float64{1, 2, 8, 16}, []float64{5, 5, 6, 7}, 4},
+		{"SIMD never faster", []float64{1, 2, 3, 4}, []float64{5, 6, 7, 8}, 16},
+		// A lucky win at size 1 must not lower the threshold below the real crossover
+		{"noisy small size", []float64{5, 2, 8, 16}, []float64{4, 5, 6, 7}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := crossover(sizes, tt.genericNs, tt.simdNs); got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Config JSON Tests
+// ============================================================================
+
+func TestConfigJSON_RoundTrip(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MinCmpInt64 = 12
+	cfg.AvgByteThreshold = 48
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded Config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded != cfg {
+		t.Errorf("Expected %+v, got %+v", cfg, decoded)
+	}
+}
+
+// Fields missing from a profile keep their defaults
+func TestConfigJSON_PartialProfile(t *testing.T) {
+	var cfg Config
+	if err := json.Unmarshal([]byte(`{"min_strings": 64}`), &cfg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	expected := DefaultConfig()
+	expected.MinStrings = 64
+	if cfg != expected {
+		t.Errorf("Expected %+v, got %+v", expected, cfg)
+	}
+}
+
+func TestConfigJSON_Rejected(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"unknown field", `{"min_cmp_int32": 8}`},
+		{"invalid value", `{"min_hash": 0}`},
+		{"wrong type", `{"min_hash": "16"}`},
+		{"malformed", `{"min_hash": `},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.MinHash = 99
+			if err := json.Unmarshal([]byte(tt.data), &cfg); err == nil {
+				t.Fatal("Expected error")
+			}
+			if cfg.MinHash != 99 {
+				t.Errorf("Rejected profile modified the config: %+v", cfg)
+			}
+		})
+	}
+
+	var cfg Config
+	err := json.Unmarshal([]byte(`{"min_hash": -1}`), &cfg)
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig, got %v", err)
+	}
+}
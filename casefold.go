@@ -0,0 +1,290 @@
+package syndrdbsimd
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// The UTF-8 case functions below map every rune with Unicode's simple case
+// mappings, where StrToLower, StrToUpper and the IgnoreCase compares map only
+// ASCII letters. Text is mostly ASCII even in European datasets, so runs of
+// ASCII still go through the SIMD kernels: a kernel finds the next non-ASCII
+// byte, the run before it is converted or compared in vector registers, and
+// only the following chunk of utf8Chunk bytes is decoded rune by rune.
+
+// utf8Chunk is the number of bytes decoded rune by rune after a non-ASCII byte
+// before handing back to the ASCII kernels, so text with scattered accents
+// does not pay a kernel call per rune.
+const utf8Chunk = 32
+
+// utf8EqWindow bounds the bytes scanned for ASCII ahead of a case-insensitive
+// compare, so a long ASCII run on one side is not rescanned after every
+// non-ASCII rune on the other.
+const utf8EqWindow = 256
+
+// caseTableSize is the number of runes mapped through tables: Latin, Greek,
+// Cyrillic and Armenian. Other runes go through package unicode.
+const caseTableSize = 0x600
+
+// caseMapping maps runes to their lowercase, uppercase or case-folded form.
+type caseMapping struct {
+	table [caseTableSize]rune
+	slow  func(rune) rune          // for runes past the table
+	ascii func(*kernelSet, []byte) // in-place SIMD mapping of ASCII bytes
+}
+
+func newCaseMapping(slow func(rune) rune, ascii func(*kernelSet, []byte)) *caseMapping {
+	m := &caseMapping{slow: slow, ascii: ascii}
+	for r := range m.table {
+		m.table[r] = slow(rune(r))
+	}
+	return m
+}
+
+var (
+	lowerMapping = newCaseMapping(unicode.ToLower, strToLowerImpl)
+	upperMapping = newCaseMapping(unicode.ToUpper, strToUpperImpl)
+	foldMapping  = newCaseMapping(foldRune, strToLowerImpl)
+)
+
+// foldRune returns one rune standing for every rune r is equal to under
+// simple case folding, that is every rune unicode.SimpleFold cycles through
+// from r. It is the smallest of them, except that ASCII letters fold to
+// lowercase as the SIMD kernels lower them; 'K', 'k' and the Kelvin sign all
+// fold to 'k'.
+func foldRune(r rune) rune {
+	least := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		least = min(least, f)
+	}
+	if 'A' <= least && least <= 'Z' {
+		least += 'a' - 'A'
+	}
+	return least
+}
+
+func (m *caseMapping) mapRune(r rune) rune {
+	if r < caseTableSize {
+		return m.table[r]
+	}
+	return m.slow(r)
+}
+
+// append appends s, mapped rune by rune, to dst. Invalid UTF-8 bytes are
+// copied unchanged. s and dst must not overlap, as a mapped rune may take more
+// bytes than the original.
+func (m *caseMapping) append(dst, s []byte) []byte {
+	ks := currentKernels()
+	dst = slices.Grow(dst, len(s))
+	for len(s) > 0 {
+		k := asciiPrefixImpl(ks, s)
+		start := len(dst)
+		dst = append(dst, s[:k]...)
+		m.ascii(ks, dst[start:])
+		s = s[k:]
+
+		for done := 0; done < utf8Chunk && len(s) > 0; {
+			c := s[0]
+			if c < utf8.RuneSelf {
+				dst = append(dst, byte(m.table[c]))
+				s = s[1:]
+				done++
+				continue
+			}
+			r, w := utf8.DecodeRune(s)
+			if r == utf8.RuneError && w == 1 {
+				dst = append(dst, c)
+			} else {
+				dst = utf8.AppendRune(dst, m.mapRune(r))
+			}
+			s = s[w:]
+			done += w
+		}
+	}
+	return dst
+}
+
+// decodeFold returns the case folding of the first rune of s and its length.
+// An invalid byte c decodes as -c, which equals only the same invalid byte.
+func decodeFold(s []byte) (rune, int) {
+	c := s[0]
+	if c < utf8.RuneSelf {
+		return foldMapping.table[c], 1
+	}
+	r, w := utf8.DecodeRune(s)
+	if r == utf8.RuneError && w == 1 {
+		return -rune(c), 1
+	}
+	return foldMapping.mapRune(r), w
+}
+
+// strEqFoldUTF8 reports whether a and b are equal under simple case folding.
+// Where both are ASCII, windows are compared with the SIMD case-insensitive
+// equality; from the first non-ASCII byte on either side, a chunk is compared
+// rune by rune, as the two sides may then advance by different byte counts.
+func strEqFoldUTF8(a, b []byte) bool {
+	ks := currentKernels()
+	for {
+		// Most unequal values differ early; reject them before scanning a window
+		if len(a) > 0 && len(b) > 0 && a[0]|b[0] < utf8.RuneSelf &&
+			foldMapping.table[a[0]] != foldMapping.table[b[0]] {
+			return false
+		}
+		n := min(len(a), len(b), utf8EqWindow)
+		k := asciiPrefixImpl(ks, a[:n])
+		k = asciiPrefixImpl(ks, b[:k])
+		if !strEqIgnoreCaseImpl(ks, a[:k], b[:k]) {
+			return false
+		}
+		a, b = a[k:], b[k:]
+		if len(a) == 0 || len(b) == 0 {
+			return len(a) == len(b)
+		}
+		if k == n {
+			continue
+		}
+
+		for done := 0; done < utf8Chunk && len(a) > 0 && len(b) > 0; {
+			ra, wa := decodeFold(a)
+			rb, wb := decodeFold(b)
+			if ra != rb {
+				return false
+			}
+			a, b = a[wa:], b[wb:]
+			done += wa
+		}
+	}
+}
+
+// foldStrings returns the case folding of each value. The results share one
+// buffer.
+func foldStrings(values []string) []string {
+	ends := make([]int, len(values))
+	var buf []byte
+	for i, v := range values {
+		buf = foldMapping.append(buf, stringToBytes(v))
+		ends[i] = len(buf)
+	}
+
+	folded := make([]string, len(values))
+	start := 0
+	for i, end := range ends {
+		if end > start {
+			folded[i] = unsafe.String(&buf[start], end-start)
+		}
+		start = end
+	}
+	return folded
+}
+
+// StrToLowerUTF8 appends the lowercase form of the UTF-8 text s to dst and
+// returns the extended slice. Every rune maps through Unicode's simple case
+// mapping, so "ÄÖÜ" becomes "äöü"; invalid bytes are copied unchanged. The
+// result may be longer or shorter than s, so dst must not overlap s.
+//
+// Runs of ASCII are lowered with the StrToLower kernels; only the chunks
+// following a non-ASCII byte are decoded rune by rune.
+func StrToLowerUTF8(dst, s []byte) []byte {
+	return lowerMapping.append(dst, s)
+}
+
+// StrToUpperUTF8 appends the uppercase form of the UTF-8 text s to dst and
+// returns the extended slice, as StrToLowerUTF8 does for lowercase.
+func StrToUpperUTF8(dst, s []byte) []byte {
+	return upperMapping.append(dst, s)
+}
+
+// StrEqIgnoreCaseUTF8 reports whether the UTF-8 texts a and b are equal under
+// Unicode simple case folding, as strings.EqualFold does: "Äpfel" equals
+// "äPFEL" and "ẞ" equals "ß". Invalid bytes must match exactly.
+//
+// Runs where both sides are ASCII are compared with the StrEqIgnoreCase
+// kernels; only the chunks following a non-ASCII byte are decoded rune by
+// rune.
+func StrEqIgnoreCaseUTF8(a, b []byte) bool {
+	return strEqFoldUTF8(a, b)
+}
+
+// CmpEqStringIgnoreCaseUTF8 compares string values for equality under Unicode
+// simple case folding, as StrEqIgnoreCaseUTF8 does. Returns a slice of booleans
+// where result[i] == true if values[i] equals threshold ignoring case.
+func CmpEqStringIgnoreCaseUTF8(values []string, threshold string) []bool {
+	results := make([]bool, len(values))
+	t := stringToBytes(threshold)
+	for i, v := range values {
+		results[i] = strEqFoldUTF8(stringToBytes(v), t)
+	}
+	return results
+}
+
+// CmpEqStringIgnoreCaseUTF8Mask is CmpEqStringIgnoreCaseUTF8 returning a bitmask.
+func CmpEqStringIgnoreCaseUTF8Mask(values []string, threshold string) []uint64 {
+	bools := CmpEqStringIgnoreCaseUTF8(values, threshold)
+	return boolsToBitmask(bools)
+}
+
+// CmpILikeStringUTF8 performs SQL ILIKE pattern matching with every letter
+// compared under Unicode simple case folding, not just ASCII ones. The pattern
+// and the values are case-folded, then matched as CmpLikeString matches, except
+// that _ stands for one UTF-8 character (or one invalid byte) rather than one
+// byte, so ILIKE '_' matches "é". Returns all false for an invalid pattern.
+//
+// Patterns without _ keep the SIMD equality, prefix, suffix, substring and
+// segment matchers; patterns with _ are matched by a regular expression.
+func CmpILikeStringUTF8(values []string, pattern string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	// % and _ are ASCII non-letters, so folding keeps the wildcards
+	compiled, err := compileLikeUTF8(string(foldMapping.append(nil, stringToBytes(pattern))))
+	if err != nil {
+		return make([]bool, len(values))
+	}
+	return cmpLikeStringCompiled(currentKernels(), foldStrings(values), compiled)
+}
+
+// compileLikeUTF8 compiles a LIKE pattern in which _ matches one UTF-8
+// character. Without _, byte and character matching agree: the literals are
+// valid UTF-8, so they can only match at character boundaries.
+func compileLikeUTF8(pattern string) (*CompiledPattern, error) {
+	if strings.IndexByte(pattern, '_') < 0 {
+		return CompilePatternAuto(pattern)
+	}
+
+	var b strings.Builder
+	b.WriteString(`^(?:`)
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '%':
+			b.WriteString(`(?s:.*)`)
+		case '_':
+			b.WriteString(`(?s:.)`)
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString(`)$`)
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid LIKE pattern %q: %v", pattern, err)
+	}
+	return &CompiledPattern{
+		Type:            PatternRegex,
+		OriginalPattern: pattern,
+		HasWildcard:     true,
+		regex:           re,
+	}, nil
+}
+
+// CmpILikeStringUTF8Mask performs UTF-8 aware ILIKE matching and returns a bitmask.
+func CmpILikeStringUTF8Mask(values []string, pattern string) []uint64 {
+	bools := CmpILikeStringUTF8(values, pattern)
+	return boolsToBitmask(bools)
+}
@@ -0,0 +1,287 @@
+package syndrdbsimd
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ============================================================================
+// UTF-8 Case Folding Tests
+// ============================================================================
+
+func TestStrToLowerUTF8(t *testing.T) {
+	tests := []struct {
+		input, lower, upper string
+	}{
+		{"", "", ""},
+		{"Hello World", "hello world", "HELLO WORLD"},
+		{"ÄÖÜ äöü ß", "äöü äöü ß", "ÄÖÜ ÄÖÜ ß"},
+		{"Ελληνικά ΣΊΣΥΦΟΣ", "ελληνικά σίσυφοσ", "ΕΛΛΗΝΙΚΆ ΣΊΣΥΦΟΣ"},
+		{"Москва", "москва", "МОСКВА"},
+		// Kelvin sign lowers to ASCII; ſ uppercases to ASCII
+		{"Kelvin ſ", "kelvin ſ", "KELVIN S"},
+		// Ⱥ lowers to a 3-byte rune; İ lowers to ASCII
+		{"ȺİI", "ⱥii", "ȺİI"},
+		{"bad \xff\xc3 utf8 É", "bad \xff\xc3 utf8 é", "BAD \xff\xc3 UTF8 É"},
+		{strings.Repeat("Straße ", 10) + "ÉCOLE", strings.Repeat("straße ", 10) + "école", strings.Repeat("STRAßE ", 10) + "ÉCOLE"},
+	}
+
+	for _, tt := range tests {
+		if got := string(StrToLowerUTF8(nil, []byte(tt.input))); got != tt.lower {
+			t.Errorf("StrToLowerUTF8(%q) = %q, want %q", tt.input, got, tt.lower)
+		}
+		if got := string(StrToUpperUTF8(nil, []byte(tt.input))); got != tt.upper {
+			t.Errorf("StrToUpperUTF8(%q) = %q, want %q", tt.input, got, tt.upper)
+		}
+	}
+
+	// The result is appended to dst
+	if got := string(StrToLowerUTF8([]byte("key="), []byte("ÄÖ"))); got != "key=äö" {
+		t.Errorf("StrToLowerUTF8 with dst = %q, want %q", got, "key=äö")
+	}
+}
+
+func TestStrEqIgnoreCaseUTF8(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"", "", true},
+		{"Äpfel", "äPFEL", true},
+		{"Äpfel", "Apfel", false},
+		{"ẞ", "ß", true},
+		{"K", "k", true},
+		{"K", "K", true},
+		{"ſ", "S", true},
+		{"Σ", "ς", true},
+		{"σ", "ς", true},
+		{"ΣΊΣΥΦΟΣ", "σίσυφος", true},
+		{"Москва", "МОСКВА", true},
+		{"Москва", "МОСКВ", false},
+		{"abc\xff", "ABC\xff", true},
+		{"abc\xff", "ABC\xfe", false},
+		{"\xff", "�", false},
+		{strings.Repeat("a", 300) + "É", strings.Repeat("A", 300) + "é", true},
+		{strings.Repeat("a", 300) + "É", strings.Repeat("A", 300) + "e", false},
+		{strings.Repeat("k", 100), strings.Repeat("K", 100), true},
+	}
+
+	for _, tt := range tests {
+		if got := StrEqIgnoreCaseUTF8([]byte(tt.a), []byte(tt.b)); got != tt.expected {
+			t.Errorf("StrEqIgnoreCaseUTF8(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.expected)
+		}
+		if got := StrEqIgnoreCaseUTF8([]byte(tt.b), []byte(tt.a)); got != tt.expected {
+			t.Errorf("StrEqIgnoreCaseUTF8(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.expected)
+		}
+	}
+}
+
+func TestCmpStringIgnoreCaseUTF8(t *testing.T) {
+	values := []string{"Müller", "MÜLLER", "Muller", "müllerin", "", "Ärztekammer Köln", "ÄRZTEKAMMER KÖLN"}
+
+	checkEqual(t, len(values), "CmpEqStringIgnoreCaseUTF8", CmpEqStringIgnoreCaseUTF8(values, "müller"),
+		[]bool{true, true, false, false, false, false, false})
+	checkEqual(t, len(values), "CmpEqStringIgnoreCaseUTF8Mask", CmpEqStringIgnoreCaseUTF8Mask(values, "müller"),
+		[]uint64{0b000_0011})
+
+	tests := []struct {
+		pattern  string
+		expected []bool
+	}{
+		{"müller", []bool{true, true, false, false, false, false, false}},
+		{"MÜL%", []bool{true, true, false, true, false, false, false}},
+		{"%KÖLN", []bool{false, false, false, false, false, true, true}},
+		{"%kammer%", []bool{false, false, false, false, false, true, true}},
+		// _ is one character, however many bytes encode it
+		{"m_ller%", []bool{true, true, true, true, false, false, false}},
+		{"m__ller%", []bool{false, false, false, false, false, false, false}},
+		{"_rztekammer k_ln", []bool{false, false, false, false, false, true, true}},
+		{"%_", []bool{true, true, true, true, false, true, true}},
+	}
+	for _, tt := range tests {
+		checkEqual(t, len(values), "CmpILikeStringUTF8 "+tt.pattern, CmpILikeStringUTF8(values, tt.pattern), tt.expected)
+		checkEqual(t, len(values), "CmpILikeStringUTF8Mask "+tt.pattern, CmpILikeStringUTF8Mask(values, tt.pattern), boolsToBitmask(tt.expected))
+	}
+
+	multibyte := []string{"é", "É", "e", "ée", "\xff", "€", ""}
+	checkEqual(t, len(multibyte), "CmpILikeStringUTF8 _", CmpILikeStringUTF8(multibyte, "_"),
+		[]bool{true, true, true, false, true, true, false})
+	checkEqual(t, len(multibyte), "CmpILikeStringUTF8 É_", CmpILikeStringUTF8(multibyte, "É_"),
+		[]bool{false, false, false, true, false, false, false})
+
+	if got := CmpILikeStringUTF8(nil, "x%"); len(got) != 0 {
+		t.Errorf("CmpILikeStringUTF8(nil) = %v, want empty", got)
+	}
+}
+
+// caseTestText returns a random string of ASCII runs and non-ASCII runes that
+// change length or map to ASCII under case mapping, so the slow path starts at
+// every offset within the SIMD blocks.
+func caseTestText(rng *rand.Rand, maxLen int) string {
+	pieces := []string{"a", "Z", "k", "s", " ", "abcdefghijklmnopqrstuvwxyzABCDEFGH", "Ä", "ö", "ß", "ẞ",
+		"Σ", "ς", "Ж", "K", "ſ", "Ⱥ", "ⱥ", "İ", "ı", "\xff", "\xe2\x84"}
+	var sb strings.Builder
+	for sb.Len() < maxLen {
+		if rng.Intn(4) == 0 {
+			break
+		}
+		sb.WriteString(pieces[rng.Intn(len(pieces))])
+	}
+	return sb.String()
+}
+
+// mapRunes maps each rune of s with f, copying invalid bytes unchanged.
+func mapRunes(s string, f func(rune) rune) string {
+	var b []byte
+	for i := 0; i < len(s); {
+		r, w := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && w == 1 {
+			b = append(b, s[i])
+		} else {
+			b = utf8.AppendRune(b, f(r))
+		}
+		i += w
+	}
+	return string(b)
+}
+
+// The ASCII fast path and the rune-by-rune slow path must agree with package
+// unicode, wherever the non-ASCII bytes fall
+func TestCaseUTF8_MatchUnicode(t *testing.T) {
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(490))
+				for trial := 0; trial < 2000; trial++ {
+					s := caseTestText(rng, 400)
+					if got, want := string(StrToLowerUTF8(nil, []byte(s))), mapRunes(s, unicode.ToLower); got != want {
+						t.Fatalf("StrToLowerUTF8(%q) = %q, want %q", s, got, want)
+					}
+					if got, want := string(StrToUpperUTF8(nil, []byte(s))), mapRunes(s, unicode.ToUpper); got != want {
+						t.Fatalf("StrToUpperUTF8(%q) = %q, want %q", s, got, want)
+					}
+
+					// Equal pairs with every rune's case changed at random, and
+					// pairs that may differ in one rune
+					mixed := mapRunes(s, func(r rune) rune {
+						for k := rng.Intn(3); k > 0; k-- {
+							r = unicode.SimpleFold(r)
+						}
+						return r
+					})
+					other := mixed
+					if i := rng.Intn(len(mixed) + 1); rng.Intn(2) == 0 {
+						other = mixed[:i] + caseTestText(rng, 4) + mixed[i:]
+					}
+					for _, b := range []string{mixed, other} {
+						// strings.EqualFold treats invalid bytes as U+FFFD
+						want := strings.EqualFold(s, b)
+						if !utf8.ValidString(s) || !utf8.ValidString(b) {
+							want = want && mapRunes(s, foldRune) == mapRunes(b, foldRune)
+						}
+						if got := StrEqIgnoreCaseUTF8([]byte(s), []byte(b)); got != want {
+							t.Fatalf("StrEqIgnoreCaseUTF8(%q, %q) = %v, want %v", s, b, got, want)
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
+// The kernels must find the first non-ASCII byte wherever it lies, including
+// inside the final, overlapping block
+func TestASCIIPrefix_MatchGeneric(t *testing.T) {
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				for n := 0; n <= 150; n++ {
+					s := []byte(strings.Repeat("x", n))
+					if got := asciiPrefixImpl(currentKernels(), s); got != n {
+						t.Fatalf("asciiPrefixImpl(currentKernels(), %d ASCII bytes) = %d", n, got)
+					}
+					for i := 0; i < n; i++ {
+						s[i] = 0x80 | byte(i)
+						if got := asciiPrefixImpl(currentKernels(), s); got != i {
+							t.Fatalf("asciiPrefixImpl(currentKernels(), n=%d, non-ASCII at %d) = %d", n, i, got)
+						}
+						// A later non-ASCII byte must not hide the first
+						s[n-1] |= 0x80
+						if got := asciiPrefixImpl(currentKernels(), s); got != i {
+							t.Fatalf("asciiPrefixImpl(currentKernels(), n=%d, non-ASCII at %d and %d) = %d", n, i, n-1, got)
+						}
+						s[i], s[n-1] = 'x', 'x'
+					}
+				}
+			})
+		})
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+// europeanNames returns n mostly ASCII strings with a German or French accent
+// in every other row.
+func europeanNames(n int) []string {
+	names := []string{"Müller", "Schmidt", "Bäcker", "Lefèvre", "Dubois", "Größmann", "Weber", "Garçon"}
+	values := make([]string, n)
+	for i := range values {
+		values[i] = names[i%len(names)] + " & Söhne Handelsgesellschaft mbH"
+	}
+	return values
+}
+
+func BenchmarkStrToLowerUTF8(b *testing.B) {
+	values := stringsToBytes(europeanNames(65536))
+	var buf []byte
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range values {
+			buf = StrToLowerUTF8(buf[:0], v)
+		}
+	}
+}
+
+func BenchmarkBytesToLower(b *testing.B) {
+	values := stringsToBytes(europeanNames(65536))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range values {
+			bytes.ToLower(v)
+		}
+	}
+}
+
+func BenchmarkCmpEqStringIgnoreCaseUTF8(b *testing.B) {
+	values := europeanNames(65536)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CmpEqStringIgnoreCaseUTF8Mask(values, "GRÖSSMANN & SÖHNE HANDELSGESELLSCHAFT MBH")
+	}
+}
+
+func BenchmarkStringsEqualFold(b *testing.B) {
+	values := europeanNames(65536)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range values {
+			strings.EqualFold(v, "GRÖSSMANN & SÖHNE HANDELSGESELLSCHAFT MBH")
+		}
+	}
+}
@@ -0,0 +1,463 @@
+package syndrdbsimd
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// Int64Column is a column of int64 values with a validity bitmap.
+//
+// Bit i of Validity (bit i%64 of word i/64) is set if row i holds a value and
+// clear if it is null, as in Arrow; a nil Validity means the column has no nulls.
+// This is the inverse of the null bitmap taken by CountNonNull. The values of
+// null rows are ignored and may hold anything.
+//
+// The methods follow SQL semantics: comparisons are false for null rows,
+// aggregates skip them, and hashes map them to 0.
+// Every method panics if Validity is non-nil and shorter than (Len()+63)/64 words.
+type Int64Column struct {
+	Values   []int64
+	Validity []uint64
+}
+
+// Float64Column is a column of float64 values with a validity bitmap.
+// Validity and null semantics are those of Int64Column.
+type Float64Column struct {
+	Values   []float64
+	Validity []uint64
+}
+
+// StringColumn is a column of strings with a validity bitmap.
+// Validity and null semantics are those of Int64Column.
+type StringColumn struct {
+	Values   []string
+	Validity []uint64
+}
+
+// validChunkRows is the number of rows the aggregates compact at a time.
+const validChunkRows = 4096
+
+// checkValidity panics if validity is non-nil and too short for n rows.
+func checkValidity(validity []uint64, n int) {
+	if validity != nil && len(validity) < (n+63)/64 {
+		panic(fmt.Sprintf("syndrdbsimd: validity bitmap holds %d words, need %d", len(validity), (n+63)/64))
+	}
+}
+
+// isNull reports whether row i is null.
+func isNull(validity []uint64, i int) bool {
+	return validity != nil && validity[i/64]&(1<<uint(i%64)) == 0
+}
+
+// validCount returns the number of valid rows among the first n.
+// Bits past row n are ignored.
+func validCount(validity []uint64, n int) int {
+	checkValidity(validity, n)
+	if validity == nil {
+		return n
+	}
+
+	count := 0
+	if n >= 64 {
+		count = popCountImpl(currentKernels(), validity[:n/64])
+	}
+	if n%64 != 0 {
+		count += bits.OnesCount64(validity[n/64] & (1<<uint(n%64) - 1))
+	}
+	return count
+}
+
+// applyValidity clears the bits of mask for null rows.
+func applyValidity(mask, validity []uint64) []uint64 {
+	if validity != nil {
+		for i := range mask {
+			mask[i] &= validity[i]
+		}
+	}
+	return mask
+}
+
+// validChunks calls fn with the values of the valid rows in order, at most
+// validChunkRows at a time. Chunks without nulls are passed without copying;
+// the others are compacted with compress into a scratch buffer.
+func validChunks[T any](ks *kernelSet, values []T, validity []uint64, compress func(ks *kernelSet, dst, src []T, mask []uint64) int, fn func([]T)) {
+	checkValidity(validity, len(values))
+	if validity == nil {
+		if len(values) > 0 {
+			fn(values)
+		}
+		return
+	}
+
+	var buf []T
+	for start := 0; start < len(values); start += validChunkRows {
+		chunk := values[start:min(start+validChunkRows, len(values))]
+		mask := validity[start/64 : start/64+(len(chunk)+63)/64]
+		switch valid := validCount(mask, len(chunk)); valid {
+		case 0:
+		case len(chunk):
+			fn(chunk)
+		default:
+			if buf == nil {
+				buf = make([]T, min(validChunkRows, len(values)))
+			}
+			fn(buf[:compress(ks, buf, chunk, mask)])
+		}
+	}
+}
+
+// hashNulls sets the hash of every null row to 0.
+func hashNulls(output, validity []uint64) {
+	if validity == nil {
+		return
+	}
+	for w, word := range validity[:(len(output)+63)/64] {
+		for nulls := ^word; nulls != 0; nulls &= nulls - 1 {
+			if i := w*64 + bits.TrailingZeros64(nulls); i < len(output) {
+				output[i] = 0
+			}
+		}
+	}
+}
+
+// ============================================================================
+// Int64Column
+// ============================================================================
+
+// Len returns the number of rows, including nulls.
+func (c Int64Column) Len() int {
+	return len(c.Values)
+}
+
+// IsNull reports whether row i is null.
+func (c Int64Column) IsNull(i int) bool {
+	return isNull(c.Validity, i)
+}
+
+// Count returns the number of non-null rows, like SQL COUNT(column).
+func (c Int64Column) Count() int {
+	return validCount(c.Validity, len(c.Values))
+}
+
+// NullCount returns the number of null rows.
+func (c Int64Column) NullCount() int {
+	return len(c.Values) - c.Count()
+}
+
+// Compare compares each value against threshold with the operator op.
+// Returns a slice of booleans where result[i] == true if row i is not null and
+// values[i] op threshold. Panics if op is invalid.
+func (c Int64Column) Compare(op Op, threshold int64) []bool {
+	return bitmaskToBools(c.CompareMask(op, threshold), len(c.Values))
+}
+
+// CompareMask compares each value against threshold and returns a bitmask in
+// which the bits of null rows are clear. Panics if op is invalid.
+//
+// Uses the same kernels as CmpEqInt64Mask and the other int64 comparisons.
+func (c Int64Column) CompareMask(op Op, threshold int64) []uint64 {
+	checkOp(op)
+	checkValidity(c.Validity, len(c.Values))
+	if len(c.Values) == 0 {
+		return []uint64{}
+	}
+	return applyValidity(int64CmpMaskImpls[op](currentKernels(), c.Values, threshold), c.Validity)
+}
+
+// Sum returns the sum of the non-null values, wrapping on overflow like SumInt64.
+// ok is false if every row is null, where SQL SUM returns NULL.
+func (c Int64Column) Sum() (sum int64, ok bool) {
+	ks := currentKernels()
+	validChunks(ks, c.Values, c.Validity, compressInt64Impl, func(values []int64) {
+		sum += sumInt64Impl(ks, values)
+		ok = true
+	})
+	return sum, ok
+}
+
+// Min returns the smallest non-null value. ok is false if every row is null.
+func (c Int64Column) Min() (m int64, ok bool) {
+	ks := currentKernels()
+	validChunks(ks, c.Values, c.Validity, compressInt64Impl, func(values []int64) {
+		if v := minInt64Impl(ks, values); !ok || v < m {
+			m = v
+		}
+		ok = true
+	})
+	return m, ok
+}
+
+// Max returns the largest non-null value. ok is false if every row is null.
+func (c Int64Column) Max() (m int64, ok bool) {
+	ks := currentKernels()
+	validChunks(ks, c.Values, c.Validity, compressInt64Impl, func(values []int64) {
+		if v := maxInt64Impl(ks, values); !ok || v > m {
+			m = v
+		}
+		ok = true
+	})
+	return m, ok
+}
+
+// Avg returns the average of the non-null values. ok is false if every row is null.
+func (c Int64Column) Avg() (avg float64, ok bool) {
+	sum, ok := c.Sum()
+	if !ok {
+		return 0, false
+	}
+	return float64(sum) / float64(c.Count()), true
+}
+
+// XXHash64 computes the XXHash64 hash of each value into output, with 0 for null rows.
+// Like XXHash64, it does nothing unless output has the same length as the column.
+func (c Int64Column) XXHash64(output []uint64) {
+	checkValidity(c.Validity, len(c.Values))
+	if len(c.Values) == 0 || len(output) != len(c.Values) {
+		return
+	}
+	xxhash64Impl(currentKernels(), c.Values, output)
+	hashNulls(output, c.Validity)
+}
+
+// ============================================================================
+// Float64Column
+// ============================================================================
+
+// Len returns the number of rows, including nulls.
+func (c Float64Column) Len() int {
+	return len(c.Values)
+}
+
+// IsNull reports whether row i is null.
+func (c Float64Column) IsNull(i int) bool {
+	return isNull(c.Validity, i)
+}
+
+// Count returns the number of non-null rows, like SQL COUNT(column).
+func (c Float64Column) Count() int {
+	return validCount(c.Validity, len(c.Values))
+}
+
+// NullCount returns the number of null rows.
+func (c Float64Column) NullCount() int {
+	return len(c.Values) - c.Count()
+}
+
+// Compare compares each value against threshold with the operator op.
+// Returns a slice of booleans where result[i] == true if row i is not null and
+// values[i] op threshold. NaN values compare as in CmpEqFloat64 and friends.
+// Panics if op is invalid.
+func (c Float64Column) Compare(op Op, threshold float64) []bool {
+	return bitmaskToBools(c.CompareMask(op, threshold), len(c.Values))
+}
+
+// CompareMask compares each value against threshold and returns a bitmask in
+// which the bits of null rows are clear. Panics if op is invalid.
+func (c Float64Column) CompareMask(op Op, threshold float64) []uint64 {
+	checkOp(op)
+	checkValidity(c.Validity, len(c.Values))
+	if len(c.Values) == 0 {
+		return []uint64{}
+	}
+	return applyValidity(float64CmpMaskImpls[op](currentKernels(), c.Values, threshold), c.Validity)
+}
+
+// Sum returns the sum of the non-null values. ok is false if every row is null.
+func (c Float64Column) Sum() (sum float64, ok bool) {
+	validChunks(currentKernels(), c.Values, c.Validity, compressFloat64Impl, func(values []float64) {
+		sum += Sum(values)
+		ok = true
+	})
+	return sum, ok
+}
+
+// Min returns the smallest non-null value. NaN values are skipped like nulls, as
+// in MinFloat32, so ok is false if every row is null or NaN.
+func (c Float64Column) Min() (m float64, ok bool) {
+	ks := currentKernels()
+	m = math.Inf(1)
+	validChunks(ks, c.Values, c.Validity, compressFloat64Impl, func(values []float64) {
+		v := minFloat64Impl(ks, values)
+		m = min(m, v)
+		ok = ok || v < math.Inf(1) || slices.ContainsFunc(values, isNumber)
+	})
+	if !ok {
+		return 0, false
+	}
+	return m, true
+}
+
+// Max returns the largest non-null value. NaN values are skipped like nulls, as
+// in MaxFloat32, so ok is false if every row is null or NaN.
+func (c Float64Column) Max() (m float64, ok bool) {
+	ks := currentKernels()
+	m = math.Inf(-1)
+	validChunks(ks, c.Values, c.Validity, compressFloat64Impl, func(values []float64) {
+		v := maxFloat64Impl(ks, values)
+		m = max(m, v)
+		ok = ok || v > math.Inf(-1) || slices.ContainsFunc(values, isNumber)
+	})
+	if !ok {
+		return 0, false
+	}
+	return m, true
+}
+
+// isNumber reports whether v is not NaN. The min and max kernels return an
+// infinity both for an infinite value and for a chunk of NaN values.
+func isNumber(v float64) bool {
+	return !math.IsNaN(v)
+}
+
+// Avg returns the average of the non-null values. ok is false if every row is null.
+func (c Float64Column) Avg() (avg float64, ok bool) {
+	sum, ok := c.Sum()
+	if !ok {
+		return 0, false
+	}
+	return sum / float64(c.Count()), true
+}
+
+// XXHash64 computes the XXHash64 hash of each value's bits into output, with 0
+// for null rows. -0 hashes like 0, so values that compare equal hash equal.
+// It does nothing unless output has the same length as the column.
+func (c Float64Column) XXHash64(output []uint64) {
+	ks := currentKernels()
+	checkValidity(c.Validity, len(c.Values))
+	if len(c.Values) == 0 || len(output) != len(c.Values) {
+		return
+	}
+	xxhash64Impl(ks, float64sAsInt64s(c.Values), output)
+
+	var zeroHash [1]uint64
+	for i, v := range c.Values {
+		if v == 0 {
+			if zeroHash[0] == 0 {
+				xxhash64Impl(ks, []int64{0}, zeroHash[:])
+			}
+			output[i] = zeroHash[0]
+		}
+	}
+	hashNulls(output, c.Validity)
+}
+
+// ============================================================================
+// StringColumn
+// ============================================================================
+
+// Len returns the number of rows, including nulls.
+func (c StringColumn) Len() int {
+	return len(c.Values)
+}
+
+// IsNull reports whether row i is null.
+func (c StringColumn) IsNull(i int) bool {
+	return isNull(c.Validity, i)
+}
+
+// Count returns the number of non-null rows, like SQL COUNT(column).
+func (c StringColumn) Count() int {
+	return validCount(c.Validity, len(c.Values))
+}
+
+// NullCount returns the number of null rows.
+func (c StringColumn) NullCount() int {
+	return len(c.Values) - c.Count()
+}
+
+// Compare compares each value against threshold with the operator op, ordering
+// strings by bytes as Go does. Returns a slice of booleans where result[i] == true
+// if row i is not null and values[i] op threshold. Panics if op is invalid.
+func (c StringColumn) Compare(op Op, threshold string) []bool {
+	return bitmaskToBools(c.CompareMask(op, threshold), len(c.Values))
+}
+
+// CompareMask compares each value against threshold and returns a bitmask in
+// which the bits of null rows are clear. Panics if op is invalid.
+//
+// OpEq and OpNe use the same kernels as CmpEqStringMask and CmpNeStringMask;
+// the ordering operators compare one string at a time.
+func (c StringColumn) CompareMask(op Op, threshold string) []uint64 {
+	checkOp(op)
+	checkValidity(c.Validity, len(c.Values))
+	var mask []uint64
+	switch op {
+	case OpEq:
+		mask = CmpEqStringMask(c.Values, threshold)
+	case OpNe:
+		mask = CmpNeStringMask(c.Values, threshold)
+	default:
+		mask = boolsToBitmask(cmpOrderStringImpl(currentKernels(), stringsToBytes(c.Values), op, stringToBytes(threshold)))
+	}
+	return applyValidity(mask, c.Validity)
+}
+
+// HasPrefixMask returns a bitmask of the non-null rows that start with prefix.
+func (c StringColumn) HasPrefixMask(prefix string) []uint64 {
+	checkValidity(c.Validity, len(c.Values))
+	return applyValidity(CmpHasPrefixStringMask(c.Values, prefix), c.Validity)
+}
+
+// HasSuffixMask returns a bitmask of the non-null rows that end with suffix.
+func (c StringColumn) HasSuffixMask(suffix string) []uint64 {
+	checkValidity(c.Validity, len(c.Values))
+	return applyValidity(CmpHasSuffixStringMask(c.Values, suffix), c.Validity)
+}
+
+// ContainsMask returns a bitmask of the non-null rows that contain substr.
+func (c StringColumn) ContainsMask(substr string) []uint64 {
+	checkValidity(c.Validity, len(c.Values))
+	return applyValidity(CmpContainsStringMask(c.Values, substr), c.Validity)
+}
+
+// LikeMask returns a bitmask of the non-null rows that match the SQL LIKE pattern.
+// Like CmpLikeStringMask, an invalid pattern matches nothing.
+func (c StringColumn) LikeMask(pattern string) []uint64 {
+	checkValidity(c.Validity, len(c.Values))
+	return applyValidity(CmpLikeStringMask(c.Values, pattern), c.Validity)
+}
+
+// Min returns the smallest non-null value in byte order. ok is false if every row is null.
+func (c StringColumn) Min() (m string, ok bool) {
+	validChunks(currentKernels(), c.Values, c.Validity, compressStringsImpl, func(values []string) {
+		if !ok {
+			m = values[0]
+		}
+		for _, v := range values {
+			m = min(m, v)
+		}
+		ok = true
+	})
+	return m, ok
+}
+
+// Max returns the largest non-null value in byte order. ok is false if every row is null.
+func (c StringColumn) Max() (m string, ok bool) {
+	validChunks(currentKernels(), c.Values, c.Validity, compressStringsImpl, func(values []string) {
+		if !ok {
+			m = values[0]
+		}
+		for _, v := range values {
+			m = max(m, v)
+		}
+		ok = true
+	})
+	return m, ok
+}
+
+// XXHash64 computes the XXHash64Bytes hash of each value into output, with 0 for
+// null rows. It does nothing unless output has the same length as the column.
+func (c StringColumn) XXHash64(output []uint64) {
+	checkValidity(c.Validity, len(c.Values))
+	if len(c.Values) == 0 || len(output) != len(c.Values) {
+		return
+	}
+	for i, s := range c.Values {
+		if !isNull(c.Validity, i) {
+			output[i] = xxhash64BytesGeneric(stringToBytes(s))
+		}
+	}
+	hashNulls(output, c.Validity)
+}
@@ -0,0 +1,323 @@
+package syndrdbsimd
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// ============================================================================
+// Column Tests
+// ============================================================================
+
+// validityOf returns the validity bitmap for rows that are not listed in nulls.
+// Bits past n are set, so the columns must ignore them.
+func validityOf(n int, nulls ...int) []uint64 {
+	validity := make([]uint64, (n+63)/64)
+	for i := range validity {
+		validity[i] = ^uint64(0)
+	}
+	for _, i := range nulls {
+		validity[i/64] &^= 1 << uint(i%64)
+	}
+	return validity
+}
+
+func TestInt64Column_HappyPath(t *testing.T) {
+	c := Int64Column{
+		Values:   []int64{5, 99, -3, 7, 99, 12},
+		Validity: validityOf(6, 1, 4),
+	}
+
+	if c.Len() != 6 || c.Count() != 4 || c.NullCount() != 2 {
+		t.Errorf("Len, Count, NullCount = %d, %d, %d, want 6, 4, 2", c.Len(), c.Count(), c.NullCount())
+	}
+	if !c.IsNull(1) || c.IsNull(0) {
+		t.Errorf("IsNull(1), IsNull(0) = %v, %v, want true, false", c.IsNull(1), c.IsNull(0))
+	}
+
+	// Null rows never match, even when their values would
+	checkEqual(t, 6, "CompareMask", c.CompareMask(OpGt, 6), []uint64{0b10_1000})
+	checkEqual(t, 6, "Compare", c.Compare(OpNe, 5), []bool{false, false, true, true, false, true})
+
+	if sum, ok := c.Sum(); sum != 21 || !ok {
+		t.Errorf("Sum() = %d, %v, want 21, true", sum, ok)
+	}
+	if m, ok := c.Min(); m != -3 || !ok {
+		t.Errorf("Min() = %d, %v, want -3, true", m, ok)
+	}
+	if m, ok := c.Max(); m != 12 || !ok {
+		t.Errorf("Max() = %d, %v, want 12, true", m, ok)
+	}
+	if avg, ok := c.Avg(); avg != 5.25 || !ok {
+		t.Errorf("Avg() = %v, %v, want 5.25, true", avg, ok)
+	}
+
+	hashes, plain := make([]uint64, 6), make([]uint64, 6)
+	c.XXHash64(hashes)
+	XXHash64(c.Values, plain)
+	plain[1], plain[4] = 0, 0
+	checkEqual(t, 6, "XXHash64", hashes, plain)
+}
+
+func TestInt64Column_NoValidity(t *testing.T) {
+	values := make([]int64, 300)
+	for i := range values {
+		values[i] = int64(i%37 - 18)
+	}
+	c := Int64Column{Values: values}
+
+	if c.Count() != 300 || c.NullCount() != 0 || c.IsNull(299) {
+		t.Errorf("Count, NullCount = %d, %d, want 300, 0", c.Count(), c.NullCount())
+	}
+	for _, op := range allOps {
+		checkEqual(t, 300, "CompareMask "+op.String(), c.CompareMask(op, 3), CompareMask(values, op, 3))
+	}
+	if sum, _ := c.Sum(); sum != SumInt64(values) {
+		t.Errorf("Sum() = %d, want %d", sum, SumInt64(values))
+	}
+	if m, _ := c.Min(); m != MinInt64(values) {
+		t.Errorf("Min() = %d, want %d", m, MinInt64(values))
+	}
+}
+
+func TestColumns_AllNull(t *testing.T) {
+	ints := Int64Column{Values: []int64{1, 2, 3}, Validity: []uint64{0}}
+	floats := Float64Column{Values: []float64{1, 2, 3}, Validity: []uint64{0}}
+	strs := StringColumn{Values: []string{"a", "b", "c"}, Validity: []uint64{0}}
+	empty := Int64Column{}
+
+	if _, ok := ints.Sum(); ok {
+		t.Error("Int64Column.Sum() ok on an all-null column")
+	}
+	if _, ok := ints.Min(); ok {
+		t.Error("Int64Column.Min() ok on an all-null column")
+	}
+	if _, ok := ints.Avg(); ok {
+		t.Error("Int64Column.Avg() ok on an all-null column")
+	}
+	if _, ok := floats.Max(); ok {
+		t.Error("Float64Column.Max() ok on an all-null column")
+	}
+	if _, ok := strs.Min(); ok {
+		t.Error("StringColumn.Min() ok on an all-null column")
+	}
+	if _, ok := empty.Sum(); ok {
+		t.Error("Int64Column.Sum() ok on an empty column")
+	}
+	checkEqual(t, 3, "Float64Column.CompareMask", floats.CompareMask(OpGe, 0), []uint64{0})
+	checkEqual(t, 3, "StringColumn.CompareMask", strs.CompareMask(OpNe, "z"), []uint64{0})
+	checkEqual(t, 0, "empty CompareMask", empty.CompareMask(OpEq, 0), []uint64{})
+}
+
+// The aggregates compact the valid values in chunks; mixed, full and empty
+// chunks must all give the scalar answer
+func TestColumns_MatchScalar(t *testing.T) {
+	rng := rand.New(rand.NewSource(390))
+	for _, size := range []int{1, 63, 64, 65, 4095, 4096, 4097, 10000} {
+		ints, floats, strs := make([]int64, size), make([]float64, size), make([]string, size)
+		var nulls []int
+		for i := 0; i < size; i++ {
+			ints[i] = rng.Int63n(2000) - 1000
+			floats[i] = rng.NormFloat64()
+			strs[i] = strconv.Itoa(rng.Intn(1000))
+			// Rows 4096-8191 are all valid and rows from 8192 on all null
+			if (i < 4096 && rng.Intn(3) == 0) || i >= 8192 {
+				nulls = append(nulls, i)
+			}
+		}
+		validity := validityOf(size, nulls...)
+		ic := Int64Column{Values: ints, Validity: validity}
+		fc := Float64Column{Values: floats, Validity: validity}
+		sc := StringColumn{Values: strs, Validity: validity}
+
+		var sum, count int64
+		var fsum float64
+		minI, maxI := int64(math.MaxInt64), int64(math.MinInt64)
+		minF, maxF := math.Inf(1), math.Inf(-1)
+		minS, maxS := "\xff", ""
+		wantMask := make([]uint64, (size+63)/64)
+		for i := 0; i < size; i++ {
+			if isNull(validity, i) {
+				continue
+			}
+			sum += ints[i]
+			fsum += floats[i]
+			count++
+			minI, maxI = min(minI, ints[i]), max(maxI, ints[i])
+			minF, maxF = min(minF, floats[i]), max(maxF, floats[i])
+			minS, maxS = min(minS, strs[i]), max(maxS, strs[i])
+			if strs[i] >= "5" {
+				wantMask[i/64] |= 1 << uint(i%64)
+			}
+		}
+
+		if got := ic.Count(); int64(got) != count {
+			t.Errorf("size=%d: Count() = %d, want %d", size, got, count)
+		}
+		if got, _ := ic.Sum(); got != sum {
+			t.Errorf("size=%d: Int64Column.Sum() = %d, want %d", size, got, sum)
+		}
+		if got, _ := ic.Min(); got != minI {
+			t.Errorf("size=%d: Int64Column.Min() = %d, want %d", size, got, minI)
+		}
+		if got, _ := ic.Max(); got != maxI {
+			t.Errorf("size=%d: Int64Column.Max() = %d, want %d", size, got, maxI)
+		}
+		if got, _ := fc.Sum(); math.Abs(got-fsum) > 1e-9*float64(size) {
+			t.Errorf("size=%d: Float64Column.Sum() = %v, want %v", size, got, fsum)
+		}
+		if got, _ := fc.Min(); got != minF {
+			t.Errorf("size=%d: Float64Column.Min() = %v, want %v", size, got, minF)
+		}
+		if got, _ := fc.Max(); got != maxF {
+			t.Errorf("size=%d: Float64Column.Max() = %v, want %v", size, got, maxF)
+		}
+		if got, _ := sc.Min(); got != minS {
+			t.Errorf("size=%d: StringColumn.Min() = %q, want %q", size, got, minS)
+		}
+		if got, _ := sc.Max(); got != maxS {
+			t.Errorf("size=%d: StringColumn.Max() = %q, want %q", size, got, maxS)
+		}
+		checkEqual(t, size, "StringColumn.CompareMask", sc.CompareMask(OpGe, "5"), wantMask)
+	}
+}
+
+func TestFloat64Column_NaNAndZero(t *testing.T) {
+	c := Float64Column{
+		Values:   []float64{1, math.NaN(), 0, math.Copysign(0, -1), 2},
+		Validity: validityOf(5, 4),
+	}
+
+	// NaN only matches !=, and the null row never matches
+	checkEqual(t, 5, "CompareMask Ne", c.CompareMask(OpNe, 1), []uint64{0b0_1110})
+	checkEqual(t, 5, "CompareMask Le", c.CompareMask(OpLe, 1), []uint64{0b0_1101})
+	// NaN is skipped like a null; the null row's 2 is too
+	if m, ok := c.Max(); m != 1 || !ok {
+		t.Errorf("Max() = %v, %v, want 1, true", m, ok)
+	}
+	if m, ok := c.Min(); m != 0 || !ok {
+		t.Errorf("Min() = %v, %v, want 0, true", m, ok)
+	}
+	nan := Float64Column{Values: []float64{math.NaN(), math.NaN(), 5}, Validity: validityOf(3, 2)}
+	if _, ok := nan.Min(); ok {
+		t.Error("Min() ok on a column of NaN and null rows")
+	}
+
+	hashes := make([]uint64, 5)
+	c.XXHash64(hashes)
+	if hashes[2] != hashes[3] {
+		t.Errorf("XXHash64: -0 hashed to %x, 0 to %x", hashes[3], hashes[2])
+	}
+	if hashes[4] != 0 || hashes[0] == 0 {
+		t.Errorf("XXHash64 = %x, want 0 only for the null row", hashes)
+	}
+}
+
+// Min and Max skip NaN on every ISA, and tell a chunk of NaN from one holding ±Inf
+func TestFloat64Column_MinMaxSkipsNaN(t *testing.T) {
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(391))
+				for _, size := range []int{1, 7, 8, 9, 31, 100, 4097} {
+					values := make([]float64, size)
+					var nulls []int
+					for i := range values {
+						switch rng.Intn(6) {
+						case 0:
+							values[i] = math.NaN()
+						case 1:
+							nulls = append(nulls, i)
+						}
+						if values[i] == 0 {
+							values[i] = rng.NormFloat64()
+						}
+					}
+					c := Float64Column{Values: values, Validity: validityOf(size, nulls...)}
+
+					minF, maxF, ok := math.Inf(1), math.Inf(-1), false
+					for i, v := range values {
+						if !isNull(c.Validity, i) && !math.IsNaN(v) {
+							minF, maxF, ok = min(minF, v), max(maxF, v), true
+						}
+					}
+					if got, gotOK := c.Min(); gotOK != ok || (ok && got != minF) {
+						t.Errorf("size=%d: Min() = %v, %v, want %v, %v", size, got, gotOK, minF, ok)
+					}
+					if got, gotOK := c.Max(); gotOK != ok || (ok && got != maxF) {
+						t.Errorf("size=%d: Max() = %v, %v, want %v, %v", size, got, gotOK, maxF, ok)
+					}
+				}
+
+				values := make([]float64, 40)
+				for i := range values {
+					values[i] = math.NaN()
+				}
+				if _, ok := (Float64Column{Values: values}).Max(); ok {
+					t.Error("Max() ok on an all-NaN column")
+				}
+				values[17] = math.Inf(1)
+				if m, ok := (Float64Column{Values: values}).Min(); m != math.Inf(1) || !ok {
+					t.Errorf("Min() = %v, %v, want +Inf, true", m, ok)
+				}
+			})
+		})
+	}
+}
+
+func TestStringColumn_HappyPath(t *testing.T) {
+	c := StringColumn{
+		Values:   []string{"apple", "banana", "", "apricot", "cherry", "apple"},
+		Validity: validityOf(6, 2, 5),
+	}
+
+	checkEqual(t, 6, "CompareMask Eq", c.CompareMask(OpEq, "apple"), []uint64{0b00_0001})
+	checkEqual(t, 6, "CompareMask Ne", c.CompareMask(OpNe, "apple"), []uint64{0b01_1010})
+	checkEqual(t, 6, "CompareMask Lt", c.CompareMask(OpLt, "b"), []uint64{0b00_1001})
+	checkEqual(t, 6, "HasPrefixMask", c.HasPrefixMask("ap"), []uint64{0b00_1001})
+	checkEqual(t, 6, "HasSuffixMask", c.HasSuffixMask("y"), []uint64{0b01_0000})
+	checkEqual(t, 6, "ContainsMask", c.ContainsMask("an"), []uint64{0b00_0010})
+	checkEqual(t, 6, "LikeMask", c.LikeMask("%e%"), []uint64{0b01_0001})
+
+	hashes := make([]uint64, 6)
+	c.XXHash64(hashes)
+	want := []uint64{XXHash64Bytes([]byte("apple")), XXHash64Bytes([]byte("banana")), 0,
+		XXHash64Bytes([]byte("apricot")), XXHash64Bytes([]byte("cherry")), 0}
+	checkEqual(t, 6, "XXHash64", hashes, want)
+}
+
+func TestColumns_ShortValidityPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("CompareMask with a short validity bitmap did not panic")
+		}
+	}()
+	c := Int64Column{Values: make([]int64, 65), Validity: []uint64{^uint64(0)}}
+	c.CompareMask(OpEq, 0)
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+func BenchmarkInt64ColumnSum(b *testing.B) {
+	values := make([]int64, 65536)
+	var nulls []int
+	for i := range values {
+		values[i] = int64(i)
+		if i%10 == 0 {
+			nulls = append(nulls, i)
+		}
+	}
+	c := Int64Column{Values: values, Validity: validityOf(len(values), nulls...)}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Sum()
+	}
+}
@@ -38,3 +38,75 @@ func cmpLeInt64AVX2(values *int64, threshold int64) uint64
 //
 //go:noescape
 func cmpNeInt64AVX2(values *int64, threshold int64) uint64
+
+// cmpEqInt64AVX512 compares length int64 values for equality against a threshold using AVX-512,
+// writing out[i] = values[i] == threshold. length must be a multiple of 8.
+//
+//go:noescape
+func cmpEqInt64AVX512(values *int64, threshold int64, out *bool, length int)
+
+// cmpNeInt64AVX512 compares length int64 values for inequality against a threshold using AVX-512,
+// writing out[i] = values[i] != threshold. length must be a multiple of 8.
+//
+//go:noescape
+func cmpNeInt64AVX512(values *int64, threshold int64, out *bool, length int)
+
+// cmpGtInt64AVX512 compares length int64 values against a threshold using AVX-512,
+// writing out[i] = values[i] > threshold. length must be a multiple of 8.
+//
+//go:noescape
+func cmpGtInt64AVX512(values *int64, threshold int64, out *bool, length int)
+
+// cmpGeInt64AVX512 compares length int64 values against a threshold using AVX-512,
+// writing out[i] = values[i] >= threshold. length must be a multiple of 8.
+//
+//go:noescape
+func cmpGeInt64AVX512(values *int64, threshold int64, out *bool, length int)
+
+// cmpLtInt64AVX512 compares length int64 values against a threshold using AVX-512,
+// writing out[i] = values[i] < threshold. length must be a multiple of 8.
+//
+//go:noescape
+func cmpLtInt64AVX512(values *int64, threshold int64, out *bool, length int)
+
+// cmpLeInt64AVX512 compares length int64 values against a threshold using AVX-512,
+// writing out[i] = values[i] <= threshold. length must be a multiple of 8.
+//
+//go:noescape
+func cmpLeInt64AVX512(values *int64, threshold int64, out *bool, length int)
+
+// cmpEqInt64MaskAVX512 compares length int64 values for equality against a threshold using AVX-512,
+// setting bit i of mask if values[i] == threshold. length must be a multiple of 64.
+//
+//go:noescape
+func cmpEqInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+
+// cmpNeInt64MaskAVX512 compares length int64 values for inequality against a threshold using AVX-512,
+// setting bit i of mask if values[i] != threshold. length must be a multiple of 64.
+//
+//go:noescape
+func cmpNeInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+
+// cmpGtInt64MaskAVX512 compares length int64 values against a threshold using AVX-512,
+// setting bit i of mask if values[i] > threshold. length must be a multiple of 64.
+//
+//go:noescape
+func cmpGtInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+
+// cmpGeInt64MaskAVX512 compares length int64 values against a threshold using AVX-512,
+// setting bit i of mask if values[i] >= threshold. length must be a multiple of 64.
+//
+//go:noescape
+func cmpGeInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+
+// cmpLtInt64MaskAVX512 compares length int64 values against a threshold using AVX-512,
+// setting bit i of mask if values[i] < threshold. length must be a multiple of 64.
+//
+//go:noescape
+func cmpLtInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+
+// cmpLeInt64MaskAVX512 compares length int64 values against a threshold using AVX-512,
+// setting bit i of mask if values[i] <= threshold. length must be a multiple of 64.
+//
+//go:noescape
+func cmpLeInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
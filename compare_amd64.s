@@ -23,9 +23,11 @@ TEXT ·cmpGtInt64AVX2(SB), NOSPLIT, $0-24
     MOVQ    threshold+8(FP), AX     // AX = threshold value to compare against
     
     // Broadcast the threshold value to all 4 lanes of a 256-bit YMM register
-    // VPBROADCASTQ takes a 64-bit value and replicates it across all lanes
+    // VPBROADCASTQ replicates the low 64 bits of XMM0 across all lanes.
+    // The GPR-source form of VPBROADCASTQ is AVX-512 only, so go through XMM0.
     // This creates: YMM0 = [threshold, threshold, threshold, threshold]
-    VPBROADCASTQ AX, Y0
+    MOVQ    AX, X0
+    VPBROADCASTQ X0, Y0
     
     // Load 4 consecutive int64 values from memory into YMM1
     // VMOVDQU performs an unaligned load (works regardless of memory alignment)
@@ -46,14 +48,14 @@ TEXT ·cmpGtInt64AVX2(SB), NOSPLIT, $0-24
     //   - VEX prefix: 3-byte VEX form (C4)
     //   - Byte 1 (C4): VEX prefix indicator
     //   - Byte 2 (E2): R=1, X=1, B=1 (no extension), m-mmmm=00010 (0F38 opcode map)
-    //   - Byte 3 (75): W=0 (ignore), vvvv=1110 (~Y0=14, inverted), L=1 (256-bit), pp=01 (66 prefix)
+    //   - Byte 3 (75): W=0 (ignore), vvvv=1110 (~Y1, inverted), L=1 (256-bit), pp=01 (66 prefix)
     //   - Byte 4 (37): Opcode for PCMPGTQ
-    //   - Byte 5 (D1): ModR/M = 11 010 001 (register-direct, Y2, Y1)
+    //   - Byte 5 (D0): ModR/M = 11 010 000 (register-direct, Y2, Y0)
     //     * mod=11 (register mode, no memory operand)
     //     * reg=010 (destination Y2)
-    //     * r/m=001 (source Y1)
+    //     * r/m=000 (second source Y0; the first source Y1 comes from vvvv)
     // Result: Y2 = Y1 > Y0 (each 64-bit lane)
-    BYTE $0xC4; BYTE $0xE2; BYTE $0x75; BYTE $0x37; BYTE $0xD1
+    BYTE $0xC4; BYTE $0xE2; BYTE $0x75; BYTE $0x37; BYTE $0xD0
     
     // Convert vector comparison results to a compact bitmask
     // VMOVMSKPD extracts the sign bit from each 64-bit double-precision lane
@@ -124,7 +126,8 @@ TEXT ·cmpLtInt64AVX2(SB), NOSPLIT, $0-24
     MOVQ    values+0(FP), SI
     MOVQ    threshold+8(FP), AX
     
-    VPBROADCASTQ AX, Y0
+    MOVQ    AX, X0
+    VPBROADCASTQ X0, Y0
     VMOVDQU (SI), Y1
     
     // Compare: Y0 > Y1 (equivalent to Y1 < Y0)
@@ -136,11 +139,11 @@ TEXT ·cmpLtInt64AVX2(SB), NOSPLIT, $0-24
     // Encoding breakdown:
     //   - Byte 1 (C4): VEX prefix
     //   - Byte 2 (E2): 0F38 opcode map
-    //   - Byte 3 (6D): vvvv=1101 (~Y1=13), L=1, pp=01
+    //   - Byte 3 (7D): vvvv=1111 (~Y0, first source), L=1, pp=01
     //   - Byte 4 (37): PCMPGTQ opcode
-    //   - Byte 5 (D0): ModR/M = 11 010 000 (Y2 dest, Y0 source)
+    //   - Byte 5 (D1): ModR/M = 11 010 001 (Y2 dest, Y1 second source)
     // Result: Y2 = (Y0 > Y1) = (Y1 < Y0)
-    BYTE $0xC4; BYTE $0xE2; BYTE $0x6D; BYTE $0x37; BYTE $0xD0
+    BYTE $0xC4; BYTE $0xE2; BYTE $0x7D; BYTE $0x37; BYTE $0xD1
     
     VMOVMSKPD Y2, AX
     VZEROUPPER
@@ -158,20 +161,21 @@ TEXT ·cmpGeInt64AVX2(SB), NOSPLIT, $0-24
     MOVQ    values+0(FP), SI
     MOVQ    threshold+8(FP), AX
     
-    VPBROADCASTQ AX, Y0
+    MOVQ    AX, X0
+    VPBROADCASTQ X0, Y0
     VMOVDQU (SI), Y1
     
     // First comparison: values > threshold
     // WORKAROUND: Manual VEX encoding for: VPCMPGTQ Y0, Y1, Y2
     // Y2 = (Y1 > Y0)
-    BYTE $0xC4; BYTE $0xE2; BYTE $0x75; BYTE $0x37; BYTE $0xD1
+    BYTE $0xC4; BYTE $0xE2; BYTE $0x75; BYTE $0x37; BYTE $0xD0
     
     // Second comparison: values == threshold
     // WORKAROUND: Manual VEX encoding for: VPCMPEQQ Y0, Y1, Y3
-    // Encoding: vvvv=1110 (~Y0), dest=Y3 (011), source=Y1 (001)
-    // Byte 5 (D9): ModR/M = 11 011 001
+    // Encoding: vvvv=1110 (~Y1), dest=Y3 (011), source=Y0 (000)
+    // Byte 5 (D8): ModR/M = 11 011 000
     // Y3 = (Y1 == Y0)
-    BYTE $0xC4; BYTE $0xE2; BYTE $0x75; BYTE $0x29; BYTE $0xD9
+    BYTE $0xC4; BYTE $0xE2; BYTE $0x75; BYTE $0x29; BYTE $0xD8
     
     // Combine with OR: (Y1 > Y0) OR (Y1 == Y0) ≡ (Y1 >= Y0)
     VPOR Y3, Y2, Y2                 // Y2 = Y2 | Y3
@@ -192,18 +196,19 @@ TEXT ·cmpLeInt64AVX2(SB), NOSPLIT, $0-24
     MOVQ    values+0(FP), SI
     MOVQ    threshold+8(FP), AX
     
-    VPBROADCASTQ AX, Y0
+    MOVQ    AX, X0
+    VPBROADCASTQ X0, Y0
     VMOVDQU (SI), Y1
     
     // First comparison: threshold > values (i.e., values < threshold)
     // WORKAROUND: Manual VEX encoding for: VPCMPGTQ Y1, Y0, Y2
     // Y2 = (Y0 > Y1) = (Y1 < Y0)
-    BYTE $0xC4; BYTE $0xE2; BYTE $0x6D; BYTE $0x37; BYTE $0xD0
+    BYTE $0xC4; BYTE $0xE2; BYTE $0x7D; BYTE $0x37; BYTE $0xD1
     
     // Second comparison: values == threshold
     // WORKAROUND: Manual VEX encoding for: VPCMPEQQ Y0, Y1, Y3
     // Y3 = (Y1 == Y0)
-    BYTE $0xC4; BYTE $0xE2; BYTE $0x75; BYTE $0x29; BYTE $0xD9
+    BYTE $0xC4; BYTE $0xE2; BYTE $0x75; BYTE $0x29; BYTE $0xD8
     
     // Combine with OR
     VPOR Y3, Y2, Y2
@@ -224,13 +229,14 @@ TEXT ·cmpNeInt64AVX2(SB), NOSPLIT, $0-24
     MOVQ    values+0(FP), SI
     MOVQ    threshold+8(FP), AX
     
-    VPBROADCASTQ AX, Y0
+    MOVQ    AX, X0
+    VPBROADCASTQ X0, Y0
     VMOVDQU (SI), Y1
     
     // Compare for equality
     // WORKAROUND: Manual VEX encoding for: VPCMPEQQ Y0, Y1, Y2
     // Y2 = (Y1 == Y0)
-    BYTE $0xC4; BYTE $0xE2; BYTE $0x75; BYTE $0x29; BYTE $0xD1
+    BYTE $0xC4; BYTE $0xE2; BYTE $0x75; BYTE $0x29; BYTE $0xD0
     
     // Extract bitmask
     VMOVMSKPD Y2, AX
@@ -243,3 +249,121 @@ TEXT ·cmpNeInt64AVX2(SB), NOSPLIT, $0-24
     
     MOVQ    AX, ret+16(FP)
     RET
+
+// ============================================================================
+// AVX-512 kernels
+// ============================================================================
+//
+// The AVX-512 kernels process whole slices rather than one 4-element group per call.
+// VPCMPQ writes its result into a mask register, one bit per lane, so no VMOVMSKPD
+// round trip is needed:
+//   - The bool kernels spread the 8 mask bits into 8 bytes with PDEPQ (BMI2)
+//     and store them as a single 64-bit write.
+//   - The mask kernels run 8 compares per bitmap word and merge the 8-bit masks
+//     into a GPR before storing the word.
+//
+// VPCMPQ predicates (result = values op threshold):
+//   0: EQ   1: LT   2: LE   4: NE   5: GE (NLT)   6: GT (NLE)
+
+// CMP_INT64_BOOL_AVX512 defines a kernel with the signature
+// func(values *int64, threshold int64, out *bool, length int)
+// length must be a multiple of 8.
+#define CMP_INT64_BOOL_AVX512(name, pred) \
+TEXT name(SB), NOSPLIT, $0-32 \
+    MOVQ    values+0(FP), SI \
+    MOVQ    threshold+8(FP), AX \
+    MOVQ    out+16(FP), DI \
+    MOVQ    length+24(FP), CX \
+    SHRQ    $3, CX                      /* CX = number of 8-element groups */ \
+    JZ      done \
+    VPBROADCASTQ AX, Z0                 /* Z0 = threshold in all 8 lanes */ \
+    MOVQ    $0x0101010101010101, DX     /* PDEP selector: bit i -> byte i */ \
+loop: \
+    VMOVDQU64 (SI), Z1 \
+    VPCMPQ  pred, Z0, Z1, K1            /* K1[i] = values[i] op threshold */ \
+    KMOVW   K1, AX \
+    PDEPQ   DX, AX, BX                  /* Spread 8 bits into 8 bools */ \
+    MOVQ    BX, (DI) \
+    ADDQ    $64, SI \
+    ADDQ    $8, DI \
+    DECQ    CX \
+    JNZ     loop \
+    VZEROUPPER \
+done: \
+    RET
+
+// CMP_INT64_MASK_STEP compares the 8 values at off(SI) and merges the resulting
+// 8-bit mask into BX at bit position shift.
+#define CMP_INT64_MASK_STEP(pred, off, shift) \
+    VMOVDQU64 off(SI), Z1 \
+    VPCMPQ  pred, Z0, Z1, K1 \
+    KMOVW   K1, AX \
+    SHLQ    $shift, AX \
+    ORQ     AX, BX
+
+// CMP_INT64_MASK_AVX512 defines a kernel with the signature
+// func(values *int64, threshold int64, mask *uint64, length int)
+// length must be a multiple of 64; one bitmap word is produced per iteration.
+#define CMP_INT64_MASK_AVX512(name, pred) \
+TEXT name(SB), NOSPLIT, $0-32 \
+    MOVQ    values+0(FP), SI \
+    MOVQ    threshold+8(FP), AX \
+    MOVQ    mask+16(FP), DI \
+    MOVQ    length+24(FP), CX \
+    SHRQ    $6, CX                      /* CX = number of bitmap words */ \
+    JZ      done \
+    VPBROADCASTQ AX, Z0 \
+loop: \
+    XORQ    BX, BX \
+    CMP_INT64_MASK_STEP(pred, 0, 0) \
+    CMP_INT64_MASK_STEP(pred, 64, 8) \
+    CMP_INT64_MASK_STEP(pred, 128, 16) \
+    CMP_INT64_MASK_STEP(pred, 192, 24) \
+    CMP_INT64_MASK_STEP(pred, 256, 32) \
+    CMP_INT64_MASK_STEP(pred, 320, 40) \
+    CMP_INT64_MASK_STEP(pred, 384, 48) \
+    CMP_INT64_MASK_STEP(pred, 448, 56) \
+    MOVQ    BX, (DI) \
+    ADDQ    $512, SI \
+    ADDQ    $8, DI \
+    DECQ    CX \
+    JNZ     loop \
+    VZEROUPPER \
+done: \
+    RET
+
+// func cmpEqInt64AVX512(values *int64, threshold int64, out *bool, length int)
+CMP_INT64_BOOL_AVX512(·cmpEqInt64AVX512, $0)
+
+// func cmpNeInt64AVX512(values *int64, threshold int64, out *bool, length int)
+CMP_INT64_BOOL_AVX512(·cmpNeInt64AVX512, $4)
+
+// func cmpGtInt64AVX512(values *int64, threshold int64, out *bool, length int)
+CMP_INT64_BOOL_AVX512(·cmpGtInt64AVX512, $6)
+
+// func cmpGeInt64AVX512(values *int64, threshold int64, out *bool, length int)
+CMP_INT64_BOOL_AVX512(·cmpGeInt64AVX512, $5)
+
+// func cmpLtInt64AVX512(values *int64, threshold int64, out *bool, length int)
+CMP_INT64_BOOL_AVX512(·cmpLtInt64AVX512, $1)
+
+// func cmpLeInt64AVX512(values *int64, threshold int64, out *bool, length int)
+CMP_INT64_BOOL_AVX512(·cmpLeInt64AVX512, $2)
+
+// func cmpEqInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+CMP_INT64_MASK_AVX512(·cmpEqInt64MaskAVX512, $0)
+
+// func cmpNeInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+CMP_INT64_MASK_AVX512(·cmpNeInt64MaskAVX512, $4)
+
+// func cmpGtInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+CMP_INT64_MASK_AVX512(·cmpGtInt64MaskAVX512, $6)
+
+// func cmpGeInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+CMP_INT64_MASK_AVX512(·cmpGeInt64MaskAVX512, $5)
+
+// func cmpLtInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+CMP_INT64_MASK_AVX512(·cmpLtInt64MaskAVX512, $1)
+
+// func cmpLeInt64MaskAVX512(values *int64, threshold int64, mask *uint64, length int)
+CMP_INT64_MASK_AVX512(·cmpLeInt64MaskAVX512, $2)
@@ -38,3 +38,75 @@ func cmpLeInt64NEON(values *int64, threshold int64) uint64
 //
 //go:noescape
 func cmpNeInt64NEON(values *int64, threshold int64) uint64
+
+// cmpEqInt64SVE compares length int64 values for equality against a threshold using SVE,
+// writing out[i] = values[i] == threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpEqInt64SVE(values *int64, threshold int64, out *bool, length int)
+
+// cmpNeInt64SVE compares length int64 values for inequality against a threshold using SVE,
+// writing out[i] = values[i] != threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpNeInt64SVE(values *int64, threshold int64, out *bool, length int)
+
+// cmpGtInt64SVE compares length int64 values against a threshold using SVE,
+// writing out[i] = values[i] > threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpGtInt64SVE(values *int64, threshold int64, out *bool, length int)
+
+// cmpGeInt64SVE compares length int64 values against a threshold using SVE,
+// writing out[i] = values[i] >= threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpGeInt64SVE(values *int64, threshold int64, out *bool, length int)
+
+// cmpLtInt64SVE compares length int64 values against a threshold using SVE,
+// writing out[i] = values[i] < threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpLtInt64SVE(values *int64, threshold int64, out *bool, length int)
+
+// cmpLeInt64SVE compares length int64 values against a threshold using SVE,
+// writing out[i] = values[i] <= threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpLeInt64SVE(values *int64, threshold int64, out *bool, length int)
+
+// cmpEqInt64MaskSVE compares length int64 values for equality against a threshold using SVE,
+// setting bit i of mask if values[i] == threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpEqInt64MaskSVE(values *int64, threshold int64, mask *uint64, length int)
+
+// cmpNeInt64MaskSVE compares length int64 values for inequality against a threshold using SVE,
+// setting bit i of mask if values[i] != threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpNeInt64MaskSVE(values *int64, threshold int64, mask *uint64, length int)
+
+// cmpGtInt64MaskSVE compares length int64 values against a threshold using SVE,
+// setting bit i of mask if values[i] > threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpGtInt64MaskSVE(values *int64, threshold int64, mask *uint64, length int)
+
+// cmpGeInt64MaskSVE compares length int64 values against a threshold using SVE,
+// setting bit i of mask if values[i] >= threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpGeInt64MaskSVE(values *int64, threshold int64, mask *uint64, length int)
+
+// cmpLtInt64MaskSVE compares length int64 values against a threshold using SVE,
+// setting bit i of mask if values[i] < threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpLtInt64MaskSVE(values *int64, threshold int64, mask *uint64, length int)
+
+// cmpLeInt64MaskSVE compares length int64 values against a threshold using SVE,
+// setting bit i of mask if values[i] <= threshold. Handles any length without a scalar tail.
+//
+//go:noescape
+func cmpLeInt64MaskSVE(values *int64, threshold int64, mask *uint64, length int)
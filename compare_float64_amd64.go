@@ -44,3 +44,87 @@ func cmpEqFloat64AVX2(values *float64, threshold float64) uint64
 //
 //go:noescape
 func cmpNeFloat64AVX2(values *float64, threshold float64) uint64
+
+// cmpEqFloat64AVX512 compares length float64 values for equality against a threshold using AVX-512,
+// writing out[i] = values[i] == threshold. length must be a multiple of 8.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpEqFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+
+// cmpNeFloat64AVX512 compares length float64 values for inequality against a threshold using AVX-512,
+// writing out[i] = values[i] != threshold. length must be a multiple of 8.
+// NaN comparisons return true per IEEE 754.
+//
+//go:noescape
+func cmpNeFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+
+// cmpGtFloat64AVX512 compares length float64 values against a threshold using AVX-512,
+// writing out[i] = values[i] > threshold. length must be a multiple of 8.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpGtFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+
+// cmpGeFloat64AVX512 compares length float64 values against a threshold using AVX-512,
+// writing out[i] = values[i] >= threshold. length must be a multiple of 8.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpGeFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+
+// cmpLtFloat64AVX512 compares length float64 values against a threshold using AVX-512,
+// writing out[i] = values[i] < threshold. length must be a multiple of 8.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpLtFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+
+// cmpLeFloat64AVX512 compares length float64 values against a threshold using AVX-512,
+// writing out[i] = values[i] <= threshold. length must be a multiple of 8.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpLeFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+
+// cmpEqFloat64MaskAVX512 compares length float64 values for equality against a threshold using AVX-512,
+// setting bit i of mask if values[i] == threshold. length must be a multiple of 64.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpEqFloat64MaskAVX512(values *float64, threshold float64, mask *uint64, length int)
+
+// cmpNeFloat64MaskAVX512 compares length float64 values for inequality against a threshold using AVX-512,
+// setting bit i of mask if values[i] != threshold. length must be a multiple of 64.
+// NaN comparisons return true per IEEE 754.
+//
+//go:noescape
+func cmpNeFloat64MaskAVX512(values *float64, threshold float64, mask *uint64, length int)
+
+// cmpGtFloat64MaskAVX512 compares length float64 values against a threshold using AVX-512,
+// setting bit i of mask if values[i] > threshold. length must be a multiple of 64.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpGtFloat64MaskAVX512(values *float64, threshold float64, mask *uint64, length int)
+
+// cmpGeFloat64MaskAVX512 compares length float64 values against a threshold using AVX-512,
+// setting bit i of mask if values[i] >= threshold. length must be a multiple of 64.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpGeFloat64MaskAVX512(values *float64, threshold float64, mask *uint64, length int)
+
+// cmpLtFloat64MaskAVX512 compares length float64 values against a threshold using AVX-512,
+// setting bit i of mask if values[i] < threshold. length must be a multiple of 64.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpLtFloat64MaskAVX512(values *float64, threshold float64, mask *uint64, length int)
+
+// cmpLeFloat64MaskAVX512 compares length float64 values against a threshold using AVX-512,
+// setting bit i of mask if values[i] <= threshold. length must be a multiple of 64.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpLeFloat64MaskAVX512(values *float64, threshold float64, mask *uint64, length int)
@@ -124,3 +124,118 @@ TEXT ·cmpNeFloat64AVX2(SB), NOSPLIT, $0-24
     
     MOVQ    AX, ret+16(FP)
     RET
+
+// ============================================================================
+// AVX-512 kernels
+// ============================================================================
+//
+// The AVX-512 kernels mirror the int64 ones in compare_amd64.s: VCMPPD writes
+// straight into a mask register, which is expanded into bools with PDEPQ or
+// merged into bitmap words.
+//
+// VCMPPD predicates (result = values op threshold). Ordered predicates make
+// every comparison against NaN false, except NE which is unordered and true:
+//   0x00: EQ_OQ   0x04: NEQ_UQ   0x11: LT_OQ   0x12: LE_OQ   0x1D: GE_OQ   0x1E: GT_OQ
+
+// CMP_FLOAT64_BOOL_AVX512 defines a kernel with the signature
+// func(values *float64, threshold float64, out *bool, length int)
+// length must be a multiple of 8.
+#define CMP_FLOAT64_BOOL_AVX512(name, pred) \
+TEXT name(SB), NOSPLIT, $0-32 \
+    MOVQ    values+0(FP), SI \
+    MOVQ    threshold+8(FP), X0 \
+    MOVQ    out+16(FP), DI \
+    MOVQ    length+24(FP), CX \
+    SHRQ    $3, CX                      /* CX = number of 8-element groups */ \
+    JZ      done \
+    VBROADCASTSD X0, Z0                 /* Z0 = threshold in all 8 lanes */ \
+    MOVQ    $0x0101010101010101, DX     /* PDEP selector: bit i -> byte i */ \
+loop: \
+    VMOVUPD   (SI), Z1 \
+    VCMPPD  pred, Z0, Z1, K1            /* K1[i] = values[i] op threshold */ \
+    KMOVW   K1, AX \
+    PDEPQ   DX, AX, BX                  /* Spread 8 bits into 8 bools */ \
+    MOVQ    BX, (DI) \
+    ADDQ    $64, SI \
+    ADDQ    $8, DI \
+    DECQ    CX \
+    JNZ     loop \
+    VZEROUPPER \
+done: \
+    RET
+
+// CMP_FLOAT64_MASK_STEP compares the 8 values at off(SI) and merges the resulting
+// 8-bit mask into BX at bit position shift.
+#define CMP_FLOAT64_MASK_STEP(pred, off, shift) \
+    VMOVUPD   off(SI), Z1 \
+    VCMPPD  pred, Z0, Z1, K1 \
+    KMOVW   K1, AX \
+    SHLQ    $shift, AX \
+    ORQ     AX, BX
+
+// CMP_FLOAT64_MASK_AVX512 defines a kernel with the signature
+// func(values *float64, threshold float64, mask *ufloat64, length int)
+// length must be a multiple of 64; one bitmap word is produced per iteration.
+#define CMP_FLOAT64_MASK_AVX512(name, pred) \
+TEXT name(SB), NOSPLIT, $0-32 \
+    MOVQ    values+0(FP), SI \
+    MOVQ    threshold+8(FP), X0 \
+    MOVQ    mask+16(FP), DI \
+    MOVQ    length+24(FP), CX \
+    SHRQ    $6, CX                      /* CX = number of bitmap words */ \
+    JZ      done \
+    VBROADCASTSD X0, Z0 \
+loop: \
+    XORQ    BX, BX \
+    CMP_FLOAT64_MASK_STEP(pred, 0, 0) \
+    CMP_FLOAT64_MASK_STEP(pred, 64, 8) \
+    CMP_FLOAT64_MASK_STEP(pred, 128, 16) \
+    CMP_FLOAT64_MASK_STEP(pred, 192, 24) \
+    CMP_FLOAT64_MASK_STEP(pred, 256, 32) \
+    CMP_FLOAT64_MASK_STEP(pred, 320, 40) \
+    CMP_FLOAT64_MASK_STEP(pred, 384, 48) \
+    CMP_FLOAT64_MASK_STEP(pred, 448, 56) \
+    MOVQ    BX, (DI) \
+    ADDQ    $512, SI \
+    ADDQ    $8, DI \
+    DECQ    CX \
+    JNZ     loop \
+    VZEROUPPER \
+done: \
+    RET
+
+// func cmpEqFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+CMP_FLOAT64_BOOL_AVX512(·cmpEqFloat64AVX512, $0x00)
+
+// func cmpNeFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+CMP_FLOAT64_BOOL_AVX512(·cmpNeFloat64AVX512, $0x04)
+
+// func cmpGtFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+CMP_FLOAT64_BOOL_AVX512(·cmpGtFloat64AVX512, $0x1E)
+
+// func cmpGeFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+CMP_FLOAT64_BOOL_AVX512(·cmpGeFloat64AVX512, $0x1D)
+
+// func cmpLtFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+CMP_FLOAT64_BOOL_AVX512(·cmpLtFloat64AVX512, $0x11)
+
+// func cmpLeFloat64AVX512(values *float64, threshold float64, out *bool, length int)
+CMP_FLOAT64_BOOL_AVX512(·cmpLeFloat64AVX512, $0x12)
+
+// func cmpEqFloat64MaskAVX512(values *float64, threshold float64, mask *ufloat64, length int)
+CMP_FLOAT64_MASK_AVX512(·cmpEqFloat64MaskAVX512, $0x00)
+
+// func cmpNeFloat64MaskAVX512(values *float64, threshold float64, mask *ufloat64, length int)
+CMP_FLOAT64_MASK_AVX512(·cmpNeFloat64MaskAVX512, $0x04)
+
+// func cmpGtFloat64MaskAVX512(values *float64, threshold float64, mask *ufloat64, length int)
+CMP_FLOAT64_MASK_AVX512(·cmpGtFloat64MaskAVX512, $0x1E)
+
+// func cmpGeFloat64MaskAVX512(values *float64, threshold float64, mask *ufloat64, length int)
+CMP_FLOAT64_MASK_AVX512(·cmpGeFloat64MaskAVX512, $0x1D)
+
+// func cmpLtFloat64MaskAVX512(values *float64, threshold float64, mask *ufloat64, length int)
+CMP_FLOAT64_MASK_AVX512(·cmpLtFloat64MaskAVX512, $0x11)
+
+// func cmpLeFloat64MaskAVX512(values *float64, threshold float64, mask *ufloat64, length int)
+CMP_FLOAT64_MASK_AVX512(·cmpLeFloat64MaskAVX512, $0x12)
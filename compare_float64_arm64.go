@@ -44,3 +44,87 @@ func cmpEqFloat64NEON(values *float64, threshold float64) uint64
 //
 //go:noescape
 func cmpNeFloat64NEON(values *float64, threshold float64) uint64
+
+// cmpEqFloat64SVE compares length float64 values for equality against a threshold using SVE,
+// writing out[i] = values[i] == threshold. Handles any length without a scalar tail.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpEqFloat64SVE(values *float64, threshold float64, out *bool, length int)
+
+// cmpNeFloat64SVE compares length float64 values for inequality against a threshold using SVE,
+// writing out[i] = values[i] != threshold. Handles any length without a scalar tail.
+// NaN != x returns true for all x per IEEE 754.
+//
+//go:noescape
+func cmpNeFloat64SVE(values *float64, threshold float64, out *bool, length int)
+
+// cmpGtFloat64SVE compares length float64 values against a threshold using SVE,
+// writing out[i] = values[i] > threshold. Handles any length without a scalar tail.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpGtFloat64SVE(values *float64, threshold float64, out *bool, length int)
+
+// cmpGeFloat64SVE compares length float64 values against a threshold using SVE,
+// writing out[i] = values[i] >= threshold. Handles any length without a scalar tail.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpGeFloat64SVE(values *float64, threshold float64, out *bool, length int)
+
+// cmpLtFloat64SVE compares length float64 values against a threshold using SVE,
+// writing out[i] = values[i] < threshold. Handles any length without a scalar tail.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpLtFloat64SVE(values *float64, threshold float64, out *bool, length int)
+
+// cmpLeFloat64SVE compares length float64 values against a threshold using SVE,
+// writing out[i] = values[i] <= threshold. Handles any length without a scalar tail.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpLeFloat64SVE(values *float64, threshold float64, out *bool, length int)
+
+// cmpEqFloat64MaskSVE compares length float64 values for equality against a threshold using SVE,
+// setting bit i of mask if values[i] == threshold. Handles any length without a scalar tail.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpEqFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+
+// cmpNeFloat64MaskSVE compares length float64 values for inequality against a threshold using SVE,
+// setting bit i of mask if values[i] != threshold. Handles any length without a scalar tail.
+// NaN != x returns true for all x per IEEE 754.
+//
+//go:noescape
+func cmpNeFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+
+// cmpGtFloat64MaskSVE compares length float64 values against a threshold using SVE,
+// setting bit i of mask if values[i] > threshold. Handles any length without a scalar tail.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpGtFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+
+// cmpGeFloat64MaskSVE compares length float64 values against a threshold using SVE,
+// setting bit i of mask if values[i] >= threshold. Handles any length without a scalar tail.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpGeFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+
+// cmpLtFloat64MaskSVE compares length float64 values against a threshold using SVE,
+// setting bit i of mask if values[i] < threshold. Handles any length without a scalar tail.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpLtFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+
+// cmpLeFloat64MaskSVE compares length float64 values against a threshold using SVE,
+// setting bit i of mask if values[i] <= threshold. Handles any length without a scalar tail.
+// NaN comparisons return false per IEEE 754.
+//
+//go:noescape
+func cmpLeFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
@@ -125,3 +125,462 @@ TEXT ·cmpNeFloat64NEON(SB), NOSPLIT, $0-24
     
     MOVD    R3, ret+16(FP)
     RET
+
+// ============================================================================
+// SVE kernels
+// ============================================================================
+//
+// Same structure as the int64 SVE kernels in compare_arm64.s.
+//
+// Floating-point compares are ordered, so every comparison against NaN is false
+// except FCMNE, which is true like Go's != operator.
+
+// func cmpEqFloat64SVE(values *float64, threshold float64, out *bool, length int)
+//
+// Sets out[i] = values[i] == threshold. Each vector of results is turned into
+// 0/1 lanes and stored with a narrowing byte store.
+//
+TEXT ·cmpEqFloat64SVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    out+16(FP), R2          // R2 = pointer to results
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4; P0 = lanes still in range
+    BEQ     done
+
+loop:
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c06021             // fcmeq p1.d, p0/z, z1.d, z0.d; P1 = values == threshold
+    WORD    $0x05d10022             // mov z2.d, p1/z, #1; 1 for matching lanes, 0 otherwise
+    WORD    $0xe4634042             // st1b {z2.d}, p0, [x2, x3]; Store the low byte of each lane as a bool
+    WORD    $0x04f0e3e3             // incd x3; Advance by the number of 64-bit lanes
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     loop                    // Loop while the first lane is active
+
+done:
+    RET
+
+// func cmpEqFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+//
+// Sets bit i of mask if values[i] == threshold. The bitmap is built one word at a
+// time: each lane contributes 1 << (its position in the word), and ORV folds the
+// matching lanes into a scalar that is merged into the word. A vector never spans
+// two words, so this works for any vector length.
+//
+TEXT ·cmpEqFloat64MaskSVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    mask+16(FP), R2         // R2 = pointer to bitmap words
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25f8c024             // mov z4.d, #1; Z4 = 1 in every lane
+
+word_loop:
+    CMP     R4, R3
+    BGE     done
+    MOVD    R3, R6                  // R6 = index of the word's first element
+    ADD     $64, R3, R5
+    CMP     R4, R5
+    CSEL    LT, R5, R4, R5          // R5 = min(R3 + 64, length)
+    MOVD    $0, R7                  // R7 = word being assembled
+
+chunk_loop:
+    WORD    $0x25e51c60             // whilelo p0.d, x3, x5; P0 = lanes within this word
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c06021             // fcmeq p1.d, p0/z, z1.d, z0.d; P1 = values == threshold
+    SUB     R6, R3, R8              // R8 = bit position of the first lane
+    WORD    $0x04e14502             // index z2.d, x8, #1; Z2 = bit position of each lane
+    WORD    $0x04d78082             // lslr z2.d, p0/m, z2.d, z4.d; Z2 = 1 << bit position
+    WORD    $0x04d82443             // orv d3, p1, z2.d; D3 = OR of the bits of matching lanes
+    FMOVD   F3, R9
+    ORR     R9, R7, R7
+    WORD    $0x04f0e3e3             // incd x3
+    CMP     R5, R3
+    BLT     chunk_loop
+
+    MOVD    R5, R3                  // Resume at the next word, not past it
+    MOVD.P  R7, 8(R2)               // Store the word and advance
+    B       word_loop
+
+done:
+    RET
+
+// func cmpNeFloat64SVE(values *float64, threshold float64, out *bool, length int)
+//
+// Sets out[i] = values[i] != threshold. Each vector of results is turned into
+// 0/1 lanes and stored with a narrowing byte store.
+//
+TEXT ·cmpNeFloat64SVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    out+16(FP), R2          // R2 = pointer to results
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4; P0 = lanes still in range
+    BEQ     done
+
+loop:
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c06031             // fcmne p1.d, p0/z, z1.d, z0.d; P1 = values != threshold
+    WORD    $0x05d10022             // mov z2.d, p1/z, #1; 1 for matching lanes, 0 otherwise
+    WORD    $0xe4634042             // st1b {z2.d}, p0, [x2, x3]; Store the low byte of each lane as a bool
+    WORD    $0x04f0e3e3             // incd x3; Advance by the number of 64-bit lanes
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     loop                    // Loop while the first lane is active
+
+done:
+    RET
+
+// func cmpNeFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+//
+// Sets bit i of mask if values[i] != threshold. The bitmap is built one word at a
+// time: each lane contributes 1 << (its position in the word), and ORV folds the
+// matching lanes into a scalar that is merged into the word. A vector never spans
+// two words, so this works for any vector length.
+//
+TEXT ·cmpNeFloat64MaskSVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    mask+16(FP), R2         // R2 = pointer to bitmap words
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25f8c024             // mov z4.d, #1; Z4 = 1 in every lane
+
+word_loop:
+    CMP     R4, R3
+    BGE     done
+    MOVD    R3, R6                  // R6 = index of the word's first element
+    ADD     $64, R3, R5
+    CMP     R4, R5
+    CSEL    LT, R5, R4, R5          // R5 = min(R3 + 64, length)
+    MOVD    $0, R7                  // R7 = word being assembled
+
+chunk_loop:
+    WORD    $0x25e51c60             // whilelo p0.d, x3, x5; P0 = lanes within this word
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c06031             // fcmne p1.d, p0/z, z1.d, z0.d; P1 = values != threshold
+    SUB     R6, R3, R8              // R8 = bit position of the first lane
+    WORD    $0x04e14502             // index z2.d, x8, #1; Z2 = bit position of each lane
+    WORD    $0x04d78082             // lslr z2.d, p0/m, z2.d, z4.d; Z2 = 1 << bit position
+    WORD    $0x04d82443             // orv d3, p1, z2.d; D3 = OR of the bits of matching lanes
+    FMOVD   F3, R9
+    ORR     R9, R7, R7
+    WORD    $0x04f0e3e3             // incd x3
+    CMP     R5, R3
+    BLT     chunk_loop
+
+    MOVD    R5, R3                  // Resume at the next word, not past it
+    MOVD.P  R7, 8(R2)               // Store the word and advance
+    B       word_loop
+
+done:
+    RET
+
+// func cmpGtFloat64SVE(values *float64, threshold float64, out *bool, length int)
+//
+// Sets out[i] = values[i] > threshold. Each vector of results is turned into
+// 0/1 lanes and stored with a narrowing byte store.
+//
+TEXT ·cmpGtFloat64SVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    out+16(FP), R2          // R2 = pointer to results
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4; P0 = lanes still in range
+    BEQ     done
+
+loop:
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c04031             // fcmgt p1.d, p0/z, z1.d, z0.d; P1 = values > threshold
+    WORD    $0x05d10022             // mov z2.d, p1/z, #1; 1 for matching lanes, 0 otherwise
+    WORD    $0xe4634042             // st1b {z2.d}, p0, [x2, x3]; Store the low byte of each lane as a bool
+    WORD    $0x04f0e3e3             // incd x3; Advance by the number of 64-bit lanes
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     loop                    // Loop while the first lane is active
+
+done:
+    RET
+
+// func cmpGtFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+//
+// Sets bit i of mask if values[i] > threshold. The bitmap is built one word at a
+// time: each lane contributes 1 << (its position in the word), and ORV folds the
+// matching lanes into a scalar that is merged into the word. A vector never spans
+// two words, so this works for any vector length.
+//
+TEXT ·cmpGtFloat64MaskSVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    mask+16(FP), R2         // R2 = pointer to bitmap words
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25f8c024             // mov z4.d, #1; Z4 = 1 in every lane
+
+word_loop:
+    CMP     R4, R3
+    BGE     done
+    MOVD    R3, R6                  // R6 = index of the word's first element
+    ADD     $64, R3, R5
+    CMP     R4, R5
+    CSEL    LT, R5, R4, R5          // R5 = min(R3 + 64, length)
+    MOVD    $0, R7                  // R7 = word being assembled
+
+chunk_loop:
+    WORD    $0x25e51c60             // whilelo p0.d, x3, x5; P0 = lanes within this word
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c04031             // fcmgt p1.d, p0/z, z1.d, z0.d; P1 = values > threshold
+    SUB     R6, R3, R8              // R8 = bit position of the first lane
+    WORD    $0x04e14502             // index z2.d, x8, #1; Z2 = bit position of each lane
+    WORD    $0x04d78082             // lslr z2.d, p0/m, z2.d, z4.d; Z2 = 1 << bit position
+    WORD    $0x04d82443             // orv d3, p1, z2.d; D3 = OR of the bits of matching lanes
+    FMOVD   F3, R9
+    ORR     R9, R7, R7
+    WORD    $0x04f0e3e3             // incd x3
+    CMP     R5, R3
+    BLT     chunk_loop
+
+    MOVD    R5, R3                  // Resume at the next word, not past it
+    MOVD.P  R7, 8(R2)               // Store the word and advance
+    B       word_loop
+
+done:
+    RET
+
+// func cmpGeFloat64SVE(values *float64, threshold float64, out *bool, length int)
+//
+// Sets out[i] = values[i] >= threshold. Each vector of results is turned into
+// 0/1 lanes and stored with a narrowing byte store.
+//
+TEXT ·cmpGeFloat64SVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    out+16(FP), R2          // R2 = pointer to results
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4; P0 = lanes still in range
+    BEQ     done
+
+loop:
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c04021             // fcmge p1.d, p0/z, z1.d, z0.d; P1 = values >= threshold
+    WORD    $0x05d10022             // mov z2.d, p1/z, #1; 1 for matching lanes, 0 otherwise
+    WORD    $0xe4634042             // st1b {z2.d}, p0, [x2, x3]; Store the low byte of each lane as a bool
+    WORD    $0x04f0e3e3             // incd x3; Advance by the number of 64-bit lanes
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     loop                    // Loop while the first lane is active
+
+done:
+    RET
+
+// func cmpGeFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+//
+// Sets bit i of mask if values[i] >= threshold. The bitmap is built one word at a
+// time: each lane contributes 1 << (its position in the word), and ORV folds the
+// matching lanes into a scalar that is merged into the word. A vector never spans
+// two words, so this works for any vector length.
+//
+TEXT ·cmpGeFloat64MaskSVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    mask+16(FP), R2         // R2 = pointer to bitmap words
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25f8c024             // mov z4.d, #1; Z4 = 1 in every lane
+
+word_loop:
+    CMP     R4, R3
+    BGE     done
+    MOVD    R3, R6                  // R6 = index of the word's first element
+    ADD     $64, R3, R5
+    CMP     R4, R5
+    CSEL    LT, R5, R4, R5          // R5 = min(R3 + 64, length)
+    MOVD    $0, R7                  // R7 = word being assembled
+
+chunk_loop:
+    WORD    $0x25e51c60             // whilelo p0.d, x3, x5; P0 = lanes within this word
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c04021             // fcmge p1.d, p0/z, z1.d, z0.d; P1 = values >= threshold
+    SUB     R6, R3, R8              // R8 = bit position of the first lane
+    WORD    $0x04e14502             // index z2.d, x8, #1; Z2 = bit position of each lane
+    WORD    $0x04d78082             // lslr z2.d, p0/m, z2.d, z4.d; Z2 = 1 << bit position
+    WORD    $0x04d82443             // orv d3, p1, z2.d; D3 = OR of the bits of matching lanes
+    FMOVD   F3, R9
+    ORR     R9, R7, R7
+    WORD    $0x04f0e3e3             // incd x3
+    CMP     R5, R3
+    BLT     chunk_loop
+
+    MOVD    R5, R3                  // Resume at the next word, not past it
+    MOVD.P  R7, 8(R2)               // Store the word and advance
+    B       word_loop
+
+done:
+    RET
+
+// func cmpLtFloat64SVE(values *float64, threshold float64, out *bool, length int)
+//
+// Sets out[i] = values[i] < threshold. Each vector of results is turned into
+// 0/1 lanes and stored with a narrowing byte store.
+//
+TEXT ·cmpLtFloat64SVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    out+16(FP), R2          // R2 = pointer to results
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4; P0 = lanes still in range
+    BEQ     done
+
+loop:
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c14011             // fcmlt p1.d, p0/z, z1.d, z0.d; P1 = values < threshold
+    WORD    $0x05d10022             // mov z2.d, p1/z, #1; 1 for matching lanes, 0 otherwise
+    WORD    $0xe4634042             // st1b {z2.d}, p0, [x2, x3]; Store the low byte of each lane as a bool
+    WORD    $0x04f0e3e3             // incd x3; Advance by the number of 64-bit lanes
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     loop                    // Loop while the first lane is active
+
+done:
+    RET
+
+// func cmpLtFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+//
+// Sets bit i of mask if values[i] < threshold. The bitmap is built one word at a
+// time: each lane contributes 1 << (its position in the word), and ORV folds the
+// matching lanes into a scalar that is merged into the word. A vector never spans
+// two words, so this works for any vector length.
+//
+TEXT ·cmpLtFloat64MaskSVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    mask+16(FP), R2         // R2 = pointer to bitmap words
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25f8c024             // mov z4.d, #1; Z4 = 1 in every lane
+
+word_loop:
+    CMP     R4, R3
+    BGE     done
+    MOVD    R3, R6                  // R6 = index of the word's first element
+    ADD     $64, R3, R5
+    CMP     R4, R5
+    CSEL    LT, R5, R4, R5          // R5 = min(R3 + 64, length)
+    MOVD    $0, R7                  // R7 = word being assembled
+
+chunk_loop:
+    WORD    $0x25e51c60             // whilelo p0.d, x3, x5; P0 = lanes within this word
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c14011             // fcmlt p1.d, p0/z, z1.d, z0.d; P1 = values < threshold
+    SUB     R6, R3, R8              // R8 = bit position of the first lane
+    WORD    $0x04e14502             // index z2.d, x8, #1; Z2 = bit position of each lane
+    WORD    $0x04d78082             // lslr z2.d, p0/m, z2.d, z4.d; Z2 = 1 << bit position
+    WORD    $0x04d82443             // orv d3, p1, z2.d; D3 = OR of the bits of matching lanes
+    FMOVD   F3, R9
+    ORR     R9, R7, R7
+    WORD    $0x04f0e3e3             // incd x3
+    CMP     R5, R3
+    BLT     chunk_loop
+
+    MOVD    R5, R3                  // Resume at the next word, not past it
+    MOVD.P  R7, 8(R2)               // Store the word and advance
+    B       word_loop
+
+done:
+    RET
+
+// func cmpLeFloat64SVE(values *float64, threshold float64, out *bool, length int)
+//
+// Sets out[i] = values[i] <= threshold. Each vector of results is turned into
+// 0/1 lanes and stored with a narrowing byte store.
+//
+TEXT ·cmpLeFloat64SVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    out+16(FP), R2          // R2 = pointer to results
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4; P0 = lanes still in range
+    BEQ     done
+
+loop:
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c14001             // fcmle p1.d, p0/z, z1.d, z0.d; P1 = values <= threshold
+    WORD    $0x05d10022             // mov z2.d, p1/z, #1; 1 for matching lanes, 0 otherwise
+    WORD    $0xe4634042             // st1b {z2.d}, p0, [x2, x3]; Store the low byte of each lane as a bool
+    WORD    $0x04f0e3e3             // incd x3; Advance by the number of 64-bit lanes
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     loop                    // Loop while the first lane is active
+
+done:
+    RET
+
+// func cmpLeFloat64MaskSVE(values *float64, threshold float64, mask *uint64, length int)
+//
+// Sets bit i of mask if values[i] <= threshold. The bitmap is built one word at a
+// time: each lane contributes 1 << (its position in the word), and ORV folds the
+// matching lanes into a scalar that is merged into the word. A vector never spans
+// two words, so this works for any vector length.
+//
+TEXT ·cmpLeFloat64MaskSVE(SB), NOSPLIT, $0-32
+    MOVD    values+0(FP), R0        // R0 = pointer to values
+    MOVD    threshold+8(FP), R1     // R1 = threshold bits
+    MOVD    mask+16(FP), R2         // R2 = pointer to bitmap words
+    MOVD    length+24(FP), R4       // R4 = length
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x05e03820             // dup z0.d, x1; Z0 = threshold in every lane
+    WORD    $0x25f8c024             // mov z4.d, #1; Z4 = 1 in every lane
+
+word_loop:
+    CMP     R4, R3
+    BGE     done
+    MOVD    R3, R6                  // R6 = index of the word's first element
+    ADD     $64, R3, R5
+    CMP     R4, R5
+    CSEL    LT, R5, R4, R5          // R5 = min(R3 + 64, length)
+    MOVD    $0, R7                  // R7 = word being assembled
+
+chunk_loop:
+    WORD    $0x25e51c60             // whilelo p0.d, x3, x5; P0 = lanes within this word
+    WORD    $0xa5e34001             // ld1d {z1.d}, p0/z, [x0, x3, lsl #3]
+    WORD    $0x65c14001             // fcmle p1.d, p0/z, z1.d, z0.d; P1 = values <= threshold
+    SUB     R6, R3, R8              // R8 = bit position of the first lane
+    WORD    $0x04e14502             // index z2.d, x8, #1; Z2 = bit position of each lane
+    WORD    $0x04d78082             // lslr z2.d, p0/m, z2.d, z4.d; Z2 = 1 << bit position
+    WORD    $0x04d82443             // orv d3, p1, z2.d; D3 = OR of the bits of matching lanes
+    FMOVD   F3, R9
+    ORR     R9, R7, R7
+    WORD    $0x04f0e3e3             // incd x3
+    CMP     R5, R3
+    BLT     chunk_loop
+
+    MOVD    R5, R3                  // Resume at the next word, not past it
+    MOVD.P  R7, 8(R2)               // Store the word and advance
+    B       word_loop
+
+done:
+    RET
@@ -86,6 +86,44 @@ func cmpContainsStringMaskGeneric(values [][]byte, substr []byte) []uint64 {
 	return boolsToBitmask(bools)
 }
 
+// orderMatches reports whether a comparison result c, as returned by
+// bytes.Compare, satisfies op.
+func orderMatches(op Op, c int) bool {
+	switch op {
+	case OpEq:
+		return c == 0
+	case OpNe:
+		return c != 0
+	case OpGt:
+		return c > 0
+	case OpLt:
+		return c < 0
+	case OpGe:
+		return c >= 0
+	default:
+		return c <= 0
+	}
+}
+
+// cmpOrderStringGeneric compares values lexicographically against threshold
+// with op.
+func cmpOrderStringGeneric(values [][]byte, op Op, threshold []byte) []bool {
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = orderMatches(op, bytes.Compare(v, threshold))
+	}
+	return results
+}
+
+// cmpBetweenStringGeneric checks lo <= values[i] <= hi lexicographically.
+func cmpBetweenStringGeneric(values [][]byte, lo, hi []byte) []bool {
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = bytes.Compare(v, lo) >= 0 && bytes.Compare(v, hi) <= 0
+	}
+	return results
+}
+
 // cmpEqStringIgnoreCaseGeneric performs case-insensitive equality comparison (ASCII only).
 // Non-ASCII bytes (>127) are compared as-is.
 func cmpEqStringIgnoreCaseGeneric(values [][]byte, threshold []byte) []bool {
@@ -130,6 +168,26 @@ func cmpEqStringIgnoreCaseMaskGeneric(values [][]byte, threshold []byte) []uint6
 	return boolsToBitmask(bools)
 }
 
+// cmpHasPrefixStringIgnoreCaseGeneric checks if string values start with prefix,
+// ignoring ASCII case.
+func cmpHasPrefixStringIgnoreCaseGeneric(values [][]byte, prefix []byte) []bool {
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = len(v) >= len(prefix) && strEqIgnoreCaseGeneric(v[:len(prefix)], prefix)
+	}
+	return results
+}
+
+// cmpHasSuffixStringIgnoreCaseGeneric checks if string values end with suffix,
+// ignoring ASCII case.
+func cmpHasSuffixStringIgnoreCaseGeneric(values [][]byte, suffix []byte) []bool {
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = len(v) >= len(suffix) && strEqIgnoreCaseGeneric(v[len(v)-len(suffix):], suffix)
+	}
+	return results
+}
+
 // matchWildcard matches a single string against a pattern with % and _ wildcards.
 // % matches zero or more characters, _ matches exactly one character.
 func matchWildcard(str, pattern []byte) bool {
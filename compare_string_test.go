@@ -1,6 +1,9 @@
 package syndrdbsimd
 
 import (
+	"fmt"
+	"math/rand"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -313,6 +316,46 @@ func TestCmpContainsString(t *testing.T) {
 	}
 }
 
+// TestCmpOrderString tests lexicographic range comparisons
+func TestCmpOrderString(t *testing.T) {
+	long := strings.Repeat("m", 40)
+	values := []string{"Alice", "Mallory", "Mark", "N", "Nancy", "", "m", "M", long + "a", long}
+	tests := []struct {
+		name     string
+		got      []bool
+		expected []bool
+	}{
+		// Uppercase orders before lowercase, and a prefix before its extensions
+		{"Gt M", CmpGtString(values, "M"), []bool{false, true, true, true, true, false, true, false, true, true}},
+		{"Ge M", CmpGeString(values, "M"), []bool{false, true, true, true, true, false, true, true, true, true}},
+		{"Lt N", CmpLtString(values, "N"), []bool{true, true, true, false, false, true, false, true, false, false}},
+		{"Le N", CmpLeString(values, "N"), []bool{true, true, true, true, false, true, false, true, false, false}},
+		{"Between M N", CmpBetweenString(values, "M", "N"), []bool{false, true, true, true, false, false, false, true, false, false}},
+		{"Between long", CmpBetweenString(values, long, long+"a"), []bool{false, false, false, false, false, false, false, false, true, true}},
+		{"Between empty range", CmpBetweenString(values, "N", "M"), make([]bool, len(values))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := range tt.got {
+				if tt.got[i] != tt.expected[i] {
+					t.Errorf("index %d: got %v, want %v (value=%q)", i, tt.got[i], tt.expected[i], values[i])
+				}
+			}
+		})
+	}
+
+	if got := CmpLtStringMask(values, "N"); got[0] != 0b00_1010_0111 {
+		t.Errorf("CmpLtStringMask = %b, want 10100111", got[0])
+	}
+	if got := CmpBetweenStringMask(values, "M", "N"); got[0] != 0b00_1000_1110 {
+		t.Errorf("CmpBetweenStringMask = %b, want 10001110", got[0])
+	}
+	if got := CmpGtString(nil, "x"); len(got) != 0 {
+		t.Errorf("CmpGtString(nil) = %v, want empty", got)
+	}
+}
+
 // TestCmpEqStringIgnoreCase tests case-insensitive equality
 func TestCmpEqStringIgnoreCase(t *testing.T) {
 	tests := []struct {
@@ -460,6 +503,101 @@ func TestCmpLikeStringCompiled(t *testing.T) {
 	}
 }
 
+// TestCmpILikeString tests case-insensitive pattern matching
+func TestCmpILikeString(t *testing.T) {
+	values := []string{"John Smith", "JOHNNY", "jo", "ann@Gmail.com", "BOB@GMAIL.COM", "x@gmail.co", "Café", "CAFÉ"}
+	tests := []struct {
+		pattern  string
+		expected []bool
+	}{
+		{"john%", []bool{true, true, false, false, false, false, false, false}},
+		{"%@GMAIL.COM", []bool{false, false, false, true, true, false, false, false}},
+		{"%MAIL%", []bool{false, false, false, true, true, true, false, false}},
+		{"JOHNNY", []bool{false, true, false, false, false, false, false, false}},
+		{"j_hn%", []bool{true, true, false, false, false, false, false, false}},
+		// Only ASCII letters fold, so É does not match é
+		{"caf%", []bool{false, false, false, false, false, false, true, true}},
+		{"café", []bool{false, false, false, false, false, false, true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			result := CmpILikeString(values, tt.pattern)
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("index %d: got %v, want %v (value=%q, pattern=%q)",
+						i, result[i], tt.expected[i], values[i], tt.pattern)
+				}
+			}
+		})
+	}
+
+	// The compiled pattern keeps the original and lowers copies of the literals
+	pattern := "%@GMAIL.COM"
+	compiled, err := CompilePatternAutoIgnoreCase(pattern)
+	if err != nil {
+		t.Fatalf("CompilePatternAutoIgnoreCase failed: %v", err)
+	}
+	if !compiled.IgnoreCase || string(compiled.Segments[0]) != "@gmail.com" || pattern != "%@GMAIL.COM" {
+		t.Errorf("compiled segment %q, IgnoreCase %v", compiled.Segments[0], compiled.IgnoreCase)
+	}
+	if got := CmpLikeStringCompiledMask(values, compiled); got[0] != 0b1_1000 {
+		t.Errorf("CmpLikeStringCompiledMask = %b, want 11000", got[0])
+	}
+}
+
+// Every ISA must match LIKE over lowercased values, on rows long enough to
+// take the SIMD paths
+func TestCmpILikeString_MatchLowered(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+	cfg := DefaultConfig()
+	cfg.MinStrings = 1
+	if err := SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(420))
+	values := make([]string, 300)
+	for i := range values {
+		b := make([]byte, rng.Intn(70))
+		for j := range b {
+			b[j] = "abAB@._"[rng.Intn(7)]
+		}
+		values[i] = string(b)
+	}
+	lowered := make([]string, len(values))
+	for i, v := range values {
+		lowered[i] = strings.ToLower(v)
+	}
+	patterns := []string{"ab%", "%BA", "%aBbA%", "a_b%", "%a@%b_", strings.Repeat("Ab", 20) + "%", "%" + strings.Repeat("bA", 17)}
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				for _, p := range patterns {
+					got := CmpILikeString(values, p)
+					want := CmpLikeString(lowered, strings.ToLower(p))
+					for i := range got {
+						if got[i] != want[i] {
+							t.Errorf("CmpILikeString(%q, %q) = %v, want %v", values[i], p, got[i], want[i])
+						}
+					}
+				}
+
+				// Exact matches against every value's case-flipped twin
+				for _, v := range values[:50] {
+					if got := CmpEqStringIgnoreCase([]string{v, v + "a"}, strings.ToUpper(v)); !got[0] || got[1] {
+						t.Errorf("CmpEqStringIgnoreCase(%q, upper) = %v", v, got)
+					}
+				}
+			})
+		})
+	}
+}
+
 // TestDetectPatternType tests pattern type detection
 func TestDetectPatternType(t *testing.T) {
 	tests := []struct {
@@ -549,6 +687,226 @@ func TestCompilePattern(t *testing.T) {
 	}
 }
 
+// TestCompilePattern_WildcardProgram tests the compiled wildcard matcher
+func TestCompilePattern_WildcardProgram(t *testing.T) {
+	compiled, err := CompilePattern(PatternWildcard, "a_c%%x_y__%end")
+	if err != nil {
+		t.Fatalf("CompilePattern failed: %v", err)
+	}
+	if got := fmt.Sprint(compiled.WildcardPositions); got != "[1 6 8 9]" {
+		t.Errorf("WildcardPositions = %s, want [1 6 8 9]", got)
+	}
+
+	// %% collapses, and the middle segment x_y__ has two literal runs
+	prog := compiled.program
+	if prog.exact || len(prog.middle) != 1 || prog.middle[0].length != 5 || len(prog.middle[0].runs) != 2 || prog.minLen != 11 {
+		t.Errorf("program = %+v", prog)
+	}
+
+	values := []string{"abcxzyABend", "abc--xzy12-end", "abcxzyend", "abcend", "abxzyABend", "abcxzyABen"}
+	want := []bool{true, true, false, false, false, false}
+	got := CmpLikeStringCompiled(values, compiled)
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("index %d: got %v, want %v (value=%q)", i, got[i], want[i], values[i])
+		}
+	}
+}
+
+// TestCompilePatternEscape tests LIKE patterns with an ESCAPE character
+func TestCompilePatternEscape(t *testing.T) {
+	values := []string{"user_1", "userX1", "user_", "50%", "50% off", "a_b", "axb", "a_bc", "x!", "user"}
+	tests := []struct {
+		pattern  string
+		escape   byte
+		wantType PatternType
+		expected []bool
+	}{
+		{`user\_%`, '\\', PatternPrefix, []bool{true, false, true, false, false, false, false, false, false, false}},
+		{`%\%%`, '\\', PatternContains, []bool{false, false, false, true, true, false, false, false, false, false}},
+		{"50!%", '!', PatternExact, []bool{false, false, false, true, false, false, false, false, false, false}},
+		{"%!!", '!', PatternSuffix, []bool{false, false, false, false, false, false, false, false, true, false}},
+		{"a#__", '#', PatternWildcard, []bool{false, false, false, false, false, true, false, false, false, false}},
+		{"a#_b%", '#', PatternPrefix, []bool{false, false, false, false, false, true, false, true, false, false}},
+		// Without escapes the pattern is an ordinary LIKE pattern
+		{"a_b", '\\', PatternWildcard, []bool{false, false, false, false, false, true, true, false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if got := DetectPatternTypeEscape(tt.pattern, tt.escape); got != tt.wantType {
+				t.Errorf("DetectPatternTypeEscape() = %v, want %v", got, tt.wantType)
+			}
+			compiled, err := CompilePatternAutoEscape(tt.pattern, tt.escape)
+			if err != nil {
+				t.Fatalf("CompilePatternAutoEscape failed: %v", err)
+			}
+			result := CmpLikeStringCompiled(values, compiled)
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("index %d: got %v, want %v (value=%q)", i, result[i], tt.expected[i], values[i])
+				}
+			}
+		})
+	}
+
+	compiled, err := CompilePatternEscape(PatternWildcard, `a\_%b_c`, '\\')
+	if err != nil {
+		t.Fatalf("CompilePatternEscape failed: %v", err)
+	}
+	if got := fmt.Sprint(compiled.WildcardPositions); got != "[5]" || string(compiled.Segments[0]) != "a_" {
+		t.Errorf("WildcardPositions = %s, Segments = %q, want [5] and a_ first", got, compiled.Segments)
+	}
+
+	for _, bad := range []struct {
+		patternType PatternType
+		pattern     string
+	}{
+		{PatternExact, `abc\`},
+		{PatternExact, `a\bc`},
+		{PatternPrefix, `%abc`},
+		{PatternSuffix, `\%%`},
+		{PatternContains, `%\%`},
+	} {
+		if _, err := CompilePatternEscape(bad.patternType, bad.pattern, '\\'); err == nil {
+			t.Errorf("CompilePatternEscape(%v, %q) succeeded, want error", bad.patternType, bad.pattern)
+		}
+	}
+}
+
+// TestCmpNotLikeString tests negated pattern matching
+func TestCmpNotLikeString(t *testing.T) {
+	values := []string{"hello", "hello world", "world", "help"}
+	for _, pattern := range []string{"hello%", "%l_o%", "world", "%o%"} {
+		like := CmpLikeString(values, pattern)
+		notLike, err := CmpNotLikeString(values, pattern)
+		if err != nil {
+			t.Fatalf("CmpNotLikeString(%q): %v", pattern, err)
+		}
+		for i := range values {
+			if like[i] == notLike[i] {
+				t.Errorf("pattern %q, value %q: LIKE and NOT LIKE both %v", pattern, values[i], like[i])
+			}
+		}
+	}
+
+	// An invalid pattern is an error, not a result that reads as all rows matching
+	if got, err := CmpNotLikeString(values, "%%"); err == nil {
+		t.Errorf("CmpNotLikeString with an invalid pattern = %v, want error", got)
+	}
+	if _, err := CmpNotLikeStringMask(values, "%%"); err == nil {
+		t.Error("CmpNotLikeStringMask with an invalid pattern succeeded, want error")
+	}
+	if got, err := CmpNotLikeStringMask(values, "he%"); err != nil || got[0] != 0b0100 {
+		t.Errorf("CmpNotLikeStringMask = %b, %v, want 100", got, err)
+	}
+}
+
+// TestCompileSimilarTo tests SIMILAR TO patterns
+func TestCompileSimilarTo(t *testing.T) {
+	values := []string{"abc", "abd", "cd", "a.c", "aXc", "abab", "xyz", "ab|c", "aé", ""}
+	tests := []struct {
+		pattern  string
+		wantType PatternType
+		expected []bool
+	}{
+		{"ab%", PatternPrefix, []bool{true, true, false, false, false, true, false, true, false, false}},
+		{"a.c", PatternExact, []bool{false, false, false, true, false, false, false, false, false, false}},
+		{"%", PatternRegex, []bool{true, true, true, true, true, true, true, true, true, true}},
+		{"(ab|cd)%", PatternRegex, []bool{true, true, true, false, false, true, false, true, false, false}},
+		{"a_c", PatternRegex, []bool{true, false, false, true, true, false, false, false, false, false}},
+		{"(ab)+", PatternRegex, []bool{false, false, false, false, false, true, false, false, false, false}},
+		{"[a-c]b[^c]%", PatternRegex, []bool{false, true, false, false, false, true, false, true, false, false}},
+		{`ab\|c`, PatternExact, []bool{false, false, false, false, false, false, false, true, false, false}},
+		// _ matches one character, so the two-byte é counts once
+		{"a_", PatternRegex, []bool{false, false, false, false, false, false, false, false, true, false}},
+		// The escape covers the whole multibyte character
+		{`a\é`, PatternExact, []bool{false, false, false, false, false, false, false, false, true, false}},
+		{`(a\é)`, PatternRegex, []bool{false, false, false, false, false, false, false, false, true, false}},
+		{`a[\éb]%`, PatternRegex, []bool{true, true, false, false, false, true, false, true, true, false}},
+		{"x?y{1,2}z", PatternRegex, []bool{false, false, false, false, false, false, true, false, false, false}},
+		// POSIX classes inside brackets; they match ASCII only
+		{"[[:alpha:]]+", PatternRegex, []bool{true, true, true, false, true, true, true, false, false, false}},
+		{"a[[:upper:][:punct:]]c", PatternRegex, []bool{false, false, false, true, true, false, false, false, false, false}},
+		{"%[^[:lower:]]%", PatternRegex, []bool{false, false, false, true, true, false, false, true, true, false}},
+		{"[[:alpha:][]%", PatternRegex, []bool{true, true, true, true, true, true, true, true, true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			compiled, err := CompileSimilarTo(tt.pattern)
+			if err != nil {
+				t.Fatalf("CompileSimilarTo failed: %v", err)
+			}
+			if compiled.Type != tt.wantType {
+				t.Errorf("Type = %v, want %v", compiled.Type, tt.wantType)
+			}
+			result := CmpLikeStringCompiled(values, compiled)
+			notResult := CmpNotLikeStringCompiled(values, compiled)
+			for i := range result {
+				if result[i] != tt.expected[i] || notResult[i] == result[i] {
+					t.Errorf("index %d: got %v, want %v (value=%q)", i, result[i], tt.expected[i], values[i])
+				}
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "(ab", "[abc", `abc\`, "a**", "[[:alpha:]", "[[:nosuch:]]"} {
+		if _, err := CompileSimilarTo(bad); err == nil {
+			t.Errorf("CompileSimilarTo(%q) succeeded, want error", bad)
+		}
+	}
+	if compiled, err := CompileSimilarToEscape("a#%%", '#'); err != nil || compiled.Type != PatternPrefix {
+		t.Errorf("CompileSimilarToEscape(a#%%%%) = %v, %v, want a prefix pattern", compiled, err)
+	}
+}
+
+// randomLikePattern returns a pattern of literals, _ and % over the alphabet.
+func randomLikePattern(rng *rand.Rand, alphabet string) string {
+	b := make([]byte, 1+rng.Intn(10))
+	for i := range b {
+		switch r := rng.Intn(10); {
+		case r < 2:
+			b[i] = '%'
+		case r < 3:
+			b[i] = '_'
+		default:
+			b[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+	}
+	return string(b)
+}
+
+// The match program must agree with the backtracking matcher on every ISA
+func TestLikeProgram_MatchWildcard(t *testing.T) {
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(430))
+				for trial := 0; trial < 2000; trial++ {
+					pattern := randomLikePattern(rng, "ab")
+					if strings.Trim(pattern, "%") == "" {
+						continue
+					}
+					prog := compileLikeProgram(parseLikePattern(pattern))
+					for k := 0; k < 20; k++ {
+						v := make([]byte, rng.Intn(60))
+						for i := range v {
+							v[i] = "ab"[rng.Intn(2)]
+						}
+						if got, want := prog.match(currentKernels(), v), matchWildcard(v, []byte(pattern)); got != want {
+							t.Fatalf("match(%q, %q) = %v, want %v", v, pattern, got, want)
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
 // TestBufferPoolIntegration tests buffer pool with string operations
 func TestBufferPoolIntegration(t *testing.T) {
 	// Create large strings that might use pooled buffers
@@ -579,6 +937,84 @@ func TestBufferPoolIntegration(t *testing.T) {
 	}
 }
 
+// Patterns without escape sequences must compile the same with and without an
+// escape character, and SIMILAR TO patterns routed to the LIKE matchers must
+// agree with their regular expression
+func TestPatternRoutes_MatchEachOther(t *testing.T) {
+	rng := rand.New(rand.NewSource(440))
+	values := make([]string, 200)
+	for i := range values {
+		b := make([]byte, rng.Intn(12))
+		for j := range b {
+			b[j] = "ab"[rng.Intn(2)]
+		}
+		values[i] = string(b)
+	}
+
+	for trial := 0; trial < 500; trial++ {
+		pattern := randomLikePattern(rng, "ab")
+		plain, errPlain := CompilePatternAuto(pattern)
+		escaped, errEscaped := CompilePatternAutoEscape(pattern, '\\')
+		if (errPlain != nil) != (errEscaped != nil) {
+			t.Fatalf("pattern %q: CompilePatternAuto error %v, CompilePatternAutoEscape error %v", pattern, errPlain, errEscaped)
+		}
+		if errPlain == nil {
+			want, got := CmpLikeStringCompiled(values, plain), CmpLikeStringCompiled(values, escaped)
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("pattern %q, value %q: escaped %v, plain %v", pattern, values[i], got[i], want[i])
+				}
+			}
+		}
+
+		similar := strings.ReplaceAll(pattern, "_", "")
+		compiled, err := CompileSimilarTo(similar)
+		if err != nil || compiled.Type == PatternRegex {
+			continue
+		}
+		expr, err := translateSimilarTo(similar, '\\')
+		if err != nil {
+			t.Fatalf("translateSimilarTo(%q) failed: %v", similar, err)
+		}
+		re := regexp.MustCompile(expr)
+		got := CmpLikeStringCompiled(values, compiled)
+		for i, v := range values {
+			if got[i] != re.MatchString(v) {
+				t.Fatalf("SIMILAR TO %q, value %q: routed %v, regexp %v", similar, v, got[i], !got[i])
+			}
+		}
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+// Many partial matches of the middle segments make the backtracking matcher
+// quadratic per row, while the match program scans each row once
+func BenchmarkCmpLikeStringWildcard(b *testing.B) {
+	values := repeatString(strings.Repeat("ab", 200)+"c", 1000)
+	pattern, err := CompilePattern(PatternWildcard, "%ab%aba%abab_%c")
+	if err != nil {
+		b.Fatalf("CompilePattern failed: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CmpLikeStringCompiled(values, pattern)
+	}
+}
+
+func BenchmarkCmpMatchWildcardBacktracking(b *testing.B) {
+	values := stringsToBytes(repeatString(strings.Repeat("ab", 200)+"c", 1000))
+	pattern := []byte("%ab%aba%abab_%c")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cmpMatchWildcardGeneric(values, pattern)
+	}
+}
+
 // Helper functions
 
 // repeatString creates a slice of n identical strings
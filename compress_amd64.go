@@ -0,0 +1,41 @@
+//go:build amd64
+
+package syndrdbsimd
+
+// compressInt64AVX2 packs the values selected by mask into dst using AVX2.
+// Processes 4 int64 values per iteration: each 4-bit slice of the mask selects a
+// VPERMD permutation from a lookup table, and VPMASKMOVQ stores only the selected lanes,
+// so dst never needs slack beyond the number of selected elements.
+// length must be a multiple of 4. Returns the number of elements written.
+//
+//go:noescape
+func compressInt64AVX2(dst, src *int64, mask *uint64, length int) int
+
+// compressInt64AVX512 packs the values selected by mask into dst using AVX-512.
+// Processes 8 int64 values per iteration with VPCOMPRESSQ driven directly by a mask register.
+// length must be a multiple of 8. Returns the number of elements written.
+//
+//go:noescape
+func compressInt64AVX512(dst, src *int64, mask *uint64, length int) int
+
+// gatherInt64AVX2 performs dst[i] = src[indices[i]] using AVX2 VPGATHERDQ.
+// Processes 4 indices per iteration. length must be a multiple of 4.
+// Indices are not bounds-checked and must be < 2^31.
+//
+//go:noescape
+func gatherInt64AVX2(dst, src *int64, indices *uint32, length int)
+
+// gatherInt64AVX512 performs dst[i] = src[indices[i]] using AVX-512 VPGATHERDQ.
+// Processes 8 indices per iteration. length must be a multiple of 8.
+// Indices are not bounds-checked and must be < 2^31.
+//
+//go:noescape
+func gatherInt64AVX512(dst, src *int64, indices *uint32, length int)
+
+// scatterInt64AVX512 performs dst[indices[i]] = src[i] using AVX-512 VPSCATTERDQ.
+// Processes 8 values per iteration. length must be a multiple of 8.
+// Overlapping indices are written in element order, so the last one wins.
+// Indices are not bounds-checked and must be < 2^31.
+//
+//go:noescape
+func scatterInt64AVX512(dst, src *int64, indices *uint32, length int)
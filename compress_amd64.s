@@ -0,0 +1,361 @@
+#include "textflag.h"
+
+// compressPermTable holds one VPERMD index vector per 4-bit selection mask.
+// Entry m (32 bytes) moves the int64 lanes whose bit is set in m to the front of
+// the register, preserving their order. Each int64 lane j is addressed as the dword
+// pair (2j, 2j+1). Unused trailing lanes repeat lane 0 and are never stored.
+// mask=0000: lanes []
+DATA compressPermTable<>+0(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+8(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+16(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+24(SB)/8, $0x0000000100000000
+// mask=0001: lanes [0]
+DATA compressPermTable<>+32(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+40(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+48(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+56(SB)/8, $0x0000000100000000
+// mask=0010: lanes [1]
+DATA compressPermTable<>+64(SB)/8, $0x0000000300000002
+DATA compressPermTable<>+72(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+80(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+88(SB)/8, $0x0000000100000000
+// mask=0011: lanes [0, 1]
+DATA compressPermTable<>+96(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+104(SB)/8, $0x0000000300000002
+DATA compressPermTable<>+112(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+120(SB)/8, $0x0000000100000000
+// mask=0100: lanes [2]
+DATA compressPermTable<>+128(SB)/8, $0x0000000500000004
+DATA compressPermTable<>+136(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+144(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+152(SB)/8, $0x0000000100000000
+// mask=0101: lanes [0, 2]
+DATA compressPermTable<>+160(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+168(SB)/8, $0x0000000500000004
+DATA compressPermTable<>+176(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+184(SB)/8, $0x0000000100000000
+// mask=0110: lanes [1, 2]
+DATA compressPermTable<>+192(SB)/8, $0x0000000300000002
+DATA compressPermTable<>+200(SB)/8, $0x0000000500000004
+DATA compressPermTable<>+208(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+216(SB)/8, $0x0000000100000000
+// mask=0111: lanes [0, 1, 2]
+DATA compressPermTable<>+224(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+232(SB)/8, $0x0000000300000002
+DATA compressPermTable<>+240(SB)/8, $0x0000000500000004
+DATA compressPermTable<>+248(SB)/8, $0x0000000100000000
+// mask=1000: lanes [3]
+DATA compressPermTable<>+256(SB)/8, $0x0000000700000006
+DATA compressPermTable<>+264(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+272(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+280(SB)/8, $0x0000000100000000
+// mask=1001: lanes [0, 3]
+DATA compressPermTable<>+288(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+296(SB)/8, $0x0000000700000006
+DATA compressPermTable<>+304(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+312(SB)/8, $0x0000000100000000
+// mask=1010: lanes [1, 3]
+DATA compressPermTable<>+320(SB)/8, $0x0000000300000002
+DATA compressPermTable<>+328(SB)/8, $0x0000000700000006
+DATA compressPermTable<>+336(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+344(SB)/8, $0x0000000100000000
+// mask=1011: lanes [0, 1, 3]
+DATA compressPermTable<>+352(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+360(SB)/8, $0x0000000300000002
+DATA compressPermTable<>+368(SB)/8, $0x0000000700000006
+DATA compressPermTable<>+376(SB)/8, $0x0000000100000000
+// mask=1100: lanes [2, 3]
+DATA compressPermTable<>+384(SB)/8, $0x0000000500000004
+DATA compressPermTable<>+392(SB)/8, $0x0000000700000006
+DATA compressPermTable<>+400(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+408(SB)/8, $0x0000000100000000
+// mask=1101: lanes [0, 2, 3]
+DATA compressPermTable<>+416(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+424(SB)/8, $0x0000000500000004
+DATA compressPermTable<>+432(SB)/8, $0x0000000700000006
+DATA compressPermTable<>+440(SB)/8, $0x0000000100000000
+// mask=1110: lanes [1, 2, 3]
+DATA compressPermTable<>+448(SB)/8, $0x0000000300000002
+DATA compressPermTable<>+456(SB)/8, $0x0000000500000004
+DATA compressPermTable<>+464(SB)/8, $0x0000000700000006
+DATA compressPermTable<>+472(SB)/8, $0x0000000100000000
+// mask=1111: lanes [0, 1, 2, 3]
+DATA compressPermTable<>+480(SB)/8, $0x0000000100000000
+DATA compressPermTable<>+488(SB)/8, $0x0000000300000002
+DATA compressPermTable<>+496(SB)/8, $0x0000000500000004
+DATA compressPermTable<>+504(SB)/8, $0x0000000700000006
+GLOBL compressPermTable<>(SB), RODATA|NOPTR, $512
+
+// compressStoreMaskTable holds one VPMASKMOVQ store mask per selected-lane count.
+// Entry c (32 bytes) enables the first c int64 lanes so that exactly the compressed
+// values are written and nothing past them is touched.
+DATA compressStoreMaskTable<>+0(SB)/8, $0x0000000000000000
+DATA compressStoreMaskTable<>+8(SB)/8, $0x0000000000000000
+DATA compressStoreMaskTable<>+16(SB)/8, $0x0000000000000000
+DATA compressStoreMaskTable<>+24(SB)/8, $0x0000000000000000
+DATA compressStoreMaskTable<>+32(SB)/8, $0xffffffffffffffff
+DATA compressStoreMaskTable<>+40(SB)/8, $0x0000000000000000
+DATA compressStoreMaskTable<>+48(SB)/8, $0x0000000000000000
+DATA compressStoreMaskTable<>+56(SB)/8, $0x0000000000000000
+DATA compressStoreMaskTable<>+64(SB)/8, $0xffffffffffffffff
+DATA compressStoreMaskTable<>+72(SB)/8, $0xffffffffffffffff
+DATA compressStoreMaskTable<>+80(SB)/8, $0x0000000000000000
+DATA compressStoreMaskTable<>+88(SB)/8, $0x0000000000000000
+DATA compressStoreMaskTable<>+96(SB)/8, $0xffffffffffffffff
+DATA compressStoreMaskTable<>+104(SB)/8, $0xffffffffffffffff
+DATA compressStoreMaskTable<>+112(SB)/8, $0xffffffffffffffff
+DATA compressStoreMaskTable<>+120(SB)/8, $0x0000000000000000
+DATA compressStoreMaskTable<>+128(SB)/8, $0xffffffffffffffff
+DATA compressStoreMaskTable<>+136(SB)/8, $0xffffffffffffffff
+DATA compressStoreMaskTable<>+144(SB)/8, $0xffffffffffffffff
+DATA compressStoreMaskTable<>+152(SB)/8, $0xffffffffffffffff
+GLOBL compressStoreMaskTable<>(SB), RODATA|NOPTR, $160
+
+// func compressInt64AVX2(dst, src *int64, mask *uint64, length int) int
+//
+// Packs src[i] for every set bit i of mask into dst, preserving order.
+//
+// Algorithm (per group of 4 values):
+// 1. Take the next 4 bits of the selection mask (the "nibble")
+// 2. Load the VPERMD index vector for that nibble and permute the selected lanes to the front
+// 3. Store only the first popcount(nibble) lanes with VPMASKMOVQ
+// 4. Advance the output cursor by popcount(nibble)
+//
+// Register usage:
+//   DI:  destination pointer
+//   SI:  source pointer (advances 32 bytes per group)
+//   DX:  mask pointer (advances 8 bytes per 64 values)
+//   CX:  values remaining
+//   AX:  number of values written so far
+//   R8:  current mask word, shifted right by 4 after each group
+//   R10: compressPermTable base
+//   R11: compressStoreMaskTable base
+//   R12: groups remaining in the current mask word
+//   BX:  current nibble
+//   R14: popcount(nibble)
+//   Y0:  source values, Y1: permutation, Y2: packed values, Y3: store mask
+//
+TEXT ·compressInt64AVX2(SB), NOSPLIT, $0-40
+    MOVQ    dst+0(FP), DI
+    MOVQ    src+8(FP), SI
+    MOVQ    mask+16(FP), DX
+    MOVQ    length+24(FP), CX
+    XORQ    AX, AX
+    LEAQ    compressPermTable<>(SB), R10
+    LEAQ    compressStoreMaskTable<>(SB), R11
+
+avx2_word_loop:
+    TESTQ   CX, CX
+    JZ      avx2_done
+
+    // Load the next 64 selection bits
+    MOVQ    (DX), R8
+    ADDQ    $8, DX
+
+    // R12 = min(16, CX/4) groups to process from this word
+    MOVQ    CX, R13
+    SHRQ    $2, R13
+    MOVQ    $16, R12
+    CMPQ    R13, R12
+    CMOVQLT R13, R12
+
+    // CX -= R12 * 4
+    MOVQ    R12, R13
+    SHLQ    $2, R13
+    SUBQ    R13, CX
+
+avx2_group_loop:
+    MOVQ    R8, BX
+    ANDQ    $15, BX                 // BX = selection bits for these 4 values
+    JZ      avx2_skip_group         // Nothing selected: no load, no store
+
+    VMOVDQU (SI), Y0                // Y0 = src[0:4]
+
+    // Y1 = permutation for this nibble (32-byte entries)
+    MOVQ    BX, R13
+    SHLQ    $5, R13
+    VMOVDQU (R10)(R13*1), Y1
+
+    // Y2 = selected lanes moved to the front
+    VPERMD  Y0, Y1, Y2
+
+    // Y3 = store mask enabling the first popcount(nibble) lanes
+    POPCNTQ BX, R14
+    MOVQ    R14, R13
+    SHLQ    $5, R13
+    VMOVDQU (R11)(R13*1), Y3
+
+    // Store the packed lanes at dst[AX]
+    VPMASKMOVQ Y2, Y3, (DI)(AX*8)
+    ADDQ    R14, AX
+
+avx2_skip_group:
+    ADDQ    $32, SI
+    SHRQ    $4, R8
+    DECQ    R12
+    JNZ     avx2_group_loop
+    JMP     avx2_word_loop
+
+avx2_done:
+    VZEROUPPER
+    MOVQ    AX, ret+32(FP)
+    RET
+
+// func compressInt64AVX512(dst, src *int64, mask *uint64, length int) int
+//
+// AVX-512 version of compressInt64AVX2. The selection bits are moved straight into
+// a mask register and VPCOMPRESSQ writes exactly the selected lanes to memory, so
+// neither a permutation table nor a store mask is needed.
+//
+// Register usage:
+//   DI: destination pointer
+//   SI: source pointer (advances 64 bytes per group)
+//   DX: mask pointer
+//   CX: values remaining
+//   AX: number of values written so far
+//   R8: current mask word, shifted right by 8 after each group
+//   R12: groups remaining in the current mask word
+//   BX: current selection byte, R14: popcount(BX)
+//   K1: selection mask, Z0: source values
+//
+TEXT ·compressInt64AVX512(SB), NOSPLIT, $0-40
+    MOVQ    dst+0(FP), DI
+    MOVQ    src+8(FP), SI
+    MOVQ    mask+16(FP), DX
+    MOVQ    length+24(FP), CX
+    XORQ    AX, AX
+
+avx512_word_loop:
+    TESTQ   CX, CX
+    JZ      avx512_done
+
+    MOVQ    (DX), R8
+    ADDQ    $8, DX
+
+    // R12 = min(8, CX/8) groups to process from this word
+    MOVQ    CX, R13
+    SHRQ    $3, R13
+    MOVQ    $8, R12
+    CMPQ    R13, R12
+    CMOVQLT R13, R12
+
+    // CX -= R12 * 8
+    MOVQ    R12, R13
+    SHLQ    $3, R13
+    SUBQ    R13, CX
+
+avx512_group_loop:
+    MOVQ    R8, BX
+    ANDQ    $0xFF, BX               // BX = selection bits for these 8 values
+    JZ      avx512_skip_group
+
+    KMOVW   BX, K1
+    VMOVDQU64 (SI), Z0
+    VPCOMPRESSQ Z0, K1, (DI)(AX*8)  // Write only the selected lanes, packed
+    POPCNTQ BX, R14
+    ADDQ    R14, AX
+
+avx512_skip_group:
+    ADDQ    $64, SI
+    SHRQ    $8, R8
+    DECQ    R12
+    JNZ     avx512_group_loop
+    JMP     avx512_word_loop
+
+avx512_done:
+    VZEROUPPER
+    MOVQ    AX, ret+32(FP)
+    RET
+
+// func gatherInt64AVX2(dst, src *int64, indices *uint32, length int)
+//
+// Performs dst[i] = src[indices[i]] four values at a time with VPGATHERDQ.
+// VPGATHERDQ clears its mask register as lanes complete, so the all-ones mask
+// is rebuilt on every iteration.
+//
+// Register usage:
+//   DI: destination pointer, SI: source base, DX: indices pointer, CX: remaining
+//   X1: 4 dword indices, Y2: gather mask, Y0: gathered values
+//
+TEXT ·gatherInt64AVX2(SB), NOSPLIT, $0-32
+    MOVQ    dst+0(FP), DI
+    MOVQ    src+8(FP), SI
+    MOVQ    indices+16(FP), DX
+    MOVQ    length+24(FP), CX
+
+    TESTQ   CX, CX
+    JZ      gather_avx2_done
+
+gather_avx2_loop:
+    VMOVDQU (DX), X1                // X1 = indices[0:4]
+    VPCMPEQD Y2, Y2, Y2             // Y2 = all ones (gather every lane)
+    VPGATHERDQ Y2, (SI)(X1*8), Y0   // Y0[j] = src[X1[j]]
+    VMOVDQU Y0, (DI)
+
+    ADDQ    $32, DI
+    ADDQ    $16, DX
+    SUBQ    $4, CX
+    JNZ     gather_avx2_loop
+
+gather_avx2_done:
+    VZEROUPPER
+    RET
+
+// func gatherInt64AVX512(dst, src *int64, indices *uint32, length int)
+//
+// Performs dst[i] = src[indices[i]] eight values at a time with the AVX-512 form of VPGATHERDQ.
+//
+TEXT ·gatherInt64AVX512(SB), NOSPLIT, $0-32
+    MOVQ    dst+0(FP), DI
+    MOVQ    src+8(FP), SI
+    MOVQ    indices+16(FP), DX
+    MOVQ    length+24(FP), CX
+    MOVQ    $0xFF, BX
+
+    TESTQ   CX, CX
+    JZ      gather_avx512_done
+
+gather_avx512_loop:
+    VMOVDQU (DX), Y1                // Y1 = indices[0:8]
+    KMOVW   BX, K1                  // K1 = all 8 lanes (cleared by the gather)
+    VPGATHERDQ (SI)(Y1*8), K1, Z0   // Z0[j] = src[Y1[j]]
+    VMOVDQU64 Z0, (DI)
+
+    ADDQ    $64, DI
+    ADDQ    $32, DX
+    SUBQ    $8, CX
+    JNZ     gather_avx512_loop
+
+gather_avx512_done:
+    VZEROUPPER
+    RET
+
+// func scatterInt64AVX512(dst, src *int64, indices *uint32, length int)
+//
+// Performs dst[indices[i]] = src[i] eight values at a time with VPSCATTERDQ.
+// AVX2 has no scatter instruction, so this kernel is only used on AVX-512 hardware.
+//
+TEXT ·scatterInt64AVX512(SB), NOSPLIT, $0-32
+    MOVQ    dst+0(FP), DI
+    MOVQ    src+8(FP), SI
+    MOVQ    indices+16(FP), DX
+    MOVQ    length+24(FP), CX
+    MOVQ    $0xFF, BX
+
+    TESTQ   CX, CX
+    JZ      scatter_avx512_done
+
+scatter_avx512_loop:
+    VMOVDQU64 (SI), Z0              // Z0 = src[0:8]
+    VMOVDQU (DX), Y1                // Y1 = indices[0:8]
+    KMOVW   BX, K1
+    VPSCATTERDQ Z0, K1, (DI)(Y1*8)  // dst[Y1[j]] = Z0[j], lanes written in order
+
+    ADDQ    $64, SI
+    ADDQ    $32, DX
+    SUBQ    $8, CX
+    JNZ     scatter_avx512_loop
+
+scatter_avx512_done:
+    VZEROUPPER
+    RET
@@ -0,0 +1,13 @@
+//go:build arm64
+
+package syndrdbsimd
+
+// compressInt64NEON packs the values selected by mask into dst using NEON.
+// Processes 2 int64 values per iteration: each 2-bit slice of the mask selects a
+// TBL byte shuffle that moves the selected lanes to the front of the register.
+// The full 16-byte register is stored on every selecting iteration, so dst must have
+// room for at least length elements. length must be a multiple of 2.
+// Returns the number of elements written.
+//
+//go:noescape
+func compressInt64NEON(dst, src *int64, mask *uint64, length int) int
@@ -0,0 +1,80 @@
+#include "textflag.h"
+
+// compressTblNEON holds one TBL byte-index vector per 2-bit selection mask.
+// Entry m (16 bytes) moves the int64 lanes whose bit is set in m to the front.
+// Only mask=10 actually moves data; the other entries are the identity shuffle.
+DATA compressTblNEON<>+0(SB)/8, $0x0706050403020100   // mask=00: identity (never stored)
+DATA compressTblNEON<>+8(SB)/8, $0x0f0e0d0c0b0a0908
+DATA compressTblNEON<>+16(SB)/8, $0x0706050403020100  // mask=01: lane 0 already in front
+DATA compressTblNEON<>+24(SB)/8, $0x0f0e0d0c0b0a0908
+DATA compressTblNEON<>+32(SB)/8, $0x0f0e0d0c0b0a0908  // mask=10: lane 1 -> lane 0
+DATA compressTblNEON<>+40(SB)/8, $0x0f0e0d0c0b0a0908
+DATA compressTblNEON<>+48(SB)/8, $0x0706050403020100  // mask=11: both lanes
+DATA compressTblNEON<>+56(SB)/8, $0x0f0e0d0c0b0a0908
+GLOBL compressTblNEON<>(SB), RODATA|NOPTR, $64
+
+// func compressInt64NEON(dst, src *int64, mask *uint64, length int) int
+//
+// Packs src[i] for every set bit i of mask into dst, preserving order.
+// Each group of 2 values is shuffled with TBL and stored as a full 16-byte vector at
+// the output cursor; the cursor then advances by the number of selected lanes, so the
+// unselected lane is overwritten by the next store.
+//
+// Register usage:
+//   R0: destination pointer, R1: source pointer, R2: mask pointer
+//   R3: values remaining, R9: number of values written
+//   R8: current mask word, R12: groups remaining in the word
+//   R10: compressTblNEON base, R4: current 2-bit selection
+//   V0: source values, V1: shuffle indices, V2: packed values
+//
+TEXT ·compressInt64NEON(SB), NOSPLIT, $0-40
+    MOVD    dst+0(FP), R0
+    MOVD    src+8(FP), R1
+    MOVD    mask+16(FP), R2
+    MOVD    length+24(FP), R3
+    MOVD    $0, R9
+    MOVD    $compressTblNEON<>(SB), R10
+
+word_loop:
+    CBZ     R3, done
+
+    // Load the next 64 selection bits
+    MOVD    (R2), R8
+    ADD     $8, R2
+
+    // R12 = min(32, R3/2) groups to process from this word
+    LSR     $1, R3, R13
+    MOVD    $32, R12
+    CMP     R12, R13
+    CSEL    LT, R13, R12, R12
+
+    // R3 -= R12 * 2
+    SUB     R12<<1, R3, R3
+
+group_loop:
+    AND     $3, R8, R4              // R4 = selection bits for these 2 values
+    CBZ     R4, skip_group
+
+    VLD1    (R1), [V0.B16]          // V0 = src[0:2]
+    ADD     R4<<4, R10, R5
+    VLD1    (R5), [V1.B16]          // V1 = shuffle for this selection
+    VTBL    V1.B16, [V0.B16], V2.B16
+
+    ADD     R9<<3, R0, R6           // R6 = &dst[R9]
+    VST1    [V2.D2], (R6)
+
+    // R9 += popcount(R4) = (R4 & 1) + (R4 >> 1)
+    AND     $1, R4, R7
+    ADD     R4>>1, R7, R7
+    ADD     R7, R9, R9
+
+skip_group:
+    ADD     $16, R1
+    LSR     $2, R8, R8
+    SUB     $1, R12, R12
+    CBNZ    R12, group_loop
+    B       word_loop
+
+done:
+    MOVD    R9, ret+32(FP)
+    RET
@@ -0,0 +1,107 @@
+package syndrdbsimd
+
+import "math/bits"
+
+// selectedCount returns the number of set bits in mask that refer to the first
+// length elements. Bits beyond length (and words beyond the mask) are ignored.
+func selectedCount(mask []uint64, length int) int {
+	fullWords := length / 64
+	if fullWords > len(mask) {
+		fullWords = len(mask)
+	}
+
+	count := popCountGeneric(mask[:fullWords])
+	if fullWords < len(mask) && length%64 != 0 {
+		tail := mask[fullWords] & (uint64(1)<<uint(length%64) - 1)
+		count += bits.OnesCount64(tail)
+	}
+	return count
+}
+
+// compressInt64Generic copies src[i] into dst for every bit i set in mask, preserving order.
+// Stops early once dst is full. Returns the number of elements written to dst.
+func compressInt64Generic(dst, src []int64, mask []uint64) int {
+	n := 0
+	for wordIdx, word := range mask {
+		base := wordIdx * 64
+		for word != 0 {
+			i := base + bits.TrailingZeros64(word)
+			if i >= len(src) || n == len(dst) {
+				return n
+			}
+			dst[n] = src[i]
+			n++
+			word &= word - 1
+		}
+	}
+	return n
+}
+
+// compressFloat64Generic copies src[i] into dst for every bit i set in mask, preserving order.
+// Stops early once dst is full. Returns the number of elements written to dst.
+func compressFloat64Generic(dst, src []float64, mask []uint64) int {
+	n := 0
+	for wordIdx, word := range mask {
+		base := wordIdx * 64
+		for word != 0 {
+			i := base + bits.TrailingZeros64(word)
+			if i >= len(src) || n == len(dst) {
+				return n
+			}
+			dst[n] = src[i]
+			n++
+			word &= word - 1
+		}
+	}
+	return n
+}
+
+// compressStringsGeneric copies src[i] into dst for every bit i set in mask, preserving order.
+// Only the string headers are copied; the underlying bytes are shared with src.
+// Stops early once dst is full. Returns the number of elements written to dst.
+func compressStringsGeneric(dst, src []string, mask []uint64) int {
+	n := 0
+	for wordIdx, word := range mask {
+		base := wordIdx * 64
+		for word != 0 {
+			i := base + bits.TrailingZeros64(word)
+			if i >= len(src) || n == len(dst) {
+				return n
+			}
+			dst[n] = src[i]
+			n++
+			word &= word - 1
+		}
+	}
+	return n
+}
+
+// gatherInt64Generic performs dst[i] = src[indices[i]] using scalar operations.
+// Panics with an index out of range error if any index is >= len(src).
+func gatherInt64Generic(dst, src []int64, indices []uint32) {
+	for i, idx := range indices {
+		dst[i] = src[idx]
+	}
+}
+
+// scatterInt64Generic performs dst[indices[i]] = src[i] using scalar operations.
+// When indices repeat, the value with the highest i wins.
+// Panics with an index out of range error if any index is >= len(dst).
+func scatterInt64Generic(dst, src []int64, indices []uint32) {
+	for i, idx := range indices {
+		dst[idx] = src[i]
+	}
+}
+
+// maxUint32 returns the largest value in values, or 0 for an empty slice.
+// Used to bounds-check index vectors before handing them to gather/scatter kernels,
+// which have no bounds checks of their own.
+func maxUint32(values []uint32) uint32 {
+	max := uint32(0)
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
@@ -0,0 +1,359 @@
+package syndrdbsimd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// ============================================================================
+// CompressInt64 Tests
+// ============================================================================
+
+// compressInt64Reference is a straightforward reference implementation used to
+// validate the SIMD paths.
+func compressInt64Reference(src []int64, mask []uint64) []int64 {
+	var out []int64
+	for i, v := range src {
+		if i/64 < len(mask) && mask[i/64]&(1<<uint(i%64)) != 0 {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func TestCompressInt64_HappyPath(t *testing.T) {
+	src := []int64{10, 20, 30, 40, 50, 60, 70, 80}
+	mask := []uint64{0b10100101} // rows 0, 2, 5, 7
+
+	dst := make([]int64, len(src))
+	n := CompressInt64(dst, src, mask)
+
+	expected := []int64{10, 30, 60, 80}
+	if n != len(expected) {
+		t.Fatalf("Expected %d values, got %d", len(expected), n)
+	}
+	for i := range expected {
+		if dst[i] != expected[i] {
+			t.Errorf("dst[%d]: expected %d, got %d", i, expected[i], dst[i])
+		}
+	}
+}
+
+func TestCompressInt64_LargeArray(t *testing.T) {
+	src := make([]int64, 1000)
+	selected := make([]bool, len(src))
+	for i := range src {
+		src[i] = int64(i)
+		selected[i] = i >= 900
+	}
+	mask := BoolsToBitmask(selected)
+
+	dst := make([]int64, len(src))
+	n := CompressInt64(dst, src, mask)
+
+	if n != 100 {
+		t.Fatalf("Expected 100 values, got %d", n)
+	}
+	for i := 0; i < n; i++ {
+		if dst[i] != int64(900+i) {
+			t.Errorf("dst[%d]: expected %d, got %d", i, 900+i, dst[i])
+			break
+		}
+	}
+}
+
+func TestCompressInt64_RandomMasks(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+
+	// Lengths chosen to cover scalar tails, partial mask words and SIMD group boundaries
+	for _, size := range []int{1, 3, 4, 7, 8, 15, 16, 17, 63, 64, 65, 100, 127, 128, 129, 1000, 1027} {
+		src := make([]int64, size)
+		for i := range src {
+			src[i] = rng.Int63() - rng.Int63()
+		}
+
+		for _, density := range []float64{0, 0.1, 0.5, 0.9, 1} {
+			mask := make([]uint64, (size+63)/64)
+			for i := 0; i < size; i++ {
+				if rng.Float64() < density {
+					mask[i/64] |= 1 << uint(i%64)
+				}
+			}
+
+			expected := compressInt64Reference(src, mask)
+
+			// Exactly-sized destination: the SIMD paths must not write past the selected rows
+			dst := make([]int64, len(expected)+1)
+			sentinel := int64(-12345)
+			dst[len(expected)] = sentinel
+			n := CompressInt64(dst, src, mask)
+
+			if n != len(expected) {
+				t.Fatalf("size=%d density=%.1f: expected %d values, got %d", size, density, len(expected), n)
+			}
+			for i := range expected {
+				if dst[i] != expected[i] {
+					t.Fatalf("size=%d density=%.1f: dst[%d] expected %d, got %d", size, density, i, expected[i], dst[i])
+				}
+			}
+			if dst[len(expected)] != sentinel {
+				t.Errorf("size=%d density=%.1f: wrote past the selected rows", size, density)
+			}
+		}
+	}
+}
+
+// Edge case: mask bits beyond len(src) must be ignored
+func TestCompressInt64_MaskLongerThanSource(t *testing.T) {
+	src := make([]int64, 20)
+	for i := range src {
+		src[i] = int64(i)
+	}
+	mask := []uint64{^uint64(0), ^uint64(0)}
+
+	dst := make([]int64, 128)
+	n := CompressInt64(dst, src, mask)
+
+	if n != 20 {
+		t.Fatalf("Expected 20 values, got %d", n)
+	}
+}
+
+// Edge case: mask shorter than src leaves the uncovered rows unselected
+func TestCompressInt64_MaskShorterThanSource(t *testing.T) {
+	src := make([]int64, 200)
+	for i := range src {
+		src[i] = int64(i)
+	}
+	mask := []uint64{^uint64(0)}
+
+	dst := make([]int64, len(src))
+	n := CompressInt64(dst, src, mask)
+
+	if n != 64 {
+		t.Fatalf("Expected 64 values, got %d", n)
+	}
+	if dst[63] != 63 {
+		t.Errorf("Expected dst[63] = 63, got %d", dst[63])
+	}
+}
+
+// Edge case: dst too small stops at capacity
+func TestCompressInt64_DstTooSmall(t *testing.T) {
+	src := make([]int64, 100)
+	for i := range src {
+		src[i] = int64(i)
+	}
+	mask := []uint64{^uint64(0), ^uint64(0)}
+
+	dst := make([]int64, 10)
+	n := CompressInt64(dst, src, mask)
+
+	if n != 10 {
+		t.Fatalf("Expected 10 values, got %d", n)
+	}
+	for i := 0; i < n; i++ {
+		if dst[i] != int64(i) {
+			t.Errorf("dst[%d]: expected %d, got %d", i, i, dst[i])
+		}
+	}
+}
+
+func TestCompressInt64_Empty(t *testing.T) {
+	if n := CompressInt64(nil, []int64{1, 2}, []uint64{3}); n != 0 {
+		t.Errorf("Expected 0 for nil dst, got %d", n)
+	}
+	if n := CompressInt64(make([]int64, 2), nil, []uint64{3}); n != 0 {
+		t.Errorf("Expected 0 for nil src, got %d", n)
+	}
+	if n := CompressInt64(make([]int64, 2), []int64{1, 2}, nil); n != 0 {
+		t.Errorf("Expected 0 for nil mask, got %d", n)
+	}
+}
+
+// ============================================================================
+// CompressFloat64 / CompressStrings Tests
+// ============================================================================
+
+func TestCompressFloat64_PreservesSpecialValues(t *testing.T) {
+	src := make([]float64, 40)
+	for i := range src {
+		src[i] = float64(i) + 0.5
+	}
+	src[3] = math.NaN()
+	src[5] = math.Inf(-1)
+	src[7] = math.Copysign(0, -1)
+
+	mask := []uint64{0b10101000 | 1<<39}
+	dst := make([]float64, len(src))
+	n := CompressFloat64(dst, src, mask)
+
+	if n != 4 {
+		t.Fatalf("Expected 4 values, got %d", n)
+	}
+	if !math.IsNaN(dst[0]) {
+		t.Errorf("Expected NaN, got %v", dst[0])
+	}
+	if !math.IsInf(dst[1], -1) {
+		t.Errorf("Expected -Inf, got %v", dst[1])
+	}
+	if dst[2] != 0 || !math.Signbit(dst[2]) {
+		t.Errorf("Expected -0, got %v", dst[2])
+	}
+	if dst[3] != 39.5 {
+		t.Errorf("Expected 39.5, got %v", dst[3])
+	}
+}
+
+func TestCompressStrings_HappyPath(t *testing.T) {
+	src := []string{"apple", "banana", "cherry", "date", "elderberry"}
+	mask := CmpHasPrefixStringMask(src, "b")
+	mask = OrBitmap(mask, CmpHasSuffixStringMask(src, "berry"))
+
+	dst := make([]string, len(src))
+	n := CompressStrings(dst, src, mask)
+
+	expected := []string{"banana", "elderberry"}
+	if n != len(expected) {
+		t.Fatalf("Expected %d values, got %d", len(expected), n)
+	}
+	for i := range expected {
+		if dst[i] != expected[i] {
+			t.Errorf("dst[%d]: expected %q, got %q", i, expected[i], dst[i])
+		}
+	}
+}
+
+// ============================================================================
+// GatherInt64 / ScatterInt64 Tests
+// ============================================================================
+
+func TestGatherInt64_RandomIndices(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	src := make([]int64, 500)
+	for i := range src {
+		src[i] = int64(i * 3)
+	}
+
+	for _, size := range []int{1, 5, 8, 16, 17, 33, 256, 1001} {
+		indices := make([]uint32, size)
+		for i := range indices {
+			indices[i] = uint32(rng.Intn(len(src)))
+		}
+
+		dst := make([]int64, size)
+		GatherInt64(dst, src, indices)
+
+		for i, idx := range indices {
+			if dst[i] != src[idx] {
+				t.Fatalf("size=%d: dst[%d] expected %d, got %d", size, i, src[idx], dst[i])
+			}
+		}
+	}
+}
+
+func TestGatherInt64_OutOfRangePanics(t *testing.T) {
+	src := make([]int64, 10)
+	indices := make([]uint32, 32)
+	indices[20] = 10
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for out-of-range index")
+		}
+	}()
+	GatherInt64(make([]int64, 32), src, indices)
+}
+
+func TestScatterInt64_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+
+	for _, size := range []int{1, 7, 8, 16, 23, 64, 999} {
+		src := make([]int64, size)
+		for i := range src {
+			src[i] = rng.Int63()
+		}
+		perm := rng.Perm(size)
+		indices := make([]uint32, size)
+		for i, p := range perm {
+			indices[i] = uint32(p)
+		}
+
+		dst := make([]int64, size)
+		ScatterInt64(dst, src, indices)
+
+		back := make([]int64, size)
+		GatherInt64(back, dst, indices)
+		for i := range src {
+			if back[i] != src[i] {
+				t.Fatalf("size=%d: round trip mismatch at %d: expected %d, got %d", size, i, src[i], back[i])
+			}
+		}
+	}
+}
+
+// Duplicate indices: the last value written must win
+func TestScatterInt64_DuplicateIndices(t *testing.T) {
+	src := make([]int64, 32)
+	indices := make([]uint32, 32)
+	for i := range src {
+		src[i] = int64(i + 1)
+		indices[i] = uint32(i % 4)
+	}
+
+	dst := make([]int64, 4)
+	ScatterInt64(dst, src, indices)
+
+	expected := []int64{29, 30, 31, 32}
+	for i := range expected {
+		if dst[i] != expected[i] {
+			t.Errorf("dst[%d]: expected %d, got %d", i, expected[i], dst[i])
+		}
+	}
+}
+
+func TestScatterInt64_OutOfRangePanics(t *testing.T) {
+	dst := make([]int64, 10)
+	indices := make([]uint32, 32)
+	indices[31] = 99
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for out-of-range index")
+		}
+	}()
+	ScatterInt64(dst, make([]int64, 32), indices)
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+func BenchmarkCompressInt64(b *testing.B) {
+	src := make([]int64, 4096)
+	for i := range src {
+		src[i] = int64(i)
+	}
+	mask := CmpLtInt64Mask(src, 2048)
+	dst := make([]int64, len(src))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CompressInt64(dst, src, mask)
+	}
+}
+
+func BenchmarkGatherInt64(b *testing.B) {
+	src := make([]int64, 4096)
+	indices := make([]uint32, 4096)
+	for i := range indices {
+		indices[i] = uint32((i * 7919) % len(src))
+	}
+	dst := make([]int64, len(indices))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		GatherInt64(dst, src, indices)
+	}
+}
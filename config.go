@@ -1,7 +1,11 @@
 package syndrdbsimd
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 )
 
@@ -28,6 +32,11 @@ const (
 	// PatternWildcard matches complex patterns with % (any chars) and _ (single char)
 	// Example: "h_llo%" matches "hello world", "hallo there", etc.
 	PatternWildcard
+
+	// PatternRegex matches a SQL SIMILAR TO pattern that needs regular expression
+	// features such as alternation or repetition. Only CompileSimilarTo produces it
+	// Example: "(ab|cd)%" matches "abc", "cde", etc.
+	PatternRegex
 )
 
 // String returns a human-readable representation of the PatternType.
@@ -43,30 +52,136 @@ func (pt PatternType) String() string {
 		return "Contains"
 	case PatternWildcard:
 		return "Wildcard"
+	case PatternRegex:
+		return "Regex"
 	default:
 		return fmt.Sprintf("Unknown(%d)", int(pt))
 	}
 }
 
-// thresholdConfig holds the SIMD threshold configuration for string operations.
-type thresholdConfig struct {
-	minStrings       int // Minimum number of strings to use SIMD
-	avgByteThreshold int // Average string length threshold for adaptive SIMD
+// ErrInvalidConfig is returned by SetConfig and SetStringSIMDThreshold when a
+// threshold is out of range.
+var ErrInvalidConfig = errors.New("invalid SIMD configuration")
+
+// Config holds the minimum input sizes at which each operation group switches
+// from the scalar implementation to SIMD kernels. Below these sizes the setup cost
+// of the vector path outweighs its throughput, so the generic code is used instead.
+//
+// Lengths are counted in elements, except for the bitmap groups, which count
+// uint64 words. Every field must be > 0.
+type Config struct {
+	MinCmpInt64      int `json:"min_cmp_int64"`      // Int64 comparisons (Cmp*Int64 and Cmp*Int64Mask)
+	MinCmpFloat64    int `json:"min_cmp_float64"`    // Float64 comparisons (Cmp*Float64 and Cmp*Float64Mask)
+	MinBitmapWords   int `json:"min_bitmap_words"`   // AndBitmap, OrBitmap, XorBitmap and NotBitmap
+	MinPopCountWords int `json:"min_popcount_words"` // PopCount
+	MinAggregate     int `json:"min_aggregate"`      // SumInt64, CountNonNull and Float64Column Min and Max
+	MinHash          int `json:"min_hash"`           // XXHash64
+	MinCompress      int `json:"min_compress"`       // CompressInt64, GatherInt64 and ScatterInt64
+	MinNarrow        int `json:"min_narrow"`         // Int8/16/32 and Uint8/16/32 compares, sums, min and max
+	MinFloat32       int `json:"min_float32"`        // Float32 compares, aggregates and conversions
+	MinArith         int `json:"min_arith"`          // Element-wise int64/float64 arithmetic, DateTrunc and DateExtract
+
+	MinStrings       int `json:"min_strings"`        // Minimum number of strings for SIMD string comparisons
+	AvgByteThreshold int `json:"avg_byte_threshold"` // Minimum average string length for SIMD equality
+}
+
+// DefaultConfig returns the thresholds tuned for the current architecture.
+// These are the values in effect until SetConfig is called.
+func DefaultConfig() Config {
+	return defaultConfig
+}
+
+// Validate reports whether every threshold in c is in range.
+// The returned error wraps ErrInvalidConfig and names the first offending field.
+func (c Config) Validate() error {
+	fields := []struct {
+		name  string
+		value int
+	}{
+		{"MinCmpInt64", c.MinCmpInt64},
+		{"MinCmpFloat64", c.MinCmpFloat64},
+		{"MinBitmapWords", c.MinBitmapWords},
+		{"MinPopCountWords", c.MinPopCountWords},
+		{"MinAggregate", c.MinAggregate},
+		{"MinHash", c.MinHash},
+		{"MinCompress", c.MinCompress},
+		{"MinNarrow", c.MinNarrow},
+		{"MinFloat32", c.MinFloat32},
+		{"MinArith", c.MinArith},
+		{"MinStrings", c.MinStrings},
+		{"AvgByteThreshold", c.AvgByteThreshold},
+	}
+	for _, f := range fields {
+		if f.value <= 0 {
+			return fmt.Errorf("%w: %s must be > 0, got %d", ErrInvalidConfig, f.name, f.value)
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a configuration saved with encoding/json, such as a
+// profile produced by Calibrate. Fields missing from data keep their
+// DefaultConfig values, so profiles written by older versions still load.
+//
+// Returns an error for unknown fields, or one wrapping ErrInvalidConfig if the
+// decoded configuration fails Validate.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	// configJSON has Config's fields but not its methods, so decoding into it
+	// does not recurse into UnmarshalJSON
+	type configJSON Config
+	decoded := configJSON(DefaultConfig())
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&decoded); err != nil {
+		return err
+	}
+	if err := Config(decoded).Validate(); err != nil {
+		return err
+	}
+
+	*c = Config(decoded)
+	return nil
 }
 
-// Global atomic pointer to threshold configuration
-var stringSIMDThreshold atomic.Pointer[thresholdConfig]
+// Global atomic pointer to the active configuration.
+// Readers load it on every call; writers replace it wholesale, so a call never
+// observes a mix of old and new thresholds.
+var simdConfig atomic.Pointer[Config]
+
+// configMu serializes read-modify-write updates of simdConfig.
+var configMu sync.Mutex
 
 func init() {
-	// Set default thresholds
-	defaultConfig := &thresholdConfig{
-		minStrings:       16,
-		avgByteThreshold: 32,
+	config := DefaultConfig()
+	simdConfig.Store(&config)
+}
+
+// SetConfig atomically replaces every threshold with the values in c.
+//
+// Returns an error wrapping ErrInvalidConfig if c fails Validate; the current
+// configuration is left unchanged in that case.
+//
+// This function is thread-safe and can be called at runtime, though it's recommended
+// to configure thresholds once at startup for best performance.
+func SetConfig(c Config) error {
+	if err := c.Validate(); err != nil {
+		return err
 	}
-	stringSIMDThreshold.Store(defaultConfig)
+
+	configMu.Lock()
+	defer configMu.Unlock()
+	simdConfig.Store(&c)
+	return nil
+}
+
+// GetConfig returns a copy of the active configuration.
+func GetConfig() Config {
+	return *simdConfig.Load()
 }
 
-// SetStringSIMDThreshold configures the adaptive SIMD thresholds for string operations.
+// SetStringSIMDThreshold configures the adaptive SIMD thresholds for string operations,
+// leaving the other fields of the active Config unchanged.
 //
 // Parameters:
 //   - minStrings: Minimum number of strings required to use SIMD (must be > 0)
@@ -80,27 +195,25 @@ func init() {
 // This function is thread-safe and can be called at runtime, though it's recommended
 // to set thresholds once at startup for best performance.
 //
-// Panics if minStrings <= 0 or avgByteThreshold <= 0.
-//
-// TODO: Return error instead of panic for library-friendly API in future.
-func SetStringSIMDThreshold(minStrings, avgByteThreshold int) {
-	if minStrings <= 0 {
-		panic(fmt.Sprintf("minStrings must be > 0, got %d", minStrings))
-	}
-	if avgByteThreshold <= 0 {
-		panic(fmt.Sprintf("avgByteThreshold must be > 0, got %d", avgByteThreshold))
-	}
+// Returns an error wrapping ErrInvalidConfig if minStrings <= 0 or avgByteThreshold <= 0.
+func SetStringSIMDThreshold(minStrings, avgByteThreshold int) error {
+	configMu.Lock()
+	defer configMu.Unlock()
 
-	config := &thresholdConfig{
-		minStrings:       minStrings,
-		avgByteThreshold: avgByteThreshold,
+	config := *simdConfig.Load()
+	config.MinStrings = minStrings
+	config.AvgByteThreshold = avgByteThreshold
+	if err := config.Validate(); err != nil {
+		return err
 	}
-	stringSIMDThreshold.Store(config)
+
+	simdConfig.Store(&config)
+	return nil
 }
 
 // GetStringSIMDThreshold returns the current SIMD threshold configuration.
 // Returns (minStrings, avgByteThreshold).
 func GetStringSIMDThreshold() (int, int) {
-	config := stringSIMDThreshold.Load()
-	return config.minStrings, config.avgByteThreshold
+	config := simdConfig.Load()
+	return config.MinStrings, config.AvgByteThreshold
 }
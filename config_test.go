@@ -0,0 +1,194 @@
+package syndrdbsimd
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Config Tests
+// ============================================================================
+
+func TestDefaultConfig_IsActiveAndValid(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Fatalf("DefaultConfig is invalid: %v", err)
+	}
+	if GetConfig() != DefaultConfig() {
+		t.Errorf("Expected active config %+v, got %+v", DefaultConfig(), GetConfig())
+	}
+
+	minStrings, avgBytes := GetStringSIMDThreshold()
+	if minStrings != 16 || avgBytes != 32 {
+		t.Errorf("Expected string thresholds (16, 32), got (%d, %d)", minStrings, avgBytes)
+	}
+}
+
+func TestConfig_ValidateRejectsNonPositive(t *testing.T) {
+	tests := []struct {
+		field  string
+		modify func(c *Config)
+	}{
+		{"MinCmpInt64", func(c *Config) { c.MinCmpInt64 = 0 }},
+		{"MinCmpFloat64", func(c *Config) { c.MinCmpFloat64 = -1 }},
+		{"MinBitmapWords", func(c *Config) { c.MinBitmapWords = 0 }},
+		{"MinPopCountWords", func(c *Config) { c.MinPopCountWords = 0 }},
+		{"MinAggregate", func(c *Config) { c.MinAggregate = -16 }},
+		{"MinHash", func(c *Config) { c.MinHash = 0 }},
+		{"MinCompress", func(c *Config) { c.MinCompress = 0 }},
+		{"MinNarrow", func(c *Config) { c.MinNarrow = 0 }},
+		{"MinFloat32", func(c *Config) { c.MinFloat32 = 0 }},
+		{"MinArith", func(c *Config) { c.MinArith = 0 }},
+		{"MinStrings", func(c *Config) { c.MinStrings = 0 }},
+		{"AvgByteThreshold", func(c *Config) { c.AvgByteThreshold = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			c := DefaultConfig()
+			tt.modify(&c)
+
+			err := c.Validate()
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("Expected ErrInvalidConfig, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("Expected error to name %s, got %q", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestSetConfig_InvalidLeavesConfigUnchanged(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+
+	c := DefaultConfig()
+	c.MinCmpInt64 = 64
+	c.MinHash = 0
+	if err := SetConfig(c); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Expected ErrInvalidConfig, got %v", err)
+	}
+	if GetConfig() != DefaultConfig() {
+		t.Errorf("Rejected SetConfig changed the config to %+v", GetConfig())
+	}
+}
+
+func TestSetConfig_RoundTrip(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+
+	c := Config{
+		MinCmpInt64:      100,
+		MinCmpFloat64:    200,
+		MinBitmapWords:   3,
+		MinPopCountWords: 4,
+		MinAggregate:     5,
+		MinHash:          6,
+		MinCompress:      7,
+		MinNarrow:        10,
+		MinFloat32:       11,
+		MinArith:         12,
+		MinStrings:       8,
+		AvgByteThreshold: 9,
+	}
+	if err := SetConfig(c); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if got := GetConfig(); got != c {
+		t.Errorf("Expected %+v, got %+v", c, got)
+	}
+}
+
+func TestSetStringSIMDThreshold(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+
+	if err := SetStringSIMDThreshold(4, 8); err != nil {
+		t.Fatalf("SetStringSIMDThreshold failed: %v", err)
+	}
+	if minStrings, avgBytes := GetStringSIMDThreshold(); minStrings != 4 || avgBytes != 8 {
+		t.Errorf("Expected (4, 8), got (%d, %d)", minStrings, avgBytes)
+	}
+
+	// Only the string fields change
+	if got := GetConfig().MinCmpInt64; got != DefaultConfig().MinCmpInt64 {
+		t.Errorf("MinCmpInt64 changed to %d", got)
+	}
+
+	for _, args := range [][2]int{{0, 32}, {16, 0}, {-1, -1}} {
+		if err := SetStringSIMDThreshold(args[0], args[1]); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("SetStringSIMDThreshold(%d, %d): expected ErrInvalidConfig, got %v", args[0], args[1], err)
+		}
+	}
+	if minStrings, avgBytes := GetStringSIMDThreshold(); minStrings != 4 || avgBytes != 8 {
+		t.Errorf("Rejected update changed thresholds to (%d, %d)", minStrings, avgBytes)
+	}
+}
+
+// With every threshold at its minimum the SIMD kernels run on inputs smaller than
+// a single vector, which must still match the generic implementations.
+func TestConfig_MinimumThresholdsMatchGeneric(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+
+	err := SetConfig(Config{
+		MinCmpInt64:      1,
+		MinCmpFloat64:    1,
+		MinBitmapWords:   1,
+		MinPopCountWords: 1,
+		MinAggregate:     1,
+		MinHash:          1,
+		MinCompress:      1,
+		MinNarrow:        1,
+		MinFloat32:       1,
+		MinArith:         1,
+		MinStrings:       1,
+		AvgByteThreshold: 1,
+	})
+	if err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(30))
+				for size := 1; size <= 20; size++ {
+					ints := make([]int64, size)
+					floats := make([]float64, size)
+					words := make([]uint64, size)
+					for i := range ints {
+						ints[i] = rng.Int63n(7) - 3
+						floats[i] = float64(ints[i]) / 2
+						words[i] = rng.Uint64()
+					}
+
+					checkInt64Compares(t, size, ints, 0)
+					checkFloat64Compares(t, size, floats, 0)
+
+					checkEqual(t, size, "AndBitmap", AndBitmap(words, words), andBitmapGeneric(words, words))
+					checkEqual(t, size, "NotBitmap", NotBitmap(words), notBitmapGeneric(words))
+					if got, want := PopCount(words), popCountGeneric(words); got != want {
+						t.Errorf("size=%d: PopCount = %d, want %d", size, got, want)
+					}
+					if got, want := SumInt64(ints), sumInt64Generic(ints); got != want {
+						t.Errorf("size=%d: SumInt64 = %d, want %d", size, got, want)
+					}
+
+					hashes := make([]uint64, size)
+					XXHash64(ints, hashes)
+					expected := make([]uint64, size)
+					xxhash64SliceGeneric(ints, expected)
+					checkEqual(t, size, "XXHash64", hashes, expected)
+
+					mask := cmpGtInt64MaskGeneric(ints, 0)
+					dst := make([]int64, size)
+					want := make([]int64, size)
+					n := CompressInt64(dst, ints, mask)
+					checkEqual(t, size, "CompressInt64", dst[:n], want[:compressInt64Generic(want, ints, mask)])
+				}
+			})
+		})
+	}
+}
@@ -18,6 +18,28 @@ func init() {
 	hasSSE42 = cpu.X86.HasSSE42
 }
 
+// x86Features is the subset of CPU features the kernel selection depends on.
+type x86Features struct {
+	SSE42           bool
+	AVX2            bool
+	AVX512F         bool
+	AVX512DQ        bool // VPMULLQ on 512-bit vectors
+	AVX512VPOPCNTDQ bool // VPOPCNTQ
+	BMI2            bool // PDEPQ, used to expand compare masks into bools
+}
+
+// detectX86Features reports the features of the running CPU.
+func detectX86Features() x86Features {
+	return x86Features{
+		SSE42:           cpu.X86.HasSSE42,
+		AVX2:            cpu.X86.HasAVX2,
+		AVX512F:         cpu.X86.HasAVX512F,
+		AVX512DQ:        cpu.X86.HasAVX512DQ,
+		AVX512VPOPCNTDQ: cpu.X86.HasAVX512VPOPCNTDQ,
+		BMI2:            cpu.X86.HasBMI2,
+	}
+}
+
 // HasAVX2 returns true if the CPU supports AVX2 instructions.
 func HasAVX2() bool {
 	return hasAVX2
@@ -17,6 +17,20 @@ func init() {
 	hasSVE = cpu.ARM64.HasSVE
 }
 
+// arm64Features is the subset of CPU features the kernel selection depends on.
+type arm64Features struct {
+	NEON bool
+	SVE  bool
+}
+
+// detectARM64Features reports the features of the running CPU.
+func detectARM64Features() arm64Features {
+	return arm64Features{
+		NEON: true, // NEON is always available on ARM64
+		SVE:  cpu.ARM64.HasSVE,
+	}
+}
+
 // HasNEON returns true if the CPU supports NEON instructions.
 // On ARM64, this is always true.
 func HasNEON() bool {
@@ -0,0 +1,18 @@
+# Run the arm64 test suite, including the SVE kernels, under qemu-user emulation.
+# Works on any x86-64 Linux box; no ARM hardware or Docker needed.
+
+# 1. Install the user-mode emulator (Debian/Ubuntu)
+sudo apt-get install -y qemu-user
+
+# 2. Cross-compile the test binary (pure Go, no C toolchain required)
+GOARCH=arm64 go test -c -o syndrdb-simd-arm64.test .
+
+# 3. Run with SVE at several vector lengths. The kernels are vector-length
+#    agnostic, so every length must pass; 384 bits exercises a vector that
+#    does not divide a 64-bit bitmap word evenly.
+for vl in 128 256 384 512 2048; do
+    qemu-aarch64 -cpu max,sve${vl}=on ./syndrdb-simd-arm64.test -test.v -test.run 'ARM64|Cmp|Bitmap|PopCount|Sum|XXHash'
+done
+
+# 4. Run without SVE to cover the NEON dispatch path
+qemu-aarch64 -cpu max,sve=off ./syndrdb-simd-arm64.test -test.run 'ARM64|Cmp|Bitmap|PopCount|Sum|XXHash'
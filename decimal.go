@@ -0,0 +1,152 @@
+package syndrdbsimd
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MaxDecimalScale is the largest supported decimal scale: 10^18 is the largest
+// power of ten that fits in an int64.
+const MaxDecimalScale = 18
+
+// ErrDecimalOverflow is returned when a rescaled mantissa does not fit in an int64.
+var ErrDecimalOverflow = errors.New("decimal overflow")
+
+// Decimal64 is a column of fixed-point decimals sharing one scale.
+// The value of element i is Mantissas[i] / 10^Scale, so 12.34 at scale 2 is stored as 1234.
+//
+// Comparisons against a threshold of a different scale are exact: the threshold is
+// rescaled to the column scale once and the int64 comparison kernels do the rest.
+type Decimal64 struct {
+	Mantissas []int64
+	Scale     int
+}
+
+// checkScale panics if scale is outside [0, MaxDecimalScale].
+func checkScale(scale int) {
+	if scale < 0 || scale > MaxDecimalScale {
+		panic(fmt.Sprintf("syndrdbsimd: decimal scale %d out of range [0, %d]", scale, MaxDecimalScale))
+	}
+}
+
+// Compare compares each value against the decimal mantissa / 10^scale with the operator op.
+// Returns a slice of booleans where result[i] == true if value[i] op threshold.
+//
+// The threshold may have any scale; Compare(OpEq, 5, 1) on a column at scale 2
+// matches the mantissa 50. Thresholds beyond the int64 range at the column scale
+// and thresholds with more fractional digits than the column are handled exactly.
+// Panics if op or either scale is invalid.
+//
+// This function automatically selects the best implementation:
+//   - The int64 comparison kernels (AVX-512, AVX2, NEON) on the rescaled threshold
+//   - Scalar fallback for other platforms
+func (d Decimal64) Compare(op Op, mantissa int64, scale int) []bool {
+	checkOp(op)
+	checkScale(d.Scale)
+	checkScale(scale)
+	if len(d.Mantissas) == 0 {
+		return []bool{}
+	}
+
+	op, threshold, all, ok := decimalThreshold(op, mantissa, scale, d.Scale)
+	if !ok {
+		return constantBools(len(d.Mantissas), all)
+	}
+	return int64CmpImpls[op](currentKernels(), d.Mantissas, threshold)
+}
+
+// CompareMask compares each value against the decimal mantissa / 10^scale and returns a bitmask.
+// Returns a slice of uint64 where bit i in result[j] is set if value[j*64+i] op threshold.
+// Panics if op or either scale is invalid.
+func (d Decimal64) CompareMask(op Op, mantissa int64, scale int) []uint64 {
+	checkOp(op)
+	checkScale(d.Scale)
+	checkScale(scale)
+	if len(d.Mantissas) == 0 {
+		return []uint64{}
+	}
+
+	op, threshold, all, ok := decimalThreshold(op, mantissa, scale, d.Scale)
+	if !ok {
+		return constantMask(len(d.Mantissas), all)
+	}
+	return int64CmpMaskImpls[op](currentKernels(), d.Mantissas, threshold)
+}
+
+// Sum computes the exact sum of the column with a 128-bit accumulator.
+// The result has the column's scale. Returns 0 for empty columns.
+//
+// This function automatically selects the best implementation:
+//   - The int64 sum kernels for chunks whose values cannot overflow 64 bits
+//   - Scalar 128-bit accumulation for chunks with larger values
+func (d Decimal64) Sum() Decimal128 {
+	checkScale(d.Scale)
+	hi, lo := sumDecimal64(d.Mantissas)
+	return Decimal128{Hi: hi, Lo: lo, Scale: d.Scale}
+}
+
+// Round rescales the column to scale and returns a new column; d is not modified.
+//
+// Reducing the scale rounds half away from zero, as SQL ROUND does: 1.25 at
+// scale 1 becomes 1.3 and -1.25 becomes -1.3. Increasing the scale is exact and
+// returns ErrDecimalOverflow if a value no longer fits in an int64.
+// Panics if either scale is invalid.
+func (d Decimal64) Round(scale int) (Decimal64, error) {
+	checkScale(d.Scale)
+	checkScale(scale)
+
+	result := Decimal64{Mantissas: make([]int64, len(d.Mantissas)), Scale: scale}
+	if i := roundDecimal64Generic(result.Mantissas, d.Mantissas, d.Scale, scale); i >= 0 {
+		return Decimal64{}, fmt.Errorf("%w: value %d at index %d does not fit scale %d",
+			ErrDecimalOverflow, d.Mantissas[i], i, scale)
+	}
+	return result, nil
+}
+
+// Decimal128 is a single fixed-point decimal with a 128-bit two's complement
+// mantissa Hi:Lo, as returned by Decimal64.Sum.
+type Decimal128 struct {
+	Hi    int64
+	Lo    uint64
+	Scale int
+}
+
+// Mantissa64 returns the mantissa as an int64 and reports whether it fits.
+func (d Decimal128) Mantissa64() (int64, bool) {
+	v := int64(d.Lo)
+	return v, d.Hi == v>>63
+}
+
+// BigMantissa returns the mantissa as a big.Int.
+func (d Decimal128) BigMantissa() *big.Int {
+	b := big.NewInt(d.Hi)
+	b.Lsh(b, 64)
+	return b.Add(b, new(big.Int).SetUint64(d.Lo))
+}
+
+// String formats the decimal with exactly Scale fractional digits, e.g. "-12.50".
+func (d Decimal128) String() string {
+	digits := d.BigMantissa().String()
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimPrefix(digits, "-")
+
+	if d.Scale > 0 {
+		if len(digits) <= d.Scale {
+			digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.Scale] + "." + digits[len(digits)-d.Scale:]
+	}
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Float64 returns the nearest float64 to the decimal.
+func (d Decimal128) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
@@ -0,0 +1,159 @@
+package syndrdbsimd
+
+import (
+	"math"
+	"math/bits"
+)
+
+// pow10Int64 holds 10^0 through 10^MaxDecimalScale.
+var pow10Int64 = [MaxDecimalScale + 1]int64{
+	1, 10, 100, 1_000, 10_000, 100_000, 1_000_000, 10_000_000, 100_000_000, 1_000_000_000,
+	10_000_000_000, 100_000_000_000, 1_000_000_000_000, 10_000_000_000_000,
+	100_000_000_000_000, 1_000_000_000_000_000, 10_000_000_000_000_000,
+	100_000_000_000_000_000, 1_000_000_000_000_000_000,
+}
+
+// decimalThreshold rewrites "v op mantissa/10^scale" for mantissas v at colScale
+// as the equivalent integer comparison "v newOp threshold".
+// If the answer is the same for every v, ok is false and all holds that answer.
+func decimalThreshold(op Op, mantissa int64, scale, colScale int) (newOp Op, threshold int64, all, ok bool) {
+	switch {
+	case scale == colScale:
+		return op, mantissa, false, true
+
+	case scale < colScale:
+		// Scale the threshold up; if that overflows, it lies outside the int64 range
+		p := pow10Int64[colScale-scale]
+		if mantissa > math.MaxInt64/p || mantissa < math.MinInt64/p {
+			above := mantissa > 0
+			switch op {
+			case OpEq:
+				return op, 0, false, false
+			case OpNe:
+				return op, 0, true, false
+			case OpGt, OpGe:
+				return op, 0, !above, false
+			default:
+				return op, 0, above, false
+			}
+		}
+		return op, mantissa * p, false, true
+
+	default:
+		// Scale the threshold down; an inexact quotient lies strictly between q and q+1
+		p := pow10Int64[scale-colScale]
+		q, r := mantissa/p, mantissa%p
+		if r == 0 {
+			return op, q, false, true
+		}
+		if r < 0 {
+			q--
+		}
+		switch op {
+		case OpEq:
+			return op, 0, false, false
+		case OpNe:
+			return op, 0, true, false
+		case OpGt, OpGe:
+			return OpGt, q, false, true
+		default:
+			return OpLe, q, false, true
+		}
+	}
+}
+
+// constantBools returns n booleans all set to value.
+func constantBools(n int, value bool) []bool {
+	result := make([]bool, n)
+	if value {
+		for i := range result {
+			result[i] = true
+		}
+	}
+	return result
+}
+
+// constantMask returns a bitmask for n elements with every bit set to value.
+func constantMask(n int, value bool) []uint64 {
+	mask := make([]uint64, (n+63)/64)
+	if value {
+		for i := range mask {
+			mask[i] = ^uint64(0)
+		}
+		if n%64 != 0 {
+			mask[len(mask)-1] = 1<<(n%64) - 1
+		}
+	}
+	return mask
+}
+
+// decimalSumChunk and decimalSumLimit bound the chunks summed by the int64 kernel:
+// 4096 values of magnitude at most 2^50 cannot overflow an int64 accumulator.
+const (
+	decimalSumChunk = 4096
+	decimalSumLimit = 1 << 50
+)
+
+// sumDecimal64 sums mantissas into a 128-bit two's complement accumulator.
+// Chunks whose values are small enough go through the int64 sum kernel;
+// the others are accumulated one value at a time. The bounds are checked with
+// the comparison kernels, which are vectorized on every SIMD platform.
+func sumDecimal64(values []int64) (hi int64, lo uint64) {
+	ks := currentKernels()
+	for start := 0; start < len(values); start += decimalSumChunk {
+		chunk := values[start:min(start+decimalSumChunk, len(values))]
+		if !anyBitSet(cmpGtInt64MaskImpl(ks, chunk, decimalSumLimit)) && !anyBitSet(cmpLtInt64MaskImpl(ks, chunk, -decimalSumLimit)) {
+			hi, lo = add128(hi, lo, sumInt64Impl(ks, chunk))
+			continue
+		}
+		for _, v := range chunk {
+			hi, lo = add128(hi, lo, v)
+		}
+	}
+	return hi, lo
+}
+
+// anyBitSet reports whether any bit of mask is set.
+func anyBitSet(mask []uint64) bool {
+	for _, w := range mask {
+		if w != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// add128 adds the sign-extended v to the 128-bit value hi:lo.
+func add128(hi int64, lo uint64, v int64) (int64, uint64) {
+	lo, carry := bits.Add64(lo, uint64(v), 0)
+	return hi + v>>63 + int64(carry), lo
+}
+
+// roundDecimal64Generic rescales src from scale from to scale to into dst.
+// Reducing the scale rounds half away from zero; increasing it multiplies
+// and reports the index of the first value that overflows, or -1.
+func roundDecimal64Generic(dst, src []int64, from, to int) int {
+	if to >= from {
+		p := pow10Int64[to-from]
+		for i, v := range src {
+			if v > math.MaxInt64/p || v < math.MinInt64/p {
+				return i
+			}
+			dst[i] = v * p
+		}
+		return -1
+	}
+
+	p := pow10Int64[from-to]
+	half := p / 2
+	for i, v := range src {
+		q, r := v/p, v%p
+		if r >= half {
+			q++
+		} else if r <= -half {
+			q--
+		}
+		dst[i] = q
+	}
+	return -1
+}
@@ -0,0 +1,223 @@
+package syndrdbsimd
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// ============================================================================
+// Decimal64 Comparison Tests
+// ============================================================================
+
+// decimalReference compares mantissa/10^colScale against threshold/10^scale exactly.
+func decimalReference(v int64, colScale int, op Op, threshold int64, scale int) bool {
+	a := new(big.Int).Mul(big.NewInt(v), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))
+	b := new(big.Int).Mul(big.NewInt(threshold), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(colScale)), nil))
+	c := a.Cmp(b)
+	switch op {
+	case OpEq:
+		return c == 0
+	case OpNe:
+		return c != 0
+	case OpGt:
+		return c > 0
+	case OpLt:
+		return c < 0
+	case OpGe:
+		return c >= 0
+	default:
+		return c <= 0
+	}
+}
+
+func TestDecimal64Compare_HappyPath(t *testing.T) {
+	// 1.00, 1.25, 1.30, -0.50 at scale 2
+	col := Decimal64{Mantissas: []int64{100, 125, 130, -50}, Scale: 2}
+
+	checkEqual(t, 4, "Eq 1.3", col.Compare(OpEq, 13, 1), []bool{false, false, true, false})
+	checkEqual(t, 4, "Gt 1.25", col.Compare(OpGt, 1250, 3), []bool{false, false, true, false})
+	checkEqual(t, 4, "Lt 1.249", col.Compare(OpLt, 1249, 3), []bool{true, false, false, true})
+	checkEqual(t, 4, "Ge 1", col.Compare(OpGe, 1, 0), []bool{true, true, true, false})
+	checkEqual(t, 4, "Eq 1.001", col.Compare(OpEq, 1001, 3), []bool{false, false, false, false})
+	checkEqual(t, 4, "Ne 1.001", col.CompareMask(OpNe, 1001, 3), []uint64{0b1111})
+}
+
+// Every operator and scale combination must match an exact big.Int comparison,
+// including thresholds that overflow when rescaled to the column scale
+func TestDecimal64Compare_MatchesReference(t *testing.T) {
+	rng := rand.New(rand.NewSource(35))
+	values := make([]int64, 300)
+	for i := range values {
+		values[i] = rng.Int63n(20001) - 10000
+	}
+	values[10], values[11] = math.MaxInt64, math.MinInt64
+
+	thresholds := []int64{0, 5, -5, 1234, -9999, 10000, math.MaxInt64, math.MinInt64, math.MinInt64 + 1}
+	for _, colScale := range []int{0, 2, 18} {
+		col := Decimal64{Mantissas: values, Scale: colScale}
+		for _, scale := range []int{0, 1, 2, 3, 18} {
+			for _, threshold := range thresholds {
+				for _, op := range allOps {
+					expected := make([]bool, len(values))
+					for i, v := range values {
+						expected[i] = decimalReference(v, colScale, op, threshold, scale)
+					}
+					name := op.String()
+					checkEqual(t, len(values), name, col.Compare(op, threshold, scale), expected)
+					checkEqual(t, len(values), name+" mask", col.CompareMask(op, threshold, scale), BoolsToBitmask(expected))
+				}
+			}
+		}
+	}
+}
+
+func TestDecimal64Compare_InvalidScale(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for scale 19")
+		}
+	}()
+	Decimal64{Mantissas: []int64{1}, Scale: 2}.Compare(OpEq, 1, MaxDecimalScale+1)
+}
+
+// ============================================================================
+// Decimal64 Aggregation Tests
+// ============================================================================
+
+func TestDecimal64Sum_SmallValues(t *testing.T) {
+	values := make([]int64, 10000)
+	expected := int64(0)
+	for i := range values {
+		values[i] = int64(i*37) - 150000
+		expected += values[i]
+	}
+
+	sum := Decimal64{Mantissas: values, Scale: 2}.Sum()
+	if got, ok := sum.Mantissa64(); !ok || got != expected {
+		t.Errorf("Expected %d, got %d (fits=%v)", expected, got, ok)
+	}
+	if sum.Scale != 2 {
+		t.Errorf("Expected scale 2, got %d", sum.Scale)
+	}
+}
+
+// Sums beyond the int64 range are exact in 128 bits
+func TestDecimal64Sum_Overflow128(t *testing.T) {
+	values := make([]int64, 5000)
+	for i := range values {
+		values[i] = math.MaxInt64
+	}
+	values[4321] = 7
+
+	expected := new(big.Int).Mul(big.NewInt(math.MaxInt64), big.NewInt(4999))
+	expected.Add(expected, big.NewInt(7))
+
+	sum := Decimal64{Mantissas: values}.Sum()
+	if sum.BigMantissa().Cmp(expected) != 0 {
+		t.Errorf("Expected %s, got %s", expected, sum.BigMantissa())
+	}
+	if _, ok := sum.Mantissa64(); ok {
+		t.Error("Expected mantissa not to fit in int64")
+	}
+
+	for i := range values {
+		values[i] = math.MinInt64
+	}
+	expected.Mul(big.NewInt(math.MinInt64), big.NewInt(5000))
+	if got := (Decimal64{Mantissas: values}).Sum().BigMantissa(); got.Cmp(expected) != 0 {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+func TestDecimal128_Format(t *testing.T) {
+	tests := []struct {
+		mantissa int64
+		scale    int
+		expected string
+	}{
+		{1234, 2, "12.34"},
+		{-1250, 2, "-12.50"},
+		{5, 3, "0.005"},
+		{-5, 1, "-0.5"},
+		{0, 2, "0.00"},
+		{42, 0, "42"},
+	}
+
+	for _, tt := range tests {
+		d := Decimal64{Mantissas: []int64{tt.mantissa}, Scale: tt.scale}.Sum()
+		if got := d.String(); got != tt.expected {
+			t.Errorf("String(%d, %d) = %q, want %q", tt.mantissa, tt.scale, got, tt.expected)
+		}
+	}
+
+	if got := (Decimal128{Lo: 1234, Scale: 2}).Float64(); got != 12.34 {
+		t.Errorf("Float64: expected 12.34, got %v", got)
+	}
+}
+
+// ============================================================================
+// Decimal64 Rounding Tests
+// ============================================================================
+
+func TestDecimal64Round_HalfAwayFromZero(t *testing.T) {
+	// 1.25, -1.25, 1.24, -1.26, 0.05, -0.04 at scale 2
+	col := Decimal64{Mantissas: []int64{125, -125, 124, -126, 5, -4}, Scale: 2}
+
+	rounded, err := col.Round(1)
+	if err != nil {
+		t.Fatalf("Round failed: %v", err)
+	}
+	checkEqual(t, 6, "Round(1)", rounded.Mantissas, []int64{13, -13, 12, -13, 1, 0})
+	if rounded.Scale != 1 {
+		t.Errorf("Expected scale 1, got %d", rounded.Scale)
+	}
+
+	rounded, _ = col.Round(0)
+	checkEqual(t, 6, "Round(0)", rounded.Mantissas, []int64{1, -1, 1, -1, 0, 0})
+
+	// The source column is unchanged
+	checkEqual(t, 6, "source", col.Mantissas, []int64{125, -125, 124, -126, 5, -4})
+}
+
+func TestDecimal64Round_Extremes(t *testing.T) {
+	col := Decimal64{Mantissas: []int64{math.MaxInt64, math.MinInt64}, Scale: 18}
+	rounded, err := col.Round(0)
+	if err != nil {
+		t.Fatalf("Round failed: %v", err)
+	}
+	checkEqual(t, 2, "Round(0)", rounded.Mantissas, []int64{9, -9})
+}
+
+func TestDecimal64Round_IncreaseScale(t *testing.T) {
+	col := Decimal64{Mantissas: []int64{125, -3}, Scale: 2}
+	rounded, err := col.Round(5)
+	if err != nil {
+		t.Fatalf("Round failed: %v", err)
+	}
+	checkEqual(t, 2, "Round(5)", rounded.Mantissas, []int64{125000, -3000})
+
+	col.Mantissas = []int64{1, math.MaxInt64 / 10}
+	if _, err := col.Round(4); !errors.Is(err, ErrDecimalOverflow) {
+		t.Errorf("Expected ErrDecimalOverflow, got %v", err)
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+func BenchmarkDecimal64Sum(b *testing.B) {
+	values := make([]int64, 4096)
+	for i := range values {
+		values[i] = int64(i * 1999)
+	}
+	col := Decimal64{Mantissas: values, Scale: 2}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		col.Sum()
+	}
+}
@@ -0,0 +1,245 @@
+package syndrdbsimd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ISA identifies the instruction set a kernel is implemented with.
+//
+// ISA levels are used both to report which implementation an operation dispatches
+// to (see ActiveKernels) and to cap kernel selection for debugging or A/B
+// benchmarking, for the whole process (see SetISA and WithGlobalISA) or for the
+// calls made through a Kernels value (see NewKernels).
+type ISA int
+
+const (
+	// ISAGeneric is the portable scalar Go implementation, available everywhere.
+	ISAGeneric ISA = iota
+
+	// ISASSE42 caps x86-64 dispatch at SSE4.2. No kernels are specialised for
+	// SSE4.2 yet, so every operation runs its generic implementation.
+	ISASSE42
+
+	// ISAAVX2 selects the 256-bit AVX2 kernels on x86-64.
+	ISAAVX2
+
+	// ISAAVX512 selects the 512-bit AVX-512 kernels on x86-64, falling back to AVX2
+	// for operations without an AVX-512 kernel or whose extensions are missing.
+	ISAAVX512
+
+	// ISANEON selects the fixed-width 128-bit NEON kernels on ARM64.
+	ISANEON
+
+	// ISASVE selects the vector-length-agnostic SVE kernels on ARM64, falling back
+	// to NEON for operations without an SVE kernel.
+	ISASVE
+)
+
+// String returns a human-readable representation of the ISA.
+func (isa ISA) String() string {
+	switch isa {
+	case ISAGeneric:
+		return "Generic"
+	case ISASSE42:
+		return "SSE4.2"
+	case ISAAVX2:
+		return "AVX2"
+	case ISAAVX512:
+		return "AVX-512"
+	case ISANEON:
+		return "NEON"
+	case ISASVE:
+		return "SVE"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(isa))
+	}
+}
+
+// ErrISANotSupported is returned by SetISA, WithGlobalISA and NewKernels when the
+// requested ISA cannot run on this CPU.
+var ErrISANotSupported = errors.New("ISA not supported on this CPU")
+
+// KernelInfo describes the implementation an operation currently dispatches to.
+type KernelInfo struct {
+	Operation string // Operation group, e.g. "CmpInt64" or "PopCount"
+	ISA       ISA    // Instruction set of the kernel that will run
+}
+
+// Operation groups reported by ActiveKernels, in report order.
+// Every architecture reports the same groups so reports can be compared across machines.
+var kernelOperations = []string{
+	"CmpInt64",
+	"CmpFloat64",
+	"NarrowInt",
+	"Float32",
+	"Arith",
+	"Bitmap",
+	"PopCount",
+	"SumInt64",
+	"MinMaxInt64",
+	"MinMaxFloat64",
+	"CountNonNull",
+	"HashInt64",
+	"CRC32Int64",
+	"XXHash64",
+	"Compress",
+	"Gather",
+	"Scatter",
+	"String",
+}
+
+// isaMu guards the kernel selection state below. It is never held while
+// caller code runs.
+var isaMu sync.Mutex
+
+var (
+	// isaCap is the cap set by SetISA; isaCapped is false under automatic selection.
+	isaCap    ISA
+	isaCapped bool
+
+	// isaOverrides holds the WithGlobalISA calls still running, oldest first.
+	// The newest one decides the selection until it returns.
+	isaOverrides []*ISA
+)
+
+// applyISA recomputes the kernel selection from the state above. isaMu must be held.
+func applyISA() {
+	switch {
+	case len(isaOverrides) > 0:
+		capKernels(*isaOverrides[len(isaOverrides)-1])
+	case isaCapped:
+		capKernels(isaCap)
+	default:
+		resetKernels()
+	}
+}
+
+// SetISA caps kernel selection at isa for all subsequent calls from every goroutine.
+// Operations without a kernel for isa use the best lower level available, so
+// SetISA(ISAAVX2) on an AVX-512 machine runs the AVX2 kernels and
+// SetISA(ISAGeneric) runs the scalar implementations everywhere.
+// While a WithGlobalISA call is running, its ISA takes precedence until it returns.
+//
+// Returns an error wrapping ErrISANotSupported if this CPU cannot execute isa;
+// the current selection is left unchanged in that case.
+//
+// This function is thread-safe, but it is intended for debugging and benchmarking;
+// production code should rely on automatic selection.
+func SetISA(isa ISA) error {
+	if !isaSupported(isa) {
+		return fmt.Errorf("%w: %s", ErrISANotSupported, isa)
+	}
+
+	isaMu.Lock()
+	defer isaMu.Unlock()
+	isaCap, isaCapped = isa, true
+	applyISA()
+	return nil
+}
+
+// ResetISA restores automatic kernel selection based on the detected CPU features,
+// undoing SetISA. Running WithGlobalISA calls keep their ISA until they return.
+func ResetISA() {
+	isaMu.Lock()
+	defer isaMu.Unlock()
+	isaCapped = false
+	applyISA()
+}
+
+// WithGlobalISA caps kernel selection at isa for every goroutine while fn runs,
+// then restores the selection made through SetISA and ResetISA. It is meant for
+// tests and benchmarks that compare implementations:
+//
+//	err := syndrdbsimd.WithGlobalISA(syndrdbsimd.ISAAVX2, func() {
+//		mask = syndrdbsimd.CmpGtInt64Mask(values, 100)
+//	})
+//
+// The override is not scoped to fn's own calls: other goroutines calling into
+// the package while fn runs use isa as well. Overlapping calls nest, with the
+// most recently started one in effect, and fn may call SetISA, ResetISA or
+// WithGlobalISA itself. To override one caller without affecting the rest of
+// the process, use a Kernels value from NewKernels instead.
+//
+// Returns an error wrapping ErrISANotSupported (without calling fn) if this CPU
+// cannot execute isa.
+func WithGlobalISA(isa ISA, fn func()) error {
+	if !isaSupported(isa) {
+		return fmt.Errorf("%w: %s", ErrISANotSupported, isa)
+	}
+
+	override := &isa
+	isaMu.Lock()
+	isaOverrides = append(isaOverrides, override)
+	applyISA()
+	isaMu.Unlock()
+
+	defer func() {
+		isaMu.Lock()
+		defer isaMu.Unlock()
+		isaOverrides = slices.DeleteFunc(isaOverrides, func(o *ISA) bool { return o == override })
+		applyISA()
+	}()
+
+	fn()
+	return nil
+}
+
+// ActiveKernels reports which implementation each operation group will use on
+// this CPU under the current selection, in a fixed order.
+func ActiveKernels() []KernelInfo {
+	return Kernels{}.ActiveKernels()
+}
+
+// Kernels is a kernel selection that travels with the call instead of living in
+// the package. Its methods mirror the package-level functions in api.go and
+// return the same results, but dispatch on the selection k was created with, so
+// one goroutine can run the AVX2 kernels while others keep the automatic
+// selection:
+//
+//	avx2, err := syndrdbsimd.NewKernels(syndrdbsimd.ISAAVX2)
+//	if err != nil {
+//		return err
+//	}
+//	mask := avx2.CmpGtInt64Mask(values, 100)
+//
+// The zero Kernels follows the package-wide selection of SetISA, ResetISA and
+// WithGlobalISA, which is what the package-level functions use. Kernels values
+// are immutable and safe for concurrent use. Higher-level types such as
+// PackedStrings, Int64Column and CompiledRegex always use the package-wide
+// selection.
+type Kernels struct {
+	set *kernelSet // nil for the package-wide selection
+}
+
+// NewKernels returns a Kernels capped at isa: operations run the kernels of isa,
+// or of the best lower level where isa has none, whatever SetISA or
+// WithGlobalISA select for the rest of the process.
+//
+// Returns an error wrapping ErrISANotSupported if this CPU cannot execute isa.
+func NewKernels(isa ISA) (Kernels, error) {
+	if !isaSupported(isa) {
+		return Kernels{}, fmt.Errorf("%w: %s", ErrISANotSupported, isa)
+	}
+	return Kernels{set: cappedKernels(isa)}, nil
+}
+
+func (k Kernels) kernels() *kernelSet {
+	if k.set == nil {
+		return currentKernels()
+	}
+	return k.set
+}
+
+// ActiveKernels reports which implementation each operation group dispatches to
+// under k, in the order of the package-level ActiveKernels.
+func (k Kernels) ActiveKernels() []KernelInfo {
+	ks := k.kernels()
+	kernels := make([]KernelInfo, len(kernelOperations))
+	for i, op := range kernelOperations {
+		kernels[i] = KernelInfo{Operation: op, ISA: kernelISA(ks, op)}
+	}
+	return kernels
+}
@@ -0,0 +1,264 @@
+package syndrdbsimd
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// ============================================================================
+// ISA Override Tests
+// ============================================================================
+
+func TestISA_String(t *testing.T) {
+	tests := []struct {
+		isa      ISA
+		expected string
+	}{
+		{ISAGeneric, "Generic"},
+		{ISASSE42, "SSE4.2"},
+		{ISAAVX2, "AVX2"},
+		{ISAAVX512, "AVX-512"},
+		{ISANEON, "NEON"},
+		{ISASVE, "SVE"},
+		{ISA(99), "Unknown(99)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.isa.String(); got != tt.expected {
+			t.Errorf("ISA(%d).String() = %q, want %q", int(tt.isa), got, tt.expected)
+		}
+	}
+}
+
+func TestActiveKernels_ReportsEveryOperation(t *testing.T) {
+	kernels := ActiveKernels()
+	if len(kernels) != len(kernelOperations) {
+		t.Fatalf("Expected %d kernels, got %d", len(kernelOperations), len(kernels))
+	}
+	for i, k := range kernels {
+		if k.Operation != kernelOperations[i] {
+			t.Errorf("kernels[%d]: expected %s, got %s", i, kernelOperations[i], k.Operation)
+		}
+		if !isaSupported(k.ISA) {
+			t.Errorf("%s reports %s, which this CPU cannot run", k.Operation, k.ISA)
+		}
+	}
+}
+
+func TestSetISA_Generic(t *testing.T) {
+	t.Cleanup(ResetISA)
+
+	if err := SetISA(ISAGeneric); err != nil {
+		t.Fatalf("SetISA(ISAGeneric) failed: %v", err)
+	}
+	for _, k := range ActiveKernels() {
+		if k.ISA != ISAGeneric {
+			t.Errorf("%s: expected Generic after SetISA(ISAGeneric), got %s", k.Operation, k.ISA)
+		}
+	}
+
+	values := []int64{5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55, 60, 65, 70, 75, 80, 85}
+	checkEqual(t, len(values), "CmpGtInt64", CmpGtInt64(values, 42), cmpGtInt64Generic(values, 42))
+}
+
+func TestSetISA_Unsupported(t *testing.T) {
+	t.Cleanup(ResetISA)
+	before := ActiveKernels()
+
+	for _, isa := range []ISA{ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE, ISA(99)} {
+		if isaSupported(isa) {
+			continue
+		}
+		err := SetISA(isa)
+		if !errors.Is(err, ErrISANotSupported) {
+			t.Errorf("SetISA(%s): expected ErrISANotSupported, got %v", isa, err)
+		}
+
+		called := false
+		err = WithGlobalISA(isa, func() { called = true })
+		if !errors.Is(err, ErrISANotSupported) {
+			t.Errorf("WithGlobalISA(%s): expected ErrISANotSupported, got %v", isa, err)
+		}
+		if called {
+			t.Errorf("WithGlobalISA(%s) called fn for an unsupported ISA", isa)
+		}
+
+		if _, err := NewKernels(isa); !errors.Is(err, ErrISANotSupported) {
+			t.Errorf("NewKernels(%s): expected ErrISANotSupported, got %v", isa, err)
+		}
+	}
+
+	// A rejected override must leave the selection unchanged
+	checkEqual(t, 0, "ActiveKernels", ActiveKernels(), before)
+}
+
+func TestResetISA_RestoresDetectedSelection(t *testing.T) {
+	t.Cleanup(ResetISA)
+	detected := ActiveKernels()
+
+	if err := SetISA(ISAGeneric); err != nil {
+		t.Fatalf("SetISA(ISAGeneric) failed: %v", err)
+	}
+	ResetISA()
+
+	checkEqual(t, 0, "ActiveKernels", ActiveKernels(), detected)
+}
+
+func TestWithGlobalISA_RestoresPreviousSelection(t *testing.T) {
+	before := ActiveKernels()
+
+	values := make([]int64, 1000)
+	for i := range values {
+		values[i] = int64(i % 37)
+	}
+
+	var inside []KernelInfo
+	var mask []uint64
+	err := WithGlobalISA(ISAGeneric, func() {
+		inside = ActiveKernels()
+		mask = CmpLtInt64Mask(values, 20)
+	})
+	if err != nil {
+		t.Fatalf("WithGlobalISA(ISAGeneric) failed: %v", err)
+	}
+
+	for _, k := range inside {
+		if k.ISA != ISAGeneric {
+			t.Errorf("%s: expected Generic inside WithGlobalISA, got %s", k.Operation, k.ISA)
+		}
+	}
+	checkEqual(t, len(values), "CmpLtInt64Mask", mask, CmpLtInt64Mask(values, 20))
+	checkEqual(t, 0, "ActiveKernels", ActiveKernels(), before)
+}
+
+// fn may change the selection itself; nested overrides unwind in order and
+// SetISA made inside fn outlasts it
+func TestWithGlobalISA_Nested(t *testing.T) {
+	defer ResetISA()
+	detected := ActiveKernels()
+
+	err := WithGlobalISA(ISAGeneric, func() {
+		if err := SetISA(ISAGeneric); err != nil {
+			t.Fatalf("SetISA inside WithGlobalISA failed: %v", err)
+		}
+		ResetISA()
+		for _, k := range ActiveKernels() {
+			if k.ISA != ISAGeneric {
+				t.Errorf("%s: expected Generic while the override runs, got %s", k.Operation, k.ISA)
+			}
+		}
+
+		if err := WithGlobalISA(ISAGeneric, func() {}); err != nil {
+			t.Fatalf("nested WithGlobalISA failed: %v", err)
+		}
+		for _, k := range ActiveKernels() {
+			if k.ISA != ISAGeneric {
+				t.Errorf("%s: expected Generic after the nested override, got %s", k.Operation, k.ISA)
+			}
+		}
+		if err := SetISA(ISAGeneric); err != nil {
+			t.Fatalf("SetISA inside WithGlobalISA failed: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("WithGlobalISA(ISAGeneric) failed: %v", err)
+	}
+
+	for _, k := range ActiveKernels() {
+		if k.ISA != ISAGeneric {
+			t.Errorf("%s: expected the SetISA cap to remain, got %s", k.Operation, k.ISA)
+		}
+	}
+	ResetISA()
+	checkEqual(t, 0, "ActiveKernels", ActiveKernels(), detected)
+}
+
+// Every supported ISA must produce the same results as the generic implementations
+func TestWithGlobalISA_SupportedLevelsMatchGeneric(t *testing.T) {
+	values := make([]int64, 257)
+	for i := range values {
+		values[i] = int64(i*7919%101) - 50
+	}
+	strs := [][]byte{
+		[]byte("the quick brown fox jumps over the lazy dog"),
+		[]byte("the quick brown fox jumps over the lazy cat"),
+		[]byte("short"),
+	}
+	for i := 0; i < 6; i++ {
+		strs = append(strs, strs...)
+	}
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			err := WithGlobalISA(isa, func() {
+				checkInt64Compares(t, len(values), values, 3)
+				if got, want := SumInt64(values), sumInt64Generic(values); got != want {
+					t.Errorf("SumInt64 = %d, want %d", got, want)
+				}
+				checkEqual(t, len(strs), "CmpEqString", cmpEqStringImpl(currentKernels(), strs, strs[0]), cmpEqStringGeneric(strs, strs[0]))
+			})
+			if err != nil {
+				t.Fatalf("WithGlobalISA(%s) failed: %v", isa, err)
+			}
+		})
+	}
+}
+
+// A Kernels value applies its ISA to the calls made through it only: a caller
+// of the package-level functions running at the same time keeps the detected
+// kernels
+func TestNewKernels_ConcurrentCallers(t *testing.T) {
+	detected := ActiveKernels()
+	values := make([]int64, 1000)
+	for i := range values {
+		values[i] = int64(i % 37)
+	}
+	want := cmpLtInt64MaskGeneric(values, 20)
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			k, err := NewKernels(isa)
+			if err != nil {
+				t.Fatalf("NewKernels(%s) failed: %v", isa, err)
+			}
+			for _, info := range k.ActiveKernels() {
+				if info.ISA > isa {
+					t.Errorf("%s: expected at most %s, got %s", info.Operation, isa, info.ISA)
+				}
+			}
+
+			stop := make(chan struct{})
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					if got := k.CmpLtInt64Mask(values, 20); !slices.Equal(got, want) {
+						t.Errorf("%s: CmpLtInt64Mask = %v, want %v", isa, got, want)
+						return
+					}
+				}
+			}()
+
+			for i := 0; i < 200; i++ {
+				checkEqual(t, 0, "ActiveKernels", ActiveKernels(), detected)
+				checkEqual(t, len(values), "CmpLtInt64Mask", CmpLtInt64Mask(values, 20), want)
+			}
+			close(stop)
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,360 @@
+package syndrdbsimd
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Expr is a boolean expression over columns, such as the WHERE clause of a query.
+// Build one from the predicate constructors (PredInt64, PredFloat64, PredString)
+// and the connectives And, Or and Not, then call Compile to get a Plan.
+//
+// Evaluating a compiled plan is equivalent to calling the Cmp*Mask function of
+// every predicate and combining the bitmaps with AndBitmap, OrBitmap and NotBitmap,
+// but it makes one pass over the rows in cache-sized chunks, reuses its scratch
+// bitmaps across calls, and skips predicates for rows whose result is already known.
+type Expr interface {
+	expr()
+}
+
+// predKind identifies the column type of a predicate.
+type predKind int
+
+const (
+	predInt64 predKind = iota
+	predFloat64
+	predString
+)
+
+type predExpr struct {
+	kind       predKind
+	op         Op
+	ints       []int64
+	floats     []float64
+	strs       []string
+	intValue   int64
+	floatValue float64
+	strValue   string
+}
+
+type andExpr struct{ children []Expr }
+type orExpr struct{ children []Expr }
+type notExpr struct{ child Expr }
+
+func (*predExpr) expr() {}
+func (*andExpr) expr()  {}
+func (*orExpr) expr()   {}
+func (*notExpr) expr()  {}
+
+// PredInt64 returns the predicate column[i] op value.
+func PredInt64(column []int64, op Op, value int64) Expr {
+	return &predExpr{kind: predInt64, op: op, ints: column, intValue: value}
+}
+
+// PredFloat64 returns the predicate column[i] op value.
+// NaN compares as in Go: every operator except OpNe is false.
+func PredFloat64(column []float64, op Op, value float64) Expr {
+	return &predExpr{kind: predFloat64, op: op, floats: column, floatValue: value}
+}
+
+// PredString returns the predicate column[i] op value, comparing strings
+// lexicographically by bytes as Go does.
+func PredString(column []string, op Op, value string) Expr {
+	return &predExpr{kind: predString, op: op, strs: column, strValue: value}
+}
+
+// And returns the conjunction of children. And() is true for every row.
+func And(children ...Expr) Expr {
+	return &andExpr{children: children}
+}
+
+// Or returns the disjunction of children. Or() is false for every row.
+func Or(children ...Expr) Expr {
+	return &orExpr{children: children}
+}
+
+// Not returns the negation of child.
+func Not(child Expr) Expr {
+	return &notExpr{child: child}
+}
+
+// exprChunkRows is the number of rows a plan evaluates at a time. A chunk's
+// bitmap is 64 words, so the scratch bitmaps of a deep tree stay in L1.
+const exprChunkRows = 4096
+
+// nodeKind identifies the operation of a compiled plan node.
+type nodeKind int
+
+const (
+	nodePred nodeKind = iota
+	nodeConst
+	nodeAnd
+	nodeOr
+	nodeNot
+)
+
+type planNode struct {
+	kind     nodeKind
+	pred     *predExpr   // nodePred
+	value    bool        // nodeConst
+	children []*planNode // nodeAnd, nodeOr, nodeNot
+}
+
+// Plan is a compiled Expr. A Plan is safe for concurrent use, and it reads the
+// columns on every evaluation, so it sees updates made to them in place.
+type Plan struct {
+	root    *planNode
+	rows    int
+	depth   int       // Number of scratch bitmaps needed per evaluation
+	scratch sync.Pool // Holds *[][]uint64 of depth chunk bitmaps
+}
+
+// Compile validates e and compiles it into a Plan.
+// Every column must have the same length, which becomes the plan's row count;
+// an expression without predicates has no rows.
+//
+// Nested And and Or nodes of the same kind are flattened, double negations are
+// removed, and And or Or nodes with a single child are replaced by the child.
+func Compile(e Expr) (*Plan, error) {
+	p := &Plan{rows: -1}
+	root, err := p.compile(e)
+	if err != nil {
+		return nil, err
+	}
+	p.root = root
+	p.rows = max(p.rows, 0)
+	p.depth = scratchDepth(root)
+	p.scratch.New = func() any {
+		const words = exprChunkRows / 64
+		buf := make([]uint64, p.depth*words)
+		levels := make([][]uint64, p.depth)
+		for i := range levels {
+			levels[i] = buf[i*words : (i+1)*words]
+		}
+		return &levels
+	}
+	return p, nil
+}
+
+func (p *Plan) compile(e Expr) (*planNode, error) {
+	switch e := e.(type) {
+	case *predExpr:
+		if e.op < OpEq || e.op > OpLe {
+			return nil, fmt.Errorf("invalid comparison operator: %v", e.op)
+		}
+		var n int
+		switch e.kind {
+		case predInt64:
+			n = len(e.ints)
+		case predFloat64:
+			n = len(e.floats)
+		default:
+			n = len(e.strs)
+		}
+		if p.rows >= 0 && n != p.rows {
+			return nil, fmt.Errorf("column length %d does not match %d", n, p.rows)
+		}
+		p.rows = n
+		return &planNode{kind: nodePred, pred: e}, nil
+
+	case *andExpr:
+		return p.compileList(nodeAnd, e.children)
+
+	case *orExpr:
+		return p.compileList(nodeOr, e.children)
+
+	case *notExpr:
+		child, err := p.compile(e.child)
+		if err != nil {
+			return nil, err
+		}
+		switch child.kind {
+		case nodeNot:
+			return child.children[0], nil
+		case nodeConst:
+			return &planNode{kind: nodeConst, value: !child.value}, nil
+		}
+		return &planNode{kind: nodeNot, children: []*planNode{child}}, nil
+
+	case nil:
+		return nil, fmt.Errorf("expression is nil")
+
+	default:
+		return nil, fmt.Errorf("unsupported expression type: %T", e)
+	}
+}
+
+// compileList compiles the children of an And or Or node.
+func (p *Plan) compileList(kind nodeKind, exprs []Expr) (*planNode, error) {
+	// The identity of And is true, that of Or is false
+	identity := kind == nodeAnd
+	n := &planNode{kind: kind}
+	for _, e := range exprs {
+		child, err := p.compile(e)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case n.kind == nodeConst:
+			// The result is already decided; later children are only validated
+		case child.kind == kind:
+			n.children = append(n.children, child.children...)
+		case child.kind == nodeConst && child.value == identity:
+			// Does not affect the result
+		case child.kind == nodeConst:
+			n = &planNode{kind: nodeConst, value: child.value}
+		default:
+			n.children = append(n.children, child)
+		}
+	}
+	switch {
+	case n.kind == nodeConst:
+		return n, nil
+	case len(n.children) == 0:
+		return &planNode{kind: nodeConst, value: identity}, nil
+	case len(n.children) == 1:
+		return n.children[0], nil
+	}
+	return n, nil
+}
+
+// scratchDepth returns the number of scratch bitmaps needed to evaluate n:
+// each And or Or node needs one for its children besides those of the children.
+func scratchDepth(n *planNode) int {
+	depth := 0
+	for _, c := range n.children {
+		depth = max(depth, scratchDepth(c))
+	}
+	if n.kind == nodeAnd || n.kind == nodeOr {
+		depth++
+	}
+	return depth
+}
+
+// Len returns the number of rows the plan evaluates.
+func (p *Plan) Len() int {
+	return p.rows
+}
+
+// Eval evaluates the plan and returns a bitmask where bit i is set if row i matches.
+func (p *Plan) Eval() []uint64 {
+	dst := make([]uint64, (p.rows+63)/64)
+	p.EvalInto(dst)
+	return dst
+}
+
+// EvalInto evaluates the plan into dst, which must hold at least (Len()+63)/64 words.
+// Bits past Len() in the last word are cleared; words after it are not touched.
+func (p *Plan) EvalInto(dst []uint64) {
+	words := (p.rows + 63) / 64
+	if len(dst) < words {
+		panic(fmt.Sprintf("syndrdbsimd: EvalInto destination holds %d words, need %d", len(dst), words))
+	}
+
+	scratch := p.scratch.Get().(*[][]uint64)
+	defer p.scratch.Put(scratch)
+
+	for row := 0; row < p.rows; row += exprChunkRows {
+		rows := min(exprChunkRows, p.rows-row)
+		w := row / 64
+		evalNode(p.root, row, rows, dst[w:w+(rows+63)/64], *scratch)
+	}
+}
+
+// evalNode writes the result of n for rows [row, row+rows) into dst, which holds
+// (rows+63)/64 words. row is a multiple of 64, so bit i of dst is row row+i.
+// scratch holds the bitmaps for the And and Or nodes at or below n.
+func evalNode(n *planNode, row, rows int, dst []uint64, scratch [][]uint64) {
+	switch n.kind {
+	case nodePred:
+		evalPred(n.pred, row, rows, dst)
+
+	case nodeConst:
+		fillMask(dst, rows, n.value)
+
+	case nodeNot:
+		evalNode(n.children[0], row, rows, dst, scratch)
+		for i := range dst {
+			dst[i] = ^dst[i]
+		}
+		if rows%64 != 0 {
+			dst[len(dst)-1] &= 1<<(rows%64) - 1
+		}
+
+	case nodeAnd, nodeOr:
+		evalNode(n.children[0], row, rows, dst, scratch[1:])
+		for _, c := range n.children[1:] {
+			if !evalCombine(n.kind, c, row, rows, dst, scratch[0][:len(dst)], scratch[1:]) {
+				break
+			}
+		}
+	}
+}
+
+// evalCombine folds child c into dst for an And or Or node. It evaluates c only
+// over the runs of words whose result is still open: words with a bit set for
+// And, words with a bit clear for Or. It returns false once no word is open.
+func evalCombine(kind nodeKind, c *planNode, row, rows int, dst, tmp []uint64, scratch [][]uint64) bool {
+	// settled is the value of a word that c can no longer change
+	settled := func(w int) uint64 {
+		if kind == nodeAnd {
+			return 0
+		}
+		if w == len(dst)-1 && rows%64 != 0 {
+			return 1<<(rows%64) - 1
+		}
+		return ^uint64(0)
+	}
+
+	open := false
+	for lo := 0; lo < len(dst); {
+		if dst[lo] == settled(lo) {
+			lo++
+			continue
+		}
+		hi := lo + 1
+		for hi < len(dst) && dst[hi] != settled(hi) {
+			hi++
+		}
+
+		evalNode(c, row+lo*64, min(hi*64, rows)-lo*64, tmp[lo:hi], scratch)
+		for w := lo; w < hi; w++ {
+			if kind == nodeAnd {
+				dst[w] &= tmp[w]
+			} else {
+				dst[w] |= tmp[w]
+			}
+			open = open || dst[w] != settled(w)
+		}
+		lo = hi
+	}
+	return open
+}
+
+// evalPred writes the bitmask of predicate e for rows [row, row+rows) into dst.
+func evalPred(e *predExpr, row, rows int, dst []uint64) {
+	ks := currentKernels()
+	switch e.kind {
+	case predInt64:
+		cmpInt64MaskIntoImpl(ks, e.ints[row:row+rows], e.op, e.intValue, dst)
+	case predFloat64:
+		cmpFloat64MaskIntoImpl(ks, e.floats[row:row+rows], e.op, e.floatValue, dst)
+	default:
+		clear(dst)
+		cmpNumberTail(e.strs[row:row+rows], e.op, e.strValue, dst, 0)
+	}
+}
+
+// fillMask sets the bits of dst for rows rows to value and clears the rest.
+func fillMask(dst []uint64, rows int, value bool) {
+	if !value {
+		clear(dst)
+		return
+	}
+	for i := range dst {
+		dst[i] = ^uint64(0)
+	}
+	if rows%64 != 0 {
+		dst[len(dst)-1] = 1<<(rows%64) - 1
+	}
+}
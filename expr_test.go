@@ -0,0 +1,314 @@
+package syndrdbsimd
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// ============================================================================
+// Expression Tests
+// ============================================================================
+
+func TestExpr_HappyPath(t *testing.T) {
+	ids := []int64{1, 2, 3, 4, 5, 6, 7, 8}
+	prices := []float64{9.5, 20, 31, 5, 100, 12, math.NaN(), 40}
+	names := []string{"apple", "pear", "fig", "kiwi", "plum", "apple", "lime", "date"}
+
+	// (id > 2 AND price < 50) OR name = 'apple', AND NOT id = 8
+	p, err := Compile(And(
+		Or(
+			And(PredInt64(ids, OpGt, 2), PredFloat64(prices, OpLt, 50)),
+			PredString(names, OpEq, "apple"),
+		),
+		Not(PredInt64(ids, OpEq, 8)),
+	))
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if p.Len() != 8 {
+		t.Errorf("Len() = %d, want 8", p.Len())
+	}
+
+	// Rows 0 and 5 match by name, rows 2, 3 and 5 by id and price; NaN never matches
+	want := uint64(1<<0 | 1<<2 | 1<<3 | 1<<5)
+	if got := p.Eval(); len(got) != 1 || got[0] != want {
+		t.Errorf("Eval() = %b, want %b", got, want)
+	}
+}
+
+func TestExpr_Constants(t *testing.T) {
+	col := make([]int64, 70)
+	tests := []struct {
+		name string
+		expr Expr
+		want []uint64
+	}{
+		{"empty and", And(), []uint64{}},
+		{"and with empty and", And(PredInt64(col, OpEq, 0), And()), []uint64{^uint64(0), 1<<6 - 1}},
+		{"or with empty or", Or(PredInt64(col, OpEq, 1), Or()), []uint64{0, 0}},
+		{"and with empty or", And(PredInt64(col, OpEq, 0), Or()), []uint64{0, 0}},
+		{"not empty or", Or(PredInt64(col, OpEq, 1), Not(Or())), []uint64{^uint64(0), 1<<6 - 1}},
+	}
+	for _, tt := range tests {
+		p, err := Compile(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: Compile failed: %v", tt.name, err)
+		}
+		checkEqual(t, p.Len(), tt.name, p.Eval(), tt.want)
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	a, b := make([]int64, 10), make([]float64, 11)
+	tests := []struct {
+		name string
+		expr Expr
+	}{
+		{"nil", nil},
+		{"nil child", And(PredInt64(a, OpEq, 0), nil)},
+		{"invalid op", PredInt64(a, Op(42), 0)},
+		{"length mismatch", Or(PredInt64(a, OpEq, 0), PredFloat64(b, OpEq, 0))},
+		{"length mismatch after constant", And(Or(), PredInt64(a, OpEq, 0), PredFloat64(b, OpEq, 0))},
+	}
+	for _, tt := range tests {
+		if p, err := Compile(tt.expr); err == nil {
+			t.Errorf("%s: Compile succeeded with %d rows, want error", tt.name, p.Len())
+		}
+	}
+}
+
+func TestCompile_Simplify(t *testing.T) {
+	col := make([]int64, 10)
+	x, y, z := PredInt64(col, OpEq, 1), PredInt64(col, OpEq, 2), PredInt64(col, OpEq, 3)
+
+	p, err := Compile(And(x, And(y, And(z)), Not(Not(x))))
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if p.root.kind != nodeAnd || len(p.root.children) != 4 || p.depth != 1 {
+		t.Errorf("root kind %d with %d children and depth %d, want a flat And of 4 with depth 1",
+			p.root.kind, len(p.root.children), p.depth)
+	}
+
+	p, err = Compile(Or(And(x), Or(y)))
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if p.root.kind != nodeOr || len(p.root.children) != 2 {
+		t.Errorf("root kind %d with %d children, want an Or of 2", p.root.kind, len(p.root.children))
+	}
+}
+
+// randomExpr builds a random tree over the given columns with the given depth.
+func randomExpr(rng *rand.Rand, depth int, ints []int64, floats []float64, strs []string) Expr {
+	if depth == 0 || rng.Intn(4) == 0 {
+		op := allOps[rng.Intn(len(allOps))]
+		switch rng.Intn(3) {
+		case 0:
+			return PredInt64(ints, op, int64(rng.Intn(20)-10))
+		case 1:
+			return PredFloat64(floats, op, float64(rng.Intn(20)-10)/2)
+		default:
+			return PredString(strs, op, strconv.Itoa(rng.Intn(20)))
+		}
+	}
+	switch rng.Intn(5) {
+	case 0:
+		return Not(randomExpr(rng, depth-1, ints, floats, strs))
+	default:
+		children := make([]Expr, rng.Intn(4))
+		for i := range children {
+			children[i] = randomExpr(rng, depth-1, ints, floats, strs)
+		}
+		if rng.Intn(2) == 0 {
+			return And(children...)
+		}
+		return Or(children...)
+	}
+}
+
+// referenceEval evaluates e with one CompareMask per predicate and the bitmap
+// functions, the way a caller would without Compile.
+func referenceEval(e Expr, rows int) []uint64 {
+	tail := func(mask []uint64) []uint64 {
+		if rows%64 != 0 {
+			mask[len(mask)-1] &= 1<<(rows%64) - 1
+		}
+		return mask
+	}
+	switch e := e.(type) {
+	case *predExpr:
+		switch e.kind {
+		case predInt64:
+			return CompareMask(e.ints, e.op, e.intValue)
+		case predFloat64:
+			return CompareMask(e.floats, e.op, e.floatValue)
+		default:
+			mask := make([]uint64, (rows+63)/64)
+			for i, v := range e.strs {
+				if Compare([]int{cmp.Compare(v, e.strValue)}, e.op, 0)[0] {
+					mask[i/64] |= 1 << uint(i%64)
+				}
+			}
+			return mask
+		}
+	case *andExpr:
+		result := constantMask(rows, true)
+		for _, c := range e.children {
+			result = AndBitmap(result, referenceEval(c, rows))
+		}
+		return result
+	case *orExpr:
+		result := constantMask(rows, false)
+		for _, c := range e.children {
+			result = OrBitmap(result, referenceEval(c, rows))
+		}
+		return result
+	case *notExpr:
+		return tail(NotBitmap(referenceEval(e.child, rows)))
+	}
+	panic("unknown expression")
+}
+
+// Every ISA must match the separate compare and bitmap calls, across chunk
+// boundaries and with the short-circuit paths taken
+func TestExpr_MatchReference(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+	cfg := DefaultConfig()
+	cfg.MinCmpInt64 = 1
+	cfg.MinCmpFloat64 = 1
+	if err := SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	sizes := []int{1, 3, 63, 64, 65, 130, 4095, 4096, 4097, 10000}
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(380))
+				for _, size := range sizes {
+					ints, floats, strs := make([]int64, size), make([]float64, size), make([]string, size)
+					for i := 0; i < size; i++ {
+						// Long runs of equal values make whole words settle early
+						v := rng.Intn(20) - 10
+						if i/512%2 == 1 {
+							v = -10
+						}
+						ints[i], floats[i], strs[i] = int64(v), float64(v)/2, strconv.Itoa(v+10)
+					}
+					floats[size/2] = math.NaN()
+
+					for trial := 0; trial < 30; trial++ {
+						// The predicate that never matches gives trees without predicates a row count
+						e := Or(randomExpr(rng, 4, ints, floats, strs), PredInt64(ints, OpGt, 100))
+						p, err := Compile(e)
+						if err != nil {
+							t.Fatalf("Compile failed: %v", err)
+						}
+						got := make([]uint64, (size+63)/64)
+						p.EvalInto(got)
+						checkEqual(t, size, "expression "+strconv.Itoa(trial), got, referenceEval(e, size))
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestPlan_EvalInto(t *testing.T) {
+	col := make([]int64, 100)
+	for i := range col {
+		col[i] = int64(i)
+	}
+	p, err := Compile(And(PredInt64(col, OpGe, 10), Not(PredInt64(col, OpGe, 90))))
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	// Stale bits are overwritten and extra words are left alone
+	dst := []uint64{^uint64(0), ^uint64(0), 7}
+	p.EvalInto(dst)
+	want := []uint64{^uint64(0) &^ (1<<10 - 1), 1<<(90-64) - 1, 7}
+	checkEqual(t, len(dst), "EvalInto", dst, want)
+
+	if allocs := testing.AllocsPerRun(100, func() { p.EvalInto(dst) }); allocs != 0 {
+		t.Errorf("EvalInto allocated %v times per run, want 0", allocs)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("EvalInto with a short destination did not panic")
+		}
+	}()
+	p.EvalInto(dst[:1])
+}
+
+func TestPlan_Concurrent(t *testing.T) {
+	col := make([]float64, 20000)
+	for i := range col {
+		col[i] = float64(i % 100)
+	}
+	p, err := Compile(Or(PredFloat64(col, OpLt, 10), And(PredFloat64(col, OpGt, 50), PredFloat64(col, OpNe, 70))))
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	want := referenceEval(Or(PredFloat64(col, OpLt, 10), And(PredFloat64(col, OpGt, 50), PredFloat64(col, OpNe, 70))), len(col))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				checkEqual(t, len(col), "concurrent Eval", p.Eval(), want)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+// benchmarkExprColumns returns the columns and expression used by the benchmarks:
+// a = 1 AND (b > 0.5 OR c < 10) over 64K rows, where a = 1 matches 1 row in 16.
+func benchmarkExprColumns() (Expr, []int64, []float64, []int64) {
+	const size = 65536
+	rng := rand.New(rand.NewSource(1))
+	a, b, c := make([]int64, size), make([]float64, size), make([]int64, size)
+	for i := range a {
+		a[i], b[i], c[i] = int64(rng.Intn(16)), rng.Float64(), int64(rng.Intn(100))
+	}
+	return And(PredInt64(a, OpEq, 1), Or(PredFloat64(b, OpGt, 0.5), PredInt64(c, OpLt, 10))), a, b, c
+}
+
+func BenchmarkPlanEvalInto(b *testing.B) {
+	e, a, _, _ := benchmarkExprColumns()
+	p, err := Compile(e)
+	if err != nil {
+		b.Fatalf("Compile failed: %v", err)
+	}
+	dst := make([]uint64, (len(a)+63)/64)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.EvalInto(dst)
+	}
+}
+
+func BenchmarkPlanSeparateCalls(b *testing.B) {
+	_, a, f, c := benchmarkExprColumns()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		AndBitmap(CmpEqInt64Mask(a, 1), OrBitmap(CmpGtFloat64Mask(f, 0.5), CmpLtInt64Mask(c, 10)))
+	}
+}
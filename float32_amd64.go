@@ -0,0 +1,39 @@
+//go:build amd64
+
+package syndrdbsimd
+
+// AVX2 kernels for float32 columns.
+// See float32_amd64.s for the NaN handling and length requirements.
+
+//go:noescape
+func cmpEqFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func cmpNeFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func cmpGtFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func cmpGeFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func cmpLtFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func cmpLeFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func sumFloat32AVX2(values *float32, length int) float64
+
+//go:noescape
+func minFloat32AVX2(values *float32, length int) float32
+
+//go:noescape
+func maxFloat32AVX2(values *float32, length int) float32
+
+//go:noescape
+func float32ToFloat64AVX2(dst *float64, src *float32, length int)
+
+//go:noescape
+func float64ToFloat32AVX2(dst *float32, src *float64, length int)
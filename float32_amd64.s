@@ -0,0 +1,203 @@
+#include "textflag.h"
+
+// ============================================================================
+// Float32 comparisons
+// ============================================================================
+//
+// Every compare kernel writes one bitmap word per 64 elements; length must be a
+// multiple of 64. VCMPPS compares 8 lanes and VMOVMSKPS packs them into 8 bits.
+//
+// VCMPPS predicates (result = values op threshold). Ordered predicates make
+// every comparison against NaN false, except NE which is unordered and true:
+//   0x00: EQ_OQ   0x04: NEQ_UQ   0x11: LT_OQ   0x12: LE_OQ   0x1D: GE_OQ   0x1E: GT_OQ
+
+// CMP_FLOAT32_MASK_STEP compares the 8 values at off(SI) and merges the resulting
+// 8-bit mask into BX at bit position shift.
+#define CMP_FLOAT32_MASK_STEP(pred, off, shift) \
+    VMOVUPS off(SI), Y1 \
+    VCMPPS  pred, Y0, Y1, Y1 \
+    VMOVMSKPS Y1, AX \
+    SHLQ    $shift, AX \
+    ORQ     AX, BX
+
+// CMP_FLOAT32_MASK_AVX2 defines a kernel with the signature
+// func(values *float32, threshold float32, mask *uint64, length int)
+#define CMP_FLOAT32_MASK_AVX2(name, pred) \
+TEXT name(SB), NOSPLIT, $0-32 \
+    MOVQ    values+0(FP), SI \
+    MOVQ    mask+16(FP), DI \
+    MOVQ    length+24(FP), CX \
+    SHRQ    $6, CX                      /* CX = number of bitmap words */ \
+    JZ      done \
+    VBROADCASTSS threshold+8(FP), Y0    /* Y0 = threshold in all 8 lanes */ \
+loop: \
+    XORQ    BX, BX \
+    CMP_FLOAT32_MASK_STEP(pred, 0, 0) \
+    CMP_FLOAT32_MASK_STEP(pred, 32, 8) \
+    CMP_FLOAT32_MASK_STEP(pred, 64, 16) \
+    CMP_FLOAT32_MASK_STEP(pred, 96, 24) \
+    CMP_FLOAT32_MASK_STEP(pred, 128, 32) \
+    CMP_FLOAT32_MASK_STEP(pred, 160, 40) \
+    CMP_FLOAT32_MASK_STEP(pred, 192, 48) \
+    CMP_FLOAT32_MASK_STEP(pred, 224, 56) \
+    MOVQ    BX, (DI) \
+    ADDQ    $256, SI \
+    ADDQ    $8, DI \
+    DECQ    CX \
+    JNZ     loop \
+    VZEROUPPER \
+done: \
+    RET
+
+// func cmpEqFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+CMP_FLOAT32_MASK_AVX2(·cmpEqFloat32MaskAVX2, $0x00)
+
+// func cmpNeFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+CMP_FLOAT32_MASK_AVX2(·cmpNeFloat32MaskAVX2, $0x04)
+
+// func cmpGtFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+CMP_FLOAT32_MASK_AVX2(·cmpGtFloat32MaskAVX2, $0x1E)
+
+// func cmpGeFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+CMP_FLOAT32_MASK_AVX2(·cmpGeFloat32MaskAVX2, $0x1D)
+
+// func cmpLtFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+CMP_FLOAT32_MASK_AVX2(·cmpLtFloat32MaskAVX2, $0x11)
+
+// func cmpLeFloat32MaskAVX2(values *float32, threshold float32, mask *uint64, length int)
+CMP_FLOAT32_MASK_AVX2(·cmpLeFloat32MaskAVX2, $0x12)
+
+// ============================================================================
+// Float32 aggregates
+// ============================================================================
+
+// func sumFloat32AVX2(values *float32, length int) float64
+//
+// Widens 16 float32 values per iteration to float64 with VCVTPS2PD and adds them
+// into two accumulators of 4 lanes each. length must be a multiple of 16.
+TEXT ·sumFloat32AVX2(SB), NOSPLIT, $0-24
+    MOVQ    values+0(FP), SI
+    MOVQ    length+8(FP), CX
+    VXORPD  Y0, Y0, Y0                  // Y0, Y1 = per-lane float64 sums
+    VXORPD  Y1, Y1, Y1
+    SHRQ    $4, CX
+    JZ      reduce
+
+loop:
+    VCVTPS2PD (SI), Y2                  // values[0:4] as float64
+    VCVTPS2PD 16(SI), Y3                // values[4:8]
+    VADDPD  Y2, Y0, Y0
+    VADDPD  Y3, Y1, Y1
+    VCVTPS2PD 32(SI), Y2                // values[8:12]
+    VCVTPS2PD 48(SI), Y3                // values[12:16]
+    VADDPD  Y2, Y0, Y0
+    VADDPD  Y3, Y1, Y1
+    ADDQ    $64, SI
+    DECQ    CX
+    JNZ     loop
+
+reduce:
+    VADDPD  Y1, Y0, Y0
+    VEXTRACTF128 $1, Y0, X1
+    VADDPD  X1, X0, X0
+    VPERMILPD $1, X0, X1                // Swap the two remaining lanes
+    VADDSD  X1, X0, X0
+    VZEROUPPER
+    MOVSD   X0, ret+16(FP)
+    RET
+
+// MINMAX_FLOAT32_AVX2 defines a kernel with the signature
+// func(values *float32, length int) float32
+// that reduces length values (a multiple of 16) with op, starting from identity.
+//
+// VMINPS and VMAXPS return their second source operand when either input is NaN.
+// The accumulators are passed as that operand, so NaN values are skipped and the
+// accumulators never hold NaN.
+#define MINMAX_FLOAT32_AVX2(name, op, identity) \
+TEXT name(SB), NOSPLIT, $0-20 \
+    MOVQ    values+0(FP), SI \
+    MOVQ    length+8(FP), CX \
+    MOVL    identity, AX \
+    MOVD    AX, X0 \
+    VBROADCASTSS X0, Y0                 /* Y0, Y1 = per-lane results */ \
+    VMOVAPS Y0, Y1 \
+    SHRQ    $4, CX \
+    JZ      reduce \
+loop: \
+    VMOVUPS (SI), Y2 \
+    VMOVUPS 32(SI), Y3 \
+    op      Y0, Y2, Y0                  /* Y0 = Y2 op Y0, keeping Y0 for NaN lanes */ \
+    op      Y1, Y3, Y1 \
+    ADDQ    $64, SI \
+    DECQ    CX \
+    JNZ     loop \
+reduce: \
+    op      Y1, Y0, Y0 \
+    VEXTRACTF128 $1, Y0, X1 \
+    op      X1, X0, X0 \
+    VPERMILPS $0x4E, X0, X1             /* Swap 64-bit halves */ \
+    op      X1, X0, X0 \
+    VPERMILPS $0xB1, X0, X1             /* Swap adjacent lanes */ \
+    op      X1, X0, X0 \
+    VZEROUPPER \
+    MOVSS   X0, ret+16(FP) \
+    RET
+
+// func minFloat32AVX2(values *float32, length int) float32
+MINMAX_FLOAT32_AVX2(·minFloat32AVX2, VMINPS, $0x7F800000)
+
+// func maxFloat32AVX2(values *float32, length int) float32
+MINMAX_FLOAT32_AVX2(·maxFloat32AVX2, VMAXPS, $0xFF800000)
+
+// ============================================================================
+// Float32 conversions
+// ============================================================================
+
+// func float32ToFloat64AVX2(dst *float64, src *float32, length int)
+//
+// Widens 8 values per iteration. length must be a multiple of 8.
+TEXT ·float32ToFloat64AVX2(SB), NOSPLIT, $0-24
+    MOVQ    dst+0(FP), DI
+    MOVQ    src+8(FP), SI
+    MOVQ    length+16(FP), CX
+    SHRQ    $3, CX
+    JZ      done
+
+loop:
+    VCVTPS2PD (SI), Y0
+    VCVTPS2PD 16(SI), Y1
+    VMOVUPD Y0, (DI)
+    VMOVUPD Y1, 32(DI)
+    ADDQ    $32, SI
+    ADDQ    $64, DI
+    DECQ    CX
+    JNZ     loop
+
+    VZEROUPPER
+done:
+    RET
+
+// func float64ToFloat32AVX2(dst *float32, src *float64, length int)
+//
+// Narrows 8 values per iteration, rounding to nearest even like Go's float32
+// conversion. length must be a multiple of 8.
+TEXT ·float64ToFloat32AVX2(SB), NOSPLIT, $0-24
+    MOVQ    dst+0(FP), DI
+    MOVQ    src+8(FP), SI
+    MOVQ    length+16(FP), CX
+    SHRQ    $3, CX
+    JZ      done
+
+loop:
+    VCVTPD2PSY (SI), X0
+    VCVTPD2PSY 32(SI), X1
+    VMOVUPS X0, (DI)
+    VMOVUPS X1, 16(DI)
+    ADDQ    $64, SI
+    ADDQ    $32, DI
+    DECQ    CX
+    JNZ     loop
+
+    VZEROUPPER
+done:
+    RET
@@ -0,0 +1,36 @@
+//go:build arm64
+
+package syndrdbsimd
+
+// NEON kernels for float32 columns.
+// See float32_arm64.s for the NaN handling and length requirements.
+
+//go:noescape
+func cmpEqFloat32MaskNEON(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func cmpGtFloat32MaskNEON(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func cmpGeFloat32MaskNEON(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func cmpLtFloat32MaskNEON(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func cmpLeFloat32MaskNEON(values *float32, threshold float32, mask *uint64, length int)
+
+//go:noescape
+func sumFloat32NEON(values *float32, length int) float64
+
+//go:noescape
+func minFloat32NEON(values *float32, length int) float32
+
+//go:noescape
+func maxFloat32NEON(values *float32, length int) float32
+
+//go:noescape
+func float32ToFloat64NEON(dst *float64, src *float32, length int)
+
+//go:noescape
+func float64ToFloat32NEON(dst *float32, src *float64, length int)
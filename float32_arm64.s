@@ -0,0 +1,243 @@
+#include "textflag.h"
+
+// Vector floating-point instructions (FCMxx, FADD, FMINNM/FMAXNM, FCVTL/FCVTN)
+// are emitted as WORD directives because older Go ARM64 assemblers have no
+// mnemonics for them. Each is annotated with its disassembly.
+
+// ============================================================================
+// Float32 comparisons
+// ============================================================================
+//
+// Every compare kernel writes one bitmap word per 64 elements; length must be a
+// multiple of 64. There is no FCMNE, so the Go side derives != by inverting the
+// words of the == kernel. Ordered compares make every comparison against NaN false.
+//
+// Each step compares 16 values in V1-V4 against the threshold in V0. The lanes
+// of each all-ones/all-zeros result are ANDed with distinct bit weights
+// (V20: 1,2,4,8  V21: 16..128  V22: 256..2048  V23: 4096..32768), ORed together
+// and summed across lanes into a 16-bit mask.
+//
+// Register usage:
+//   V0: Broadcast threshold, V1-V4: Values being compared, V20-V23: Bit weights
+//   R0: values, R1: mask, R2: remaining bitmap words, R3: current word
+
+// CMP_FLOAT32_MASK_STEP compares the next 16 values with the compare
+// instructions c1-c4 (one per register V1-V4) and merges the 16-bit
+// mask into R3 at bit position shift.
+#define CMP_FLOAT32_MASK_STEP(c1, c2, c3, c4, shift) \
+    VLD1.P  64(R0), [V1.S4, V2.S4, V3.S4, V4.S4] \
+    WORD    c1 \
+    WORD    c2 \
+    WORD    c3 \
+    WORD    c4 \
+    VAND    V20.B16, V1.B16, V1.B16 \
+    VAND    V21.B16, V2.B16, V2.B16 \
+    VAND    V22.B16, V3.B16, V3.B16 \
+    VAND    V23.B16, V4.B16, V4.B16 \
+    VORR    V2.B16, V1.B16, V1.B16 \
+    VORR    V4.B16, V3.B16, V3.B16 \
+    VORR    V3.B16, V1.B16, V1.B16 \
+    VADDV   V1.S4, V1 \
+    VMOV    V1.S[0], R5 \
+    LSL     $shift, R5, R5 \
+    ORR     R5, R3, R3
+
+// CMP_FLOAT32_MASK_NEON defines a kernel with the signature
+// func(values *float32, threshold float32, mask *uint64, length int)
+#define CMP_FLOAT32_MASK_NEON(name, c1, c2, c3, c4) \
+TEXT name(SB), NOSPLIT, $0-32 \
+    MOVD    values+0(FP), R0 \
+    MOVD    mask+16(FP), R1 \
+    MOVD    length+24(FP), R2 \
+    LSR     $6, R2, R2                  /* R2 = number of bitmap words */ \
+    CBZ     R2, done \
+    FMOVS   threshold+8(FP), F0 \
+    VDUP    V0.S[0], V0.S4              /* V0 = threshold in all 4 lanes */ \
+    MOVD    $0x0000000200000001, R4 \
+    VMOV    R4, V20.D[0] \
+    MOVD    $0x0000000800000004, R4 \
+    VMOV    R4, V20.D[1] \
+    MOVD    $0x0000002000000010, R4 \
+    VMOV    R4, V21.D[0] \
+    MOVD    $0x0000008000000040, R4 \
+    VMOV    R4, V21.D[1] \
+    MOVD    $0x0000020000000100, R4 \
+    VMOV    R4, V22.D[0] \
+    MOVD    $0x0000080000000400, R4 \
+    VMOV    R4, V22.D[1] \
+    MOVD    $0x0000200000001000, R4 \
+    VMOV    R4, V23.D[0] \
+    MOVD    $0x0000800000004000, R4 \
+    VMOV    R4, V23.D[1] \
+loop: \
+    MOVD    $0, R3 \
+    CMP_FLOAT32_MASK_STEP(c1, c2, c3, c4, 0) \
+    CMP_FLOAT32_MASK_STEP(c1, c2, c3, c4, 16) \
+    CMP_FLOAT32_MASK_STEP(c1, c2, c3, c4, 32) \
+    CMP_FLOAT32_MASK_STEP(c1, c2, c3, c4, 48) \
+    MOVD.P  R3, 8(R1) \
+    SUB     $1, R2, R2 \
+    CBNZ    R2, loop \
+done: \
+    RET
+
+// func cmpEqFloat32MaskNEON(values *float32, threshold float32, mask *uint64, length int)
+//   fcmeq v1.4s, v1.4s, v0.4s
+//   fcmeq v2.4s, v2.4s, v0.4s
+//   fcmeq v3.4s, v3.4s, v0.4s
+//   fcmeq v4.4s, v4.4s, v0.4s
+CMP_FLOAT32_MASK_NEON(·cmpEqFloat32MaskNEON, $0x4e20e421, $0x4e20e442, $0x4e20e463, $0x4e20e484)
+
+// func cmpGtFloat32MaskNEON(values *float32, threshold float32, mask *uint64, length int)
+//   fcmgt v1.4s, v1.4s, v0.4s
+//   fcmgt v2.4s, v2.4s, v0.4s
+//   fcmgt v3.4s, v3.4s, v0.4s
+//   fcmgt v4.4s, v4.4s, v0.4s
+CMP_FLOAT32_MASK_NEON(·cmpGtFloat32MaskNEON, $0x6ea0e421, $0x6ea0e442, $0x6ea0e463, $0x6ea0e484)
+
+// func cmpGeFloat32MaskNEON(values *float32, threshold float32, mask *uint64, length int)
+//   fcmge v1.4s, v1.4s, v0.4s
+//   fcmge v2.4s, v2.4s, v0.4s
+//   fcmge v3.4s, v3.4s, v0.4s
+//   fcmge v4.4s, v4.4s, v0.4s
+CMP_FLOAT32_MASK_NEON(·cmpGeFloat32MaskNEON, $0x6e20e421, $0x6e20e442, $0x6e20e463, $0x6e20e484)
+
+// func cmpLtFloat32MaskNEON(values *float32, threshold float32, mask *uint64, length int)
+//   fcmgt v1.4s, v0.4s, v1.4s
+//   fcmgt v2.4s, v0.4s, v2.4s
+//   fcmgt v3.4s, v0.4s, v3.4s
+//   fcmgt v4.4s, v0.4s, v4.4s
+CMP_FLOAT32_MASK_NEON(·cmpLtFloat32MaskNEON, $0x6ea1e401, $0x6ea2e402, $0x6ea3e403, $0x6ea4e404)
+
+// func cmpLeFloat32MaskNEON(values *float32, threshold float32, mask *uint64, length int)
+//   fcmge v1.4s, v0.4s, v1.4s
+//   fcmge v2.4s, v0.4s, v2.4s
+//   fcmge v3.4s, v0.4s, v3.4s
+//   fcmge v4.4s, v0.4s, v4.4s
+CMP_FLOAT32_MASK_NEON(·cmpLeFloat32MaskNEON, $0x6e21e401, $0x6e22e402, $0x6e23e403, $0x6e24e404)
+
+// ============================================================================
+// Float32 aggregates
+// ============================================================================
+
+// func sumFloat32NEON(values *float32, length int) float64
+//
+// Widens 8 float32 values per iteration to float64 with FCVTL/FCVTL2 and adds them
+// into four accumulators of 2 lanes each (V16-V19). length must be a multiple of 8.
+TEXT ·sumFloat32NEON(SB), NOSPLIT, $0-24
+    MOVD    values+0(FP), R0
+    MOVD    length+8(FP), R1
+    VEOR    V16.B16, V16.B16, V16.B16
+    VEOR    V17.B16, V17.B16, V17.B16
+    VEOR    V18.B16, V18.B16, V18.B16
+    VEOR    V19.B16, V19.B16, V19.B16
+    LSR     $3, R1, R1
+    CBZ     R1, reduce
+
+loop:
+    VLD1.P  32(R0), [V1.S4, V2.S4]
+    WORD    $0x0e617823                 // fcvtl  v3.2d, v1.2s
+    WORD    $0x4e617824                 // fcvtl2 v4.2d, v1.4s
+    WORD    $0x0e617845                 // fcvtl  v5.2d, v2.2s
+    WORD    $0x4e617846                 // fcvtl2 v6.2d, v2.4s
+    WORD    $0x4e63d610                 // fadd v16.2d, v16.2d, v3.2d
+    WORD    $0x4e64d631                 // fadd v17.2d, v17.2d, v4.2d
+    WORD    $0x4e65d652                 // fadd v18.2d, v18.2d, v5.2d
+    WORD    $0x4e66d673                 // fadd v19.2d, v19.2d, v6.2d
+    SUB     $1, R1, R1
+    CBNZ    R1, loop
+
+reduce:
+    WORD    $0x4e71d610                 // fadd v16.2d, v16.2d, v17.2d
+    WORD    $0x4e73d652                 // fadd v18.2d, v18.2d, v19.2d
+    WORD    $0x4e72d610                 // fadd v16.2d, v16.2d, v18.2d
+    WORD    $0x7e70da00                 // faddp d0, v16.2d
+    FMOVD   F0, ret+16(FP)
+    RET
+
+// MINMAX_FLOAT32_NEON defines a kernel with the signature
+// func(values *float32, length int) float32
+// that reduces length values (a multiple of 8) into V16 and V17, starting from
+// identity. FMINNM and FMAXNM return the other operand when one input is a quiet
+// NaN, so NaN values are skipped and the accumulators never hold NaN.
+#define MINMAX_FLOAT32_NEON(name, identity, op1, op2, combine, across) \
+TEXT name(SB), NOSPLIT, $0-20 \
+    MOVD    values+0(FP), R0 \
+    MOVD    length+8(FP), R1 \
+    MOVW    identity, R4 \
+    VDUP    R4, V16.S4 \
+    VDUP    R4, V17.S4 \
+    LSR     $3, R1, R1 \
+    CBZ     R1, reduce \
+loop: \
+    VLD1.P  32(R0), [V1.S4, V2.S4] \
+    WORD    op1 \
+    WORD    op2 \
+    SUB     $1, R1, R1 \
+    CBNZ    R1, loop \
+reduce: \
+    WORD    combine \
+    WORD    across \
+    FMOVS   F0, ret+16(FP) \
+    RET
+
+// func minFloat32NEON(values *float32, length int) float32
+//   fminnm v16.4s, v16.4s, v1.4s
+//   fminnm v17.4s, v17.4s, v2.4s
+//   fminnm v16.4s, v16.4s, v17.4s
+//   fminnmv s0, v16.4s
+MINMAX_FLOAT32_NEON(·minFloat32NEON, $0x7F800000, $0x4ea1c610, $0x4ea2c631, $0x4eb1c610, $0x6eb0ca00)
+
+// func maxFloat32NEON(values *float32, length int) float32
+//   fmaxnm v16.4s, v16.4s, v1.4s
+//   fmaxnm v17.4s, v17.4s, v2.4s
+//   fmaxnm v16.4s, v16.4s, v17.4s
+//   fmaxnmv s0, v16.4s
+MINMAX_FLOAT32_NEON(·maxFloat32NEON, $0xFF800000, $0x4e21c610, $0x4e22c631, $0x4e31c610, $0x6e30ca00)
+
+// ============================================================================
+// Float32 conversions
+// ============================================================================
+
+// func float32ToFloat64NEON(dst *float64, src *float32, length int)
+//
+// Widens 4 values per iteration. length must be a multiple of 4.
+TEXT ·float32ToFloat64NEON(SB), NOSPLIT, $0-24
+    MOVD    dst+0(FP), R0
+    MOVD    src+8(FP), R1
+    MOVD    length+16(FP), R2
+    LSR     $2, R2, R2
+    CBZ     R2, done
+
+loop:
+    VLD1.P  16(R1), [V1.S4]
+    WORD    $0x0e617822                 // fcvtl  v2.2d, v1.2s
+    WORD    $0x4e617823                 // fcvtl2 v3.2d, v1.4s
+    VST1.P  [V2.D2, V3.D2], 32(R0)
+    SUB     $1, R2, R2
+    CBNZ    R2, loop
+
+done:
+    RET
+
+// func float64ToFloat32NEON(dst *float32, src *float64, length int)
+//
+// Narrows 4 values per iteration, rounding to nearest even like Go's float32
+// conversion. length must be a multiple of 4.
+TEXT ·float64ToFloat32NEON(SB), NOSPLIT, $0-24
+    MOVD    dst+0(FP), R0
+    MOVD    src+8(FP), R1
+    MOVD    length+16(FP), R2
+    LSR     $2, R2, R2
+    CBZ     R2, done
+
+loop:
+    VLD1.P  32(R1), [V1.D2, V2.D2]
+    WORD    $0x0e616823                 // fcvtn  v3.2s, v1.2d
+    WORD    $0x4e616843                 // fcvtn2 v3.4s, v2.2d
+    VST1.P  [V3.S4], 16(R0)
+    SUB     $1, R2, R2
+    CBNZ    R2, loop
+
+done:
+    RET
@@ -0,0 +1,51 @@
+package syndrdbsimd
+
+import "math"
+
+// sumFloat32Generic computes the sum of float32 values in a float64 accumulator.
+func sumFloat32Generic(values []float32) float64 {
+	sum := float64(0)
+	for _, v := range values {
+		sum += float64(v)
+	}
+	return sum
+}
+
+// minFloat32Generic finds the minimum float32 value using scalar operations.
+// NaN values are skipped. Returns +Inf if the slice is empty or all NaN.
+func minFloat32Generic(values []float32) float32 {
+	min := float32(math.Inf(1))
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// maxFloat32Generic finds the maximum float32 value using scalar operations.
+// NaN values are skipped. Returns -Inf if the slice is empty or all NaN.
+func maxFloat32Generic(values []float32) float32 {
+	max := float32(math.Inf(-1))
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// float32ToFloat64Generic widens src into dst, which must be at least as long as src.
+func float32ToFloat64Generic(dst []float64, src []float32) {
+	for i, v := range src {
+		dst[i] = float64(v)
+	}
+}
+
+// float64ToFloat32Generic narrows src into dst, which must be at least as long as src.
+// Values round to the nearest float32; out-of-range values become ±Inf.
+func float64ToFloat32Generic(dst []float32, src []float64) {
+	for i, v := range src {
+		dst[i] = float32(v)
+	}
+}
@@ -0,0 +1,258 @@
+package syndrdbsimd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// ============================================================================
+// Float32 Comparison Tests
+// ============================================================================
+
+func TestCmpFloat32_HappyPath(t *testing.T) {
+	values := []float32{-1.5, 0, 0.5, 1, 2.5, float32(math.Inf(1))}
+
+	checkEqual(t, len(values), "CmpGtFloat32", CmpGtFloat32(values, 0.5), []bool{false, false, false, true, true, true})
+	checkEqual(t, len(values), "CmpLeFloat32", CmpLeFloat32(values, 0.5), []bool{true, true, true, false, false, false})
+	checkEqual(t, len(values), "CmpEqFloat32Mask", CmpEqFloat32Mask(values, 1), []uint64{1 << 3})
+}
+
+// NaN compares false for every operator except !=, on both sides of the comparison
+func TestCmpFloat32_NaN(t *testing.T) {
+	nan := float32(math.NaN())
+	values := make([]float32, 200)
+	for i := range values {
+		values[i] = float32(i)
+		if i%3 == 0 {
+			values[i] = nan
+		}
+	}
+
+	for _, op := range []Op{OpEq, OpNe, OpGt, OpLt, OpGe, OpLe} {
+		for _, threshold := range []float32{100, nan} {
+			got := cmpFloat32Impl(currentKernels(), values, op, threshold)
+			for i, v := range values {
+				expected := op == OpNe
+				if !math.IsNaN(float64(v)) && !math.IsNaN(float64(threshold)) {
+					expected = cmpNumberGeneric([]float32{v}, op, threshold)[0]
+				}
+				if got[i] != expected {
+					t.Errorf("op=%d threshold=%v: result[%d] (%v) = %v, want %v", op, threshold, i, v, got[i], expected)
+				}
+			}
+		}
+	}
+}
+
+// checkFloat32Compares checks every operator in both output forms against the
+// generic implementation.
+func checkFloat32Compares(t *testing.T, values []float32, threshold float32) {
+	t.Helper()
+	for _, op := range []Op{OpEq, OpNe, OpGt, OpLt, OpGe, OpLe} {
+		checkEqual(t, len(values), "cmpFloat32Impl", cmpFloat32Impl(currentKernels(), values, op, threshold), cmpNumberGeneric(values, op, threshold))
+		checkEqual(t, len(values), "cmpFloat32MaskImpl", cmpFloat32MaskImpl(currentKernels(), values, op, threshold), cmpNumberMaskGeneric(values, op, threshold))
+	}
+}
+
+// randomFloat32s returns values drawn from a small set so equality matches occur,
+// with NaN, ±Inf and signed zeros mixed in.
+func randomFloat32s(rng *rand.Rand, size int) []float32 {
+	special := []float32{float32(math.NaN()), float32(math.Inf(1)), float32(math.Inf(-1)), 0, float32(math.Copysign(0, -1))}
+	values := make([]float32, size)
+	for i := range values {
+		if rng.Intn(8) == 0 {
+			values[i] = special[rng.Intn(len(special))]
+		} else {
+			values[i] = float32(rng.Intn(64)-32) / 4
+		}
+	}
+	return values
+}
+
+// Every ISA must match the generic implementations, including the SIMD paths
+// for inputs smaller than one kernel iteration
+func TestFloat32_MatchGeneric(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+	cfg := DefaultConfig()
+	cfg.MinFloat32 = 1
+	if err := SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	// Lengths chosen to cover scalar tails and the 4/8/16/64 element group boundaries
+	sizes := []int{1, 3, 4, 7, 8, 15, 16, 17, 63, 64, 65, 127, 128, 129, 1000}
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(33))
+				for _, size := range sizes {
+					values := randomFloat32s(rng, size)
+					checkFloat32Compares(t, values, values[size/2])
+					checkFloat32Compares(t, values, 0)
+					checkFloat32Compares(t, values, float32(math.Inf(-1)))
+
+					if got, want := minFloat32Impl(currentKernels(), values), minFloat32Generic(values); got != want {
+						t.Errorf("size=%d: min = %v, want %v", size, got, want)
+					}
+					if got, want := maxFloat32Impl(currentKernels(), values), maxFloat32Generic(values); got != want {
+						t.Errorf("size=%d: max = %v, want %v", size, got, want)
+					}
+
+					// Quarter steps up to ±8 sum exactly in any order
+					finite := make([]float32, size)
+					for i := range finite {
+						finite[i] = float32(rng.Intn(64)-32) / 4
+					}
+					if got, want := sumFloat32Impl(currentKernels(), finite), sumFloat32Generic(finite); got != want {
+						t.Errorf("size=%d: sum = %v, want %v", size, got, want)
+					}
+
+					wide := make([]float64, size)
+					float32ToFloat64Impl(currentKernels(), wide, values)
+					for i, v := range values {
+						if math.Float64bits(wide[i]) != math.Float64bits(float64(v)) && !math.IsNaN(wide[i]) {
+							t.Errorf("size=%d: widened[%d] = %v, want %v", size, i, wide[i], v)
+						}
+					}
+
+					narrow := make([]float32, size)
+					for i := range wide {
+						wide[i] = rng.NormFloat64() * 1e10
+					}
+					float64ToFloat32Impl(currentKernels(), narrow, wide)
+					for i, v := range wide {
+						if narrow[i] != float32(v) {
+							t.Errorf("size=%d: narrowed[%d] = %v, want %v", size, i, narrow[i], float32(v))
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
+// ============================================================================
+// Float32 Aggregation Tests
+// ============================================================================
+
+// The float64 accumulator keeps precision that a float32 running sum loses
+func TestSumFloat32_Float64Accumulator(t *testing.T) {
+	values := make([]float32, 1<<20)
+	for i := range values {
+		values[i] = 0.1
+	}
+
+	expected := float64(len(values)) * float64(float32(0.1))
+	if got := SumFloat32(values); math.Abs(got-expected) > 1e-6 {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := AvgFloat32(values); math.Abs(got-float64(float32(0.1))) > 1e-12 {
+		t.Errorf("Expected average %v, got %v", float32(0.1), got)
+	}
+}
+
+func TestMinMaxFloat32_SkipNaN(t *testing.T) {
+	nan := float32(math.NaN())
+	values := make([]float32, 100)
+	for i := range values {
+		values[i] = nan
+	}
+	values[40] = -3
+	values[77] = 12.5
+
+	if got := MinFloat32(values); got != -3 {
+		t.Errorf("MinFloat32: expected -3, got %v", got)
+	}
+	if got := MaxFloat32(values); got != 12.5 {
+		t.Errorf("MaxFloat32: expected 12.5, got %v", got)
+	}
+
+	// Nothing but NaN behaves like an empty column
+	allNaN := []float32{nan, nan, nan}
+	if got := MinFloat32(allNaN); !math.IsInf(float64(got), 1) {
+		t.Errorf("MinFloat32(all NaN): expected +Inf, got %v", got)
+	}
+	if got := MaxFloat32(allNaN); !math.IsInf(float64(got), -1) {
+		t.Errorf("MaxFloat32(all NaN): expected -Inf, got %v", got)
+	}
+}
+
+func TestFloat32_Empty(t *testing.T) {
+	if len(CmpGtFloat32(nil, 1)) != 0 || len(CmpGtFloat32Mask(nil, 1)) != 0 {
+		t.Error("Expected empty results for empty input")
+	}
+	if SumFloat32(nil) != 0 || AvgFloat32(nil) != 0 {
+		t.Error("Expected 0 sum and average for empty input")
+	}
+	if !math.IsInf(float64(MinFloat32(nil)), 1) || !math.IsInf(float64(MaxFloat32(nil)), -1) {
+		t.Error("Expected ±Inf for empty input")
+	}
+}
+
+// ============================================================================
+// Float32 Conversion Tests
+// ============================================================================
+
+func TestFloat32ToFloat64_CopyLength(t *testing.T) {
+	src := []float32{1.5, -2, float32(math.Inf(1)), 0.1}
+
+	dst := make([]float64, 2)
+	if n := Float32ToFloat64(dst, src); n != 2 {
+		t.Fatalf("Expected 2 values converted, got %d", n)
+	}
+	checkEqual(t, 2, "Float32ToFloat64", dst, []float64{1.5, -2})
+
+	dst = make([]float64, 10)
+	if n := Float32ToFloat64(dst, src); n != len(src) {
+		t.Fatalf("Expected %d values converted, got %d", len(src), n)
+	}
+	checkEqual(t, len(src), "Float32ToFloat64", dst[:len(src)], []float64{1.5, -2, math.Inf(1), float64(float32(0.1))})
+}
+
+func TestFloat64ToFloat32_Rounding(t *testing.T) {
+	src := []float64{0.1, 1e39, -1e39, 1 + 1.0/(1<<24), math.MaxFloat32}
+	dst := make([]float32, len(src))
+
+	if n := Float64ToFloat32(dst, src); n != len(src) {
+		t.Fatalf("Expected %d values converted, got %d", len(src), n)
+	}
+	expected := []float32{0.1, float32(math.Inf(1)), float32(math.Inf(-1)), 1, math.MaxFloat32}
+	checkEqual(t, len(src), "Float64ToFloat32", dst, expected)
+
+	if Float64ToFloat32(nil, src) != 0 || Float32ToFloat64(make([]float64, 4), nil) != 0 {
+		t.Error("Expected 0 values converted for empty input")
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+func BenchmarkCmpGtFloat32Mask(b *testing.B) {
+	values := make([]float32, 4096)
+	for i := range values {
+		values[i] = float32(i) * 0.5
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CmpGtFloat32Mask(values, 1000)
+	}
+}
+
+func BenchmarkSumFloat32(b *testing.B) {
+	values := make([]float32, 4096)
+	for i := range values {
+		values[i] = float32(i) * 0.5
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SumFloat32(values)
+	}
+}
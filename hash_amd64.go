@@ -16,7 +16,15 @@ func hashInt64AVX2(values *int64, output *uint64, count int)
 func crc32Int64AVX2(values *int64, output *uint32, count int)
 
 // xxhash64AVX2 computes XXHash64 hashes for a slice of int64 values using AVX2.
-// Processes 4 int64 values at a time.
+// Processes 4 int64 values at a time; count must be a multiple of 4.
+// The 64-bit multiplies are emulated with VPMULUDQ, so only AVX2 is required.
 //
 //go:noescape
 func xxhash64AVX2(values *int64, output *uint64, count int)
+
+// xxhash64AVX512 computes XXHash64 hashes for a slice of int64 values using AVX-512.
+// Processes 8 int64 values at a time; count must be a multiple of 8.
+// Requires AVX512DQ for VPMULLQ.
+//
+//go:noescape
+func xxhash64AVX512(values *int64, output *uint64, count int)
@@ -121,8 +121,30 @@ loop_crc:
 done_crc:
 	RET
 
+// MUL64_AVX2 computes a *= b for 4 uint64 lanes using only AVX2.
+// AVX2 has no 64-bit lane multiply (VPMULLQ needs AVX512DQ+VL), so the low 64 bits
+// of the product are assembled from three 32x32->64 VPMULUDQ products:
+//   a*b mod 2^64 = lo(a)*lo(b) + ((hi(a)*lo(b) + lo(a)*hi(b)) << 32)
+// bhi must hold b >> 32. t1 and t2 are clobbered.
+#define MUL64_AVX2(b, bhi, a, t1, t2) \
+	VPMULUDQ b, a, t1 \
+	VPSRLQ   $32, a, t2 \
+	VPMULUDQ b, t2, t2 \
+	VPMULUDQ bhi, a, a \
+	VPADDQ   t2, a, a \
+	VPSLLQ   $32, a, a \
+	VPADDQ   t1, a, a
+
+// BROADCAST_AVX2 loads a 64-bit constant into all 4 lanes of dst.
+// GPR-source VPBROADCASTQ is AVX-512 only, so the value goes through an XMM register.
+#define BROADCAST_AVX2(imm, dst, xtmp) \
+	MOVQ         imm, AX \
+	MOVQ         AX, xtmp \
+	VPBROADCASTQ xtmp, dst
+
 // func xxhash64AVX2(values *int64, output *uint64, count int)
-// Computes XXHash64 for int64 values using AVX2
+// Computes XXHash64 for int64 values using AVX2.
+// count must be a multiple of 4.
 TEXT ·xxhash64AVX2(SB), NOSPLIT, $0-24
 	MOVQ values+0(FP), SI    // SI = &values[0]
 	MOVQ output+8(FP), DI    // DI = &output[0]
@@ -134,81 +156,135 @@ TEXT ·xxhash64AVX2(SB), NOSPLIT, $0-24
 	// prime64_3 = 1609587929392839161  = 0x165667B19E3779F9
 	// prime64_4 = 9650029242287828579  = 0x85EBCA77C2B2AE63
 	// prime64_5 = 2870177450012600261  = 0x27D4EB2F165667C5
-	
-	MOVQ $0x9E3779B185EBCA87, R8   // prime64_1
-	MOVQ $0xC2B2AE3D27D4EB4F, R9   // prime64_2
-	MOVQ $0x165667B19E3779F9, R10  // prime64_3
-	MOVQ $0x85EBCA77C2B2AE63, R11  // prime64_4
-	MOVQ $0x27D4EB2F165667C5, R12  // prime64_5
-	
-	// Broadcast constants to YMM
-	VPBROADCASTQ R9, Y0   // Y0 = prime64_2
-	VPBROADCASTQ R8, Y1   // Y1 = prime64_1
-	VPBROADCASTQ R11, Y2  // Y2 = prime64_4
-	
+
 	// Process 4 elements at a time
 	SHRQ $2, CX          // CX = count / 4
 	JZ remainder_xxh
 
+	// Broadcast constants (and the high halves MUL64_AVX2 needs) to YMM
+	BROADCAST_AVX2($0xC2B2AE3D27D4EB4F, Y0, X15)   // Y0  = prime64_2
+	BROADCAST_AVX2($0xC2B2AE3D, Y9, X15)           // Y9  = prime64_2 >> 32
+	BROADCAST_AVX2($0x9E3779B185EBCA87, Y1, X15)   // Y1  = prime64_1
+	BROADCAST_AVX2($0x9E3779B1, Y10, X15)          // Y10 = prime64_1 >> 32
+	BROADCAST_AVX2($0x165667B19E3779F9, Y7, X15)   // Y7  = prime64_3
+	BROADCAST_AVX2($0x165667B1, Y11, X15)          // Y11 = prime64_3 >> 32
+	BROADCAST_AVX2($0x85EBCA77C2B2AE63, Y2, X15)   // Y2  = prime64_4
+	BROADCAST_AVX2($0x27D4EB2F165667CD, Y8, X15)   // Y8  = prime64_5 + 8 (seed 0, len 8)
+
 loop_xxh:
 	// Load 4 int64 values
 	VMOVDQU (SI), Y3     // Y3 = [v0, v1, v2, v3]
-	
-	// h64 = prime64_5 + 8 for each
-	VPBROADCASTQ R12, Y4
-	MOVQ $8, R13
-	VPBROADCASTQ R13, Y6
-	VPADDQ Y6, Y4, Y4    // h64 = prime64_5 + 8
-	
+
 	// k1 = value * prime64_2
-	VPMULLQ Y0, Y3, Y5   // Y5 = k1 = value * prime64_2
-	
+	MUL64_AVX2(Y0, Y9, Y3, Y12, Y13)
+
 	// k1 = rotl64(k1, 31)
-	VPSLLQ $31, Y5, Y6
-	VPSRLQ $33, Y5, Y5
-	VPOR Y6, Y5, Y5      // Y5 = rotl64(k1, 31)
-	
+	VPSLLQ $31, Y3, Y6
+	VPSRLQ $33, Y3, Y3
+	VPOR Y6, Y3, Y3
+
 	// k1 *= prime64_1
-	VPMULLQ Y1, Y5, Y5   // Y5 = k1 * prime64_1
-	
-	// h64 ^= k1
-	VPXOR Y5, Y4, Y4
-	
+	MUL64_AVX2(Y1, Y10, Y3, Y12, Y13)
+
+	// h64 = (prime64_5 + 8) ^ k1
+	VPXOR Y8, Y3, Y4
+
 	// h64 = rotl64(h64, 27) * prime64_1 + prime64_4
 	VPSLLQ $27, Y4, Y6
 	VPSRLQ $37, Y4, Y4
-	VPOR Y6, Y4, Y4      // Y4 = rotl64(h64, 27)
-	VPMULLQ Y1, Y4, Y4   // Y4 *= prime64_1
-	VPADDQ Y2, Y4, Y4    // Y4 += prime64_4
-	
+	VPOR Y6, Y4, Y4
+	MUL64_AVX2(Y1, Y10, Y4, Y12, Y13)
+	VPADDQ Y2, Y4, Y4
+
 	// Finalization mix
 	// h64 ^= h64 >> 33
 	VPSRLQ $33, Y4, Y5
 	VPXOR Y5, Y4, Y4
-	
+
 	// h64 *= prime64_2
-	VPMULLQ Y0, Y4, Y4
-	
+	MUL64_AVX2(Y0, Y9, Y4, Y12, Y13)
+
 	// h64 ^= h64 >> 29
 	VPSRLQ $29, Y4, Y5
 	VPXOR Y5, Y4, Y4
-	
+
 	// h64 *= prime64_3
-	VPBROADCASTQ R10, Y5
-	VPMULLQ Y5, Y4, Y4
-	
+	MUL64_AVX2(Y7, Y11, Y4, Y12, Y13)
+
 	// h64 ^= h64 >> 32
 	VPSRLQ $32, Y4, Y5
 	VPXOR Y5, Y4, Y4
-	
+
 	// Store results
 	VMOVDQU Y4, (DI)
-	
+
 	ADDQ $32, SI         // Advance values pointer
 	ADDQ $32, DI         // Advance output pointer
 	DECQ CX
 	JNZ loop_xxh
 
+	VZEROUPPER
+
 remainder_xxh:
+	RET
+
+// func xxhash64AVX512(values *int64, output *uint64, count int)
+// Computes XXHash64 for int64 values using AVX-512.
+// AVX512DQ provides a native 64-bit VPMULLQ and AVX512F a native VPROLQ,
+// so each step of the scalar algorithm maps onto a single instruction.
+// count must be a multiple of 8.
+TEXT ·xxhash64AVX512(SB), NOSPLIT, $0-24
+	MOVQ values+0(FP), SI    // SI = &values[0]
+	MOVQ output+8(FP), DI    // DI = &output[0]
+	MOVQ count+16(FP), CX    // CX = count
+
+	// Process 8 elements at a time
+	SHRQ $3, CX          // CX = count / 8
+	JZ done_xxh512
+
+	MOVQ $0x9E3779B185EBCA87, AX
+	VPBROADCASTQ AX, Z1  // Z1 = prime64_1
+	MOVQ $0xC2B2AE3D27D4EB4F, AX
+	VPBROADCASTQ AX, Z0  // Z0 = prime64_2
+	MOVQ $0x165667B19E3779F9, AX
+	VPBROADCASTQ AX, Z7  // Z7 = prime64_3
+	MOVQ $0x85EBCA77C2B2AE63, AX
+	VPBROADCASTQ AX, Z2  // Z2 = prime64_4
+	MOVQ $0x27D4EB2F165667CD, AX
+	VPBROADCASTQ AX, Z8  // Z8 = prime64_5 + 8 (seed 0, len 8)
+
+loop_xxh512:
+	VMOVDQU64 (SI), Z3   // Z3 = 8 values
+
+	// k1 = rotl64(value * prime64_2, 31) * prime64_1
+	VPMULLQ Z0, Z3, Z3
+	VPROLQ $31, Z3, Z3
+	VPMULLQ Z1, Z3, Z3
+
+	// h64 = rotl64((prime64_5 + 8) ^ k1, 27) * prime64_1 + prime64_4
+	VPXORQ Z8, Z3, Z4
+	VPROLQ $27, Z4, Z4
+	VPMULLQ Z1, Z4, Z4
+	VPADDQ Z2, Z4, Z4
+
+	// Finalization mix
+	VPSRLQ $33, Z4, Z5
+	VPXORQ Z5, Z4, Z4
+	VPMULLQ Z0, Z4, Z4
+	VPSRLQ $29, Z4, Z5
+	VPXORQ Z5, Z4, Z4
+	VPMULLQ Z7, Z4, Z4
+	VPSRLQ $32, Z4, Z5
+	VPXORQ Z5, Z4, Z4
+
+	VMOVDQU64 Z4, (DI)
+
+	ADDQ $64, SI
+	ADDQ $64, DI
+	DECQ CX
+	JNZ loop_xxh512
+
 	VZEROUPPER
+
+done_xxh512:
 	RET
@@ -19,3 +19,9 @@ func crc32Int64NEON(values *int64, output *uint32, count int)
 //
 //go:noescape
 func xxhash64NEON(values *int64, output *uint64, count int)
+
+// xxhash64SVE computes XXHash64 hashes for a slice of int64 values using SVE.
+// Processes one full vector per iteration; any count is accepted.
+//
+//go:noescape
+func xxhash64SVE(values *int64, output *uint64, count int)
@@ -211,3 +211,71 @@ loop_xxh:
 
 remainder_xxh:
 	RET
+
+// ============================================================================
+// SVE kernels
+// ============================================================================
+//
+// SVE instructions are emitted as WORD directives because Go's ARM64 assembler
+// has no SVE mnemonics; see compare_arm64.s for the loop structure.
+
+// func xxhash64SVE(values *int64, output *uint64, count int)
+//
+// Computes XXHash64 (seed 0) of each value with the same steps as xxhash64Generic.
+// SVE has a native 64-bit lane multiply; rotates are built from LSL, LSR and ORR.
+//
+TEXT ·xxhash64SVE(SB), NOSPLIT, $0-24
+    MOVD    values+0(FP), R0        // R0 = &values[0]
+    MOVD    output+8(FP), R1        // R1 = &output[0]
+    MOVD    count+16(FP), R4        // R4 = count
+    MOVD    $0, R3                  // R3 = element index
+
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BEQ     xxh_done
+
+    MOVD    $0x9E3779B185EBCA87, R5
+    WORD    $0x05e038b0             // dup z16.d, x5; Z16 = prime64_1
+    MOVD    $0xC2B2AE3D27D4EB4F, R5
+    WORD    $0x05e038b1             // dup z17.d, x5; Z17 = prime64_2
+    MOVD    $0x165667B19E3779F9, R5
+    WORD    $0x05e038b2             // dup z18.d, x5; Z18 = prime64_3
+    MOVD    $0x85EBCA77C2B2AE63, R5
+    WORD    $0x05e038b3             // dup z19.d, x5; Z19 = prime64_4
+    MOVD    $0x27D4EB2F165667CD, R5
+    WORD    $0x05e038b4             // dup z20.d, x5; Z20 = prime64_5 + 8 (seed 0, len 8)
+
+xxh_loop:
+    WORD    $0xa5e34000             // ld1d {z0.d}, p0/z, [x0, x3, lsl #3]
+
+    // k1 = rotl64(value * prime64_2, 31) * prime64_1
+    WORD    $0x04d00220             // mul z0.d, p0/m, z0.d, z17.d
+    WORD    $0x04bf9c01             // lsl z1.d, z0.d, #31
+    WORD    $0x04bf9400             // lsr z0.d, z0.d, #33
+    WORD    $0x04613000             // orr z0.d, z0.d, z1.d
+    WORD    $0x04d00200             // mul z0.d, p0/m, z0.d, z16.d
+
+    // h64 = rotl64((prime64_5 + 8) ^ k1, 27) * prime64_1 + prime64_4
+    WORD    $0x04b43000             // eor z0.d, z0.d, z20.d
+    WORD    $0x04bb9c01             // lsl z1.d, z0.d, #27
+    WORD    $0x04bb9400             // lsr z0.d, z0.d, #37
+    WORD    $0x04613000             // orr z0.d, z0.d, z1.d
+    WORD    $0x04d00200             // mul z0.d, p0/m, z0.d, z16.d
+    WORD    $0x04f30000             // add z0.d, z0.d, z19.d
+
+    // Finalization mix
+    WORD    $0x04bf9401             // lsr z1.d, z0.d, #33
+    WORD    $0x04a13000             // eor z0.d, z0.d, z1.d
+    WORD    $0x04d00220             // mul z0.d, p0/m, z0.d, z17.d
+    WORD    $0x04e39401             // lsr z1.d, z0.d, #29
+    WORD    $0x04a13000             // eor z0.d, z0.d, z1.d
+    WORD    $0x04d00240             // mul z0.d, p0/m, z0.d, z18.d
+    WORD    $0x04e09401             // lsr z1.d, z0.d, #32
+    WORD    $0x04a13000             // eor z0.d, z0.d, z1.d
+
+    WORD    $0xe5e34020             // st1d {z0.d}, p0, [x1, x3, lsl #3]
+    WORD    $0x04f0e3e3             // incd x3
+    WORD    $0x25e41c60             // whilelo p0.d, x3, x4
+    BMI     xxh_loop
+
+xxh_done:
+    RET
@@ -2,22 +2,223 @@
 
 package syndrdbsimd
 
-// cmpEqInt64Impl routes to AVX2 or generic implementation based on CPU capabilities
-func cmpEqInt64Impl(values []int64, threshold int64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+import (
+	"bytes"
+	"math/bits"
+	"sync/atomic"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// x86KernelSet records the ISA selected for each group of operations.
+// Each level is only chosen when every instruction its kernels use is available.
+type x86KernelSet struct {
+	compare      ISA // int64/float64 comparisons (AVX-512 bool output needs BMI2 PDEP)
+	bitmap       ISA // And/Or/Xor/Not
+	popCount     ISA // AVX-512 needs VPOPCNTDQ
+	sum          ISA
+	countNonNull ISA // AVX2 only
+	xxhash       ISA // AVX-512 needs DQ for VPMULLQ
+	compress     ISA // Compress and gather
+	scatter      ISA // No AVX2 equivalent, so AVX-512 or generic
+	strings      ISA // Byte-string kernels, AVX2 only
+	narrow       ISA // int8/16/32 and unsigned kernels, AVX2 only
+	float32      ISA // float32 compares, aggregates and conversions, AVX2 only
+	arith        ISA // element-wise int64/float64 arithmetic and timestamp division, AVX2 only
+	floatMinMax  ISA // float64 Min and Max, AVX2 only
+
+	cutovers *Config // SIMD cutover sizes; nil for the package-wide Config
+}
+
+// config returns the cutover sizes the *Impl functions compare input sizes with.
+func (ks *x86KernelSet) config() *Config {
+	if ks.cutovers != nil {
+		return ks.cutovers
+	}
+	return simdConfig.Load()
+}
+
+// selectX86Kernels derives the kernel selection from a feature set.
+// It is a pure function so the dispatch rules can be tested for any CPU,
+// not just the one running the tests.
+func selectX86Kernels(f x86Features) x86KernelSet {
+	pick := func(avx512 bool) ISA {
+		switch {
+		case f.AVX512F && avx512:
+			return ISAAVX512
+		case f.AVX2:
+			return ISAAVX2
+		default:
+			return ISAGeneric
+		}
+	}
+
+	ks := x86KernelSet{
+		compare:      pick(f.BMI2),
+		bitmap:       pick(true),
+		popCount:     pick(f.AVX512VPOPCNTDQ),
+		sum:          pick(true),
+		countNonNull: pick(false),
+		xxhash:       pick(f.AVX512DQ),
+		compress:     pick(true),
+		scatter:      ISAGeneric,
+		strings:      pick(false),
+		narrow:       pick(false),
+		float32:      pick(false),
+		arith:        pick(false),
+		floatMinMax:  pick(false),
+	}
+	if f.AVX512F {
+		ks.scatter = ISAAVX512
+	}
+	return ks
+}
+
+// capX86Features clears the features above isa, so selectX86Kernels never picks
+// a kernel from a higher level.
+func capX86Features(f x86Features, isa ISA) x86Features {
+	switch isa {
+	case ISAAVX512:
+		return f
+	case ISAAVX2:
+		return x86Features{AVX2: f.AVX2, BMI2: f.BMI2}
+	default:
+		return x86Features{}
+	}
+}
+
+// x86Detected holds the features of the running CPU, detected once.
+var x86Detected = detectX86Features()
+
+// kernelSet is the kernel selection the *Impl functions in this file dispatch on.
+type kernelSet = x86KernelSet
+
+// x86Kernels is the kernel selection used by the package-level functions.
+// It starts from the detected CPU features and is replaced atomically by SetISA,
+// ResetISA and WithGlobalISA. A Kernels value carries its own selection instead.
+var x86Kernels atomic.Pointer[x86KernelSet]
+
+func init() {
+	resetKernels()
+}
+
+func isaSupported(isa ISA) bool {
+	switch isa {
+	case ISAGeneric:
+		return true
+	case ISASSE42:
+		return x86Detected.SSE42
+	case ISAAVX2:
+		return x86Detected.AVX2
+	case ISAAVX512:
+		return x86Detected.AVX512F
+	default:
+		return false
+	}
+}
+
+// currentKernels returns the selection the package-level functions dispatch on.
+func currentKernels() *kernelSet {
+	return x86Kernels.Load()
+}
+
+// cappedKernels returns the selection for this CPU with kernels above isa disabled.
+func cappedKernels(isa ISA) *kernelSet {
+	ks := selectX86Kernels(capX86Features(x86Detected, isa))
+	return &ks
+}
+
+// withCutovers returns a copy of ks that uses the cutover sizes in c.
+func withCutovers(ks *kernelSet, c *Config) *kernelSet {
+	with := *ks
+	with.cutovers = c
+	return &with
+}
+
+func capKernels(isa ISA) {
+	x86Kernels.Store(cappedKernels(isa))
+}
+
+func resetKernels() {
+	ks := selectX86Kernels(x86Detected)
+	x86Kernels.Store(&ks)
+}
+
+// kernelISA reports the ISA an operation group from kernelOperations dispatches to
+// under ks.
+func kernelISA(ks *kernelSet, op string) ISA {
+	switch op {
+	case "CmpInt64", "CmpFloat64":
+		return ks.compare
+	case "Bitmap":
+		return ks.bitmap
+	case "PopCount":
+		return ks.popCount
+	case "SumInt64":
+		return ks.sum
+	case "CountNonNull":
+		return ks.countNonNull
+	case "XXHash64":
+		return ks.xxhash
+	case "Compress", "Gather":
+		return ks.compress
+	case "Scatter":
+		return ks.scatter
+	case "String":
+		return ks.strings
+	case "NarrowInt":
+		return ks.narrow
+	case "Float32":
+		return ks.float32
+	case "Arith":
+		return ks.arith
+	case "MinMaxFloat64":
+		return ks.floatMinMax
+	default:
+		// MinMaxInt64, HashInt64 and CRC32Int64 have no enabled SIMD kernels
+		return ISAGeneric
+	}
+}
+
+// defaultConfig holds the x86-64 SIMD cutover sizes: a few vector iterations per operation.
+var defaultConfig = Config{
+	MinCmpInt64:      16,
+	MinCmpFloat64:    16,
+	MinBitmapWords:   8,
+	MinPopCountWords: 8,
+	MinAggregate:     16,
+	MinHash:          16,
+	MinCompress:      16,
+	MinNarrow:        64,
+	MinFloat32:       32,
+	MinArith:         16,
+
+	MinStrings:       16,
+	AvgByteThreshold: 32,
+}
+
+// cmpEqInt64Impl routes to AVX-512, AVX2 or generic implementation based on CPU capabilities
+func cmpEqInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpEqInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	// Process 4 elements at a time with AVX2
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpEqInt64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpEqInt64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpEqInt64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	// Handle remainder with scalar
@@ -28,8 +229,8 @@ func cmpEqInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpEqInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpEqInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpEqInt64MaskGeneric(values, threshold)
 	}
 
@@ -37,15 +238,21 @@ func cmpEqInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	// Process 4 elements at a time
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpEqInt64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
-
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpEqInt64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpEqInt64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
+
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -62,20 +269,27 @@ func cmpEqInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func cmpNeInt64Impl(values []int64, threshold int64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpNeInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpNeInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpNeInt64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpNeInt64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpNeInt64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	for ; i < len(values); i++ {
@@ -85,8 +299,8 @@ func cmpNeInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpNeInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpNeInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpNeInt64MaskGeneric(values, threshold)
 	}
 
@@ -94,14 +308,21 @@ func cmpNeInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpNeInt64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpNeInt64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpNeInt64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
 
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -117,20 +338,27 @@ func cmpNeInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func cmpGtInt64Impl(values []int64, threshold int64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpGtInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpGtInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpGtInt64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpGtInt64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpGtInt64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	for ; i < len(values); i++ {
@@ -140,8 +368,8 @@ func cmpGtInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpGtInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpGtInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpGtInt64MaskGeneric(values, threshold)
 	}
 
@@ -149,14 +377,21 @@ func cmpGtInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpGtInt64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpGtInt64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpGtInt64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
 
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -172,20 +407,27 @@ func cmpGtInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func cmpLtInt64Impl(values []int64, threshold int64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpLtInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpLtInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpLtInt64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpLtInt64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpLtInt64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	for ; i < len(values); i++ {
@@ -195,8 +437,8 @@ func cmpLtInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpLtInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpLtInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpLtInt64MaskGeneric(values, threshold)
 	}
 
@@ -204,14 +446,21 @@ func cmpLtInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpLtInt64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpLtInt64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpLtInt64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
 
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -227,20 +476,27 @@ func cmpLtInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func cmpGeInt64Impl(values []int64, threshold int64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpGeInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpGeInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpGeInt64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpGeInt64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpGeInt64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	for ; i < len(values); i++ {
@@ -250,8 +506,8 @@ func cmpGeInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpGeInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpGeInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpGeInt64MaskGeneric(values, threshold)
 	}
 
@@ -259,14 +515,21 @@ func cmpGeInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpGeInt64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpGeInt64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpGeInt64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
 
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -282,20 +545,27 @@ func cmpGeInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func cmpLeInt64Impl(values []int64, threshold int64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpLeInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpLeInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpLeInt64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpLeInt64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpLeInt64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	for ; i < len(values); i++ {
@@ -305,8 +575,8 @@ func cmpLeInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpLeInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpLeInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpLeInt64MaskGeneric(values, threshold)
 	}
 
@@ -314,14 +584,21 @@ func cmpLeInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpLeInt64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpLeInt64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpLeInt64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
 
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -337,8 +614,8 @@ func cmpLeInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func andBitmapImpl(a, b []uint64) []uint64 {
-	if !HasAVX2() || len(a) < 8 {
+func andBitmapImpl(ks *kernelSet, a, b []uint64) []uint64 {
+	if ks.bitmap == ISAGeneric || len(a) < ks.config().MinBitmapWords {
 		return andBitmapGeneric(a, b)
 	}
 
@@ -348,12 +625,16 @@ func andBitmapImpl(a, b []uint64) []uint64 {
 	}
 
 	result := make([]uint64, length)
-	andBitmapAVX2(&result[0], &a[0], &b[0], length)
+	if ks.bitmap == ISAAVX512 {
+		andBitmapAVX512(&result[0], &a[0], &b[0], length)
+	} else {
+		andBitmapAVX2(&result[0], &a[0], &b[0], length)
+	}
 	return result
 }
 
-func orBitmapImpl(a, b []uint64) []uint64 {
-	if !HasAVX2() || len(a) < 8 {
+func orBitmapImpl(ks *kernelSet, a, b []uint64) []uint64 {
+	if ks.bitmap == ISAGeneric || len(a) < ks.config().MinBitmapWords {
 		return orBitmapGeneric(a, b)
 	}
 
@@ -363,12 +644,16 @@ func orBitmapImpl(a, b []uint64) []uint64 {
 	}
 
 	result := make([]uint64, length)
-	orBitmapAVX2(&result[0], &a[0], &b[0], length)
+	if ks.bitmap == ISAAVX512 {
+		orBitmapAVX512(&result[0], &a[0], &b[0], length)
+	} else {
+		orBitmapAVX2(&result[0], &a[0], &b[0], length)
+	}
 	return result
 }
 
-func xorBitmapImpl(a, b []uint64) []uint64 {
-	if !HasAVX2() || len(a) < 8 {
+func xorBitmapImpl(ks *kernelSet, a, b []uint64) []uint64 {
+	if ks.bitmap == ISAGeneric || len(a) < ks.config().MinBitmapWords {
 		return xorBitmapGeneric(a, b)
 	}
 
@@ -378,25 +663,36 @@ func xorBitmapImpl(a, b []uint64) []uint64 {
 	}
 
 	result := make([]uint64, length)
-	xorBitmapAVX2(&result[0], &a[0], &b[0], length)
+	if ks.bitmap == ISAAVX512 {
+		xorBitmapAVX512(&result[0], &a[0], &b[0], length)
+	} else {
+		xorBitmapAVX2(&result[0], &a[0], &b[0], length)
+	}
 	return result
 }
 
-func notBitmapImpl(a []uint64) []uint64 {
-	if !HasAVX2() || len(a) < 8 {
+func notBitmapImpl(ks *kernelSet, a []uint64) []uint64 {
+	if ks.bitmap == ISAGeneric || len(a) < ks.config().MinBitmapWords {
 		return notBitmapGeneric(a)
 	}
 
 	result := make([]uint64, len(a))
-	notBitmapAVX2(&result[0], &a[0], len(a))
+	if ks.bitmap == ISAAVX512 {
+		notBitmapAVX512(&result[0], &a[0], len(a))
+	} else {
+		notBitmapAVX2(&result[0], &a[0], len(a))
+	}
 	return result
 }
 
-func popCountImpl(bitmap []uint64) int {
-	if !HasAVX2() || len(bitmap) < 8 {
+func popCountImpl(ks *kernelSet, bitmap []uint64) int {
+	if ks.popCount == ISAGeneric || len(bitmap) < ks.config().MinPopCountWords {
 		return popCountGeneric(bitmap)
 	}
 
+	if ks.popCount == ISAAVX512 {
+		return popCountAVX512(&bitmap[0], len(bitmap))
+	}
 	return popCountAVX2(&bitmap[0], len(bitmap))
 }
 
@@ -404,15 +700,18 @@ func popCountImpl(bitmap []uint64) int {
 // Phase 2: Aggregation Operations
 // ============================================================================
 
-func sumInt64Impl(values []int64) int64 {
-	if !HasAVX2() || len(values) < 16 {
+func sumInt64Impl(ks *kernelSet, values []int64) int64 {
+	if ks.sum == ISAGeneric || len(values) < ks.config().MinAggregate {
 		return sumInt64Generic(values)
 	}
 
+	if ks.sum == ISAAVX512 {
+		return sumInt64AVX512(&values[0], len(values))
+	}
 	return sumInt64AVX2(&values[0], len(values))
 }
 
-func minInt64Impl(values []int64) int64 {
+func minInt64Impl(ks *kernelSet, values []int64) int64 {
 	// TODO: AVX2 min/max needs debugging - use generic for now
 	return minInt64Generic(values)
 
@@ -422,7 +721,7 @@ func minInt64Impl(values []int64) int64 {
 	// return minInt64AVX2(&values[0], len(values))
 }
 
-func maxInt64Impl(values []int64) int64 {
+func maxInt64Impl(ks *kernelSet, values []int64) int64 {
 	// TODO: AVX2 min/max needs debugging - use generic for now
 	return maxInt64Generic(values)
 
@@ -432,12 +731,46 @@ func maxInt64Impl(values []int64) int64 {
 	// return maxInt64AVX2(&values[0], len(values))
 }
 
-func countNonNullImpl(values []int64, nullBitmap []uint64) int64 {
+// minFloat64Impl and maxFloat64Impl skip NaN values, returning +Inf and -Inf
+// respectively if every value is NaN.
+func minFloat64Impl(ks *kernelSet, values []float64) float64 {
+	if ks.floatMinMax == ISAGeneric || len(values) < ks.config().MinAggregate {
+		return minFloat64Generic(values)
+	}
+
+	// Process 8 elements at a time
+	n := len(values) &^ 7
+	min := minFloat64Generic(values[n:])
+	if n > 0 {
+		if m := minFloat64AVX2(&values[0], n); m < min {
+			min = m
+		}
+	}
+	return min
+}
+
+func maxFloat64Impl(ks *kernelSet, values []float64) float64 {
+	if ks.floatMinMax == ISAGeneric || len(values) < ks.config().MinAggregate {
+		return maxFloat64Generic(values)
+	}
+
+	// Process 8 elements at a time
+	n := len(values) &^ 7
+	max := maxFloat64Generic(values[n:])
+	if n > 0 {
+		if m := maxFloat64AVX2(&values[0], n); m > max {
+			max = m
+		}
+	}
+	return max
+}
+
+func countNonNullImpl(ks *kernelSet, values []int64, nullBitmap []uint64) int64 {
 	if len(nullBitmap) == 0 {
 		return int64(len(values))
 	}
 
-	if !HasAVX2() || len(values) < 16 {
+	if ks.countNonNull == ISAGeneric || len(values) < ks.config().MinAggregate {
 		return countNonNullGeneric(values, nullBitmap)
 	}
 
@@ -448,7 +781,7 @@ func countNonNullImpl(values []int64, nullBitmap []uint64) int64 {
 // Phase 3: Hashing Operations
 // ============================================================================
 
-func hashInt64Impl(values []int64, output []uint64) {
+func hashInt64Impl(ks *kernelSet, values []int64, output []uint64) {
 	// TODO: AVX2 FNV hash needs debugging - use generic for now
 	hashInt64SliceGeneric(values, output)
 	return
@@ -468,7 +801,7 @@ func hashInt64Impl(values []int64, output []uint64) {
 	// }
 }
 
-func crc32Int64Impl(values []int64, output []uint32) {
+func crc32Int64Impl(ks *kernelSet, values []int64, output []uint32) {
 	// TODO: AVX2 CRC32C needs debugging - use generic for now
 	crc32Int64SliceGeneric(values, output)
 	return
@@ -481,16 +814,21 @@ func crc32Int64Impl(values []int64, output []uint32) {
 	// crc32Int64AVX2(&values[0], &output[0], len(values))
 }
 
-func xxhash64Impl(values []int64, output []uint64) {
-	if !HasAVX2() || len(values) < 16 {
+func xxhash64Impl(ks *kernelSet, values []int64, output []uint64) {
+	if ks.xxhash == ISAGeneric || len(values) < ks.config().MinHash {
 		xxhash64SliceGeneric(values, output)
 		return
 	}
 
 	i := 0
-	// Process 4 elements at a time with AVX2
-	for ; i+3 < len(values); i += 4 {
-		xxhash64AVX2(&values[i], &output[i], 4)
+	if ks.xxhash == ISAAVX512 {
+		// Process 8 elements at a time with native 64-bit multiplies
+		i = len(values) &^ 7
+		xxhash64AVX512(&values[0], &output[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		i = len(values) &^ 3
+		xxhash64AVX2(&values[0], &output[0], i)
 	}
 
 	// Handle remainder with scalar
@@ -499,16 +837,44 @@ func xxhash64Impl(values []int64, output []uint64) {
 	}
 }
 
+// xxhash64BytesImpl hashes one byte string. Each of the four stripe accumulators is
+// a chain of dependent 64-bit multiplies, which IMUL runs faster than the AVX2
+// multiply emulated with VPMULUDQ or the long-latency VPMULLQ of AVX-512, so every
+// ISA uses the generic loop.
+func xxhash64BytesImpl(ks *kernelSet, data []byte) uint64 {
+	return xxhash64BytesGeneric(data)
+}
+
 // ============================================================================
 // Phase 4: String Operations
 // ============================================================================
 
-func strCmpImpl(a, b []byte) int {
-	return strCmpGeneric(a, b)
+// strCmpImpl compares a and b lexicographically. The strDiffAVX2 kernel finds the
+// first differing byte 32 bytes at a time; without one, the shorter slice
+// orders first.
+func strCmpImpl(ks *kernelSet, a, b []byte) int {
+	n := min(len(a), len(b))
+	if ks.strings == ISAGeneric || n < 32 {
+		return strCmpGeneric(a, b)
+	}
+
+	if i := strDiffAVX2(&a[0], &b[0], n); i < n {
+		if a[i] < b[i] {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
 }
 
-func strPrefixCmpImpl(str, prefix []byte) bool {
-	if !HasAVX2() || len(prefix) < 32 {
+func strPrefixCmpImpl(ks *kernelSet, str, prefix []byte) bool {
+	if ks.strings == ISAGeneric || len(prefix) < 32 {
 		return strPrefixCmpGeneric(str, prefix)
 	}
 
@@ -520,7 +886,7 @@ func strPrefixCmpImpl(str, prefix []byte) bool {
 	return result == 1
 }
 
-func strEqImpl(a, b []byte) bool {
+func strEqImpl(ks *kernelSet, a, b []byte) bool {
 	if len(a) != len(b) {
 		return false
 	}
@@ -529,7 +895,7 @@ func strEqImpl(a, b []byte) bool {
 		return true
 	}
 
-	if !HasAVX2() || len(a) < 32 {
+	if ks.strings == ISAGeneric || len(a) < 32 {
 		return strEqGeneric(a, b)
 	}
 
@@ -537,12 +903,100 @@ func strEqImpl(a, b []byte) bool {
 	return result == 1
 }
 
-func strToLowerImpl(s []byte) {
+// strIndexImpl returns the index of the first occurrence of substr in str, or
+// -1. The strIndexAVX2 kernel filters 32 start positions at a time on the first and
+// last byte of substr and verifies only the candidates; it needs at least one
+// full block of start positions.
+func strIndexImpl(ks *kernelSet, str, substr []byte) int {
+	switch {
+	case len(substr) == 0:
+		return 0
+	case len(substr) == 1:
+		return bytes.IndexByte(str, substr[0])
+	case len(substr) > len(str):
+		return -1
+	}
+
+	if ks.strings == ISAGeneric || len(str)-len(substr)+1 < 32 {
+		return strIndexGeneric(str, substr)
+	}
+
+	return strIndexAVX2(&str[0], len(str), &substr[0], len(substr))
+}
+
+func strContainsImpl(ks *kernelSet, str, substr []byte) bool {
+	return strIndexImpl(ks, str, substr) >= 0
+}
+
+// teddyFindImpl returns the first start position p >= from in s at which a
+// Teddy fingerprint of length m matches, or -1. The teddyAVX2 kernel checks 32
+// start positions per block and needs at least one full block.
+func teddyFindImpl(ks *kernelSet, s []byte, masks *[3][2][16]byte, m, from int) int {
+	if from > len(s)-m {
+		return -1
+	}
+
+	if ks.strings == ISAGeneric || len(s)-m+1 < 32 {
+		return teddyFindGeneric(s, masks, m, from)
+	}
+
+	return teddyAVX2(&s[0], len(s), masks, m, from)
+}
+
+// asciiPrefixImpl returns the index of the first non-ASCII byte of s, or
+// len(s). The asciiPrefixAVX2 kernel tests 32 bytes per block.
+func asciiPrefixImpl(ks *kernelSet, s []byte) int {
+	if ks.strings == ISAGeneric || len(s) < 32 {
+		return asciiPrefixGeneric(s)
+	}
+
+	return asciiPrefixAVX2(&s[0], len(s))
+}
+
+// utf8ValidImpl reports whether s is valid UTF-8. The utf8ValidAVX2 kernel checks the
+// whole 32-byte blocks; utf8.Valid checks the rest, from the start of a
+// sequence the last block may cut off.
+func utf8ValidImpl(ks *kernelSet, s []byte) bool {
+	if ks.strings == ISAGeneric || len(s) < 32 {
+		return utf8.Valid(s)
+	}
+
+	m := len(s) &^ 31
+	if utf8ValidAVX2(&s[0], m) == 0 {
+		return false
+	}
+	return utf8.Valid(s[utf8TailStart(s, m):])
+}
+
+// charLengthImpl returns the number of bytes of s that are not UTF-8
+// continuation bytes. The charLengthAVX2 kernel counts the whole 32-byte blocks.
+func charLengthImpl(ks *kernelSet, s []byte) int {
+	if ks.strings == ISAGeneric || len(s) < 32 {
+		return charLengthGeneric(s)
+	}
+
+	m := len(s) &^ 31
+	return charLengthAVX2(&s[0], m) + charLengthGeneric(s[m:])
+}
+
+func strEqIgnoreCaseImpl(ks *kernelSet, a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	if ks.strings == ISAGeneric || len(a) < 32 {
+		return strEqIgnoreCaseGeneric(a, b)
+	}
+
+	return strEqFoldAVX2(&a[0], &b[0], len(a)) == 1
+}
+
+func strToLowerImpl(ks *kernelSet, s []byte) {
 	if len(s) == 0 {
 		return
 	}
 
-	if !HasAVX2() || len(s) < 32 {
+	if ks.strings == ISAGeneric || len(s) < 32 {
 		strToLowerGeneric(s)
 		return
 	}
@@ -550,12 +1004,12 @@ func strToLowerImpl(s []byte) {
 	strToLowerAVX2(&s[0], len(s))
 }
 
-func strToUpperImpl(s []byte) {
+func strToUpperImpl(ks *kernelSet, s []byte) {
 	if len(s) == 0 {
 		return
 	}
 
-	if !HasAVX2() || len(s) < 32 {
+	if ks.strings == ISAGeneric || len(s) < 32 {
 		strToUpperGeneric(s)
 		return
 	}
@@ -565,21 +1019,27 @@ func strToUpperImpl(s []byte) {
 
 // Float64 comparison implementations
 
-func cmpGtFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpGtFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpGtFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	// Process 4 elements at a time with AVX2
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpGtFloat64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpGtFloat64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpGtFloat64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	// Handle remainder with scalar
@@ -590,8 +1050,8 @@ func cmpGtFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpGtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpGtFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpGtFloat64MaskGeneric(values, threshold)
 	}
 
@@ -599,15 +1059,21 @@ func cmpGtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	// Process 4 elements at a time
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpGtFloat64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
-
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpGtFloat64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpGtFloat64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
+
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -624,20 +1090,27 @@ func cmpGtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	return mask
 }
 
-func cmpGeFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpGeFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpGeFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpGeFloat64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpGeFloat64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpGeFloat64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	for ; i < len(values); i++ {
@@ -647,8 +1120,8 @@ func cmpGeFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpGeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpGeFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpGeFloat64MaskGeneric(values, threshold)
 	}
 
@@ -656,14 +1129,21 @@ func cmpGeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpGeFloat64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpGeFloat64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpGeFloat64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
 
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -679,20 +1159,27 @@ func cmpGeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	return mask
 }
 
-func cmpLtFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpLtFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpLtFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpLtFloat64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpLtFloat64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpLtFloat64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	for ; i < len(values); i++ {
@@ -702,8 +1189,8 @@ func cmpLtFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpLtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpLtFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpLtFloat64MaskGeneric(values, threshold)
 	}
 
@@ -711,14 +1198,21 @@ func cmpLtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpLtFloat64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpLtFloat64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpLtFloat64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
 
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -734,20 +1228,27 @@ func cmpLtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	return mask
 }
 
-func cmpLeFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpLeFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpLeFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpLeFloat64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpLeFloat64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpLeFloat64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	for ; i < len(values); i++ {
@@ -757,8 +1258,8 @@ func cmpLeFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpLeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpLeFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpLeFloat64MaskGeneric(values, threshold)
 	}
 
@@ -766,14 +1267,21 @@ func cmpLeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpLeFloat64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpLeFloat64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpLeFloat64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
 
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -789,20 +1297,27 @@ func cmpLeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	return mask
 }
 
-func cmpEqFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpEqFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpEqFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpEqFloat64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpEqFloat64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpEqFloat64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	for ; i < len(values); i++ {
@@ -812,8 +1327,8 @@ func cmpEqFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpEqFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpEqFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpEqFloat64MaskGeneric(values, threshold)
 	}
 
@@ -821,14 +1336,21 @@ func cmpEqFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpEqFloat64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpEqFloat64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpEqFloat64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
 
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -844,20 +1366,27 @@ func cmpEqFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	return mask
 }
 
-func cmpNeFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasAVX2() || len(values) < 16 {
+func cmpNeFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpNeFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		mask := cmpNeFloat64AVX2(&values[i], threshold)
-		results[i+0] = (mask & 0x1) != 0
-		results[i+1] = (mask & 0x2) != 0
-		results[i+2] = (mask & 0x4) != 0
-		results[i+3] = (mask & 0x8) != 0
+	if ks.compare == ISAAVX512 {
+		// Process 8 elements at a time, expanding the mask register straight into bools
+		i = len(values) &^ 7
+		cmpNeFloat64AVX512(&values[0], threshold, &results[0], i)
+	} else {
+		// Process 4 elements at a time with AVX2
+		for ; i+3 < len(values); i += 4 {
+			mask := cmpNeFloat64AVX2(&values[i], threshold)
+			results[i+0] = (mask & 0x1) != 0
+			results[i+1] = (mask & 0x2) != 0
+			results[i+2] = (mask & 0x4) != 0
+			results[i+3] = (mask & 0x8) != 0
+		}
 	}
 
 	for ; i < len(values); i++ {
@@ -867,8 +1396,8 @@ func cmpNeFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpNeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasAVX2() || len(values) < 16 {
+func cmpNeFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpNeFloat64MaskGeneric(values, threshold)
 	}
 
@@ -876,14 +1405,21 @@ func cmpNeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	mask := make([]uint64, numWords)
 	i := 0
 
-	for ; i+3 < len(values); i += 4 {
-		cmpMask := cmpNeFloat64AVX2(&values[i], threshold)
-		wordIdx := i / 64
-		bitIdx := uint(i % 64)
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		cmpNeFloat64MaskAVX512(&values[0], threshold, &mask[0], i)
+	} else {
+		// Process 4 elements at a time
+		for ; i+3 < len(values); i += 4 {
+			cmpMask := cmpNeFloat64AVX2(&values[i], threshold)
+			wordIdx := i / 64
+			bitIdx := uint(i % 64)
 
-		for lane := 0; lane < 4 && i+lane < len(values); lane++ {
-			if (cmpMask & (1 << uint(lane))) != 0 {
-				mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+			for lane := 0; lane < 4 && i+lane < len(values); lane++ {
+				if (cmpMask & (1 << uint(lane))) != 0 {
+					mask[wordIdx] |= 1 << (bitIdx + uint(lane))
+				}
 			}
 		}
 	}
@@ -903,10 +1439,11 @@ func cmpNeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 // String Comparisons
 // ============================================================================
 
-func cmpEqStringImpl(values [][]byte, threshold []byte) []bool {
+func cmpEqStringImpl(ks *kernelSet, values [][]byte, threshold []byte) []bool {
 	// Use adaptive threshold based on average string length
-	minStrings, avgByteThreshold := GetStringSIMDThreshold()
-	if len(values) < minStrings {
+	cfg := ks.config()
+	minStrings, avgByteThreshold := cfg.MinStrings, cfg.AvgByteThreshold
+	if ks.strings == ISAGeneric || len(values) < minStrings {
 		return cmpEqStringGeneric(values, threshold)
 	}
 
@@ -937,28 +1474,28 @@ func cmpEqStringImpl(values [][]byte, threshold []byte) []bool {
 	return results
 }
 
-func cmpEqStringMaskImpl(values [][]byte, threshold []byte) []uint64 {
-	bools := cmpEqStringImpl(values, threshold)
+func cmpEqStringMaskImpl(ks *kernelSet, values [][]byte, threshold []byte) []uint64 {
+	bools := cmpEqStringImpl(ks, values, threshold)
 	return boolsToBitmask(bools)
 }
 
-func cmpNeStringImpl(values [][]byte, threshold []byte) []bool {
-	eqResults := cmpEqStringImpl(values, threshold)
+func cmpNeStringImpl(ks *kernelSet, values [][]byte, threshold []byte) []bool {
+	eqResults := cmpEqStringImpl(ks, values, threshold)
 	for i := range eqResults {
 		eqResults[i] = !eqResults[i]
 	}
 	return eqResults
 }
 
-func cmpNeStringMaskImpl(values [][]byte, threshold []byte) []uint64 {
-	bools := cmpNeStringImpl(values, threshold)
+func cmpNeStringMaskImpl(ks *kernelSet, values [][]byte, threshold []byte) []uint64 {
+	bools := cmpNeStringImpl(ks, values, threshold)
 	return boolsToBitmask(bools)
 }
 
-func cmpHasPrefixStringImpl(values [][]byte, prefix []byte) []bool {
+func cmpHasPrefixStringImpl(ks *kernelSet, values [][]byte, prefix []byte) []bool {
 	// Adaptive routing similar to equality
-	minStrings, _ := GetStringSIMDThreshold()
-	if len(values) < minStrings {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
 		return cmpHasPrefixStringGeneric(values, prefix)
 	}
 
@@ -979,14 +1516,14 @@ func cmpHasPrefixStringImpl(values [][]byte, prefix []byte) []bool {
 	return results
 }
 
-func cmpHasPrefixStringMaskImpl(values [][]byte, prefix []byte) []uint64 {
-	bools := cmpHasPrefixStringImpl(values, prefix)
+func cmpHasPrefixStringMaskImpl(ks *kernelSet, values [][]byte, prefix []byte) []uint64 {
+	bools := cmpHasPrefixStringImpl(ks, values, prefix)
 	return boolsToBitmask(bools)
 }
 
-func cmpHasSuffixStringImpl(values [][]byte, suffix []byte) []bool {
-	minStrings, _ := GetStringSIMDThreshold()
-	if len(values) < minStrings {
+func cmpHasSuffixStringImpl(ks *kernelSet, values [][]byte, suffix []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
 		return cmpHasSuffixStringGeneric(values, suffix)
 	}
 
@@ -1008,37 +1545,767 @@ func cmpHasSuffixStringImpl(values [][]byte, suffix []byte) []bool {
 	return results
 }
 
-func cmpHasSuffixStringMaskImpl(values [][]byte, suffix []byte) []uint64 {
-	bools := cmpHasSuffixStringImpl(values, suffix)
+func cmpHasSuffixStringMaskImpl(ks *kernelSet, values [][]byte, suffix []byte) []uint64 {
+	bools := cmpHasSuffixStringImpl(ks, values, suffix)
 	return boolsToBitmask(bools)
 }
 
-func cmpContainsStringImpl(values [][]byte, substr []byte) []bool {
-	// Contains is more complex; use generic for now
-	// Future optimization: SIMD-accelerated substring search
-	return cmpContainsStringGeneric(values, substr)
+func cmpContainsStringImpl(ks *kernelSet, values [][]byte, substr []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpContainsStringGeneric(values, substr)
+	}
+
+	// strIndexImpl routes rows too short for a full block to the scalar search
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = strIndexImpl(ks, v, substr) >= 0
+	}
+	return results
 }
 
-func cmpContainsStringMaskImpl(values [][]byte, substr []byte) []uint64 {
-	bools := cmpContainsStringImpl(values, substr)
+func cmpContainsStringMaskImpl(ks *kernelSet, values [][]byte, substr []byte) []uint64 {
+	bools := cmpContainsStringImpl(ks, values, substr)
 	return boolsToBitmask(bools)
 }
 
-func cmpEqStringIgnoreCaseImpl(values [][]byte, threshold []byte) []bool {
-	// Case-insensitive requires byte transformation; use generic
-	return cmpEqStringIgnoreCaseGeneric(values, threshold)
+func cmpEqStringIgnoreCaseImpl(ks *kernelSet, values [][]byte, threshold []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpEqStringIgnoreCaseGeneric(values, threshold)
+	}
+
+	// strEqIgnoreCaseImpl folds rows of at least one block with strEqFoldAVX2
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = strEqIgnoreCaseImpl(ks, v, threshold)
+	}
+	return results
 }
 
-func cmpEqStringIgnoreCaseMaskImpl(values [][]byte, threshold []byte) []uint64 {
-	bools := cmpEqStringIgnoreCaseImpl(values, threshold)
+func cmpEqStringIgnoreCaseMaskImpl(ks *kernelSet, values [][]byte, threshold []byte) []uint64 {
+	bools := cmpEqStringIgnoreCaseImpl(ks, values, threshold)
 	return boolsToBitmask(bools)
 }
 
-func cmpMatchWildcardImpl(values [][]byte, pattern []byte) []bool {
+// cmpOrderStringImpl compares values lexicographically against threshold with
+// op, comparing each row with strCmpImpl.
+func cmpOrderStringImpl(ks *kernelSet, values [][]byte, op Op, threshold []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpOrderStringGeneric(values, op, threshold)
+	}
+
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = orderMatches(op, strCmpImpl(ks, v, threshold))
+	}
+	return results
+}
+
+func cmpBetweenStringImpl(ks *kernelSet, values [][]byte, lo, hi []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpBetweenStringGeneric(values, lo, hi)
+	}
+
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = strCmpImpl(ks, v, lo) >= 0 && strCmpImpl(ks, v, hi) <= 0
+	}
+	return results
+}
+
+func cmpHasPrefixStringIgnoreCaseImpl(ks *kernelSet, values [][]byte, prefix []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpHasPrefixStringIgnoreCaseGeneric(values, prefix)
+	}
+
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = len(v) >= len(prefix) && strEqIgnoreCaseImpl(ks, v[:len(prefix)], prefix)
+	}
+	return results
+}
+
+func cmpHasSuffixStringIgnoreCaseImpl(ks *kernelSet, values [][]byte, suffix []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpHasSuffixStringIgnoreCaseGeneric(values, suffix)
+	}
+
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = len(v) >= len(suffix) && strEqIgnoreCaseImpl(ks, v[len(v)-len(suffix):], suffix)
+	}
+	return results
+}
+
+func cmpMatchWildcardImpl(ks *kernelSet, values [][]byte, pattern []byte) []bool {
 	return cmpMatchWildcardGeneric(values, pattern)
 }
 
-func cmpMatchWildcardMaskImpl(values [][]byte, pattern []byte) []uint64 {
-	bools := cmpMatchWildcardImpl(values, pattern)
+func cmpMatchWildcardMaskImpl(ks *kernelSet, values [][]byte, pattern []byte) []uint64 {
+	bools := cmpMatchWildcardImpl(ks, values, pattern)
 	return boolsToBitmask(bools)
 }
+
+// ============================================================================
+// Selection Materialization (compress, gather, scatter)
+// ============================================================================
+
+func compressInt64Impl(ks *kernelSet, dst, src []int64, mask []uint64) int {
+	if ks.compress == ISAGeneric || len(src) < ks.config().MinCompress {
+		return compressInt64Generic(dst, src, mask)
+	}
+
+	// Only rows covered by the mask can be selected
+	length := len(src)
+	if len(mask)*64 < length {
+		length = len(mask) * 64
+	}
+
+	// The kernels assume every selected row fits; let the generic path handle truncation
+	selected := selectedCount(mask, length)
+	if selected > len(dst) {
+		return compressInt64Generic(dst, src, mask)
+	}
+	if selected == 0 {
+		return 0
+	}
+
+	var n, written int
+	if ks.compress == ISAAVX512 {
+		n = length &^ 7
+		if n > 0 {
+			written = compressInt64AVX512(&dst[0], &src[0], &mask[0], n)
+		}
+	} else {
+		n = length &^ 3
+		if n > 0 {
+			written = compressInt64AVX2(&dst[0], &src[0], &mask[0], n)
+		}
+	}
+
+	// Handle remainder with scalar
+	for i := n; i < length; i++ {
+		if mask[i/64]&(1<<uint(i%64)) != 0 {
+			dst[written] = src[i]
+			written++
+		}
+	}
+
+	return written
+}
+
+func compressFloat64Impl(ks *kernelSet, dst, src []float64, mask []uint64) int {
+	// Compression only moves bits, so float64 reuses the int64 kernels
+	return compressInt64Impl(ks, float64sAsInt64s(dst), float64sAsInt64s(src), mask)
+}
+
+func compressStringsImpl(ks *kernelSet, dst, src []string, mask []uint64) int {
+	// String headers hold pointers, so they must be copied by Go code for the GC's sake
+	return compressStringsGeneric(dst, src, mask)
+}
+
+func gatherInt64Impl(ks *kernelSet, dst, src []int64, indices []uint32) {
+	if ks.compress == ISAGeneric || len(indices) < ks.config().MinCompress || len(src) > 1<<31-1 || int(maxUint32(indices)) >= len(src) {
+		// The generic path also produces the index out of range panic for bad indices
+		gatherInt64Generic(dst, src, indices)
+		return
+	}
+
+	n := 0
+	if ks.compress == ISAAVX512 {
+		n = len(indices) &^ 7
+		gatherInt64AVX512(&dst[0], &src[0], &indices[0], n)
+	} else {
+		n = len(indices) &^ 3
+		gatherInt64AVX2(&dst[0], &src[0], &indices[0], n)
+	}
+
+	// Handle remainder with scalar
+	for i := n; i < len(indices); i++ {
+		dst[i] = src[indices[i]]
+	}
+}
+
+func scatterInt64Impl(ks *kernelSet, dst, src []int64, indices []uint32) {
+	// AVX2 has no scatter instruction; only AVX-512 accelerates this
+	if ks.scatter != ISAAVX512 || len(indices) < ks.config().MinCompress || len(dst) > 1<<31-1 || int(maxUint32(indices)) >= len(dst) {
+		scatterInt64Generic(dst, src, indices)
+		return
+	}
+
+	n := len(indices) &^ 7
+	scatterInt64AVX512(&dst[0], &src[0], &indices[0], n)
+
+	// Handle remainder with scalar
+	for i := n; i < len(indices); i++ {
+		dst[indices[i]] = src[i]
+	}
+}
+
+// ============================================================================
+// Narrow Integer Operations
+// ============================================================================
+
+// narrowLayout returns the element width of T in bytes and, for unsigned types,
+// the sign bit that the compare kernels XOR into each lane to reuse the signed
+// compare instructions. Signed types get a zero bias.
+func narrowLayout[T narrowInt]() (width int, bias uint32) {
+	var zero T
+	width = int(unsafe.Sizeof(zero))
+	if ^zero > 0 {
+		bias = 1 << (8*width - 1)
+	}
+	return width, bias
+}
+
+// narrowCmpKernel is the signature shared by the AVX2 narrow compare kernels.
+type narrowCmpKernel func(values unsafe.Pointer, threshold, bias uint32, mask *uint64, length int)
+
+// narrowCmpKernelsAVX2 holds the ==, > and < kernels, indexed by log2 of the
+// element width. The unsigned types share them through the bias.
+var narrowCmpKernelsAVX2 = [3][3]narrowCmpKernel{
+	{cmpEqInt8MaskAVX2, cmpGtInt8MaskAVX2, cmpLtInt8MaskAVX2},
+	{cmpEqInt16MaskAVX2, cmpGtInt16MaskAVX2, cmpLtInt16MaskAVX2},
+	{cmpEqInt32MaskAVX2, cmpGtInt32MaskAVX2, cmpLtInt32MaskAVX2},
+}
+
+// narrowMinKernelsAVX2 and narrowMaxKernelsAVX2 are indexed by signedness
+// (0 signed, 1 unsigned), then by log2 of the element width.
+var narrowMinKernelsAVX2 = [2][3]func(values unsafe.Pointer, size int, acc unsafe.Pointer){
+	{minInt8AVX2, minInt16AVX2, minInt32AVX2},
+	{minUint8AVX2, minUint16AVX2, minUint32AVX2},
+}
+
+var narrowMaxKernelsAVX2 = [2][3]func(values unsafe.Pointer, size int, acc unsafe.Pointer){
+	{maxInt8AVX2, maxInt16AVX2, maxInt32AVX2},
+	{maxUint8AVX2, maxUint16AVX2, maxUint32AVX2},
+}
+
+// narrowIndex returns the kernel table indexes for T: 0 for signed or 1 for
+// unsigned, and log2 of the element width.
+func narrowIndex[T narrowInt]() (sign, width int) {
+	w, bias := narrowLayout[T]()
+	if bias != 0 {
+		sign = 1
+	}
+	return sign, bits.TrailingZeros(uint(w))
+}
+
+func cmpNarrowImpl[T narrowInt](ks *kernelSet, values []T, op Op, threshold T) []bool {
+	if ks.narrow == ISAGeneric || len(values) < ks.config().MinNarrow {
+		return cmpNumberGeneric(values, op, threshold)
+	}
+	return bitmaskToBools(cmpNarrowMaskImpl(ks, values, op, threshold), len(values))
+}
+
+func cmpNarrowMaskImpl[T narrowInt](ks *kernelSet, values []T, op Op, threshold T) []uint64 {
+	if ks.narrow == ISAGeneric || len(values) < ks.config().MinNarrow {
+		return cmpNumberMaskGeneric(values, op, threshold)
+	}
+
+	mask := make([]uint64, (len(values)+63)/64)
+
+	// Process 64 elements at a time, one result word per iteration
+	n := len(values) &^ 63
+	if n > 0 {
+		_, bias := narrowLayout[T]()
+		_, width := narrowIndex[T]()
+
+		// Only ==, > and < have kernels; !=, <= and >= invert their words
+		kernels := narrowCmpKernelsAVX2[width]
+		kernel, invert := kernels[0], false
+		switch op {
+		case OpNe:
+			invert = true
+		case OpGt:
+			kernel = kernels[1]
+		case OpLe:
+			kernel, invert = kernels[1], true
+		case OpLt:
+			kernel = kernels[2]
+		case OpGe:
+			kernel, invert = kernels[2], true
+		}
+
+		// uint32(threshold) sign-extends signed types; the kernels only read the
+		// low width bytes, where the bias flips the sign bit
+		kernel(unsafe.Pointer(&values[0]), uint32(threshold)^bias, bias, &mask[0], n)
+		if invert {
+			for i := range mask[:n/64] {
+				mask[i] = ^mask[i]
+			}
+		}
+	}
+
+	// Handle remainder with scalar
+	cmpNumberTail(values, op, threshold, mask, n)
+	return mask
+}
+
+func sumSignedImpl[T signedNarrowInt](ks *kernelSet, values []T) int64 {
+	if ks.narrow == ISAGeneric || len(values) < ks.config().MinNarrow {
+		return sumSignedGeneric(values)
+	}
+
+	// Process 16 elements at a time
+	n := len(values) &^ 15
+	var sum int64
+	if n > 0 {
+		p := unsafe.Pointer(&values[0])
+		switch width, _ := narrowLayout[T](); width {
+		case 1:
+			sum = sumInt8AVX2(p, n)
+		case 2:
+			sum = sumInt16AVX2(p, n)
+		default:
+			sum = sumInt32AVX2(p, n)
+		}
+	}
+
+	// Handle remainder with scalar
+	return sum + sumSignedGeneric(values[n:])
+}
+
+func sumUnsignedImpl[T unsignedNarrowInt](ks *kernelSet, values []T) uint64 {
+	if ks.narrow == ISAGeneric || len(values) < ks.config().MinNarrow {
+		return sumUnsignedGeneric(values)
+	}
+
+	// Process 16 elements at a time
+	n := len(values) &^ 15
+	var sum uint64
+	if n > 0 {
+		p := unsafe.Pointer(&values[0])
+		switch width, _ := narrowLayout[T](); width {
+		case 1:
+			sum = uint64(sumUint8AVX2(p, n))
+		case 2:
+			sum = uint64(sumUint16AVX2(p, n))
+		default:
+			sum = uint64(sumUint32AVX2(p, n))
+		}
+	}
+
+	// Handle remainder with scalar
+	return sum + sumUnsignedGeneric(values[n:])
+}
+
+// minMaxNarrowImpl reduces values with the given AVX2 kernel, which leaves one
+// vector of per-lane results, then finishes with reduce over those lanes and the tail.
+func minMaxNarrowImpl[T narrowInt](ks *kernelSet, values []T, kernel func(unsafe.Pointer, int, unsafe.Pointer), reduce func([]T) T) T {
+	width, _ := narrowLayout[T]()
+	size := len(values) * width &^ 31
+	if size == 0 {
+		return reduce(values)
+	}
+
+	// [8]uint32 is 32 bytes with the alignment of the widest element type
+	var acc [8]uint32
+	kernel(unsafe.Pointer(&values[0]), size, unsafe.Pointer(&acc[0]))
+	lanes := unsafe.Slice((*T)(unsafe.Pointer(&acc[0])), 32/width)
+
+	if tail := values[size/width:]; len(tail) > 0 {
+		return reduce([]T{reduce(lanes), reduce(tail)})
+	}
+	return reduce(lanes)
+}
+
+func minNarrowImpl[T narrowInt](ks *kernelSet, values []T) T {
+	if ks.narrow == ISAGeneric || len(values) < ks.config().MinNarrow {
+		return minNarrowGeneric(values)
+	}
+
+	sign, width := narrowIndex[T]()
+	return minMaxNarrowImpl(ks, values, narrowMinKernelsAVX2[sign][width], minNarrowGeneric[T])
+}
+
+func maxNarrowImpl[T narrowInt](ks *kernelSet, values []T) T {
+	if ks.narrow == ISAGeneric || len(values) < ks.config().MinNarrow {
+		return maxNarrowGeneric(values)
+	}
+
+	sign, width := narrowIndex[T]()
+	return minMaxNarrowImpl(ks, values, narrowMaxKernelsAVX2[sign][width], maxNarrowGeneric[T])
+}
+
+// ============================================================================
+// Float32 Operations
+// ============================================================================
+
+// float32CmpKernelsAVX2 holds the AVX2 compare kernels, indexed by Op.
+var float32CmpKernelsAVX2 = [...]func(values *float32, threshold float32, mask *uint64, length int){
+	OpEq: cmpEqFloat32MaskAVX2,
+	OpNe: cmpNeFloat32MaskAVX2,
+	OpGt: cmpGtFloat32MaskAVX2,
+	OpLt: cmpLtFloat32MaskAVX2,
+	OpGe: cmpGeFloat32MaskAVX2,
+	OpLe: cmpLeFloat32MaskAVX2,
+}
+
+func cmpFloat32Impl(ks *kernelSet, values []float32, op Op, threshold float32) []bool {
+	if ks.float32 == ISAGeneric || len(values) < ks.config().MinFloat32 {
+		return cmpNumberGeneric(values, op, threshold)
+	}
+	return bitmaskToBools(cmpFloat32MaskImpl(ks, values, op, threshold), len(values))
+}
+
+func cmpFloat32MaskImpl(ks *kernelSet, values []float32, op Op, threshold float32) []uint64 {
+	if ks.float32 == ISAGeneric || len(values) < ks.config().MinFloat32 {
+		return cmpNumberMaskGeneric(values, op, threshold)
+	}
+
+	mask := make([]uint64, (len(values)+63)/64)
+
+	// Process 64 elements at a time, one result word per iteration
+	n := len(values) &^ 63
+	if n > 0 {
+		float32CmpKernelsAVX2[op](&values[0], threshold, &mask[0], n)
+	}
+
+	// Handle remainder with scalar
+	cmpNumberTail(values, op, threshold, mask, n)
+	return mask
+}
+
+func sumFloat32Impl(ks *kernelSet, values []float32) float64 {
+	if ks.float32 == ISAGeneric || len(values) < ks.config().MinFloat32 {
+		return sumFloat32Generic(values)
+	}
+
+	// Process 16 elements at a time
+	n := len(values) &^ 15
+	sum := float64(0)
+	if n > 0 {
+		sum = sumFloat32AVX2(&values[0], n)
+	}
+
+	// Handle remainder with scalar
+	return sum + sumFloat32Generic(values[n:])
+}
+
+func minFloat32Impl(ks *kernelSet, values []float32) float32 {
+	if ks.float32 == ISAGeneric || len(values) < ks.config().MinFloat32 {
+		return minFloat32Generic(values)
+	}
+
+	// Process 16 elements at a time
+	n := len(values) &^ 15
+	min := minFloat32Generic(values[n:])
+	if n > 0 {
+		if m := minFloat32AVX2(&values[0], n); m < min {
+			min = m
+		}
+	}
+	return min
+}
+
+func maxFloat32Impl(ks *kernelSet, values []float32) float32 {
+	if ks.float32 == ISAGeneric || len(values) < ks.config().MinFloat32 {
+		return maxFloat32Generic(values)
+	}
+
+	// Process 16 elements at a time
+	n := len(values) &^ 15
+	max := maxFloat32Generic(values[n:])
+	if n > 0 {
+		if m := maxFloat32AVX2(&values[0], n); m > max {
+			max = m
+		}
+	}
+	return max
+}
+
+// float32ToFloat64Impl widens src into dst, which must be at least as long as src.
+func float32ToFloat64Impl(ks *kernelSet, dst []float64, src []float32) {
+	if ks.float32 == ISAGeneric || len(src) < ks.config().MinFloat32 {
+		float32ToFloat64Generic(dst, src)
+		return
+	}
+
+	// Process 8 elements at a time
+	n := len(src) &^ 7
+	if n > 0 {
+		float32ToFloat64AVX2(&dst[0], &src[0], n)
+	}
+
+	// Handle remainder with scalar
+	float32ToFloat64Generic(dst[n:], src[n:])
+}
+
+// float64ToFloat32Impl narrows src into dst, which must be at least as long as src.
+func float64ToFloat32Impl(ks *kernelSet, dst []float32, src []float64) {
+	if ks.float32 == ISAGeneric || len(src) < ks.config().MinFloat32 {
+		float64ToFloat32Generic(dst, src)
+		return
+	}
+
+	// Process 8 elements at a time
+	n := len(src) &^ 7
+	if n > 0 {
+		float64ToFloat32AVX2(&dst[0], &src[0], n)
+	}
+
+	// Handle remainder with scalar
+	float64ToFloat32Generic(dst[n:], src[n:])
+}
+
+// ============================================================================
+// Arithmetic Operations
+// ============================================================================
+
+// arithInt64Impl runs an int64 column-column kernel over the first multiple of 8
+// elements and generic over the rest, returning whether any element overflowed.
+func arithInt64Impl(ks *kernelSet, dst, a, b []int64, kernel func(dst, a, b *int64, length int) bool, generic func(dst, a, b []int64) bool) bool {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		return generic(dst, a, b)
+	}
+
+	// Process 8 elements at a time
+	n := len(a) &^ 7
+	overflow := kernel(&dst[0], &a[0], &b[0], n)
+
+	// Handle remainder with scalar
+	return generic(dst[n:], a[n:], b[n:]) || overflow
+}
+
+// arithInt64ScalarImpl is arithInt64Impl for column-scalar kernels.
+func arithInt64ScalarImpl(ks *kernelSet, dst, a []int64, b int64, kernel func(dst, a *int64, b int64, length int) bool, generic func(dst, a []int64, b int64) bool) bool {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		return generic(dst, a, b)
+	}
+
+	n := len(a) &^ 7
+	overflow := kernel(&dst[0], &a[0], b, n)
+	return generic(dst[n:], a[n:], b) || overflow
+}
+
+// unaryInt64Impl is arithInt64Impl for single-operand kernels.
+func unaryInt64Impl(ks *kernelSet, dst, a []int64, kernel func(dst, a *int64, length int) bool, generic func(dst, a []int64) bool) bool {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		return generic(dst, a)
+	}
+
+	n := len(a) &^ 7
+	overflow := kernel(&dst[0], &a[0], n)
+	return generic(dst[n:], a[n:]) || overflow
+}
+
+// arithFloat64Impl runs a float64 column-column kernel over the first multiple
+// of 8 elements and generic over the rest.
+func arithFloat64Impl(ks *kernelSet, dst, a, b []float64, kernel func(dst, a, b *float64, length int), generic func(dst, a, b []float64)) {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		generic(dst, a, b)
+		return
+	}
+
+	n := len(a) &^ 7
+	kernel(&dst[0], &a[0], &b[0], n)
+	generic(dst[n:], a[n:], b[n:])
+}
+
+// arithFloat64ScalarImpl is arithFloat64Impl for column-scalar kernels.
+func arithFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64, kernel func(dst, a *float64, b float64, length int), generic func(dst, a []float64, b float64)) {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		generic(dst, a, b)
+		return
+	}
+
+	n := len(a) &^ 7
+	kernel(&dst[0], &a[0], b, n)
+	generic(dst[n:], a[n:], b)
+}
+
+// unaryFloat64Impl is arithFloat64Impl for single-operand kernels.
+func unaryFloat64Impl(ks *kernelSet, dst, a []float64, kernel func(dst, a *float64, length int), generic func(dst, a []float64)) {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		generic(dst, a)
+		return
+	}
+
+	n := len(a) &^ 7
+	kernel(&dst[0], &a[0], n)
+	generic(dst[n:], a[n:])
+}
+
+func addInt64Impl(ks *kernelSet, dst, a, b []int64) bool {
+	return arithInt64Impl(ks, dst, a, b, addInt64AVX2, addInt64Generic)
+}
+
+func addInt64ScalarImpl(ks *kernelSet, dst, a []int64, b int64) bool {
+	return arithInt64ScalarImpl(ks, dst, a, b, addInt64ScalarAVX2, addInt64ScalarGeneric)
+}
+
+func subInt64Impl(ks *kernelSet, dst, a, b []int64) bool {
+	return arithInt64Impl(ks, dst, a, b, subInt64AVX2, subInt64Generic)
+}
+
+func subInt64ScalarImpl(ks *kernelSet, dst, a []int64, b int64) bool {
+	return arithInt64ScalarImpl(ks, dst, a, b, subInt64ScalarAVX2, subInt64ScalarGeneric)
+}
+
+func negInt64Impl(ks *kernelSet, dst, a []int64) bool {
+	return unaryInt64Impl(ks, dst, a, negInt64AVX2, negInt64Generic)
+}
+
+func absInt64Impl(ks *kernelSet, dst, a []int64) bool {
+	return unaryInt64Impl(ks, dst, a, absInt64AVX2, absInt64Generic)
+}
+
+func addFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	arithFloat64Impl(ks, dst, a, b, addFloat64AVX2, addFloat64Generic)
+}
+
+func addFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	arithFloat64ScalarImpl(ks, dst, a, b, addFloat64ScalarAVX2, addFloat64ScalarGeneric)
+}
+
+func subFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	arithFloat64Impl(ks, dst, a, b, subFloat64AVX2, subFloat64Generic)
+}
+
+func subFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	arithFloat64ScalarImpl(ks, dst, a, b, subFloat64ScalarAVX2, subFloat64ScalarGeneric)
+}
+
+func mulFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	arithFloat64Impl(ks, dst, a, b, mulFloat64AVX2, mulFloat64Generic)
+}
+
+func mulFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	arithFloat64ScalarImpl(ks, dst, a, b, mulFloat64ScalarAVX2, mulFloat64ScalarGeneric)
+}
+
+func divFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	arithFloat64Impl(ks, dst, a, b, divFloat64AVX2, divFloat64Generic)
+}
+
+func divFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	arithFloat64ScalarImpl(ks, dst, a, b, divFloat64ScalarAVX2, divFloat64ScalarGeneric)
+}
+
+func negFloat64Impl(ks *kernelSet, dst, a []float64) {
+	unaryFloat64Impl(ks, dst, a, negFloat64AVX2, negFloat64Generic)
+}
+
+func absFloat64Impl(ks *kernelSet, dst, a []float64) {
+	unaryFloat64Impl(ks, dst, a, absFloat64AVX2, absFloat64Generic)
+}
+
+// ============================================================================
+// Expression Operations
+// ============================================================================
+
+// int64MaskKernelsAVX512 and the tables below hold the compare kernels used to
+// evaluate expression predicates, indexed by Op.
+var int64MaskKernelsAVX512 = [...]func(values *int64, threshold int64, mask *uint64, length int){
+	OpEq: cmpEqInt64MaskAVX512,
+	OpNe: cmpNeInt64MaskAVX512,
+	OpGt: cmpGtInt64MaskAVX512,
+	OpLt: cmpLtInt64MaskAVX512,
+	OpGe: cmpGeInt64MaskAVX512,
+	OpLe: cmpLeInt64MaskAVX512,
+}
+
+var int64LaneKernelsAVX2 = [...]func(values *int64, threshold int64) uint64{
+	OpEq: cmpEqInt64AVX2,
+	OpNe: cmpNeInt64AVX2,
+	OpGt: cmpGtInt64AVX2,
+	OpLt: cmpLtInt64AVX2,
+	OpGe: cmpGeInt64AVX2,
+	OpLe: cmpLeInt64AVX2,
+}
+
+var float64MaskKernelsAVX512 = [...]func(values *float64, threshold float64, mask *uint64, length int){
+	OpEq: cmpEqFloat64MaskAVX512,
+	OpNe: cmpNeFloat64MaskAVX512,
+	OpGt: cmpGtFloat64MaskAVX512,
+	OpLt: cmpLtFloat64MaskAVX512,
+	OpGe: cmpGeFloat64MaskAVX512,
+	OpLe: cmpLeFloat64MaskAVX512,
+}
+
+var float64LaneKernelsAVX2 = [...]func(values *float64, threshold float64) uint64{
+	OpEq: cmpEqFloat64AVX2,
+	OpNe: cmpNeFloat64AVX2,
+	OpGt: cmpGtFloat64AVX2,
+	OpLt: cmpLtFloat64AVX2,
+	OpGe: cmpGeFloat64AVX2,
+	OpLe: cmpLeFloat64AVX2,
+}
+
+// cmpMaskIntoImpl writes the comparison bitmask for values into mask without
+// allocating. mask must hold at least (len(values)+63)/64 words.
+func cmpMaskIntoImpl[T int64 | float64](ks *kernelSet, values []T, op Op, threshold T, mask []uint64,
+	wide func(*T, T, *uint64, int), lanes func(*T, T) uint64, minLen int) {
+	clear(mask[:(len(values)+63)/64])
+	if ks.compare == ISAGeneric || len(values) < minLen {
+		cmpNumberTail(values, op, threshold, mask, 0)
+		return
+	}
+
+	i := 0
+	if ks.compare == ISAAVX512 {
+		// Process 64 elements at a time, one result word per iteration
+		i = len(values) &^ 63
+		if i > 0 {
+			wide(&values[0], threshold, &mask[0], i)
+		}
+	} else {
+		// Process 4 elements at a time; a group never straddles a word
+		for ; i+3 < len(values); i += 4 {
+			mask[i/64] |= (lanes(&values[i], threshold) & 0xF) << uint(i%64)
+		}
+	}
+
+	// Handle remainder with scalar
+	cmpNumberTail(values, op, threshold, mask, i)
+}
+
+func cmpInt64MaskIntoImpl(ks *kernelSet, values []int64, op Op, threshold int64, mask []uint64) {
+	cmpMaskIntoImpl(ks, values, op, threshold, mask, int64MaskKernelsAVX512[op], int64LaneKernelsAVX2[op], ks.config().MinCmpInt64)
+}
+
+func cmpFloat64MaskIntoImpl(ks *kernelSet, values []float64, op Op, threshold float64, mask []uint64) {
+	cmpMaskIntoImpl(ks, values, op, threshold, mask, float64MaskKernelsAVX512[op], float64LaneKernelsAVX2[op], ks.config().MinCmpFloat64)
+}
+
+// ============================================================================
+// Temporal Operations
+// ============================================================================
+
+// truncFixedImpl rounds each timestamp down to a multiple of width, which must be
+// at least 1<<12 for the float64 quotient estimate of the kernels to be within one.
+func truncFixedImpl(ks *kernelSet, dst, src []int64, width int64) {
+	if ks.arith == ISAGeneric || len(src) < ks.config().MinArith {
+		truncFixedGeneric(dst, src, width)
+		return
+	}
+
+	// Process 4 elements at a time
+	n := len(src) &^ 3
+	truncFixedAVX2(&dst[0], &src[0], n, width, 1/float64(width))
+
+	// Handle remainder with scalar
+	truncFixedGeneric(dst[n:], src[n:], width)
+}
+
+// floorDivModImpl splits each timestamp into floorDiv(v, width) and floorMod(v, width),
+// with width at least 1<<12 as for truncFixedImpl.
+func floorDivModImpl(ks *kernelSet, quo, rem, src []int64, width int64) {
+	if ks.arith == ISAGeneric || len(src) < ks.config().MinArith {
+		floorDivModGeneric(quo, rem, src, width)
+		return
+	}
+
+	// Process 4 elements at a time
+	n := len(src) &^ 3
+	floorDivModAVX2(&quo[0], &rem[0], &src[0], n, width, 1/float64(width))
+
+	// Handle remainder with scalar
+	floorDivModGeneric(quo[n:], rem[n:], src[n:], width)
+}
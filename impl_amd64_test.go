@@ -0,0 +1,175 @@
+//go:build amd64
+
+package syndrdbsimd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// ============================================================================
+// Kernel Selection Tests
+// ============================================================================
+
+func TestSelectX86Kernels(t *testing.T) {
+	tests := []struct {
+		name     string
+		features x86Features
+		expected x86KernelSet
+	}{
+		{
+			name:     "no SIMD",
+			features: x86Features{},
+			expected: x86KernelSet{},
+		},
+		{
+			name:     "AVX2 only",
+			features: x86Features{AVX2: true, BMI2: true},
+			expected: x86KernelSet{
+				compare: ISAAVX2, bitmap: ISAAVX2, popCount: ISAAVX2, sum: ISAAVX2,
+				xxhash: ISAAVX2, compress: ISAAVX2, scatter: ISAGeneric,
+				countNonNull: ISAAVX2, strings: ISAAVX2, narrow: ISAAVX2, float32: ISAAVX2, arith: ISAAVX2,
+				floatMinMax: ISAAVX2,
+			},
+		},
+		{
+			name:     "AVX-512 Foundation only",
+			features: x86Features{AVX2: true, AVX512F: true},
+			expected: x86KernelSet{
+				compare: ISAAVX2, bitmap: ISAAVX512, popCount: ISAAVX2, sum: ISAAVX512,
+				xxhash: ISAAVX2, compress: ISAAVX512, scatter: ISAAVX512,
+				countNonNull: ISAAVX2, strings: ISAAVX2, narrow: ISAAVX2, float32: ISAAVX2, arith: ISAAVX2,
+				floatMinMax: ISAAVX2,
+			},
+		},
+		{
+			name:     "full AVX-512",
+			features: x86Features{AVX2: true, AVX512F: true, AVX512DQ: true, AVX512VPOPCNTDQ: true, BMI2: true},
+			expected: x86KernelSet{
+				compare: ISAAVX512, bitmap: ISAAVX512, popCount: ISAAVX512, sum: ISAAVX512,
+				xxhash: ISAAVX512, compress: ISAAVX512, scatter: ISAAVX512,
+				countNonNull: ISAAVX2, strings: ISAAVX2, narrow: ISAAVX2, float32: ISAAVX2, arith: ISAAVX2,
+				floatMinMax: ISAAVX2,
+			},
+		},
+		{
+			// Extension flags without AVX512F must not select AVX-512 kernels
+			name:     "extensions without Foundation",
+			features: x86Features{AVX2: true, AVX512DQ: true, AVX512VPOPCNTDQ: true, BMI2: true},
+			expected: x86KernelSet{
+				compare: ISAAVX2, bitmap: ISAAVX2, popCount: ISAAVX2, sum: ISAAVX2,
+				xxhash: ISAAVX2, compress: ISAAVX2, scatter: ISAGeneric,
+				countNonNull: ISAAVX2, strings: ISAAVX2, narrow: ISAAVX2, float32: ISAAVX2, arith: ISAAVX2,
+				floatMinMax: ISAAVX2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectX86Kernels(tt.features)
+			if got != tt.expected {
+				t.Errorf("selectX86Kernels(%+v) = %+v, want %+v", tt.features, got, tt.expected)
+			}
+		})
+	}
+}
+
+// withX86Kernels installs ks as the kernel selection for the duration of the test.
+func withX86Kernels(t *testing.T, ks x86KernelSet) {
+	t.Helper()
+	saved := x86Kernels.Load()
+	x86Kernels.Store(&ks)
+	t.Cleanup(func() { x86Kernels.Store(saved) })
+}
+
+// availableX86KernelSets returns the kernel selections the running CPU can execute,
+// from the detected one down to generic. On AVX-512 hardware this forces the AVX2
+// fallbacks, so they stay covered even though dispatch would never pick them.
+func availableX86KernelSets() map[string]x86KernelSet {
+	f := detectX86Features()
+	sets := map[string]x86KernelSet{
+		"detected": selectX86Kernels(f),
+		"generic":  selectX86Kernels(x86Features{}),
+	}
+	if f.AVX2 {
+		sets["AVX2"] = selectX86Kernels(x86Features{AVX2: true, BMI2: f.BMI2})
+	}
+	return sets
+}
+
+// Every kernel level must produce the same results as the generic implementations
+func TestX86Kernels_MatchGeneric(t *testing.T) {
+	// Lengths chosen to cover scalar tails and the 4/8/16/64 element group boundaries
+	sizes := []int{1, 7, 8, 15, 16, 17, 31, 63, 64, 65, 100, 127, 128, 129, 1000}
+
+	for name, ks := range availableX86KernelSets() {
+		t.Run(name, func(t *testing.T) {
+			withX86Kernels(t, ks)
+			rng := rand.New(rand.NewSource(27))
+
+			for _, size := range sizes {
+				ints := make([]int64, size)
+				floats := make([]float64, size)
+				for i := range ints {
+					ints[i] = rng.Int63n(21) - 10
+					floats[i] = float64(ints[i]) / 2
+				}
+				floats[size/2] = math.NaN()
+				threshold := ints[size/3]
+
+				checkInt64Compares(t, size, ints, threshold)
+				checkFloat64Compares(t, size, floats, float64(threshold)/2)
+
+				if got, want := sumInt64Impl(currentKernels(), ints), sumInt64Generic(ints); got != want {
+					t.Errorf("size=%d: SumInt64 = %d, want %d", size, got, want)
+				}
+
+				hashes := make([]uint64, size)
+				xxhash64Impl(currentKernels(), ints, hashes)
+				for i, v := range ints {
+					if hashes[i] != xxhash64Generic(v) {
+						t.Fatalf("size=%d: XXHash64[%d] = %x, want %x", size, i, hashes[i], xxhash64Generic(v))
+					}
+				}
+
+				a := make([]uint64, size)
+				b := make([]uint64, size)
+				for i := range a {
+					a[i] = rng.Uint64()
+					b[i] = rng.Uint64()
+				}
+				checkEqual(t, size, "AndBitmap", andBitmapImpl(currentKernels(), a, b), andBitmapGeneric(a, b))
+				checkEqual(t, size, "OrBitmap", orBitmapImpl(currentKernels(), a, b), orBitmapGeneric(a, b))
+				checkEqual(t, size, "XorBitmap", xorBitmapImpl(currentKernels(), a, b), xorBitmapGeneric(a, b))
+				checkEqual(t, size, "NotBitmap", notBitmapImpl(currentKernels(), a), notBitmapGeneric(a))
+				if got, want := popCountImpl(currentKernels(), a), popCountGeneric(a); got != want {
+					t.Errorf("size=%d: PopCount = %d, want %d", size, got, want)
+				}
+
+				mask := cmpGtInt64MaskGeneric(ints, 0)
+				dst := make([]int64, size)
+				expected := make([]int64, size)
+				n := compressInt64Impl(currentKernels(), dst, ints, mask)
+				checkEqual(t, size, "CompressInt64", dst[:n], expected[:compressInt64Generic(expected, ints, mask)])
+
+				indices := make([]uint32, size)
+				for i := range indices {
+					indices[i] = uint32(rng.Intn(size))
+				}
+				gathered := make([]int64, size)
+				gatherInt64Impl(currentKernels(), gathered, ints, indices)
+				expected = make([]int64, size)
+				gatherInt64Generic(expected, ints, indices)
+				checkEqual(t, size, "GatherInt64", gathered, expected)
+
+				scattered := make([]int64, size)
+				scatterInt64Impl(currentKernels(), scattered, ints, indices)
+				expected = make([]int64, size)
+				scatterInt64Generic(expected, ints, indices)
+				checkEqual(t, size, "ScatterInt64", scattered, expected)
+			}
+		})
+	}
+}
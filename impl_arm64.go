@@ -2,13 +2,189 @@
 
 package syndrdbsimd
 
-// cmpEqInt64Impl routes to NEON or generic implementation based on CPU capabilities
-func cmpEqInt64Impl(values []int64, threshold int64) []bool {
-	if !HasNEON() || len(values) < 8 {
+import (
+	"bytes"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// arm64KernelSet records the ISA selected for each group of operations.
+type arm64KernelSet struct {
+	compare     ISA // int64/float64 comparisons
+	bitmap      ISA // And/Or/Xor/Not
+	popCount    ISA
+	sum         ISA
+	xxhash      ISA
+	compress    ISA // NEON only
+	strings     ISA // Byte-string kernels, NEON only
+	float32     ISA // float32 compares, aggregates and conversions, NEON only
+	arith       ISA // element-wise int64/float64 arithmetic and timestamp division, NEON only
+	floatMinMax ISA // float64 Min and Max, NEON only
+
+	cutovers *Config // SIMD cutover sizes; nil for the package-wide Config
+}
+
+// config returns the cutover sizes the *Impl functions compare input sizes with.
+func (ks *arm64KernelSet) config() *Config {
+	if ks.cutovers != nil {
+		return ks.cutovers
+	}
+	return simdConfig.Load()
+}
+
+// selectARM64Kernels derives the kernel selection from a feature set.
+// It is a pure function so the dispatch rules can be tested on any CPU.
+func selectARM64Kernels(f arm64Features) arm64KernelSet {
+	neon := ISAGeneric
+	if f.NEON {
+		neon = ISANEON
+	}
+	sve := neon
+	if f.NEON && f.SVE {
+		sve = ISASVE
+	}
+	return arm64KernelSet{
+		compare:     sve,
+		bitmap:      sve,
+		popCount:    sve,
+		sum:         sve,
+		xxhash:      sve,
+		compress:    neon,
+		strings:     neon,
+		float32:     neon,
+		arith:       neon,
+		floatMinMax: neon,
+	}
+}
+
+// capARM64Features clears the features above isa, so selectARM64Kernels never
+// picks a kernel from a higher level.
+func capARM64Features(f arm64Features, isa ISA) arm64Features {
+	switch isa {
+	case ISASVE:
+		return f
+	case ISANEON:
+		return arm64Features{NEON: f.NEON}
+	default:
+		return arm64Features{}
+	}
+}
+
+// arm64Detected holds the features of the running CPU, detected once.
+var arm64Detected = detectARM64Features()
+
+// kernelSet is the kernel selection the *Impl functions in this file dispatch on.
+type kernelSet = arm64KernelSet
+
+// arm64Kernels is the kernel selection used by the package-level functions.
+// It starts from the detected CPU features and is replaced atomically by SetISA,
+// ResetISA and WithGlobalISA. A Kernels value carries its own selection instead.
+var arm64Kernels atomic.Pointer[arm64KernelSet]
+
+func init() {
+	resetKernels()
+}
+
+func isaSupported(isa ISA) bool {
+	switch isa {
+	case ISAGeneric:
+		return true
+	case ISANEON:
+		return arm64Detected.NEON
+	case ISASVE:
+		return arm64Detected.SVE
+	default:
+		return false
+	}
+}
+
+// currentKernels returns the selection the package-level functions dispatch on.
+func currentKernels() *kernelSet {
+	return arm64Kernels.Load()
+}
+
+// cappedKernels returns the selection for this CPU with kernels above isa disabled.
+func cappedKernels(isa ISA) *kernelSet {
+	ks := selectARM64Kernels(capARM64Features(arm64Detected, isa))
+	return &ks
+}
+
+// withCutovers returns a copy of ks that uses the cutover sizes in c.
+func withCutovers(ks *kernelSet, c *Config) *kernelSet {
+	with := *ks
+	with.cutovers = c
+	return &with
+}
+
+func capKernels(isa ISA) {
+	arm64Kernels.Store(cappedKernels(isa))
+}
+
+func resetKernels() {
+	ks := selectARM64Kernels(arm64Detected)
+	arm64Kernels.Store(&ks)
+}
+
+// kernelISA reports the ISA an operation group from kernelOperations dispatches to
+// under ks.
+func kernelISA(ks *kernelSet, op string) ISA {
+	switch op {
+	case "CmpInt64", "CmpFloat64":
+		return ks.compare
+	case "Bitmap":
+		return ks.bitmap
+	case "PopCount":
+		return ks.popCount
+	case "SumInt64":
+		return ks.sum
+	case "XXHash64":
+		return ks.xxhash
+	case "Compress":
+		return ks.compress
+	case "String":
+		return ks.strings
+	case "Float32":
+		return ks.float32
+	case "Arith":
+		return ks.arith
+	case "MinMaxFloat64":
+		return ks.floatMinMax
+	default:
+		// The remaining groups have no enabled SIMD kernels on ARM64
+		return ISAGeneric
+	}
+}
+
+// defaultConfig holds the ARM64 SIMD cutover sizes: a few vector iterations per operation.
+var defaultConfig = Config{
+	MinCmpInt64:      8,
+	MinCmpFloat64:    16,
+	MinBitmapWords:   4,
+	MinPopCountWords: 4,
+	MinAggregate:     8,
+	MinHash:          8,
+	MinCompress:      8,
+	MinNarrow:        64,
+	MinFloat32:       32,
+	MinArith:         8,
+
+	MinStrings:       16,
+	AvgByteThreshold: 32,
+}
+
+// cmpEqInt64Impl routes to SVE, NEON or generic implementation based on CPU capabilities
+func cmpEqInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpEqInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpEqInt64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	// Process 2 elements at a time with NEON
@@ -26,13 +202,18 @@ func cmpEqInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpEqInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasNEON() || len(values) < 8 {
+func cmpEqInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpEqInt64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpEqInt64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	// Process 2 elements at a time
@@ -60,12 +241,18 @@ func cmpEqInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func cmpNeInt64Impl(values []int64, threshold int64) []bool {
-	if !HasNEON() || len(values) < 8 {
+func cmpNeInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpNeInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpNeInt64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -81,13 +268,18 @@ func cmpNeInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpNeInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasNEON() || len(values) < 8 {
+func cmpNeInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpNeInt64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpNeInt64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -113,12 +305,18 @@ func cmpNeInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func cmpGtInt64Impl(values []int64, threshold int64) []bool {
-	if !HasNEON() || len(values) < 8 {
+func cmpGtInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpGtInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpGtInt64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -134,13 +332,18 @@ func cmpGtInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpGtInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasNEON() || len(values) < 8 {
+func cmpGtInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpGtInt64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpGtInt64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -166,12 +369,18 @@ func cmpGtInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func cmpLtInt64Impl(values []int64, threshold int64) []bool {
-	if !HasNEON() || len(values) < 8 {
+func cmpLtInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpLtInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpLtInt64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -187,13 +396,18 @@ func cmpLtInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpLtInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasNEON() || len(values) < 8 {
+func cmpLtInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpLtInt64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpLtInt64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -219,12 +433,18 @@ func cmpLtInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func cmpGeInt64Impl(values []int64, threshold int64) []bool {
-	if !HasNEON() || len(values) < 8 {
+func cmpGeInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpGeInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpGeInt64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -240,13 +460,18 @@ func cmpGeInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpGeInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasNEON() || len(values) < 8 {
+func cmpGeInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpGeInt64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpGeInt64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -272,12 +497,18 @@ func cmpGeInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func cmpLeInt64Impl(values []int64, threshold int64) []bool {
-	if !HasNEON() || len(values) < 8 {
+func cmpLeInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpLeInt64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpLeInt64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -293,13 +524,18 @@ func cmpLeInt64Impl(values []int64, threshold int64) []bool {
 	return results
 }
 
-func cmpLeInt64MaskImpl(values []int64, threshold int64) []uint64 {
-	if !HasNEON() || len(values) < 8 {
+func cmpLeInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpInt64 {
 		return cmpLeInt64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpLeInt64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -325,8 +561,8 @@ func cmpLeInt64MaskImpl(values []int64, threshold int64) []uint64 {
 	return mask
 }
 
-func andBitmapImpl(a, b []uint64) []uint64 {
-	if !HasNEON() || len(a) < 4 {
+func andBitmapImpl(ks *kernelSet, a, b []uint64) []uint64 {
+	if ks.bitmap == ISAGeneric || len(a) < ks.config().MinBitmapWords {
 		return andBitmapGeneric(a, b)
 	}
 
@@ -336,12 +572,16 @@ func andBitmapImpl(a, b []uint64) []uint64 {
 	}
 
 	result := make([]uint64, length)
-	andBitmapNEON(&result[0], &a[0], &b[0], length)
+	if ks.bitmap == ISASVE {
+		andBitmapSVE(&result[0], &a[0], &b[0], length)
+	} else {
+		andBitmapNEON(&result[0], &a[0], &b[0], length)
+	}
 	return result
 }
 
-func orBitmapImpl(a, b []uint64) []uint64 {
-	if !HasNEON() || len(a) < 4 {
+func orBitmapImpl(ks *kernelSet, a, b []uint64) []uint64 {
+	if ks.bitmap == ISAGeneric || len(a) < ks.config().MinBitmapWords {
 		return orBitmapGeneric(a, b)
 	}
 
@@ -351,12 +591,16 @@ func orBitmapImpl(a, b []uint64) []uint64 {
 	}
 
 	result := make([]uint64, length)
-	orBitmapNEON(&result[0], &a[0], &b[0], length)
+	if ks.bitmap == ISASVE {
+		orBitmapSVE(&result[0], &a[0], &b[0], length)
+	} else {
+		orBitmapNEON(&result[0], &a[0], &b[0], length)
+	}
 	return result
 }
 
-func xorBitmapImpl(a, b []uint64) []uint64 {
-	if !HasNEON() || len(a) < 4 {
+func xorBitmapImpl(ks *kernelSet, a, b []uint64) []uint64 {
+	if ks.bitmap == ISAGeneric || len(a) < ks.config().MinBitmapWords {
 		return xorBitmapGeneric(a, b)
 	}
 
@@ -366,25 +610,36 @@ func xorBitmapImpl(a, b []uint64) []uint64 {
 	}
 
 	result := make([]uint64, length)
-	xorBitmapNEON(&result[0], &a[0], &b[0], length)
+	if ks.bitmap == ISASVE {
+		xorBitmapSVE(&result[0], &a[0], &b[0], length)
+	} else {
+		xorBitmapNEON(&result[0], &a[0], &b[0], length)
+	}
 	return result
 }
 
-func notBitmapImpl(a []uint64) []uint64 {
-	if !HasNEON() || len(a) < 4 {
+func notBitmapImpl(ks *kernelSet, a []uint64) []uint64 {
+	if ks.bitmap == ISAGeneric || len(a) < ks.config().MinBitmapWords {
 		return notBitmapGeneric(a)
 	}
 
 	result := make([]uint64, len(a))
-	notBitmapNEON(&result[0], &a[0], len(a))
+	if ks.bitmap == ISASVE {
+		notBitmapSVE(&result[0], &a[0], len(a))
+	} else {
+		notBitmapNEON(&result[0], &a[0], len(a))
+	}
 	return result
 }
 
-func popCountImpl(bitmap []uint64) int {
-	if !HasNEON() || len(bitmap) < 4 {
+func popCountImpl(ks *kernelSet, bitmap []uint64) int {
+	if ks.popCount == ISAGeneric || len(bitmap) < ks.config().MinPopCountWords {
 		return popCountGeneric(bitmap)
 	}
 
+	if ks.popCount == ISASVE {
+		return popCountSVE(&bitmap[0], len(bitmap))
+	}
 	return popCountNEON(&bitmap[0], len(bitmap))
 }
 
@@ -392,15 +647,18 @@ func popCountImpl(bitmap []uint64) int {
 // Phase 2: Aggregation Operations
 // ============================================================================
 
-func sumInt64Impl(values []int64) int64 {
-	if !HasNEON() || len(values) < 8 {
+func sumInt64Impl(ks *kernelSet, values []int64) int64 {
+	if ks.sum == ISAGeneric || len(values) < ks.config().MinAggregate {
 		return sumInt64Generic(values)
 	}
 
+	if ks.sum == ISASVE {
+		return sumInt64SVE(&values[0], len(values))
+	}
 	return sumInt64NEON(&values[0], len(values))
 }
 
-func minInt64Impl(values []int64) int64 {
+func minInt64Impl(ks *kernelSet, values []int64) int64 {
 	// TODO: NEON min/max needs debugging - use generic for now
 	return minInt64Generic(values)
 
@@ -410,7 +668,7 @@ func minInt64Impl(values []int64) int64 {
 	// return minInt64NEON(&values[0], len(values))
 }
 
-func maxInt64Impl(values []int64) int64 {
+func maxInt64Impl(ks *kernelSet, values []int64) int64 {
 	// TODO: NEON min/max needs debugging - use generic for now
 	return maxInt64Generic(values)
 
@@ -420,7 +678,41 @@ func maxInt64Impl(values []int64) int64 {
 	// return maxInt64NEON(&values[0], len(values))
 }
 
-func countNonNullImpl(values []int64, nullBitmap []uint64) int64 {
+// minFloat64Impl and maxFloat64Impl skip NaN values, returning +Inf and -Inf
+// respectively if every value is NaN.
+func minFloat64Impl(ks *kernelSet, values []float64) float64 {
+	if ks.floatMinMax == ISAGeneric || len(values) < ks.config().MinAggregate {
+		return minFloat64Generic(values)
+	}
+
+	// Process 4 elements at a time
+	n := len(values) &^ 3
+	min := minFloat64Generic(values[n:])
+	if n > 0 {
+		if m := minFloat64NEON(&values[0], n); m < min {
+			min = m
+		}
+	}
+	return min
+}
+
+func maxFloat64Impl(ks *kernelSet, values []float64) float64 {
+	if ks.floatMinMax == ISAGeneric || len(values) < ks.config().MinAggregate {
+		return maxFloat64Generic(values)
+	}
+
+	// Process 4 elements at a time
+	n := len(values) &^ 3
+	max := maxFloat64Generic(values[n:])
+	if n > 0 {
+		if m := maxFloat64NEON(&values[0], n); m > max {
+			max = m
+		}
+	}
+	return max
+}
+
+func countNonNullImpl(ks *kernelSet, values []int64, nullBitmap []uint64) int64 {
 	// TODO: NEON countNonNull needs debugging - use generic for now
 	return countNonNullGeneric(values, nullBitmap)
 
@@ -437,7 +729,7 @@ func countNonNullImpl(values []int64, nullBitmap []uint64) int64 {
 // Phase 3: Hashing Operations
 // ============================================================================
 
-func hashInt64Impl(values []int64, output []uint64) {
+func hashInt64Impl(ks *kernelSet, values []int64, output []uint64) {
 	// TODO: NEON FNV hash needs debugging - use generic for now
 	hashInt64SliceGeneric(values, output)
 	return
@@ -457,7 +749,7 @@ func hashInt64Impl(values []int64, output []uint64) {
 	// }
 }
 
-func crc32Int64Impl(values []int64, output []uint32) {
+func crc32Int64Impl(ks *kernelSet, values []int64, output []uint32) {
 	// TODO: NEON CRC32C needs debugging - use generic for now
 	crc32Int64SliceGeneric(values, output)
 	return
@@ -470,12 +762,17 @@ func crc32Int64Impl(values []int64, output []uint32) {
 	// crc32Int64NEON(&values[0], &output[0], len(values))
 }
 
-func xxhash64Impl(values []int64, output []uint64) {
-	if !HasNEON() || len(values) < 8 {
+func xxhash64Impl(ks *kernelSet, values []int64, output []uint64) {
+	if ks.xxhash == ISAGeneric || len(values) < ks.config().MinHash {
 		xxhash64SliceGeneric(values, output)
 		return
 	}
 
+	if ks.xxhash == ISASVE {
+		xxhash64SVE(&values[0], &output[0], len(values))
+		return
+	}
+
 	i := 0
 	// Process 2 elements at a time with NEON
 	for ; i+1 < len(values); i += 2 {
@@ -488,16 +785,44 @@ func xxhash64Impl(values []int64, output []uint64) {
 	}
 }
 
+// xxhash64BytesImpl hashes one byte string. Each of the four stripe accumulators is
+// a chain of dependent 64-bit multiplies, which the scalar MUL runs faster than
+// NEON, with no 64-bit lane multiply, or SVE at 128-bit vectors, so every ISA
+// uses the generic loop.
+func xxhash64BytesImpl(ks *kernelSet, data []byte) uint64 {
+	return xxhash64BytesGeneric(data)
+}
+
 // ============================================================================
 // Phase 4: String Operations
 // ============================================================================
 
-func strCmpImpl(a, b []byte) int {
-	return strCmpGeneric(a, b)
+// strCmpImpl compares a and b lexicographically. The strDiffNEON kernel finds the
+// first differing byte 16 bytes at a time; without one, the shorter slice
+// orders first.
+func strCmpImpl(ks *kernelSet, a, b []byte) int {
+	n := min(len(a), len(b))
+	if ks.strings == ISAGeneric || n < 16 {
+		return strCmpGeneric(a, b)
+	}
+
+	if i := strDiffNEON(&a[0], &b[0], n); i < n {
+		if a[i] < b[i] {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
 }
 
-func strPrefixCmpImpl(str, prefix []byte) bool {
-	if !HasNEON() || len(prefix) < 16 {
+func strPrefixCmpImpl(ks *kernelSet, str, prefix []byte) bool {
+	if ks.strings == ISAGeneric || len(prefix) < 16 {
 		return strPrefixCmpGeneric(str, prefix)
 	}
 
@@ -509,7 +834,7 @@ func strPrefixCmpImpl(str, prefix []byte) bool {
 	return result == 1
 }
 
-func strEqImpl(a, b []byte) bool {
+func strEqImpl(ks *kernelSet, a, b []byte) bool {
 	if len(a) != len(b) {
 		return false
 	}
@@ -518,7 +843,7 @@ func strEqImpl(a, b []byte) bool {
 		return true
 	}
 
-	if !HasNEON() || len(a) < 16 {
+	if ks.strings == ISAGeneric || len(a) < 16 {
 		return strEqGeneric(a, b)
 	}
 
@@ -526,34 +851,134 @@ func strEqImpl(a, b []byte) bool {
 	return result == 1
 }
 
-func strToLowerImpl(s []byte) {
+// strIndexImpl returns the index of the first occurrence of substr in str, or
+// -1. The strIndexNEON kernel filters 16 start positions at a time on the first and
+// last byte of substr and verifies only the candidates; it needs at least one
+// full block of start positions.
+func strIndexImpl(ks *kernelSet, str, substr []byte) int {
+	switch {
+	case len(substr) == 0:
+		return 0
+	case len(substr) == 1:
+		return bytes.IndexByte(str, substr[0])
+	case len(substr) > len(str):
+		return -1
+	}
+
+	if ks.strings == ISAGeneric || len(str)-len(substr)+1 < 16 {
+		return strIndexGeneric(str, substr)
+	}
+
+	return strIndexNEON(&str[0], len(str), &substr[0], len(substr))
+}
+
+func strContainsImpl(ks *kernelSet, str, substr []byte) bool {
+	return strIndexImpl(ks, str, substr) >= 0
+}
+
+// teddyFindImpl returns the first start position p >= from in s at which a
+// Teddy fingerprint of length m matches, or -1. The teddyNEON kernel checks 16
+// start positions per block and needs at least one full block.
+func teddyFindImpl(ks *kernelSet, s []byte, masks *[3][2][16]byte, m, from int) int {
+	if from > len(s)-m {
+		return -1
+	}
+
+	if ks.strings == ISAGeneric || len(s)-m+1 < 16 {
+		return teddyFindGeneric(s, masks, m, from)
+	}
+
+	return teddyNEON(&s[0], len(s), masks, m, from)
+}
+
+// asciiPrefixImpl returns the index of the first non-ASCII byte of s, or
+// len(s). The asciiPrefixNEON kernel tests 16 bytes per block.
+func asciiPrefixImpl(ks *kernelSet, s []byte) int {
+	if ks.strings == ISAGeneric || len(s) < 16 {
+		return asciiPrefixGeneric(s)
+	}
+
+	return asciiPrefixNEON(&s[0], len(s))
+}
+
+// utf8ValidImpl reports whether s is valid UTF-8. The utf8ValidNEON kernel checks the
+// whole 16-byte blocks; utf8.Valid checks the rest, from the start of a
+// sequence the last block may cut off.
+func utf8ValidImpl(ks *kernelSet, s []byte) bool {
+	if ks.strings == ISAGeneric || len(s) < 16 {
+		return utf8.Valid(s)
+	}
+
+	m := len(s) &^ 15
+	if utf8ValidNEON(&s[0], m) == 0 {
+		return false
+	}
+	return utf8.Valid(s[utf8TailStart(s, m):])
+}
+
+// charLengthImpl returns the number of bytes of s that are not UTF-8
+// continuation bytes. The charLengthNEON kernel counts the whole 16-byte blocks.
+func charLengthImpl(ks *kernelSet, s []byte) int {
+	if ks.strings == ISAGeneric || len(s) < 16 {
+		return charLengthGeneric(s)
+	}
+
+	m := len(s) &^ 15
+	return charLengthNEON(&s[0], m) + charLengthGeneric(s[m:])
+}
+
+func strEqIgnoreCaseImpl(ks *kernelSet, a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	if ks.strings == ISAGeneric || len(a) < 16 {
+		return strEqIgnoreCaseGeneric(a, b)
+	}
+
+	return strEqFoldNEON(&a[0], &b[0], len(a)) == 1
+}
+
+func strToLowerImpl(ks *kernelSet, s []byte) {
 	if len(s) == 0 {
 		return
 	}
 
-	// Note: NEON implementation disabled - Go's ARM64 assembler doesn't support
-	// vector comparison instructions (VCMGE, VCMGT) needed for case conversion range checks
-	strToLowerGeneric(s)
+	if ks.strings == ISAGeneric || len(s) < 16 {
+		strToLowerGeneric(s)
+		return
+	}
+
+	strToLowerNEON(&s[0], len(s))
 }
 
-func strToUpperImpl(s []byte) {
+func strToUpperImpl(ks *kernelSet, s []byte) {
 	if len(s) == 0 {
 		return
 	}
 
-	// Note: NEON implementation disabled - Go's ARM64 assembler doesn't support
-	// vector comparison instructions (VCMGE, VCMGT) needed for case conversion range checks
-	strToUpperGeneric(s)
+	if ks.strings == ISAGeneric || len(s) < 16 {
+		strToUpperGeneric(s)
+		return
+	}
+
+	strToUpperNEON(&s[0], len(s))
 }
 
 // Float64 comparison implementations using NEON
 
-func cmpGtFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasNEON() || len(values) < 16 {
+func cmpGtFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpGtFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpGtFloat64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	// Process 2 elements at a time with NEON
@@ -571,13 +996,18 @@ func cmpGtFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpGtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasNEON() || len(values) < 16 {
+func cmpGtFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpGtFloat64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpGtFloat64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -603,12 +1033,18 @@ func cmpGtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	return mask
 }
 
-func cmpGeFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasNEON() || len(values) < 16 {
+func cmpGeFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpGeFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpGeFloat64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -624,13 +1060,18 @@ func cmpGeFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpGeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasNEON() || len(values) < 16 {
+func cmpGeFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpGeFloat64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpGeFloat64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -656,12 +1097,18 @@ func cmpGeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	return mask
 }
 
-func cmpLtFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasNEON() || len(values) < 16 {
+func cmpLtFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpLtFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpLtFloat64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -677,13 +1124,18 @@ func cmpLtFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpLtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasNEON() || len(values) < 16 {
+func cmpLtFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpLtFloat64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpLtFloat64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -709,12 +1161,18 @@ func cmpLtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	return mask
 }
 
-func cmpLeFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasNEON() || len(values) < 16 {
+func cmpLeFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpLeFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpLeFloat64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -730,13 +1188,18 @@ func cmpLeFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpLeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasNEON() || len(values) < 16 {
+func cmpLeFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpLeFloat64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpLeFloat64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -762,12 +1225,18 @@ func cmpLeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	return mask
 }
 
-func cmpEqFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasNEON() || len(values) < 16 {
+func cmpEqFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpEqFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpEqFloat64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -783,13 +1252,18 @@ func cmpEqFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpEqFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasNEON() || len(values) < 16 {
+func cmpEqFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpEqFloat64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpEqFloat64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -815,12 +1289,18 @@ func cmpEqFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 	return mask
 }
 
-func cmpNeFloat64Impl(values []float64, threshold float64) []bool {
-	if !HasNEON() || len(values) < 16 {
+func cmpNeFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpNeFloat64Generic(values, threshold)
 	}
 
 	results := make([]bool, len(values))
+
+	if ks.compare == ISASVE {
+		// The predicated SVE loop covers the whole slice, including the tail
+		cmpNeFloat64SVE(&values[0], threshold, &results[0], len(values))
+		return results
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -836,13 +1316,18 @@ func cmpNeFloat64Impl(values []float64, threshold float64) []bool {
 	return results
 }
 
-func cmpNeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
-	if !HasNEON() || len(values) < 16 {
+func cmpNeFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
+	if ks.compare == ISAGeneric || len(values) < ks.config().MinCmpFloat64 {
 		return cmpNeFloat64MaskGeneric(values, threshold)
 	}
 
 	numWords := (len(values) + 63) / 64
 	mask := make([]uint64, numWords)
+
+	if ks.compare == ISASVE {
+		cmpNeFloat64MaskSVE(&values[0], threshold, &mask[0], len(values))
+		return mask
+	}
 	i := 0
 
 	for ; i+1 < len(values); i += 2 {
@@ -872,10 +1357,11 @@ func cmpNeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 // String Comparisons
 // ============================================================================
 
-func cmpEqStringImpl(values [][]byte, threshold []byte) []bool {
+func cmpEqStringImpl(ks *kernelSet, values [][]byte, threshold []byte) []bool {
 	// Use adaptive threshold based on average string length
-	minStrings, avgByteThreshold := GetStringSIMDThreshold()
-	if len(values) < minStrings {
+	cfg := ks.config()
+	minStrings, avgByteThreshold := cfg.MinStrings, cfg.AvgByteThreshold
+	if ks.strings == ISAGeneric || len(values) < minStrings {
 		return cmpEqStringGeneric(values, threshold)
 	}
 
@@ -906,28 +1392,28 @@ func cmpEqStringImpl(values [][]byte, threshold []byte) []bool {
 	return results
 }
 
-func cmpEqStringMaskImpl(values [][]byte, threshold []byte) []uint64 {
-	bools := cmpEqStringImpl(values, threshold)
+func cmpEqStringMaskImpl(ks *kernelSet, values [][]byte, threshold []byte) []uint64 {
+	bools := cmpEqStringImpl(ks, values, threshold)
 	return boolsToBitmask(bools)
 }
 
-func cmpNeStringImpl(values [][]byte, threshold []byte) []bool {
-	eqResults := cmpEqStringImpl(values, threshold)
+func cmpNeStringImpl(ks *kernelSet, values [][]byte, threshold []byte) []bool {
+	eqResults := cmpEqStringImpl(ks, values, threshold)
 	for i := range eqResults {
 		eqResults[i] = !eqResults[i]
 	}
 	return eqResults
 }
 
-func cmpNeStringMaskImpl(values [][]byte, threshold []byte) []uint64 {
-	bools := cmpNeStringImpl(values, threshold)
+func cmpNeStringMaskImpl(ks *kernelSet, values [][]byte, threshold []byte) []uint64 {
+	bools := cmpNeStringImpl(ks, values, threshold)
 	return boolsToBitmask(bools)
 }
 
-func cmpHasPrefixStringImpl(values [][]byte, prefix []byte) []bool {
+func cmpHasPrefixStringImpl(ks *kernelSet, values [][]byte, prefix []byte) []bool {
 	// Adaptive routing similar to equality
-	minStrings, _ := GetStringSIMDThreshold()
-	if len(values) < minStrings {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
 		return cmpHasPrefixStringGeneric(values, prefix)
 	}
 
@@ -948,14 +1434,14 @@ func cmpHasPrefixStringImpl(values [][]byte, prefix []byte) []bool {
 	return results
 }
 
-func cmpHasPrefixStringMaskImpl(values [][]byte, prefix []byte) []uint64 {
-	bools := cmpHasPrefixStringImpl(values, prefix)
+func cmpHasPrefixStringMaskImpl(ks *kernelSet, values [][]byte, prefix []byte) []uint64 {
+	bools := cmpHasPrefixStringImpl(ks, values, prefix)
 	return boolsToBitmask(bools)
 }
 
-func cmpHasSuffixStringImpl(values [][]byte, suffix []byte) []bool {
-	minStrings, _ := GetStringSIMDThreshold()
-	if len(values) < minStrings {
+func cmpHasSuffixStringImpl(ks *kernelSet, values [][]byte, suffix []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
 		return cmpHasSuffixStringGeneric(values, suffix)
 	}
 
@@ -977,37 +1463,574 @@ func cmpHasSuffixStringImpl(values [][]byte, suffix []byte) []bool {
 	return results
 }
 
-func cmpHasSuffixStringMaskImpl(values [][]byte, suffix []byte) []uint64 {
-	bools := cmpHasSuffixStringImpl(values, suffix)
+func cmpHasSuffixStringMaskImpl(ks *kernelSet, values [][]byte, suffix []byte) []uint64 {
+	bools := cmpHasSuffixStringImpl(ks, values, suffix)
 	return boolsToBitmask(bools)
 }
 
-func cmpContainsStringImpl(values [][]byte, substr []byte) []bool {
-	// Contains is more complex; use generic for now
-	// Future optimization: SIMD-accelerated substring search
-	return cmpContainsStringGeneric(values, substr)
+func cmpContainsStringImpl(ks *kernelSet, values [][]byte, substr []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpContainsStringGeneric(values, substr)
+	}
+
+	// strIndexImpl routes rows too short for a full block to the scalar search
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = strIndexImpl(ks, v, substr) >= 0
+	}
+	return results
 }
 
-func cmpContainsStringMaskImpl(values [][]byte, substr []byte) []uint64 {
-	bools := cmpContainsStringImpl(values, substr)
+func cmpContainsStringMaskImpl(ks *kernelSet, values [][]byte, substr []byte) []uint64 {
+	bools := cmpContainsStringImpl(ks, values, substr)
 	return boolsToBitmask(bools)
 }
 
-func cmpEqStringIgnoreCaseImpl(values [][]byte, threshold []byte) []bool {
-	// Case-insensitive requires byte transformation; use generic
-	return cmpEqStringIgnoreCaseGeneric(values, threshold)
+func cmpEqStringIgnoreCaseImpl(ks *kernelSet, values [][]byte, threshold []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpEqStringIgnoreCaseGeneric(values, threshold)
+	}
+
+	// strEqIgnoreCaseImpl folds rows of at least one block with strEqFoldNEON
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = strEqIgnoreCaseImpl(ks, v, threshold)
+	}
+	return results
 }
 
-func cmpEqStringIgnoreCaseMaskImpl(values [][]byte, threshold []byte) []uint64 {
-	bools := cmpEqStringIgnoreCaseImpl(values, threshold)
+func cmpEqStringIgnoreCaseMaskImpl(ks *kernelSet, values [][]byte, threshold []byte) []uint64 {
+	bools := cmpEqStringIgnoreCaseImpl(ks, values, threshold)
 	return boolsToBitmask(bools)
 }
 
-func cmpMatchWildcardImpl(values [][]byte, pattern []byte) []bool {
+// cmpOrderStringImpl compares values lexicographically against threshold with
+// op, comparing each row with strCmpImpl.
+func cmpOrderStringImpl(ks *kernelSet, values [][]byte, op Op, threshold []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpOrderStringGeneric(values, op, threshold)
+	}
+
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = orderMatches(op, strCmpImpl(ks, v, threshold))
+	}
+	return results
+}
+
+func cmpBetweenStringImpl(ks *kernelSet, values [][]byte, lo, hi []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpBetweenStringGeneric(values, lo, hi)
+	}
+
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = strCmpImpl(ks, v, lo) >= 0 && strCmpImpl(ks, v, hi) <= 0
+	}
+	return results
+}
+
+func cmpHasPrefixStringIgnoreCaseImpl(ks *kernelSet, values [][]byte, prefix []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpHasPrefixStringIgnoreCaseGeneric(values, prefix)
+	}
+
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = len(v) >= len(prefix) && strEqIgnoreCaseImpl(ks, v[:len(prefix)], prefix)
+	}
+	return results
+}
+
+func cmpHasSuffixStringIgnoreCaseImpl(ks *kernelSet, values [][]byte, suffix []byte) []bool {
+	minStrings := ks.config().MinStrings
+	if ks.strings == ISAGeneric || len(values) < minStrings {
+		return cmpHasSuffixStringIgnoreCaseGeneric(values, suffix)
+	}
+
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = len(v) >= len(suffix) && strEqIgnoreCaseImpl(ks, v[len(v)-len(suffix):], suffix)
+	}
+	return results
+}
+
+func cmpMatchWildcardImpl(ks *kernelSet, values [][]byte, pattern []byte) []bool {
 	return cmpMatchWildcardGeneric(values, pattern)
 }
 
-func cmpMatchWildcardMaskImpl(values [][]byte, pattern []byte) []uint64 {
-	bools := cmpMatchWildcardImpl(values, pattern)
+func cmpMatchWildcardMaskImpl(ks *kernelSet, values [][]byte, pattern []byte) []uint64 {
+	bools := cmpMatchWildcardImpl(ks, values, pattern)
 	return boolsToBitmask(bools)
 }
+
+// ============================================================================
+// Selection Materialization (compress, gather, scatter)
+// ============================================================================
+
+func compressInt64Impl(ks *kernelSet, dst, src []int64, mask []uint64) int {
+	if ks.compress == ISAGeneric || len(src) < ks.config().MinCompress {
+		return compressInt64Generic(dst, src, mask)
+	}
+
+	// Only rows covered by the mask can be selected
+	length := len(src)
+	if len(mask)*64 < length {
+		length = len(mask) * 64
+	}
+
+	// The NEON kernel stores whole vectors, so dst needs room for every processed row
+	n := length &^ 1
+	if n == 0 || len(dst) < n {
+		return compressInt64Generic(dst, src, mask)
+	}
+
+	written := compressInt64NEON(&dst[0], &src[0], &mask[0], n)
+
+	// Handle remainder with scalar
+	for i := n; i < length; i++ {
+		if mask[i/64]&(1<<uint(i%64)) != 0 {
+			dst[written] = src[i]
+			written++
+		}
+	}
+
+	return written
+}
+
+func compressFloat64Impl(ks *kernelSet, dst, src []float64, mask []uint64) int {
+	// Compression only moves bits, so float64 reuses the int64 kernel
+	return compressInt64Impl(ks, float64sAsInt64s(dst), float64sAsInt64s(src), mask)
+}
+
+func compressStringsImpl(ks *kernelSet, dst, src []string, mask []uint64) int {
+	// String headers hold pointers, so they must be copied by Go code for the GC's sake
+	return compressStringsGeneric(dst, src, mask)
+}
+
+func gatherInt64Impl(ks *kernelSet, dst, src []int64, indices []uint32) {
+	// NEON has no gather instruction; scalar loads are as fast as lane inserts
+	gatherInt64Generic(dst, src, indices)
+}
+
+func scatterInt64Impl(ks *kernelSet, dst, src []int64, indices []uint32) {
+	// NEON has no scatter instruction
+	scatterInt64Generic(dst, src, indices)
+}
+
+// ============================================================================
+// Narrow Integer Operations
+// ============================================================================
+
+// NEON kernels for the narrow integer types are not implemented yet
+
+func cmpNarrowImpl[T narrowInt](ks *kernelSet, values []T, op Op, threshold T) []bool {
+	return cmpNumberGeneric(values, op, threshold)
+}
+
+func cmpNarrowMaskImpl[T narrowInt](ks *kernelSet, values []T, op Op, threshold T) []uint64 {
+	return cmpNumberMaskGeneric(values, op, threshold)
+}
+
+func sumSignedImpl[T signedNarrowInt](ks *kernelSet, values []T) int64 {
+	return sumSignedGeneric(values)
+}
+
+func sumUnsignedImpl[T unsignedNarrowInt](ks *kernelSet, values []T) uint64 {
+	return sumUnsignedGeneric(values)
+}
+
+func minNarrowImpl[T narrowInt](ks *kernelSet, values []T) T {
+	return minNarrowGeneric(values)
+}
+
+func maxNarrowImpl[T narrowInt](ks *kernelSet, values []T) T {
+	return maxNarrowGeneric(values)
+}
+
+// ============================================================================
+// Float32 Operations
+// ============================================================================
+
+func cmpFloat32Impl(ks *kernelSet, values []float32, op Op, threshold float32) []bool {
+	if ks.float32 == ISAGeneric || len(values) < ks.config().MinFloat32 {
+		return cmpNumberGeneric(values, op, threshold)
+	}
+	return bitmaskToBools(cmpFloat32MaskImpl(ks, values, op, threshold), len(values))
+}
+
+func cmpFloat32MaskImpl(ks *kernelSet, values []float32, op Op, threshold float32) []uint64 {
+	if ks.float32 == ISAGeneric || len(values) < ks.config().MinFloat32 {
+		return cmpNumberMaskGeneric(values, op, threshold)
+	}
+
+	mask := make([]uint64, (len(values)+63)/64)
+
+	// Process 64 elements at a time, one result word per iteration
+	n := len(values) &^ 63
+	if n > 0 {
+		p := &values[0]
+		switch op {
+		case OpEq:
+			cmpEqFloat32MaskNEON(p, threshold, &mask[0], n)
+		case OpNe:
+			// There is no FCMNE: != is the inverse of ==, which is also true for NaN
+			cmpEqFloat32MaskNEON(p, threshold, &mask[0], n)
+			for i := range mask[:n/64] {
+				mask[i] = ^mask[i]
+			}
+		case OpGt:
+			cmpGtFloat32MaskNEON(p, threshold, &mask[0], n)
+		case OpGe:
+			cmpGeFloat32MaskNEON(p, threshold, &mask[0], n)
+		case OpLt:
+			cmpLtFloat32MaskNEON(p, threshold, &mask[0], n)
+		case OpLe:
+			cmpLeFloat32MaskNEON(p, threshold, &mask[0], n)
+		}
+	}
+
+	// Handle remainder with scalar
+	cmpNumberTail(values, op, threshold, mask, n)
+	return mask
+}
+
+func sumFloat32Impl(ks *kernelSet, values []float32) float64 {
+	if ks.float32 == ISAGeneric || len(values) < ks.config().MinFloat32 {
+		return sumFloat32Generic(values)
+	}
+
+	// Process 8 elements at a time
+	n := len(values) &^ 7
+	sum := float64(0)
+	if n > 0 {
+		sum = sumFloat32NEON(&values[0], n)
+	}
+
+	// Handle remainder with scalar
+	return sum + sumFloat32Generic(values[n:])
+}
+
+func minFloat32Impl(ks *kernelSet, values []float32) float32 {
+	if ks.float32 == ISAGeneric || len(values) < ks.config().MinFloat32 {
+		return minFloat32Generic(values)
+	}
+
+	// Process 8 elements at a time
+	n := len(values) &^ 7
+	min := minFloat32Generic(values[n:])
+	if n > 0 {
+		if m := minFloat32NEON(&values[0], n); m < min {
+			min = m
+		}
+	}
+	return min
+}
+
+func maxFloat32Impl(ks *kernelSet, values []float32) float32 {
+	if ks.float32 == ISAGeneric || len(values) < ks.config().MinFloat32 {
+		return maxFloat32Generic(values)
+	}
+
+	// Process 8 elements at a time
+	n := len(values) &^ 7
+	max := maxFloat32Generic(values[n:])
+	if n > 0 {
+		if m := maxFloat32NEON(&values[0], n); m > max {
+			max = m
+		}
+	}
+	return max
+}
+
+// float32ToFloat64Impl widens src into dst, which must be at least as long as src.
+func float32ToFloat64Impl(ks *kernelSet, dst []float64, src []float32) {
+	if ks.float32 == ISAGeneric || len(src) < ks.config().MinFloat32 {
+		float32ToFloat64Generic(dst, src)
+		return
+	}
+
+	// Process 4 elements at a time
+	n := len(src) &^ 3
+	if n > 0 {
+		float32ToFloat64NEON(&dst[0], &src[0], n)
+	}
+
+	// Handle remainder with scalar
+	float32ToFloat64Generic(dst[n:], src[n:])
+}
+
+// float64ToFloat32Impl narrows src into dst, which must be at least as long as src.
+func float64ToFloat32Impl(ks *kernelSet, dst []float32, src []float64) {
+	if ks.float32 == ISAGeneric || len(src) < ks.config().MinFloat32 {
+		float64ToFloat32Generic(dst, src)
+		return
+	}
+
+	// Process 4 elements at a time
+	n := len(src) &^ 3
+	if n > 0 {
+		float64ToFloat32NEON(&dst[0], &src[0], n)
+	}
+
+	// Handle remainder with scalar
+	float64ToFloat32Generic(dst[n:], src[n:])
+}
+
+// ============================================================================
+// Arithmetic Operations
+// ============================================================================
+
+// arithInt64Impl runs an int64 column-column kernel over the first multiple of 4
+// elements and generic over the rest, returning whether any element overflowed.
+func arithInt64Impl(ks *kernelSet, dst, a, b []int64, kernel func(dst, a, b *int64, length int) bool, generic func(dst, a, b []int64) bool) bool {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		return generic(dst, a, b)
+	}
+
+	// Process 4 elements at a time
+	n := len(a) &^ 3
+	overflow := kernel(&dst[0], &a[0], &b[0], n)
+
+	// Handle remainder with scalar
+	return generic(dst[n:], a[n:], b[n:]) || overflow
+}
+
+// arithInt64ScalarImpl is arithInt64Impl for column-scalar kernels.
+func arithInt64ScalarImpl(ks *kernelSet, dst, a []int64, b int64, kernel func(dst, a *int64, b int64, length int) bool, generic func(dst, a []int64, b int64) bool) bool {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		return generic(dst, a, b)
+	}
+
+	n := len(a) &^ 3
+	overflow := kernel(&dst[0], &a[0], b, n)
+	return generic(dst[n:], a[n:], b) || overflow
+}
+
+// unaryInt64Impl is arithInt64Impl for single-operand kernels.
+func unaryInt64Impl(ks *kernelSet, dst, a []int64, kernel func(dst, a *int64, length int) bool, generic func(dst, a []int64) bool) bool {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		return generic(dst, a)
+	}
+
+	n := len(a) &^ 3
+	overflow := kernel(&dst[0], &a[0], n)
+	return generic(dst[n:], a[n:]) || overflow
+}
+
+// arithFloat64Impl runs a float64 column-column kernel over the first multiple
+// of 8 elements and generic over the rest.
+func arithFloat64Impl(ks *kernelSet, dst, a, b []float64, kernel func(dst, a, b *float64, length int), generic func(dst, a, b []float64)) {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		generic(dst, a, b)
+		return
+	}
+
+	n := len(a) &^ 3
+	kernel(&dst[0], &a[0], &b[0], n)
+	generic(dst[n:], a[n:], b[n:])
+}
+
+// arithFloat64ScalarImpl is arithFloat64Impl for column-scalar kernels.
+func arithFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64, kernel func(dst, a *float64, b float64, length int), generic func(dst, a []float64, b float64)) {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		generic(dst, a, b)
+		return
+	}
+
+	n := len(a) &^ 3
+	kernel(&dst[0], &a[0], b, n)
+	generic(dst[n:], a[n:], b)
+}
+
+// unaryFloat64Impl is arithFloat64Impl for single-operand kernels.
+func unaryFloat64Impl(ks *kernelSet, dst, a []float64, kernel func(dst, a *float64, length int), generic func(dst, a []float64)) {
+	if ks.arith == ISAGeneric || len(a) < ks.config().MinArith {
+		generic(dst, a)
+		return
+	}
+
+	n := len(a) &^ 3
+	kernel(&dst[0], &a[0], n)
+	generic(dst[n:], a[n:])
+}
+
+func addInt64Impl(ks *kernelSet, dst, a, b []int64) bool {
+	return arithInt64Impl(ks, dst, a, b, addInt64NEON, addInt64Generic)
+}
+
+func addInt64ScalarImpl(ks *kernelSet, dst, a []int64, b int64) bool {
+	return arithInt64ScalarImpl(ks, dst, a, b, addInt64ScalarNEON, addInt64ScalarGeneric)
+}
+
+func subInt64Impl(ks *kernelSet, dst, a, b []int64) bool {
+	return arithInt64Impl(ks, dst, a, b, subInt64NEON, subInt64Generic)
+}
+
+func subInt64ScalarImpl(ks *kernelSet, dst, a []int64, b int64) bool {
+	return arithInt64ScalarImpl(ks, dst, a, b, subInt64ScalarNEON, subInt64ScalarGeneric)
+}
+
+func negInt64Impl(ks *kernelSet, dst, a []int64) bool {
+	return unaryInt64Impl(ks, dst, a, negInt64NEON, negInt64Generic)
+}
+
+func absInt64Impl(ks *kernelSet, dst, a []int64) bool {
+	return unaryInt64Impl(ks, dst, a, absInt64NEON, absInt64Generic)
+}
+
+func addFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	arithFloat64Impl(ks, dst, a, b, addFloat64NEON, addFloat64Generic)
+}
+
+func addFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	arithFloat64ScalarImpl(ks, dst, a, b, addFloat64ScalarNEON, addFloat64ScalarGeneric)
+}
+
+func subFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	arithFloat64Impl(ks, dst, a, b, subFloat64NEON, subFloat64Generic)
+}
+
+func subFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	arithFloat64ScalarImpl(ks, dst, a, b, subFloat64ScalarNEON, subFloat64ScalarGeneric)
+}
+
+func mulFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	arithFloat64Impl(ks, dst, a, b, mulFloat64NEON, mulFloat64Generic)
+}
+
+func mulFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	arithFloat64ScalarImpl(ks, dst, a, b, mulFloat64ScalarNEON, mulFloat64ScalarGeneric)
+}
+
+func divFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	arithFloat64Impl(ks, dst, a, b, divFloat64NEON, divFloat64Generic)
+}
+
+func divFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	arithFloat64ScalarImpl(ks, dst, a, b, divFloat64ScalarNEON, divFloat64ScalarGeneric)
+}
+
+func negFloat64Impl(ks *kernelSet, dst, a []float64) {
+	unaryFloat64Impl(ks, dst, a, negFloat64NEON, negFloat64Generic)
+}
+
+func absFloat64Impl(ks *kernelSet, dst, a []float64) {
+	unaryFloat64Impl(ks, dst, a, absFloat64NEON, absFloat64Generic)
+}
+
+// ============================================================================
+// Expression Operations
+// ============================================================================
+
+// int64MaskKernelsSVE and the tables below hold the compare kernels used to
+// evaluate expression predicates, indexed by Op.
+var int64MaskKernelsSVE = [...]func(values *int64, threshold int64, mask *uint64, length int){
+	OpEq: cmpEqInt64MaskSVE,
+	OpNe: cmpNeInt64MaskSVE,
+	OpGt: cmpGtInt64MaskSVE,
+	OpLt: cmpLtInt64MaskSVE,
+	OpGe: cmpGeInt64MaskSVE,
+	OpLe: cmpLeInt64MaskSVE,
+}
+
+var int64LaneKernelsNEON = [...]func(values *int64, threshold int64) uint64{
+	OpEq: cmpEqInt64NEON,
+	OpNe: cmpNeInt64NEON,
+	OpGt: cmpGtInt64NEON,
+	OpLt: cmpLtInt64NEON,
+	OpGe: cmpGeInt64NEON,
+	OpLe: cmpLeInt64NEON,
+}
+
+var float64MaskKernelsSVE = [...]func(values *float64, threshold float64, mask *uint64, length int){
+	OpEq: cmpEqFloat64MaskSVE,
+	OpNe: cmpNeFloat64MaskSVE,
+	OpGt: cmpGtFloat64MaskSVE,
+	OpLt: cmpLtFloat64MaskSVE,
+	OpGe: cmpGeFloat64MaskSVE,
+	OpLe: cmpLeFloat64MaskSVE,
+}
+
+var float64LaneKernelsNEON = [...]func(values *float64, threshold float64) uint64{
+	OpEq: cmpEqFloat64NEON,
+	OpNe: cmpNeFloat64NEON,
+	OpGt: cmpGtFloat64NEON,
+	OpLt: cmpLtFloat64NEON,
+	OpGe: cmpGeFloat64NEON,
+	OpLe: cmpLeFloat64NEON,
+}
+
+// cmpMaskIntoImpl writes the comparison bitmask for values into mask without
+// allocating. mask must hold at least (len(values)+63)/64 words.
+func cmpMaskIntoImpl[T int64 | float64](ks *kernelSet, values []T, op Op, threshold T, mask []uint64,
+	wide func(*T, T, *uint64, int), lanes func(*T, T) uint64, minLen int) {
+	clear(mask[:(len(values)+63)/64])
+	if ks.compare == ISAGeneric || len(values) < minLen {
+		cmpNumberTail(values, op, threshold, mask, 0)
+		return
+	}
+
+	if ks.compare == ISASVE {
+		wide(&values[0], threshold, &mask[0], len(values))
+		return
+	}
+
+	// Process 2 elements at a time; a pair never straddles a word
+	i := 0
+	for ; i+1 < len(values); i += 2 {
+		mask[i/64] |= (lanes(&values[i], threshold) & 0x3) << uint(i%64)
+	}
+
+	// Handle remainder with scalar
+	cmpNumberTail(values, op, threshold, mask, i)
+}
+
+func cmpInt64MaskIntoImpl(ks *kernelSet, values []int64, op Op, threshold int64, mask []uint64) {
+	cmpMaskIntoImpl(ks, values, op, threshold, mask, int64MaskKernelsSVE[op], int64LaneKernelsNEON[op], ks.config().MinCmpInt64)
+}
+
+func cmpFloat64MaskIntoImpl(ks *kernelSet, values []float64, op Op, threshold float64, mask []uint64) {
+	cmpMaskIntoImpl(ks, values, op, threshold, mask, float64MaskKernelsSVE[op], float64LaneKernelsNEON[op], ks.config().MinCmpFloat64)
+}
+
+// ============================================================================
+// Temporal Operations
+// ============================================================================
+
+// truncFixedImpl rounds each timestamp down to a multiple of width, which must be
+// at least 1<<12 for the float64 quotient estimate of the kernels to be within one.
+func truncFixedImpl(ks *kernelSet, dst, src []int64, width int64) {
+	if ks.arith == ISAGeneric || len(src) < ks.config().MinArith {
+		truncFixedGeneric(dst, src, width)
+		return
+	}
+
+	// Process 2 elements at a time
+	n := len(src) &^ 1
+	truncFixedNEON(&dst[0], &src[0], n, width, 1/float64(width))
+
+	// Handle remainder with scalar
+	truncFixedGeneric(dst[n:], src[n:], width)
+}
+
+// floorDivModImpl splits each timestamp into floorDiv(v, width) and floorMod(v, width),
+// with width at least 1<<12 as for truncFixedImpl.
+func floorDivModImpl(ks *kernelSet, quo, rem, src []int64, width int64) {
+	if ks.arith == ISAGeneric || len(src) < ks.config().MinArith {
+		floorDivModGeneric(quo, rem, src, width)
+		return
+	}
+
+	// Process 2 elements at a time
+	n := len(src) &^ 1
+	floorDivModNEON(&quo[0], &rem[0], &src[0], n, width, 1/float64(width))
+
+	// Handle remainder with scalar
+	floorDivModGeneric(quo[n:], rem[n:], src[n:], width)
+}
@@ -0,0 +1,118 @@
+//go:build arm64
+
+package syndrdbsimd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// ============================================================================
+// Kernel Selection Tests
+// ============================================================================
+
+func TestSelectARM64Kernels(t *testing.T) {
+	neon := arm64KernelSet{
+		compare: ISANEON, bitmap: ISANEON, popCount: ISANEON, sum: ISANEON,
+		xxhash: ISANEON, compress: ISANEON, strings: ISANEON, float32: ISANEON, arith: ISANEON,
+		floatMinMax: ISANEON,
+	}
+	sve := arm64KernelSet{
+		compare: ISASVE, bitmap: ISASVE, popCount: ISASVE, sum: ISASVE,
+		xxhash: ISASVE, compress: ISANEON, strings: ISANEON, float32: ISANEON, arith: ISANEON,
+		floatMinMax: ISANEON,
+	}
+
+	tests := []struct {
+		name     string
+		features arm64Features
+		expected arm64KernelSet
+	}{
+		{"no SIMD", arm64Features{}, arm64KernelSet{}},
+		{"NEON only", arm64Features{NEON: true}, neon},
+		{"NEON and SVE", arm64Features{NEON: true, SVE: true}, sve},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectARM64Kernels(tt.features)
+			if got != tt.expected {
+				t.Errorf("selectARM64Kernels(%+v) = %+v, want %+v", tt.features, got, tt.expected)
+			}
+		})
+	}
+}
+
+// withARM64Kernels installs ks as the kernel selection for the duration of the test.
+func withARM64Kernels(t *testing.T, ks arm64KernelSet) {
+	t.Helper()
+	saved := arm64Kernels.Load()
+	arm64Kernels.Store(&ks)
+	t.Cleanup(func() { arm64Kernels.Store(saved) })
+}
+
+// availableARM64KernelSets returns the kernel selections the running CPU can execute.
+// On SVE hardware (or qemu-aarch64 with SVE enabled) this also forces the NEON paths.
+func availableARM64KernelSets() map[string]arm64KernelSet {
+	f := detectARM64Features()
+	return map[string]arm64KernelSet{
+		"detected": selectARM64Kernels(f),
+		"NEON":     selectARM64Kernels(arm64Features{NEON: true}),
+		"generic":  selectARM64Kernels(arm64Features{}),
+	}
+}
+
+// Every kernel level must produce the same results as the generic implementations
+func TestARM64Kernels_MatchGeneric(t *testing.T) {
+	// Lengths chosen to cover partial vectors at every SVE vector length and
+	// bitmap words that end mid-vector
+	sizes := []int{1, 2, 3, 7, 8, 15, 16, 17, 31, 63, 64, 65, 100, 127, 128, 129, 1000}
+
+	for name, ks := range availableARM64KernelSets() {
+		t.Run(name, func(t *testing.T) {
+			withARM64Kernels(t, ks)
+			rng := rand.New(rand.NewSource(28))
+
+			for _, size := range sizes {
+				ints := make([]int64, size)
+				floats := make([]float64, size)
+				for i := range ints {
+					ints[i] = rng.Int63n(21) - 10
+					floats[i] = float64(ints[i]) / 2
+				}
+				floats[size/2] = math.NaN()
+				threshold := ints[size/3]
+
+				checkInt64Compares(t, size, ints, threshold)
+				checkFloat64Compares(t, size, floats, float64(threshold)/2)
+
+				if got, want := sumInt64Impl(currentKernels(), ints), sumInt64Generic(ints); got != want {
+					t.Errorf("size=%d: SumInt64 = %d, want %d", size, got, want)
+				}
+
+				hashes := make([]uint64, size)
+				xxhash64Impl(currentKernels(), ints, hashes)
+				for i, v := range ints {
+					if hashes[i] != xxhash64Generic(v) {
+						t.Fatalf("size=%d: XXHash64[%d] = %x, want %x", size, i, hashes[i], xxhash64Generic(v))
+					}
+				}
+
+				a := make([]uint64, size)
+				b := make([]uint64, size)
+				for i := range a {
+					a[i] = rng.Uint64()
+					b[i] = rng.Uint64()
+				}
+				checkEqual(t, size, "AndBitmap", andBitmapImpl(currentKernels(), a, b), andBitmapGeneric(a, b))
+				checkEqual(t, size, "OrBitmap", orBitmapImpl(currentKernels(), a, b), orBitmapGeneric(a, b))
+				checkEqual(t, size, "XorBitmap", xorBitmapImpl(currentKernels(), a, b), xorBitmapGeneric(a, b))
+				checkEqual(t, size, "NotBitmap", notBitmapImpl(currentKernels(), a), notBitmapGeneric(a))
+				if got, want := popCountImpl(currentKernels(), a), popCountGeneric(a); got != want {
+					t.Errorf("size=%d: PopCount = %d, want %d", size, got, want)
+				}
+			}
+		})
+	}
+}
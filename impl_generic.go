@@ -2,73 +2,124 @@
 
 package syndrdbsimd
 
+import "unicode/utf8"
+
 // Generic implementation fallback for non-SIMD architectures
 
-func cmpEqInt64Impl(values []int64, threshold int64) []bool {
+// Only the generic kernels exist on these architectures, so ISA overrides are no-ops.
+
+func isaSupported(isa ISA) bool {
+	return isa == ISAGeneric
+}
+
+// kernelSet is empty: there is nothing to select between.
+type kernelSet struct{}
+
+var genericKernels kernelSet
+
+func currentKernels() *kernelSet {
+	return &genericKernels
+}
+
+func cappedKernels(isa ISA) *kernelSet {
+	return &genericKernels
+}
+
+// withCutovers returns ks: the generic implementations have no cutover sizes.
+func withCutovers(ks *kernelSet, c *Config) *kernelSet {
+	return ks
+}
+
+func capKernels(isa ISA) {}
+
+func resetKernels() {}
+
+func kernelISA(ks *kernelSet, op string) ISA {
+	return ISAGeneric
+}
+
+// defaultConfig is unused by the generic implementations but keeps GetConfig meaningful.
+var defaultConfig = Config{
+	MinCmpInt64:      16,
+	MinCmpFloat64:    16,
+	MinBitmapWords:   8,
+	MinPopCountWords: 8,
+	MinAggregate:     16,
+	MinHash:          16,
+	MinCompress:      16,
+	MinNarrow:        64,
+	MinFloat32:       32,
+	MinArith:         16,
+
+	MinStrings:       16,
+	AvgByteThreshold: 32,
+}
+
+func cmpEqInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
 	return cmpEqInt64Generic(values, threshold)
 }
 
-func cmpEqInt64MaskImpl(values []int64, threshold int64) []uint64 {
+func cmpEqInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
 	return cmpEqInt64MaskGeneric(values, threshold)
 }
 
-func cmpNeInt64Impl(values []int64, threshold int64) []bool {
+func cmpNeInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
 	return cmpNeInt64Generic(values, threshold)
 }
 
-func cmpNeInt64MaskImpl(values []int64, threshold int64) []uint64 {
+func cmpNeInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
 	return cmpNeInt64MaskGeneric(values, threshold)
 }
 
-func cmpGtInt64Impl(values []int64, threshold int64) []bool {
+func cmpGtInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
 	return cmpGtInt64Generic(values, threshold)
 }
 
-func cmpGtInt64MaskImpl(values []int64, threshold int64) []uint64 {
+func cmpGtInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
 	return cmpGtInt64MaskGeneric(values, threshold)
 }
 
-func cmpLtInt64Impl(values []int64, threshold int64) []bool {
+func cmpLtInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
 	return cmpLtInt64Generic(values, threshold)
 }
 
-func cmpLtInt64MaskImpl(values []int64, threshold int64) []uint64 {
+func cmpLtInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
 	return cmpLtInt64MaskGeneric(values, threshold)
 }
 
-func cmpGeInt64Impl(values []int64, threshold int64) []bool {
+func cmpGeInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
 	return cmpGeInt64Generic(values, threshold)
 }
 
-func cmpGeInt64MaskImpl(values []int64, threshold int64) []uint64 {
+func cmpGeInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
 	return cmpGeInt64MaskGeneric(values, threshold)
 }
 
-func cmpLeInt64Impl(values []int64, threshold int64) []bool {
+func cmpLeInt64Impl(ks *kernelSet, values []int64, threshold int64) []bool {
 	return cmpLeInt64Generic(values, threshold)
 }
 
-func cmpLeInt64MaskImpl(values []int64, threshold int64) []uint64 {
+func cmpLeInt64MaskImpl(ks *kernelSet, values []int64, threshold int64) []uint64 {
 	return cmpLeInt64MaskGeneric(values, threshold)
 }
 
-func andBitmapImpl(a, b []uint64) []uint64 {
+func andBitmapImpl(ks *kernelSet, a, b []uint64) []uint64 {
 	return andBitmapGeneric(a, b)
 }
 
-func orBitmapImpl(a, b []uint64) []uint64 {
+func orBitmapImpl(ks *kernelSet, a, b []uint64) []uint64 {
 	return orBitmapGeneric(a, b)
 }
 
-func xorBitmapImpl(a, b []uint64) []uint64 {
+func xorBitmapImpl(ks *kernelSet, a, b []uint64) []uint64 {
 	return xorBitmapGeneric(a, b)
 }
 
-func notBitmapImpl(a []uint64) []uint64 {
+func notBitmapImpl(ks *kernelSet, a []uint64) []uint64 {
 	return notBitmapGeneric(a)
 }
 
-func popCountImpl(bitmap []uint64) int {
+func popCountImpl(ks *kernelSet, bitmap []uint64) int {
 	return popCountGeneric(bitmap)
 }
 
@@ -76,19 +127,27 @@ func popCountImpl(bitmap []uint64) int {
 // Phase 2: Aggregation Operations
 // ============================================================================
 
-func sumInt64Impl(values []int64) int64 {
+func sumInt64Impl(ks *kernelSet, values []int64) int64 {
 	return sumInt64Generic(values)
 }
 
-func minInt64Impl(values []int64) int64 {
+func minInt64Impl(ks *kernelSet, values []int64) int64 {
 	return minInt64Generic(values)
 }
 
-func maxInt64Impl(values []int64) int64 {
+func maxInt64Impl(ks *kernelSet, values []int64) int64 {
 	return maxInt64Generic(values)
 }
 
-func countNonNullImpl(values []int64, nullBitmap []uint64) int64 {
+func minFloat64Impl(ks *kernelSet, values []float64) float64 {
+	return minFloat64Generic(values)
+}
+
+func maxFloat64Impl(ks *kernelSet, values []float64) float64 {
+	return maxFloat64Generic(values)
+}
+
+func countNonNullImpl(ks *kernelSet, values []int64, nullBitmap []uint64) int64 {
 	return countNonNullGeneric(values, nullBitmap)
 }
 
@@ -96,39 +155,71 @@ func countNonNullImpl(values []int64, nullBitmap []uint64) int64 {
 // Phase 3: Hashing Operations
 // ============================================================================
 
-func hashInt64Impl(values []int64, output []uint64) {
+func hashInt64Impl(ks *kernelSet, values []int64, output []uint64) {
 	hashInt64SliceGeneric(values, output)
 }
 
-func crc32Int64Impl(values []int64, output []uint32) {
+func crc32Int64Impl(ks *kernelSet, values []int64, output []uint32) {
 	crc32Int64SliceGeneric(values, output)
 }
 
-func xxhash64Impl(values []int64, output []uint64) {
+func xxhash64Impl(ks *kernelSet, values []int64, output []uint64) {
 	xxhash64SliceGeneric(values, output)
 }
 
+func xxhash64BytesImpl(ks *kernelSet, data []byte) uint64 {
+	return xxhash64BytesGeneric(data)
+}
+
 // ============================================================================
 // Phase 4: String Operations
 // ============================================================================
 
-func strCmpImpl(a, b []byte) int {
+func strCmpImpl(ks *kernelSet, a, b []byte) int {
 	return strCmpGeneric(a, b)
 }
 
-func strPrefixCmpImpl(str, prefix []byte) bool {
+func strPrefixCmpImpl(ks *kernelSet, str, prefix []byte) bool {
 	return strPrefixCmpGeneric(str, prefix)
 }
 
-func strEqImpl(a, b []byte) bool {
+func strEqImpl(ks *kernelSet, a, b []byte) bool {
 	return strEqGeneric(a, b)
 }
 
-func strToLowerImpl(s []byte) {
+func strIndexImpl(ks *kernelSet, str, substr []byte) int {
+	return strIndexGeneric(str, substr)
+}
+
+func strContainsImpl(ks *kernelSet, str, substr []byte) bool {
+	return strContainsGeneric(str, substr)
+}
+
+func teddyFindImpl(ks *kernelSet, s []byte, masks *[3][2][16]byte, m, from int) int {
+	return teddyFindGeneric(s, masks, m, from)
+}
+
+func asciiPrefixImpl(ks *kernelSet, s []byte) int {
+	return asciiPrefixGeneric(s)
+}
+
+func utf8ValidImpl(ks *kernelSet, s []byte) bool {
+	return utf8.Valid(s)
+}
+
+func charLengthImpl(ks *kernelSet, s []byte) int {
+	return charLengthGeneric(s)
+}
+
+func strEqIgnoreCaseImpl(ks *kernelSet, a, b []byte) bool {
+	return strEqIgnoreCaseGeneric(a, b)
+}
+
+func strToLowerImpl(ks *kernelSet, s []byte) {
 	strToLowerGeneric(s)
 }
 
-func strToUpperImpl(s []byte) {
+func strToUpperImpl(ks *kernelSet, s []byte) {
 	strToUpperGeneric(s)
 }
 
@@ -136,51 +227,51 @@ func strToUpperImpl(s []byte) {
 // Float64 Comparisons
 // ============================================================================
 
-func cmpGtFloat64Impl(values []float64, threshold float64) []bool {
+func cmpGtFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
 	return cmpGtFloat64Generic(values, threshold)
 }
 
-func cmpGtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
+func cmpGtFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
 	return cmpGtFloat64MaskGeneric(values, threshold)
 }
 
-func cmpGeFloat64Impl(values []float64, threshold float64) []bool {
+func cmpGeFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
 	return cmpGeFloat64Generic(values, threshold)
 }
 
-func cmpGeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
+func cmpGeFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
 	return cmpGeFloat64MaskGeneric(values, threshold)
 }
 
-func cmpLtFloat64Impl(values []float64, threshold float64) []bool {
+func cmpLtFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
 	return cmpLtFloat64Generic(values, threshold)
 }
 
-func cmpLtFloat64MaskImpl(values []float64, threshold float64) []uint64 {
+func cmpLtFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
 	return cmpLtFloat64MaskGeneric(values, threshold)
 }
 
-func cmpLeFloat64Impl(values []float64, threshold float64) []bool {
+func cmpLeFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
 	return cmpLeFloat64Generic(values, threshold)
 }
 
-func cmpLeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
+func cmpLeFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
 	return cmpLeFloat64MaskGeneric(values, threshold)
 }
 
-func cmpEqFloat64Impl(values []float64, threshold float64) []bool {
+func cmpEqFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
 	return cmpEqFloat64Generic(values, threshold)
 }
 
-func cmpEqFloat64MaskImpl(values []float64, threshold float64) []uint64 {
+func cmpEqFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
 	return cmpEqFloat64MaskGeneric(values, threshold)
 }
 
-func cmpNeFloat64Impl(values []float64, threshold float64) []bool {
+func cmpNeFloat64Impl(ks *kernelSet, values []float64, threshold float64) []bool {
 	return cmpNeFloat64Generic(values, threshold)
 }
 
-func cmpNeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
+func cmpNeFloat64MaskImpl(ks *kernelSet, values []float64, threshold float64) []uint64 {
 	return cmpNeFloat64MaskGeneric(values, threshold)
 }
 
@@ -188,58 +279,254 @@ func cmpNeFloat64MaskImpl(values []float64, threshold float64) []uint64 {
 // String Comparisons
 // ============================================================================
 
-func cmpEqStringImpl(values [][]byte, threshold []byte) []bool {
+func cmpEqStringImpl(ks *kernelSet, values [][]byte, threshold []byte) []bool {
 	return cmpEqStringGeneric(values, threshold)
 }
 
-func cmpEqStringMaskImpl(values [][]byte, threshold []byte) []uint64 {
+func cmpEqStringMaskImpl(ks *kernelSet, values [][]byte, threshold []byte) []uint64 {
 	return cmpEqStringMaskGeneric(values, threshold)
 }
 
-func cmpNeStringImpl(values [][]byte, threshold []byte) []bool {
+func cmpNeStringImpl(ks *kernelSet, values [][]byte, threshold []byte) []bool {
 	return cmpNeStringGeneric(values, threshold)
 }
 
-func cmpNeStringMaskImpl(values [][]byte, threshold []byte) []uint64 {
+func cmpNeStringMaskImpl(ks *kernelSet, values [][]byte, threshold []byte) []uint64 {
 	return cmpNeStringMaskGeneric(values, threshold)
 }
 
-func cmpHasPrefixStringImpl(values [][]byte, prefix []byte) []bool {
+func cmpHasPrefixStringImpl(ks *kernelSet, values [][]byte, prefix []byte) []bool {
 	return cmpHasPrefixStringGeneric(values, prefix)
 }
 
-func cmpHasPrefixStringMaskImpl(values [][]byte, prefix []byte) []uint64 {
+func cmpHasPrefixStringMaskImpl(ks *kernelSet, values [][]byte, prefix []byte) []uint64 {
 	return cmpHasPrefixStringMaskGeneric(values, prefix)
 }
 
-func cmpHasSuffixStringImpl(values [][]byte, suffix []byte) []bool {
+func cmpHasSuffixStringImpl(ks *kernelSet, values [][]byte, suffix []byte) []bool {
 	return cmpHasSuffixStringGeneric(values, suffix)
 }
 
-func cmpHasSuffixStringMaskImpl(values [][]byte, suffix []byte) []uint64 {
+func cmpHasSuffixStringMaskImpl(ks *kernelSet, values [][]byte, suffix []byte) []uint64 {
 	return cmpHasSuffixStringMaskGeneric(values, suffix)
 }
 
-func cmpContainsStringImpl(values [][]byte, substr []byte) []bool {
+func cmpContainsStringImpl(ks *kernelSet, values [][]byte, substr []byte) []bool {
 	return cmpContainsStringGeneric(values, substr)
 }
 
-func cmpContainsStringMaskImpl(values [][]byte, substr []byte) []uint64 {
+func cmpContainsStringMaskImpl(ks *kernelSet, values [][]byte, substr []byte) []uint64 {
 	return cmpContainsStringMaskGeneric(values, substr)
 }
 
-func cmpEqStringIgnoreCaseImpl(values [][]byte, threshold []byte) []bool {
+func cmpEqStringIgnoreCaseImpl(ks *kernelSet, values [][]byte, threshold []byte) []bool {
 	return cmpEqStringIgnoreCaseGeneric(values, threshold)
 }
 
-func cmpEqStringIgnoreCaseMaskImpl(values [][]byte, threshold []byte) []uint64 {
+func cmpEqStringIgnoreCaseMaskImpl(ks *kernelSet, values [][]byte, threshold []byte) []uint64 {
 	return cmpEqStringIgnoreCaseMaskGeneric(values, threshold)
 }
 
-func cmpMatchWildcardImpl(values [][]byte, pattern []byte) []bool {
+func cmpOrderStringImpl(ks *kernelSet, values [][]byte, op Op, threshold []byte) []bool {
+	return cmpOrderStringGeneric(values, op, threshold)
+}
+
+func cmpBetweenStringImpl(ks *kernelSet, values [][]byte, lo, hi []byte) []bool {
+	return cmpBetweenStringGeneric(values, lo, hi)
+}
+
+func cmpHasPrefixStringIgnoreCaseImpl(ks *kernelSet, values [][]byte, prefix []byte) []bool {
+	return cmpHasPrefixStringIgnoreCaseGeneric(values, prefix)
+}
+
+func cmpHasSuffixStringIgnoreCaseImpl(ks *kernelSet, values [][]byte, suffix []byte) []bool {
+	return cmpHasSuffixStringIgnoreCaseGeneric(values, suffix)
+}
+
+func cmpMatchWildcardImpl(ks *kernelSet, values [][]byte, pattern []byte) []bool {
 	return cmpMatchWildcardGeneric(values, pattern)
 }
 
-func cmpMatchWildcardMaskImpl(values [][]byte, pattern []byte) []uint64 {
+func cmpMatchWildcardMaskImpl(ks *kernelSet, values [][]byte, pattern []byte) []uint64 {
 	return cmpMatchWildcardMaskGeneric(values, pattern)
 }
+
+// ============================================================================
+// Selection Materialization (compress, gather, scatter)
+// ============================================================================
+
+func compressInt64Impl(ks *kernelSet, dst, src []int64, mask []uint64) int {
+	return compressInt64Generic(dst, src, mask)
+}
+
+func compressFloat64Impl(ks *kernelSet, dst, src []float64, mask []uint64) int {
+	return compressFloat64Generic(dst, src, mask)
+}
+
+func compressStringsImpl(ks *kernelSet, dst, src []string, mask []uint64) int {
+	return compressStringsGeneric(dst, src, mask)
+}
+
+func gatherInt64Impl(ks *kernelSet, dst, src []int64, indices []uint32) {
+	gatherInt64Generic(dst, src, indices)
+}
+
+func scatterInt64Impl(ks *kernelSet, dst, src []int64, indices []uint32) {
+	scatterInt64Generic(dst, src, indices)
+}
+
+// ============================================================================
+// Narrow Integer Operations
+// ============================================================================
+
+// Narrow integer types use the scalar implementations
+
+func cmpNarrowImpl[T narrowInt](ks *kernelSet, values []T, op Op, threshold T) []bool {
+	return cmpNumberGeneric(values, op, threshold)
+}
+
+func cmpNarrowMaskImpl[T narrowInt](ks *kernelSet, values []T, op Op, threshold T) []uint64 {
+	return cmpNumberMaskGeneric(values, op, threshold)
+}
+
+func sumSignedImpl[T signedNarrowInt](ks *kernelSet, values []T) int64 {
+	return sumSignedGeneric(values)
+}
+
+func sumUnsignedImpl[T unsignedNarrowInt](ks *kernelSet, values []T) uint64 {
+	return sumUnsignedGeneric(values)
+}
+
+func minNarrowImpl[T narrowInt](ks *kernelSet, values []T) T {
+	return minNarrowGeneric(values)
+}
+
+func maxNarrowImpl[T narrowInt](ks *kernelSet, values []T) T {
+	return maxNarrowGeneric(values)
+}
+
+// ============================================================================
+// Float32 Operations
+// ============================================================================
+
+func cmpFloat32Impl(ks *kernelSet, values []float32, op Op, threshold float32) []bool {
+	return cmpNumberGeneric(values, op, threshold)
+}
+
+func cmpFloat32MaskImpl(ks *kernelSet, values []float32, op Op, threshold float32) []uint64 {
+	return cmpNumberMaskGeneric(values, op, threshold)
+}
+
+func sumFloat32Impl(ks *kernelSet, values []float32) float64 {
+	return sumFloat32Generic(values)
+}
+
+func minFloat32Impl(ks *kernelSet, values []float32) float32 {
+	return minFloat32Generic(values)
+}
+
+func maxFloat32Impl(ks *kernelSet, values []float32) float32 {
+	return maxFloat32Generic(values)
+}
+
+func float32ToFloat64Impl(ks *kernelSet, dst []float64, src []float32) {
+	float32ToFloat64Generic(dst, src)
+}
+
+func float64ToFloat32Impl(ks *kernelSet, dst []float32, src []float64) {
+	float64ToFloat32Generic(dst, src)
+}
+
+// ============================================================================
+// Arithmetic Operations
+// ============================================================================
+
+func addInt64Impl(ks *kernelSet, dst, a, b []int64) bool {
+	return addInt64Generic(dst, a, b)
+}
+
+func addInt64ScalarImpl(ks *kernelSet, dst, a []int64, b int64) bool {
+	return addInt64ScalarGeneric(dst, a, b)
+}
+
+func subInt64Impl(ks *kernelSet, dst, a, b []int64) bool {
+	return subInt64Generic(dst, a, b)
+}
+
+func subInt64ScalarImpl(ks *kernelSet, dst, a []int64, b int64) bool {
+	return subInt64ScalarGeneric(dst, a, b)
+}
+
+func negInt64Impl(ks *kernelSet, dst, a []int64) bool {
+	return negInt64Generic(dst, a)
+}
+
+func absInt64Impl(ks *kernelSet, dst, a []int64) bool {
+	return absInt64Generic(dst, a)
+}
+
+func addFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	addFloat64Generic(dst, a, b)
+}
+
+func addFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	addFloat64ScalarGeneric(dst, a, b)
+}
+
+func subFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	subFloat64Generic(dst, a, b)
+}
+
+func subFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	subFloat64ScalarGeneric(dst, a, b)
+}
+
+func mulFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	mulFloat64Generic(dst, a, b)
+}
+
+func mulFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	mulFloat64ScalarGeneric(dst, a, b)
+}
+
+func divFloat64Impl(ks *kernelSet, dst, a, b []float64) {
+	divFloat64Generic(dst, a, b)
+}
+
+func divFloat64ScalarImpl(ks *kernelSet, dst, a []float64, b float64) {
+	divFloat64ScalarGeneric(dst, a, b)
+}
+
+func negFloat64Impl(ks *kernelSet, dst, a []float64) {
+	negFloat64Generic(dst, a)
+}
+
+func absFloat64Impl(ks *kernelSet, dst, a []float64) {
+	absFloat64Generic(dst, a)
+}
+
+// ============================================================================
+// Expression Operations
+// ============================================================================
+
+func cmpInt64MaskIntoImpl(ks *kernelSet, values []int64, op Op, threshold int64, mask []uint64) {
+	clear(mask[:(len(values)+63)/64])
+	cmpNumberTail(values, op, threshold, mask, 0)
+}
+
+func cmpFloat64MaskIntoImpl(ks *kernelSet, values []float64, op Op, threshold float64, mask []uint64) {
+	clear(mask[:(len(values)+63)/64])
+	cmpNumberTail(values, op, threshold, mask, 0)
+}
+
+// ============================================================================
+// Temporal Operations
+// ============================================================================
+
+func truncFixedImpl(ks *kernelSet, dst, src []int64, width int64) {
+	truncFixedGeneric(dst, src, width)
+}
+
+func floorDivModImpl(ks *kernelSet, quo, rem, src []int64, width int64) {
+	floorDivModGeneric(quo, rem, src, width)
+}
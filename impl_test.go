@@ -0,0 +1,48 @@
+package syndrdbsimd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Helpers shared by the per-architecture dispatch tests, which force each kernel
+// level in turn and compare every *Impl function against its generic version.
+
+func checkInt64Compares(t *testing.T, size int, values []int64, threshold int64) {
+	t.Helper()
+	checkEqual(t, size, "CmpEqInt64", cmpEqInt64Impl(currentKernels(), values, threshold), cmpEqInt64Generic(values, threshold))
+	checkEqual(t, size, "CmpNeInt64", cmpNeInt64Impl(currentKernels(), values, threshold), cmpNeInt64Generic(values, threshold))
+	checkEqual(t, size, "CmpGtInt64", cmpGtInt64Impl(currentKernels(), values, threshold), cmpGtInt64Generic(values, threshold))
+	checkEqual(t, size, "CmpGeInt64", cmpGeInt64Impl(currentKernels(), values, threshold), cmpGeInt64Generic(values, threshold))
+	checkEqual(t, size, "CmpLtInt64", cmpLtInt64Impl(currentKernels(), values, threshold), cmpLtInt64Generic(values, threshold))
+	checkEqual(t, size, "CmpLeInt64", cmpLeInt64Impl(currentKernels(), values, threshold), cmpLeInt64Generic(values, threshold))
+	checkEqual(t, size, "CmpEqInt64Mask", cmpEqInt64MaskImpl(currentKernels(), values, threshold), cmpEqInt64MaskGeneric(values, threshold))
+	checkEqual(t, size, "CmpNeInt64Mask", cmpNeInt64MaskImpl(currentKernels(), values, threshold), cmpNeInt64MaskGeneric(values, threshold))
+	checkEqual(t, size, "CmpGtInt64Mask", cmpGtInt64MaskImpl(currentKernels(), values, threshold), cmpGtInt64MaskGeneric(values, threshold))
+	checkEqual(t, size, "CmpGeInt64Mask", cmpGeInt64MaskImpl(currentKernels(), values, threshold), cmpGeInt64MaskGeneric(values, threshold))
+	checkEqual(t, size, "CmpLtInt64Mask", cmpLtInt64MaskImpl(currentKernels(), values, threshold), cmpLtInt64MaskGeneric(values, threshold))
+	checkEqual(t, size, "CmpLeInt64Mask", cmpLeInt64MaskImpl(currentKernels(), values, threshold), cmpLeInt64MaskGeneric(values, threshold))
+}
+
+func checkFloat64Compares(t *testing.T, size int, values []float64, threshold float64) {
+	t.Helper()
+	checkEqual(t, size, "CmpEqFloat64", cmpEqFloat64Impl(currentKernels(), values, threshold), cmpEqFloat64Generic(values, threshold))
+	checkEqual(t, size, "CmpNeFloat64", cmpNeFloat64Impl(currentKernels(), values, threshold), cmpNeFloat64Generic(values, threshold))
+	checkEqual(t, size, "CmpGtFloat64", cmpGtFloat64Impl(currentKernels(), values, threshold), cmpGtFloat64Generic(values, threshold))
+	checkEqual(t, size, "CmpGeFloat64", cmpGeFloat64Impl(currentKernels(), values, threshold), cmpGeFloat64Generic(values, threshold))
+	checkEqual(t, size, "CmpLtFloat64", cmpLtFloat64Impl(currentKernels(), values, threshold), cmpLtFloat64Generic(values, threshold))
+	checkEqual(t, size, "CmpLeFloat64", cmpLeFloat64Impl(currentKernels(), values, threshold), cmpLeFloat64Generic(values, threshold))
+	checkEqual(t, size, "CmpEqFloat64Mask", cmpEqFloat64MaskImpl(currentKernels(), values, threshold), cmpEqFloat64MaskGeneric(values, threshold))
+	checkEqual(t, size, "CmpNeFloat64Mask", cmpNeFloat64MaskImpl(currentKernels(), values, threshold), cmpNeFloat64MaskGeneric(values, threshold))
+	checkEqual(t, size, "CmpGtFloat64Mask", cmpGtFloat64MaskImpl(currentKernels(), values, threshold), cmpGtFloat64MaskGeneric(values, threshold))
+	checkEqual(t, size, "CmpGeFloat64Mask", cmpGeFloat64MaskImpl(currentKernels(), values, threshold), cmpGeFloat64MaskGeneric(values, threshold))
+	checkEqual(t, size, "CmpLtFloat64Mask", cmpLtFloat64MaskImpl(currentKernels(), values, threshold), cmpLtFloat64MaskGeneric(values, threshold))
+	checkEqual(t, size, "CmpLeFloat64Mask", cmpLeFloat64MaskImpl(currentKernels(), values, threshold), cmpLeFloat64MaskGeneric(values, threshold))
+}
+
+func checkEqual(t *testing.T, size int, op string, got, want interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("size=%d: %s = %v, want %v", size, op, got, want)
+	}
+}
@@ -0,0 +1,2076 @@
+package syndrdbsimd
+
+import "math"
+
+// The Kernels methods below mirror the package-level functions in api.go, which
+// call them with the zero Kernels.
+
+// CmpEqInt64 is CmpEqInt64 using k's kernel selection.
+func (k Kernels) CmpEqInt64(values []int64, threshold int64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	// TODO: I could add a heuristic to skip SIMD for very small arrays (< 8 elements)
+	// where the overhead might not be worth it
+
+	return cmpEqInt64Impl(k.kernels(), values, threshold)
+}
+
+// CmpEqInt64Mask is CmpEqInt64Mask using k's kernel selection.
+func (k Kernels) CmpEqInt64Mask(values []int64, threshold int64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpEqInt64MaskImpl(k.kernels(), values, threshold)
+}
+
+// CmpNeInt64 is CmpNeInt64 using k's kernel selection.
+func (k Kernels) CmpNeInt64(values []int64, threshold int64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNeInt64Impl(k.kernels(), values, threshold)
+}
+
+// CmpNeInt64Mask is CmpNeInt64Mask using k's kernel selection.
+func (k Kernels) CmpNeInt64Mask(values []int64, threshold int64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNeInt64MaskImpl(k.kernels(), values, threshold)
+}
+
+// CmpGtInt64 is CmpGtInt64 using k's kernel selection.
+func (k Kernels) CmpGtInt64(values []int64, threshold int64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpGtInt64Impl(k.kernels(), values, threshold)
+}
+
+// CmpGtInt64Mask is CmpGtInt64Mask using k's kernel selection.
+func (k Kernels) CmpGtInt64Mask(values []int64, threshold int64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpGtInt64MaskImpl(k.kernels(), values, threshold)
+}
+
+// CmpLtInt64 is CmpLtInt64 using k's kernel selection.
+func (k Kernels) CmpLtInt64(values []int64, threshold int64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpLtInt64Impl(k.kernels(), values, threshold)
+}
+
+// CmpLtInt64Mask is CmpLtInt64Mask using k's kernel selection.
+func (k Kernels) CmpLtInt64Mask(values []int64, threshold int64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpLtInt64MaskImpl(k.kernels(), values, threshold)
+}
+
+// CmpGeInt64 is CmpGeInt64 using k's kernel selection.
+func (k Kernels) CmpGeInt64(values []int64, threshold int64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpGeInt64Impl(k.kernels(), values, threshold)
+}
+
+// CmpGeInt64Mask is CmpGeInt64Mask using k's kernel selection.
+func (k Kernels) CmpGeInt64Mask(values []int64, threshold int64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpGeInt64MaskImpl(k.kernels(), values, threshold)
+}
+
+// CmpLeInt64 is CmpLeInt64 using k's kernel selection.
+func (k Kernels) CmpLeInt64(values []int64, threshold int64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpLeInt64Impl(k.kernels(), values, threshold)
+}
+
+// CmpLeInt64Mask is CmpLeInt64Mask using k's kernel selection.
+func (k Kernels) CmpLeInt64Mask(values []int64, threshold int64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpLeInt64MaskImpl(k.kernels(), values, threshold)
+}
+
+// AndBitmap is AndBitmap using k's kernel selection.
+func (k Kernels) AndBitmap(a, b []uint64) []uint64 {
+	if len(a) == 0 || len(b) == 0 {
+		return []uint64{}
+	}
+
+	return andBitmapImpl(k.kernels(), a, b)
+}
+
+// OrBitmap is OrBitmap using k's kernel selection.
+func (k Kernels) OrBitmap(a, b []uint64) []uint64 {
+	if len(a) == 0 || len(b) == 0 {
+		return []uint64{}
+	}
+
+	return orBitmapImpl(k.kernels(), a, b)
+}
+
+// XorBitmap is XorBitmap using k's kernel selection.
+func (k Kernels) XorBitmap(a, b []uint64) []uint64 {
+	if len(a) == 0 || len(b) == 0 {
+		return []uint64{}
+	}
+
+	return xorBitmapImpl(k.kernels(), a, b)
+}
+
+// NotBitmap is NotBitmap using k's kernel selection.
+func (k Kernels) NotBitmap(a []uint64) []uint64 {
+	if len(a) == 0 {
+		return []uint64{}
+	}
+
+	return notBitmapImpl(k.kernels(), a)
+}
+
+// PopCount is PopCount using k's kernel selection.
+func (k Kernels) PopCount(bitmap []uint64) int {
+	if len(bitmap) == 0 {
+		return 0
+	}
+
+	return popCountImpl(k.kernels(), bitmap)
+}
+
+// ============================================================================
+// Float64 Comparisons
+// ============================================================================
+
+// CmpGtFloat64 is CmpGtFloat64 using k's kernel selection.
+func (k Kernels) CmpGtFloat64(values []float64, threshold float64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpGtFloat64Impl(k.kernels(), values, threshold)
+}
+
+// CmpGtFloat64Mask is CmpGtFloat64Mask using k's kernel selection.
+func (k Kernels) CmpGtFloat64Mask(values []float64, threshold float64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpGtFloat64MaskImpl(k.kernels(), values, threshold)
+}
+
+// CmpGeFloat64 is CmpGeFloat64 using k's kernel selection.
+func (k Kernels) CmpGeFloat64(values []float64, threshold float64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpGeFloat64Impl(k.kernels(), values, threshold)
+}
+
+// CmpGeFloat64Mask is CmpGeFloat64Mask using k's kernel selection.
+func (k Kernels) CmpGeFloat64Mask(values []float64, threshold float64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpGeFloat64MaskImpl(k.kernels(), values, threshold)
+}
+
+// CmpLtFloat64 is CmpLtFloat64 using k's kernel selection.
+func (k Kernels) CmpLtFloat64(values []float64, threshold float64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpLtFloat64Impl(k.kernels(), values, threshold)
+}
+
+// CmpLtFloat64Mask is CmpLtFloat64Mask using k's kernel selection.
+func (k Kernels) CmpLtFloat64Mask(values []float64, threshold float64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpLtFloat64MaskImpl(k.kernels(), values, threshold)
+}
+
+// CmpLeFloat64 is CmpLeFloat64 using k's kernel selection.
+func (k Kernels) CmpLeFloat64(values []float64, threshold float64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpLeFloat64Impl(k.kernels(), values, threshold)
+}
+
+// CmpLeFloat64Mask is CmpLeFloat64Mask using k's kernel selection.
+func (k Kernels) CmpLeFloat64Mask(values []float64, threshold float64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpLeFloat64MaskImpl(k.kernels(), values, threshold)
+}
+
+// CmpEqFloat64 is CmpEqFloat64 using k's kernel selection.
+func (k Kernels) CmpEqFloat64(values []float64, threshold float64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpEqFloat64Impl(k.kernels(), values, threshold)
+}
+
+// CmpEqFloat64Mask is CmpEqFloat64Mask using k's kernel selection.
+func (k Kernels) CmpEqFloat64Mask(values []float64, threshold float64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpEqFloat64MaskImpl(k.kernels(), values, threshold)
+}
+
+// CmpNeFloat64 is CmpNeFloat64 using k's kernel selection.
+func (k Kernels) CmpNeFloat64(values []float64, threshold float64) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNeFloat64Impl(k.kernels(), values, threshold)
+}
+
+// CmpNeFloat64Mask is CmpNeFloat64Mask using k's kernel selection.
+func (k Kernels) CmpNeFloat64Mask(values []float64, threshold float64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNeFloat64MaskImpl(k.kernels(), values, threshold)
+}
+
+// ============================================================================
+// String Comparisons
+// ============================================================================
+
+// CmpEqString is CmpEqString using k's kernel selection.
+func (k Kernels) CmpEqString(values []string, threshold string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	byteValues := stringsToBytes(values)
+	byteThreshold := []byte(threshold)
+	return cmpEqStringImpl(k.kernels(), byteValues, byteThreshold)
+}
+
+// CmpEqStringMask is CmpEqStringMask using k's kernel selection.
+func (k Kernels) CmpEqStringMask(values []string, threshold string) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	byteValues := stringsToBytes(values)
+	byteThreshold := []byte(threshold)
+	return cmpEqStringMaskImpl(k.kernels(), byteValues, byteThreshold)
+}
+
+// CmpNeString is CmpNeString using k's kernel selection.
+func (k Kernels) CmpNeString(values []string, threshold string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	byteValues := stringsToBytes(values)
+	byteThreshold := []byte(threshold)
+	return cmpNeStringImpl(k.kernels(), byteValues, byteThreshold)
+}
+
+// CmpNeStringMask is CmpNeStringMask using k's kernel selection.
+func (k Kernels) CmpNeStringMask(values []string, threshold string) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	byteValues := stringsToBytes(values)
+	byteThreshold := []byte(threshold)
+	return cmpNeStringMaskImpl(k.kernels(), byteValues, byteThreshold)
+}
+
+// CmpGtString is CmpGtString using k's kernel selection.
+func (k Kernels) CmpGtString(values []string, threshold string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpOrderStringImpl(k.kernels(), stringsToBytes(values), OpGt, stringToBytes(threshold))
+}
+
+// CmpGtStringMask is CmpGtStringMask using k's kernel selection.
+func (k Kernels) CmpGtStringMask(values []string, threshold string) []uint64 {
+	bools := k.CmpGtString(values, threshold)
+	return boolsToBitmask(bools)
+}
+
+// CmpGeString is CmpGeString using k's kernel selection.
+func (k Kernels) CmpGeString(values []string, threshold string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpOrderStringImpl(k.kernels(), stringsToBytes(values), OpGe, stringToBytes(threshold))
+}
+
+// CmpGeStringMask is CmpGeStringMask using k's kernel selection.
+func (k Kernels) CmpGeStringMask(values []string, threshold string) []uint64 {
+	bools := k.CmpGeString(values, threshold)
+	return boolsToBitmask(bools)
+}
+
+// CmpLtString is CmpLtString using k's kernel selection.
+func (k Kernels) CmpLtString(values []string, threshold string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpOrderStringImpl(k.kernels(), stringsToBytes(values), OpLt, stringToBytes(threshold))
+}
+
+// CmpLtStringMask is CmpLtStringMask using k's kernel selection.
+func (k Kernels) CmpLtStringMask(values []string, threshold string) []uint64 {
+	bools := k.CmpLtString(values, threshold)
+	return boolsToBitmask(bools)
+}
+
+// CmpLeString is CmpLeString using k's kernel selection.
+func (k Kernels) CmpLeString(values []string, threshold string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpOrderStringImpl(k.kernels(), stringsToBytes(values), OpLe, stringToBytes(threshold))
+}
+
+// CmpLeStringMask is CmpLeStringMask using k's kernel selection.
+func (k Kernels) CmpLeStringMask(values []string, threshold string) []uint64 {
+	bools := k.CmpLeString(values, threshold)
+	return boolsToBitmask(bools)
+}
+
+// CmpBetweenString is CmpBetweenString using k's kernel selection.
+func (k Kernels) CmpBetweenString(values []string, lo, hi string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpBetweenStringImpl(k.kernels(), stringsToBytes(values), stringToBytes(lo), stringToBytes(hi))
+}
+
+// CmpBetweenStringMask is CmpBetweenStringMask using k's kernel selection.
+func (k Kernels) CmpBetweenStringMask(values []string, lo, hi string) []uint64 {
+	bools := k.CmpBetweenString(values, lo, hi)
+	return boolsToBitmask(bools)
+}
+
+// CmpHasPrefixString is CmpHasPrefixString using k's kernel selection.
+func (k Kernels) CmpHasPrefixString(values []string, prefix string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	byteValues := stringsToBytes(values)
+	bytePrefix := []byte(prefix)
+	return cmpHasPrefixStringImpl(k.kernels(), byteValues, bytePrefix)
+}
+
+// CmpHasPrefixStringMask is CmpHasPrefixStringMask using k's kernel selection.
+func (k Kernels) CmpHasPrefixStringMask(values []string, prefix string) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	byteValues := stringsToBytes(values)
+	bytePrefix := []byte(prefix)
+	return cmpHasPrefixStringMaskImpl(k.kernels(), byteValues, bytePrefix)
+}
+
+// CmpHasSuffixString is CmpHasSuffixString using k's kernel selection.
+func (k Kernels) CmpHasSuffixString(values []string, suffix string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	byteValues := stringsToBytes(values)
+	byteSuffix := []byte(suffix)
+	return cmpHasSuffixStringImpl(k.kernels(), byteValues, byteSuffix)
+}
+
+// CmpHasSuffixStringMask is CmpHasSuffixStringMask using k's kernel selection.
+func (k Kernels) CmpHasSuffixStringMask(values []string, suffix string) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	byteValues := stringsToBytes(values)
+	byteSuffix := []byte(suffix)
+	return cmpHasSuffixStringMaskImpl(k.kernels(), byteValues, byteSuffix)
+}
+
+// CmpContainsString is CmpContainsString using k's kernel selection.
+func (k Kernels) CmpContainsString(values []string, substr string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	byteValues := stringsToBytes(values)
+	byteSubstr := []byte(substr)
+	return cmpContainsStringImpl(k.kernels(), byteValues, byteSubstr)
+}
+
+// CmpContainsStringMask is CmpContainsStringMask using k's kernel selection.
+func (k Kernels) CmpContainsStringMask(values []string, substr string) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	byteValues := stringsToBytes(values)
+	byteSubstr := []byte(substr)
+	return cmpContainsStringMaskImpl(k.kernels(), byteValues, byteSubstr)
+}
+
+// CmpEqStringIgnoreCase is CmpEqStringIgnoreCase using k's kernel selection.
+func (k Kernels) CmpEqStringIgnoreCase(values []string, threshold string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	byteValues := stringsToBytes(values)
+	byteThreshold := []byte(threshold)
+	return cmpEqStringIgnoreCaseImpl(k.kernels(), byteValues, byteThreshold)
+}
+
+// CmpEqStringIgnoreCaseMask is CmpEqStringIgnoreCaseMask using k's kernel selection.
+func (k Kernels) CmpEqStringIgnoreCaseMask(values []string, threshold string) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	byteValues := stringsToBytes(values)
+	byteThreshold := []byte(threshold)
+	return cmpEqStringIgnoreCaseMaskImpl(k.kernels(), byteValues, byteThreshold)
+}
+
+// CmpLikeString is CmpLikeString using k's kernel selection.
+func (k Kernels) CmpLikeString(values []string, pattern string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	compiled, err := CompilePatternAuto(pattern)
+	if err != nil {
+		// Return all false on invalid pattern
+		results := make([]bool, len(values))
+		return results
+	}
+
+	return cmpLikeStringCompiled(k.kernels(), values, compiled)
+}
+
+// CmpLikeStringMask is CmpLikeStringMask using k's kernel selection.
+func (k Kernels) CmpLikeStringMask(values []string, pattern string) []uint64 {
+	bools := k.CmpLikeString(values, pattern)
+	return boolsToBitmask(bools)
+}
+
+// CmpLikeStringCompiled is CmpLikeStringCompiled using k's kernel selection.
+func (k Kernels) CmpLikeStringCompiled(values []string, pattern *CompiledPattern) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpLikeStringCompiled(k.kernels(), values, pattern)
+}
+
+// CmpLikeStringCompiledMask is CmpLikeStringCompiledMask using k's kernel selection.
+func (k Kernels) CmpLikeStringCompiledMask(values []string, pattern *CompiledPattern) []uint64 {
+	bools := k.CmpLikeStringCompiled(values, pattern)
+	return boolsToBitmask(bools)
+}
+
+// CmpNotLikeString is CmpNotLikeString using k's kernel selection.
+func (k Kernels) CmpNotLikeString(values []string, pattern string) ([]bool, error) {
+	compiled, err := CompilePatternAuto(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.CmpNotLikeStringCompiled(values, compiled), nil
+}
+
+// CmpNotLikeStringMask is CmpNotLikeStringMask using k's kernel selection.
+func (k Kernels) CmpNotLikeStringMask(values []string, pattern string) ([]uint64, error) {
+	bools, err := k.CmpNotLikeString(values, pattern)
+	if err != nil {
+		return nil, err
+	}
+	return boolsToBitmask(bools), nil
+}
+
+// CmpNotLikeStringCompiled is CmpNotLikeStringCompiled using k's kernel selection.
+func (k Kernels) CmpNotLikeStringCompiled(values []string, pattern *CompiledPattern) []bool {
+	results := k.CmpLikeStringCompiled(values, pattern)
+	for i := range results {
+		results[i] = !results[i]
+	}
+	return results
+}
+
+// CmpNotLikeStringCompiledMask is CmpNotLikeStringCompiledMask using k's kernel selection.
+func (k Kernels) CmpNotLikeStringCompiledMask(values []string, pattern *CompiledPattern) []uint64 {
+	bools := k.CmpNotLikeStringCompiled(values, pattern)
+	return boolsToBitmask(bools)
+}
+
+// CmpILikeString is CmpILikeString using k's kernel selection.
+func (k Kernels) CmpILikeString(values []string, pattern string) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	compiled, err := CompilePatternAutoIgnoreCase(pattern)
+	if err != nil {
+		// Return all false on invalid pattern
+		results := make([]bool, len(values))
+		return results
+	}
+
+	return cmpLikeStringCompiled(k.kernels(), values, compiled)
+}
+
+// CmpILikeStringMask is CmpILikeStringMask using k's kernel selection.
+func (k Kernels) CmpILikeStringMask(values []string, pattern string) []uint64 {
+	bools := k.CmpILikeString(values, pattern)
+	return boolsToBitmask(bools)
+}
+
+// ============================================================================
+// Phase 2: Aggregation Operations
+// ============================================================================
+
+// SumInt64 is SumInt64 using k's kernel selection.
+func (k Kernels) SumInt64(values []int64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return sumInt64Impl(k.kernels(), values)
+}
+
+// MinInt64 is MinInt64 using k's kernel selection.
+func (k Kernels) MinInt64(values []int64) int64 {
+	if len(values) == 0 {
+		return 1<<63 - 1 // math.MaxInt64
+	}
+
+	return minInt64Impl(k.kernels(), values)
+}
+
+// MaxInt64 is MaxInt64 using k's kernel selection.
+func (k Kernels) MaxInt64(values []int64) int64 {
+	if len(values) == 0 {
+		return -1 << 63 // math.MinInt64
+	}
+
+	return maxInt64Impl(k.kernels(), values)
+}
+
+// CountNonNull is CountNonNull using k's kernel selection.
+func (k Kernels) CountNonNull(values []int64, nullBitmap []uint64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return countNonNullImpl(k.kernels(), values, nullBitmap)
+}
+
+// AvgInt64 is AvgInt64 using k's kernel selection.
+func (k Kernels) AvgInt64(values []int64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sum := k.SumInt64(values)
+	return float64(sum) / float64(len(values))
+}
+
+// ============================================================================
+// Phase 3: Hashing Operations
+// ============================================================================
+
+// HashInt64 is HashInt64 using k's kernel selection.
+func (k Kernels) HashInt64(values []int64, output []uint64) {
+	if len(values) == 0 || len(output) != len(values) {
+		return
+	}
+
+	hashInt64Impl(k.kernels(), values, output)
+}
+
+// CRC32Int64 is CRC32Int64 using k's kernel selection.
+func (k Kernels) CRC32Int64(values []int64, output []uint32) {
+	if len(values) == 0 || len(output) != len(values) {
+		return
+	}
+
+	crc32Int64Impl(k.kernels(), values, output)
+}
+
+// XXHash64 is XXHash64 using k's kernel selection.
+func (k Kernels) XXHash64(values []int64, output []uint64) {
+	if len(values) == 0 || len(output) != len(values) {
+		return
+	}
+
+	xxhash64Impl(k.kernels(), values, output)
+}
+
+// XXHash64Bytes is XXHash64Bytes using k's kernel selection.
+func (k Kernels) XXHash64Bytes(data []byte) uint64 {
+	return xxhash64BytesImpl(k.kernels(), data)
+}
+
+// ============================================================================
+// Phase 4: String Operations
+// ============================================================================
+
+// StrCmp is StrCmp using k's kernel selection.
+func (k Kernels) StrCmp(a, b []byte) int {
+	return strCmpImpl(k.kernels(), a, b)
+}
+
+// StrPrefixCmp is StrPrefixCmp using k's kernel selection.
+func (k Kernels) StrPrefixCmp(str, prefix []byte) bool {
+	return strPrefixCmpImpl(k.kernels(), str, prefix)
+}
+
+// StrContains is StrContains using k's kernel selection.
+func (k Kernels) StrContains(str, substr []byte) bool {
+	return strContainsImpl(k.kernels(), str, substr)
+}
+
+// StrEq is StrEq using k's kernel selection.
+func (k Kernels) StrEq(a, b []byte) bool {
+	return strEqImpl(k.kernels(), a, b)
+}
+
+// StrToLower is StrToLower using k's kernel selection.
+func (k Kernels) StrToLower(s []byte) {
+	strToLowerImpl(k.kernels(), s)
+}
+
+// StrToUpper is StrToUpper using k's kernel selection.
+func (k Kernels) StrToUpper(s []byte) {
+	strToUpperImpl(k.kernels(), s)
+}
+
+// StrEqIgnoreCase is StrEqIgnoreCase using k's kernel selection.
+func (k Kernels) StrEqIgnoreCase(a, b []byte) bool {
+	return strEqIgnoreCaseImpl(k.kernels(), a, b)
+}
+
+// ============================================================================
+// Selection Materialization
+// ============================================================================
+
+// CompressInt64 is CompressInt64 using k's kernel selection.
+func (k Kernels) CompressInt64(dst, src []int64, mask []uint64) int {
+	if len(src) == 0 || len(mask) == 0 || len(dst) == 0 {
+		return 0
+	}
+
+	return compressInt64Impl(k.kernels(), dst, src, mask)
+}
+
+// CompressFloat64 is CompressFloat64 using k's kernel selection.
+func (k Kernels) CompressFloat64(dst, src []float64, mask []uint64) int {
+	if len(src) == 0 || len(mask) == 0 || len(dst) == 0 {
+		return 0
+	}
+
+	return compressFloat64Impl(k.kernels(), dst, src, mask)
+}
+
+// CompressStrings is CompressStrings using k's kernel selection.
+func (k Kernels) CompressStrings(dst, src []string, mask []uint64) int {
+	if len(src) == 0 || len(mask) == 0 || len(dst) == 0 {
+		return 0
+	}
+
+	return compressStringsImpl(k.kernels(), dst, src, mask)
+}
+
+// GatherInt64 is GatherInt64 using k's kernel selection.
+func (k Kernels) GatherInt64(dst, src []int64, indices []uint32) {
+	if len(indices) == 0 || len(dst) < len(indices) {
+		return
+	}
+
+	gatherInt64Impl(k.kernels(), dst[:len(indices)], src, indices)
+}
+
+// ScatterInt64 is ScatterInt64 using k's kernel selection.
+func (k Kernels) ScatterInt64(dst, src []int64, indices []uint32) {
+	if len(indices) == 0 || len(src) < len(indices) {
+		return
+	}
+
+	scatterInt64Impl(k.kernels(), dst, src[:len(indices)], indices)
+}
+
+// ============================================================================
+// Narrow Integer Operations
+// ============================================================================
+
+// CmpEqInt8 is CmpEqInt8 using k's kernel selection.
+func (k Kernels) CmpEqInt8(values []int8, threshold int8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpEqInt8Mask is CmpEqInt8Mask using k's kernel selection.
+func (k Kernels) CmpEqInt8Mask(values []int8, threshold int8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpNeInt8 is CmpNeInt8 using k's kernel selection.
+func (k Kernels) CmpNeInt8(values []int8, threshold int8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpNeInt8Mask is CmpNeInt8Mask using k's kernel selection.
+func (k Kernels) CmpNeInt8Mask(values []int8, threshold int8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpGtInt8 is CmpGtInt8 using k's kernel selection.
+func (k Kernels) CmpGtInt8(values []int8, threshold int8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpGtInt8Mask is CmpGtInt8Mask using k's kernel selection.
+func (k Kernels) CmpGtInt8Mask(values []int8, threshold int8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpLtInt8 is CmpLtInt8 using k's kernel selection.
+func (k Kernels) CmpLtInt8(values []int8, threshold int8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpLtInt8Mask is CmpLtInt8Mask using k's kernel selection.
+func (k Kernels) CmpLtInt8Mask(values []int8, threshold int8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpGeInt8 is CmpGeInt8 using k's kernel selection.
+func (k Kernels) CmpGeInt8(values []int8, threshold int8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpGeInt8Mask is CmpGeInt8Mask using k's kernel selection.
+func (k Kernels) CmpGeInt8Mask(values []int8, threshold int8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpLeInt8 is CmpLeInt8 using k's kernel selection.
+func (k Kernels) CmpLeInt8(values []int8, threshold int8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// CmpLeInt8Mask is CmpLeInt8Mask using k's kernel selection.
+func (k Kernels) CmpLeInt8Mask(values []int8, threshold int8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// SumInt8 is SumInt8 using k's kernel selection.
+func (k Kernels) SumInt8(values []int8) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return sumSignedImpl(k.kernels(), values)
+}
+
+// MinInt8 is MinInt8 using k's kernel selection.
+func (k Kernels) MinInt8(values []int8) int8 {
+	if len(values) == 0 {
+		return math.MaxInt8
+	}
+
+	return minNarrowImpl(k.kernels(), values)
+}
+
+// MaxInt8 is MaxInt8 using k's kernel selection.
+func (k Kernels) MaxInt8(values []int8) int8 {
+	if len(values) == 0 {
+		return math.MinInt8
+	}
+
+	return maxNarrowImpl(k.kernels(), values)
+}
+
+// XXHash64Int8 is XXHash64Int8 using k's kernel selection.
+func (k Kernels) XXHash64Int8(values []int8, output []uint64) {
+	if len(values) == 0 || len(output) != len(values) {
+		return
+	}
+
+	xxhash64NarrowImpl(k.kernels(), values, output)
+}
+
+// CmpEqInt16 is CmpEqInt16 using k's kernel selection.
+func (k Kernels) CmpEqInt16(values []int16, threshold int16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpEqInt16Mask is CmpEqInt16Mask using k's kernel selection.
+func (k Kernels) CmpEqInt16Mask(values []int16, threshold int16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpNeInt16 is CmpNeInt16 using k's kernel selection.
+func (k Kernels) CmpNeInt16(values []int16, threshold int16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpNeInt16Mask is CmpNeInt16Mask using k's kernel selection.
+func (k Kernels) CmpNeInt16Mask(values []int16, threshold int16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpGtInt16 is CmpGtInt16 using k's kernel selection.
+func (k Kernels) CmpGtInt16(values []int16, threshold int16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpGtInt16Mask is CmpGtInt16Mask using k's kernel selection.
+func (k Kernels) CmpGtInt16Mask(values []int16, threshold int16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpLtInt16 is CmpLtInt16 using k's kernel selection.
+func (k Kernels) CmpLtInt16(values []int16, threshold int16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpLtInt16Mask is CmpLtInt16Mask using k's kernel selection.
+func (k Kernels) CmpLtInt16Mask(values []int16, threshold int16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpGeInt16 is CmpGeInt16 using k's kernel selection.
+func (k Kernels) CmpGeInt16(values []int16, threshold int16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpGeInt16Mask is CmpGeInt16Mask using k's kernel selection.
+func (k Kernels) CmpGeInt16Mask(values []int16, threshold int16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpLeInt16 is CmpLeInt16 using k's kernel selection.
+func (k Kernels) CmpLeInt16(values []int16, threshold int16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// CmpLeInt16Mask is CmpLeInt16Mask using k's kernel selection.
+func (k Kernels) CmpLeInt16Mask(values []int16, threshold int16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// SumInt16 is SumInt16 using k's kernel selection.
+func (k Kernels) SumInt16(values []int16) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return sumSignedImpl(k.kernels(), values)
+}
+
+// MinInt16 is MinInt16 using k's kernel selection.
+func (k Kernels) MinInt16(values []int16) int16 {
+	if len(values) == 0 {
+		return math.MaxInt16
+	}
+
+	return minNarrowImpl(k.kernels(), values)
+}
+
+// MaxInt16 is MaxInt16 using k's kernel selection.
+func (k Kernels) MaxInt16(values []int16) int16 {
+	if len(values) == 0 {
+		return math.MinInt16
+	}
+
+	return maxNarrowImpl(k.kernels(), values)
+}
+
+// XXHash64Int16 is XXHash64Int16 using k's kernel selection.
+func (k Kernels) XXHash64Int16(values []int16, output []uint64) {
+	if len(values) == 0 || len(output) != len(values) {
+		return
+	}
+
+	xxhash64NarrowImpl(k.kernels(), values, output)
+}
+
+// CmpEqInt32 is CmpEqInt32 using k's kernel selection.
+func (k Kernels) CmpEqInt32(values []int32, threshold int32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpEqInt32Mask is CmpEqInt32Mask using k's kernel selection.
+func (k Kernels) CmpEqInt32Mask(values []int32, threshold int32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpNeInt32 is CmpNeInt32 using k's kernel selection.
+func (k Kernels) CmpNeInt32(values []int32, threshold int32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpNeInt32Mask is CmpNeInt32Mask using k's kernel selection.
+func (k Kernels) CmpNeInt32Mask(values []int32, threshold int32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpGtInt32 is CmpGtInt32 using k's kernel selection.
+func (k Kernels) CmpGtInt32(values []int32, threshold int32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpGtInt32Mask is CmpGtInt32Mask using k's kernel selection.
+func (k Kernels) CmpGtInt32Mask(values []int32, threshold int32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpLtInt32 is CmpLtInt32 using k's kernel selection.
+func (k Kernels) CmpLtInt32(values []int32, threshold int32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpLtInt32Mask is CmpLtInt32Mask using k's kernel selection.
+func (k Kernels) CmpLtInt32Mask(values []int32, threshold int32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpGeInt32 is CmpGeInt32 using k's kernel selection.
+func (k Kernels) CmpGeInt32(values []int32, threshold int32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpGeInt32Mask is CmpGeInt32Mask using k's kernel selection.
+func (k Kernels) CmpGeInt32Mask(values []int32, threshold int32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpLeInt32 is CmpLeInt32 using k's kernel selection.
+func (k Kernels) CmpLeInt32(values []int32, threshold int32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// CmpLeInt32Mask is CmpLeInt32Mask using k's kernel selection.
+func (k Kernels) CmpLeInt32Mask(values []int32, threshold int32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// SumInt32 is SumInt32 using k's kernel selection.
+func (k Kernels) SumInt32(values []int32) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return sumSignedImpl(k.kernels(), values)
+}
+
+// MinInt32 is MinInt32 using k's kernel selection.
+func (k Kernels) MinInt32(values []int32) int32 {
+	if len(values) == 0 {
+		return math.MaxInt32
+	}
+
+	return minNarrowImpl(k.kernels(), values)
+}
+
+// MaxInt32 is MaxInt32 using k's kernel selection.
+func (k Kernels) MaxInt32(values []int32) int32 {
+	if len(values) == 0 {
+		return math.MinInt32
+	}
+
+	return maxNarrowImpl(k.kernels(), values)
+}
+
+// XXHash64Int32 is XXHash64Int32 using k's kernel selection.
+func (k Kernels) XXHash64Int32(values []int32, output []uint64) {
+	if len(values) == 0 || len(output) != len(values) {
+		return
+	}
+
+	xxhash64NarrowImpl(k.kernels(), values, output)
+}
+
+// CmpEqUint8 is CmpEqUint8 using k's kernel selection.
+func (k Kernels) CmpEqUint8(values []uint8, threshold uint8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpEqUint8Mask is CmpEqUint8Mask using k's kernel selection.
+func (k Kernels) CmpEqUint8Mask(values []uint8, threshold uint8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpNeUint8 is CmpNeUint8 using k's kernel selection.
+func (k Kernels) CmpNeUint8(values []uint8, threshold uint8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpNeUint8Mask is CmpNeUint8Mask using k's kernel selection.
+func (k Kernels) CmpNeUint8Mask(values []uint8, threshold uint8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpGtUint8 is CmpGtUint8 using k's kernel selection.
+func (k Kernels) CmpGtUint8(values []uint8, threshold uint8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpGtUint8Mask is CmpGtUint8Mask using k's kernel selection.
+func (k Kernels) CmpGtUint8Mask(values []uint8, threshold uint8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpLtUint8 is CmpLtUint8 using k's kernel selection.
+func (k Kernels) CmpLtUint8(values []uint8, threshold uint8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpLtUint8Mask is CmpLtUint8Mask using k's kernel selection.
+func (k Kernels) CmpLtUint8Mask(values []uint8, threshold uint8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpGeUint8 is CmpGeUint8 using k's kernel selection.
+func (k Kernels) CmpGeUint8(values []uint8, threshold uint8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpGeUint8Mask is CmpGeUint8Mask using k's kernel selection.
+func (k Kernels) CmpGeUint8Mask(values []uint8, threshold uint8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpLeUint8 is CmpLeUint8 using k's kernel selection.
+func (k Kernels) CmpLeUint8(values []uint8, threshold uint8) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// CmpLeUint8Mask is CmpLeUint8Mask using k's kernel selection.
+func (k Kernels) CmpLeUint8Mask(values []uint8, threshold uint8) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// SumUint8 is SumUint8 using k's kernel selection.
+func (k Kernels) SumUint8(values []uint8) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return sumUnsignedImpl(k.kernels(), values)
+}
+
+// MinUint8 is MinUint8 using k's kernel selection.
+func (k Kernels) MinUint8(values []uint8) uint8 {
+	if len(values) == 0 {
+		return math.MaxUint8
+	}
+
+	return minNarrowImpl(k.kernels(), values)
+}
+
+// MaxUint8 is MaxUint8 using k's kernel selection.
+func (k Kernels) MaxUint8(values []uint8) uint8 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return maxNarrowImpl(k.kernels(), values)
+}
+
+// XXHash64Uint8 is XXHash64Uint8 using k's kernel selection.
+func (k Kernels) XXHash64Uint8(values []uint8, output []uint64) {
+	if len(values) == 0 || len(output) != len(values) {
+		return
+	}
+
+	xxhash64NarrowImpl(k.kernels(), values, output)
+}
+
+// CmpEqUint16 is CmpEqUint16 using k's kernel selection.
+func (k Kernels) CmpEqUint16(values []uint16, threshold uint16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpEqUint16Mask is CmpEqUint16Mask using k's kernel selection.
+func (k Kernels) CmpEqUint16Mask(values []uint16, threshold uint16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpNeUint16 is CmpNeUint16 using k's kernel selection.
+func (k Kernels) CmpNeUint16(values []uint16, threshold uint16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpNeUint16Mask is CmpNeUint16Mask using k's kernel selection.
+func (k Kernels) CmpNeUint16Mask(values []uint16, threshold uint16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpGtUint16 is CmpGtUint16 using k's kernel selection.
+func (k Kernels) CmpGtUint16(values []uint16, threshold uint16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpGtUint16Mask is CmpGtUint16Mask using k's kernel selection.
+func (k Kernels) CmpGtUint16Mask(values []uint16, threshold uint16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpLtUint16 is CmpLtUint16 using k's kernel selection.
+func (k Kernels) CmpLtUint16(values []uint16, threshold uint16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpLtUint16Mask is CmpLtUint16Mask using k's kernel selection.
+func (k Kernels) CmpLtUint16Mask(values []uint16, threshold uint16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpGeUint16 is CmpGeUint16 using k's kernel selection.
+func (k Kernels) CmpGeUint16(values []uint16, threshold uint16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpGeUint16Mask is CmpGeUint16Mask using k's kernel selection.
+func (k Kernels) CmpGeUint16Mask(values []uint16, threshold uint16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpLeUint16 is CmpLeUint16 using k's kernel selection.
+func (k Kernels) CmpLeUint16(values []uint16, threshold uint16) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// CmpLeUint16Mask is CmpLeUint16Mask using k's kernel selection.
+func (k Kernels) CmpLeUint16Mask(values []uint16, threshold uint16) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// SumUint16 is SumUint16 using k's kernel selection.
+func (k Kernels) SumUint16(values []uint16) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return sumUnsignedImpl(k.kernels(), values)
+}
+
+// MinUint16 is MinUint16 using k's kernel selection.
+func (k Kernels) MinUint16(values []uint16) uint16 {
+	if len(values) == 0 {
+		return math.MaxUint16
+	}
+
+	return minNarrowImpl(k.kernels(), values)
+}
+
+// MaxUint16 is MaxUint16 using k's kernel selection.
+func (k Kernels) MaxUint16(values []uint16) uint16 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return maxNarrowImpl(k.kernels(), values)
+}
+
+// XXHash64Uint16 is XXHash64Uint16 using k's kernel selection.
+func (k Kernels) XXHash64Uint16(values []uint16, output []uint64) {
+	if len(values) == 0 || len(output) != len(values) {
+		return
+	}
+
+	xxhash64NarrowImpl(k.kernels(), values, output)
+}
+
+// CmpEqUint32 is CmpEqUint32 using k's kernel selection.
+func (k Kernels) CmpEqUint32(values []uint32, threshold uint32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpEqUint32Mask is CmpEqUint32Mask using k's kernel selection.
+func (k Kernels) CmpEqUint32Mask(values []uint32, threshold uint32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpNeUint32 is CmpNeUint32 using k's kernel selection.
+func (k Kernels) CmpNeUint32(values []uint32, threshold uint32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpNeUint32Mask is CmpNeUint32Mask using k's kernel selection.
+func (k Kernels) CmpNeUint32Mask(values []uint32, threshold uint32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpGtUint32 is CmpGtUint32 using k's kernel selection.
+func (k Kernels) CmpGtUint32(values []uint32, threshold uint32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpGtUint32Mask is CmpGtUint32Mask using k's kernel selection.
+func (k Kernels) CmpGtUint32Mask(values []uint32, threshold uint32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpLtUint32 is CmpLtUint32 using k's kernel selection.
+func (k Kernels) CmpLtUint32(values []uint32, threshold uint32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpLtUint32Mask is CmpLtUint32Mask using k's kernel selection.
+func (k Kernels) CmpLtUint32Mask(values []uint32, threshold uint32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpGeUint32 is CmpGeUint32 using k's kernel selection.
+func (k Kernels) CmpGeUint32(values []uint32, threshold uint32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpGeUint32Mask is CmpGeUint32Mask using k's kernel selection.
+func (k Kernels) CmpGeUint32Mask(values []uint32, threshold uint32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpLeUint32 is CmpLeUint32 using k's kernel selection.
+func (k Kernels) CmpLeUint32(values []uint32, threshold uint32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpNarrowImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// CmpLeUint32Mask is CmpLeUint32Mask using k's kernel selection.
+func (k Kernels) CmpLeUint32Mask(values []uint32, threshold uint32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpNarrowMaskImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// SumUint32 is SumUint32 using k's kernel selection.
+func (k Kernels) SumUint32(values []uint32) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return sumUnsignedImpl(k.kernels(), values)
+}
+
+// MinUint32 is MinUint32 using k's kernel selection.
+func (k Kernels) MinUint32(values []uint32) uint32 {
+	if len(values) == 0 {
+		return math.MaxUint32
+	}
+
+	return minNarrowImpl(k.kernels(), values)
+}
+
+// MaxUint32 is MaxUint32 using k's kernel selection.
+func (k Kernels) MaxUint32(values []uint32) uint32 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return maxNarrowImpl(k.kernels(), values)
+}
+
+// XXHash64Uint32 is XXHash64Uint32 using k's kernel selection.
+func (k Kernels) XXHash64Uint32(values []uint32, output []uint64) {
+	if len(values) == 0 || len(output) != len(values) {
+		return
+	}
+
+	xxhash64NarrowImpl(k.kernels(), values, output)
+}
+
+// ============================================================================
+// Float32 Operations
+// ============================================================================
+
+// CmpEqFloat32 is CmpEqFloat32 using k's kernel selection.
+func (k Kernels) CmpEqFloat32(values []float32, threshold float32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpFloat32Impl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpEqFloat32Mask is CmpEqFloat32Mask using k's kernel selection.
+func (k Kernels) CmpEqFloat32Mask(values []float32, threshold float32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpFloat32MaskImpl(k.kernels(), values, OpEq, threshold)
+}
+
+// CmpNeFloat32 is CmpNeFloat32 using k's kernel selection.
+func (k Kernels) CmpNeFloat32(values []float32, threshold float32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpFloat32Impl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpNeFloat32Mask is CmpNeFloat32Mask using k's kernel selection.
+func (k Kernels) CmpNeFloat32Mask(values []float32, threshold float32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpFloat32MaskImpl(k.kernels(), values, OpNe, threshold)
+}
+
+// CmpGtFloat32 is CmpGtFloat32 using k's kernel selection.
+func (k Kernels) CmpGtFloat32(values []float32, threshold float32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpFloat32Impl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpGtFloat32Mask is CmpGtFloat32Mask using k's kernel selection.
+func (k Kernels) CmpGtFloat32Mask(values []float32, threshold float32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpFloat32MaskImpl(k.kernels(), values, OpGt, threshold)
+}
+
+// CmpGeFloat32 is CmpGeFloat32 using k's kernel selection.
+func (k Kernels) CmpGeFloat32(values []float32, threshold float32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpFloat32Impl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpGeFloat32Mask is CmpGeFloat32Mask using k's kernel selection.
+func (k Kernels) CmpGeFloat32Mask(values []float32, threshold float32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpFloat32MaskImpl(k.kernels(), values, OpGe, threshold)
+}
+
+// CmpLtFloat32 is CmpLtFloat32 using k's kernel selection.
+func (k Kernels) CmpLtFloat32(values []float32, threshold float32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpFloat32Impl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpLtFloat32Mask is CmpLtFloat32Mask using k's kernel selection.
+func (k Kernels) CmpLtFloat32Mask(values []float32, threshold float32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpFloat32MaskImpl(k.kernels(), values, OpLt, threshold)
+}
+
+// CmpLeFloat32 is CmpLeFloat32 using k's kernel selection.
+func (k Kernels) CmpLeFloat32(values []float32, threshold float32) []bool {
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	return cmpFloat32Impl(k.kernels(), values, OpLe, threshold)
+}
+
+// CmpLeFloat32Mask is CmpLeFloat32Mask using k's kernel selection.
+func (k Kernels) CmpLeFloat32Mask(values []float32, threshold float32) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	return cmpFloat32MaskImpl(k.kernels(), values, OpLe, threshold)
+}
+
+// SumFloat32 is SumFloat32 using k's kernel selection.
+func (k Kernels) SumFloat32(values []float32) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return sumFloat32Impl(k.kernels(), values)
+}
+
+// MinFloat32 is MinFloat32 using k's kernel selection.
+func (k Kernels) MinFloat32(values []float32) float32 {
+	if len(values) == 0 {
+		return float32(math.Inf(1))
+	}
+
+	return minFloat32Impl(k.kernels(), values)
+}
+
+// MaxFloat32 is MaxFloat32 using k's kernel selection.
+func (k Kernels) MaxFloat32(values []float32) float32 {
+	if len(values) == 0 {
+		return float32(math.Inf(-1))
+	}
+
+	return maxFloat32Impl(k.kernels(), values)
+}
+
+// AvgFloat32 is AvgFloat32 using k's kernel selection.
+func (k Kernels) AvgFloat32(values []float32) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return sumFloat32Impl(k.kernels(), values) / float64(len(values))
+}
+
+// Float32ToFloat64 is Float32ToFloat64 using k's kernel selection.
+func (k Kernels) Float32ToFloat64(dst []float64, src []float32) int {
+	n := min(len(dst), len(src))
+	if n == 0 {
+		return 0
+	}
+
+	float32ToFloat64Impl(k.kernels(), dst[:n], src[:n])
+	return n
+}
+
+// Float64ToFloat32 is Float64ToFloat32 using k's kernel selection.
+func (k Kernels) Float64ToFloat32(dst []float32, src []float64) int {
+	n := min(len(dst), len(src))
+	if n == 0 {
+		return 0
+	}
+
+	float64ToFloat32Impl(k.kernels(), dst[:n], src[:n])
+	return n
+}
+
+// ============================================================================
+// Arithmetic Operations
+// ============================================================================
+
+// AddInt64 is AddInt64 using k's kernel selection.
+func (k Kernels) AddInt64(a, b []int64) (result []int64, overflow bool) {
+	result = make([]int64, min(len(a), len(b)))
+	_, overflow = k.AddInt64Into(result, a, b)
+	return result, overflow
+}
+
+// AddInt64Into is AddInt64Into using k's kernel selection.
+func (k Kernels) AddInt64Into(dst, a, b []int64) (n int, overflow bool) {
+	n = min(len(dst), len(a), len(b))
+	if n == 0 {
+		return 0, false
+	}
+
+	return n, addInt64Impl(k.kernels(), dst[:n], a[:n], b[:n])
+}
+
+// AddInt64Scalar is AddInt64Scalar using k's kernel selection.
+func (k Kernels) AddInt64Scalar(a []int64, b int64) (result []int64, overflow bool) {
+	result = make([]int64, len(a))
+	_, overflow = k.AddInt64ScalarInto(result, a, b)
+	return result, overflow
+}
+
+// AddInt64ScalarInto is AddInt64ScalarInto using k's kernel selection.
+func (k Kernels) AddInt64ScalarInto(dst, a []int64, b int64) (n int, overflow bool) {
+	n = min(len(dst), len(a))
+	if n == 0 {
+		return 0, false
+	}
+
+	return n, addInt64ScalarImpl(k.kernels(), dst[:n], a[:n], b)
+}
+
+// SubInt64 is SubInt64 using k's kernel selection.
+func (k Kernels) SubInt64(a, b []int64) (result []int64, overflow bool) {
+	result = make([]int64, min(len(a), len(b)))
+	_, overflow = k.SubInt64Into(result, a, b)
+	return result, overflow
+}
+
+// SubInt64Into is SubInt64Into using k's kernel selection.
+func (k Kernels) SubInt64Into(dst, a, b []int64) (n int, overflow bool) {
+	n = min(len(dst), len(a), len(b))
+	if n == 0 {
+		return 0, false
+	}
+
+	return n, subInt64Impl(k.kernels(), dst[:n], a[:n], b[:n])
+}
+
+// SubInt64Scalar is SubInt64Scalar using k's kernel selection.
+func (k Kernels) SubInt64Scalar(a []int64, b int64) (result []int64, overflow bool) {
+	result = make([]int64, len(a))
+	_, overflow = k.SubInt64ScalarInto(result, a, b)
+	return result, overflow
+}
+
+// SubInt64ScalarInto is SubInt64ScalarInto using k's kernel selection.
+func (k Kernels) SubInt64ScalarInto(dst, a []int64, b int64) (n int, overflow bool) {
+	n = min(len(dst), len(a))
+	if n == 0 {
+		return 0, false
+	}
+
+	return n, subInt64ScalarImpl(k.kernels(), dst[:n], a[:n], b)
+}
+
+// NegInt64 is NegInt64 using k's kernel selection.
+func (k Kernels) NegInt64(a []int64) (result []int64, overflow bool) {
+	result = make([]int64, len(a))
+	_, overflow = k.NegInt64Into(result, a)
+	return result, overflow
+}
+
+// NegInt64Into is NegInt64Into using k's kernel selection.
+func (k Kernels) NegInt64Into(dst, a []int64) (n int, overflow bool) {
+	n = min(len(dst), len(a))
+	if n == 0 {
+		return 0, false
+	}
+
+	return n, negInt64Impl(k.kernels(), dst[:n], a[:n])
+}
+
+// AbsInt64 is AbsInt64 using k's kernel selection.
+func (k Kernels) AbsInt64(a []int64) (result []int64, overflow bool) {
+	result = make([]int64, len(a))
+	_, overflow = k.AbsInt64Into(result, a)
+	return result, overflow
+}
+
+// AbsInt64Into is AbsInt64Into using k's kernel selection.
+func (k Kernels) AbsInt64Into(dst, a []int64) (n int, overflow bool) {
+	n = min(len(dst), len(a))
+	if n == 0 {
+		return 0, false
+	}
+
+	return n, absInt64Impl(k.kernels(), dst[:n], a[:n])
+}
+
+// AddFloat64 is AddFloat64 using k's kernel selection.
+func (k Kernels) AddFloat64(a, b []float64) []float64 {
+	result := make([]float64, min(len(a), len(b)))
+	k.AddFloat64Into(result, a, b)
+	return result
+}
+
+// AddFloat64Into is AddFloat64Into using k's kernel selection.
+func (k Kernels) AddFloat64Into(dst, a, b []float64) int {
+	n := min(len(dst), len(a), len(b))
+	if n == 0 {
+		return 0
+	}
+
+	addFloat64Impl(k.kernels(), dst[:n], a[:n], b[:n])
+	return n
+}
+
+// AddFloat64Scalar is AddFloat64Scalar using k's kernel selection.
+func (k Kernels) AddFloat64Scalar(a []float64, b float64) []float64 {
+	result := make([]float64, len(a))
+	k.AddFloat64ScalarInto(result, a, b)
+	return result
+}
+
+// AddFloat64ScalarInto is AddFloat64ScalarInto using k's kernel selection.
+func (k Kernels) AddFloat64ScalarInto(dst, a []float64, b float64) int {
+	n := min(len(dst), len(a))
+	if n == 0 {
+		return 0
+	}
+
+	addFloat64ScalarImpl(k.kernels(), dst[:n], a[:n], b)
+	return n
+}
+
+// SubFloat64 is SubFloat64 using k's kernel selection.
+func (k Kernels) SubFloat64(a, b []float64) []float64 {
+	result := make([]float64, min(len(a), len(b)))
+	k.SubFloat64Into(result, a, b)
+	return result
+}
+
+// SubFloat64Into is SubFloat64Into using k's kernel selection.
+func (k Kernels) SubFloat64Into(dst, a, b []float64) int {
+	n := min(len(dst), len(a), len(b))
+	if n == 0 {
+		return 0
+	}
+
+	subFloat64Impl(k.kernels(), dst[:n], a[:n], b[:n])
+	return n
+}
+
+// SubFloat64Scalar is SubFloat64Scalar using k's kernel selection.
+func (k Kernels) SubFloat64Scalar(a []float64, b float64) []float64 {
+	result := make([]float64, len(a))
+	k.SubFloat64ScalarInto(result, a, b)
+	return result
+}
+
+// SubFloat64ScalarInto is SubFloat64ScalarInto using k's kernel selection.
+func (k Kernels) SubFloat64ScalarInto(dst, a []float64, b float64) int {
+	n := min(len(dst), len(a))
+	if n == 0 {
+		return 0
+	}
+
+	subFloat64ScalarImpl(k.kernels(), dst[:n], a[:n], b)
+	return n
+}
+
+// MulFloat64 is MulFloat64 using k's kernel selection.
+func (k Kernels) MulFloat64(a, b []float64) []float64 {
+	result := make([]float64, min(len(a), len(b)))
+	k.MulFloat64Into(result, a, b)
+	return result
+}
+
+// MulFloat64Into is MulFloat64Into using k's kernel selection.
+func (k Kernels) MulFloat64Into(dst, a, b []float64) int {
+	n := min(len(dst), len(a), len(b))
+	if n == 0 {
+		return 0
+	}
+
+	mulFloat64Impl(k.kernels(), dst[:n], a[:n], b[:n])
+	return n
+}
+
+// MulFloat64Scalar is MulFloat64Scalar using k's kernel selection.
+func (k Kernels) MulFloat64Scalar(a []float64, b float64) []float64 {
+	result := make([]float64, len(a))
+	k.MulFloat64ScalarInto(result, a, b)
+	return result
+}
+
+// MulFloat64ScalarInto is MulFloat64ScalarInto using k's kernel selection.
+func (k Kernels) MulFloat64ScalarInto(dst, a []float64, b float64) int {
+	n := min(len(dst), len(a))
+	if n == 0 {
+		return 0
+	}
+
+	mulFloat64ScalarImpl(k.kernels(), dst[:n], a[:n], b)
+	return n
+}
+
+// DivFloat64 is DivFloat64 using k's kernel selection.
+func (k Kernels) DivFloat64(a, b []float64) []float64 {
+	result := make([]float64, min(len(a), len(b)))
+	k.DivFloat64Into(result, a, b)
+	return result
+}
+
+// DivFloat64Into is DivFloat64Into using k's kernel selection.
+func (k Kernels) DivFloat64Into(dst, a, b []float64) int {
+	n := min(len(dst), len(a), len(b))
+	if n == 0 {
+		return 0
+	}
+
+	divFloat64Impl(k.kernels(), dst[:n], a[:n], b[:n])
+	return n
+}
+
+// DivFloat64Scalar is DivFloat64Scalar using k's kernel selection.
+func (k Kernels) DivFloat64Scalar(a []float64, b float64) []float64 {
+	result := make([]float64, len(a))
+	k.DivFloat64ScalarInto(result, a, b)
+	return result
+}
+
+// DivFloat64ScalarInto is DivFloat64ScalarInto using k's kernel selection.
+func (k Kernels) DivFloat64ScalarInto(dst, a []float64, b float64) int {
+	n := min(len(dst), len(a))
+	if n == 0 {
+		return 0
+	}
+
+	divFloat64ScalarImpl(k.kernels(), dst[:n], a[:n], b)
+	return n
+}
+
+// NegFloat64 is NegFloat64 using k's kernel selection.
+func (k Kernels) NegFloat64(a []float64) []float64 {
+	result := make([]float64, len(a))
+	k.NegFloat64Into(result, a)
+	return result
+}
+
+// NegFloat64Into is NegFloat64Into using k's kernel selection.
+func (k Kernels) NegFloat64Into(dst, a []float64) int {
+	n := min(len(dst), len(a))
+	if n == 0 {
+		return 0
+	}
+
+	negFloat64Impl(k.kernels(), dst[:n], a[:n])
+	return n
+}
+
+// AbsFloat64 is AbsFloat64 using k's kernel selection.
+func (k Kernels) AbsFloat64(a []float64) []float64 {
+	result := make([]float64, len(a))
+	k.AbsFloat64Into(result, a)
+	return result
+}
+
+// AbsFloat64Into is AbsFloat64Into using k's kernel selection.
+func (k Kernels) AbsFloat64Into(dst, a []float64) int {
+	n := min(len(dst), len(a))
+	if n == 0 {
+		return 0
+	}
+
+	absFloat64Impl(k.kernels(), dst[:n], a[:n])
+	return n
+}
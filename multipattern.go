@@ -0,0 +1,343 @@
+package syndrdbsimd
+
+import (
+	"bytes"
+	"math/bits"
+	"slices"
+)
+
+// MultiPattern matches a set of substrings against string columns in one pass
+// per row, instead of one CmpContainsString pass per substring. It is safe
+// for concurrent use.
+//
+// Sets of up to teddyMaxPatterns substrings use Teddy: the first bytes of the
+// patterns are spread over 8 buckets, a SIMD kernel finds the positions whose
+// fingerprint matches some bucket, and only the patterns of those buckets are
+// verified there. Larger sets, short rows and the generic ISA use an
+// Aho-Corasick automaton, which reads each byte once whatever the set size.
+type MultiPattern struct {
+	patterns [][]byte
+	empty    []int // ids of empty patterns, which every row contains
+	teddy    *teddy
+	ac       *ahoCorasick
+}
+
+// teddyMaxPatterns is the largest set Teddy handles. Beyond it the 8 buckets
+// get crowded, and most candidates fail verification.
+const teddyMaxPatterns = 32
+
+// teddyMinRow is the shortest row searched with Teddy; shorter rows do not fill
+// a vector block and go through the automaton.
+const teddyMinRow = 32
+
+// CompileMultiPattern prepares patterns for matching. A pattern's id is its
+// index in patterns; duplicates keep their own ids.
+func CompileMultiPattern(patterns []string) *MultiPattern {
+	mp := &MultiPattern{patterns: make([][]byte, len(patterns))}
+	var ids []int
+	for id, p := range patterns {
+		mp.patterns[id] = []byte(p)
+		if p == "" {
+			mp.empty = append(mp.empty, id)
+		} else {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > 0 && len(ids) <= teddyMaxPatterns {
+		mp.teddy = newTeddy(mp.patterns, ids)
+	}
+	mp.ac = newAhoCorasick(mp.patterns, ids)
+	return mp
+}
+
+// Len returns the number of patterns.
+func (mp *MultiPattern) Len() int {
+	return len(mp.patterns)
+}
+
+// Pattern returns the pattern with the given id.
+func (mp *MultiPattern) Pattern(id int) string {
+	return string(mp.patterns[id])
+}
+
+// ContainsAny reports for each row whether it contains any of the patterns.
+func (mp *MultiPattern) ContainsAny(values []string) []bool {
+	results := make([]bool, len(values))
+	useTeddy := mp.useTeddy()
+	set := make([]uint64, mp.words())
+	for i, v := range stringsToBytes(values) {
+		results[i] = mp.scan(v, useTeddy, true, set)
+	}
+	return results
+}
+
+// ContainsAnyMask is ContainsAny returning a bitmask.
+func (mp *MultiPattern) ContainsAnyMask(values []string) []uint64 {
+	bools := mp.ContainsAny(values)
+	return boolsToBitmask(bools)
+}
+
+// FirstMatch returns for each row the lowest id among the patterns it contains,
+// or -1 if it contains none. Listing patterns by priority makes this the rule
+// that classifies the row.
+func (mp *MultiPattern) FirstMatch(values []string) []int {
+	results := make([]int, len(values))
+	useTeddy := mp.useTeddy()
+	set := make([]uint64, mp.words())
+	for i, v := range stringsToBytes(values) {
+		results[i] = -1
+		clear(set)
+		if !mp.scan(v, useTeddy, false, set) {
+			continue
+		}
+		for w, word := range set {
+			if word != 0 {
+				results[i] = w*64 + bits.TrailingZeros64(word)
+				break
+			}
+		}
+	}
+	return results
+}
+
+// MatchMasks returns for each row a bitmask of the patterns it contains: bit
+// id%64 of word id/64 is set if the row contains pattern id. Each row takes
+// (Len()+63)/64 words, so row i's mask is result[i*words : (i+1)*words].
+func (mp *MultiPattern) MatchMasks(values []string) []uint64 {
+	words := mp.words()
+	results := make([]uint64, len(values)*words)
+	useTeddy := mp.useTeddy()
+	for i, v := range stringsToBytes(values) {
+		mp.scan(v, useTeddy, false, results[i*words:(i+1)*words])
+	}
+	return results
+}
+
+func (mp *MultiPattern) words() int {
+	return (len(mp.patterns) + 63) / 64
+}
+
+// useTeddy reports whether rows long enough for a vector block use Teddy.
+func (mp *MultiPattern) useTeddy() bool {
+	return mp.teddy != nil && kernelISA(currentKernels(), "String") != ISAGeneric
+}
+
+// scan sets the bits of the patterns s contains in set and reports whether
+// there were any. With first set, it stops at the first match.
+func (mp *MultiPattern) scan(s []byte, useTeddy, first bool, set []uint64) bool {
+	for _, id := range mp.empty {
+		set[id/64] |= 1 << uint(id%64)
+		if first {
+			return true
+		}
+	}
+	found := len(mp.empty) > 0
+	if useTeddy && len(s) >= teddyMinRow {
+		return mp.teddy.scan(s, mp.patterns, first, set) || found
+	}
+	return mp.ac.scan(s, first, set) || found
+}
+
+// ============================================================================
+// Teddy
+// ============================================================================
+
+// teddy holds the fingerprint tables of a small pattern set. Each pattern goes
+// to one of 8 buckets, and masks[k] maps each nibble of byte k of a pattern to
+// the buckets with a pattern holding it there. A position may start a pattern
+// of bucket b only if bit b is set in the tables for all m fingerprint bytes.
+type teddy struct {
+	masks   [3][2][16]byte // low and high nibble tables per fingerprint byte
+	m       int            // fingerprint length, the shortest pattern up to 3
+	buckets [8][]int       // pattern ids by bucket
+}
+
+// newTeddy builds the tables for the non-empty patterns listed in ids.
+func newTeddy(patterns [][]byte, ids []int) *teddy {
+	t := &teddy{m: 3}
+	for _, id := range ids {
+		t.m = min(t.m, len(patterns[id]))
+	}
+
+	// Patterns with the same fingerprint should share a bucket, so sort by it
+	// and split the order into contiguous runs
+	sorted := slices.Clone(ids)
+	slices.SortStableFunc(sorted, func(a, b int) int {
+		return bytes.Compare(patterns[a][:t.m], patterns[b][:t.m])
+	})
+	nb := min(len(sorted), 8)
+	for i, id := range sorted {
+		b := i * nb / len(sorted)
+		t.buckets[b] = append(t.buckets[b], id)
+		for k := 0; k < t.m; k++ {
+			c := patterns[id][k]
+			t.masks[k][0][c&15] |= 1 << uint(b)
+			t.masks[k][1][c>>4] |= 1 << uint(b)
+		}
+	}
+	for b := range t.buckets {
+		slices.Sort(t.buckets[b])
+	}
+	return t
+}
+
+// teddyBuckets returns the buckets whose fingerprint matches the start of s.
+func teddyBuckets(s []byte, masks *[3][2][16]byte, m int) uint8 {
+	buckets := ^uint8(0)
+	for k := 0; k < m; k++ {
+		c := s[k]
+		buckets &= masks[k][0][c&15] & masks[k][1][c>>4]
+	}
+	return buckets
+}
+
+func (t *teddy) scan(s []byte, patterns [][]byte, first bool, set []uint64) bool {
+	found := false
+	for p := 0; ; p++ {
+		p = teddyFindImpl(currentKernels(), s, &t.masks, t.m, p)
+		if p < 0 {
+			return found
+		}
+		for buckets := teddyBuckets(s[p:], &t.masks, t.m); buckets != 0; buckets &= buckets - 1 {
+			for _, id := range t.buckets[bits.TrailingZeros8(buckets)] {
+				if bytes.HasPrefix(s[p:], patterns[id]) {
+					set[id/64] |= 1 << uint(id%64)
+					if first {
+						return true
+					}
+					found = true
+				}
+			}
+		}
+	}
+}
+
+// ============================================================================
+// Aho-Corasick
+// ============================================================================
+
+// ahoCorasick is the automaton of a pattern set, with every transition filled
+// in so each byte costs one table lookup. Bytes that occur in no pattern share
+// class 0, which keeps the table narrow for sets over a small alphabet.
+//
+// States are stored as their offset in next, and those where a pattern ends are
+// numbered last, so the scan tests for a match with one comparison.
+type ahoCorasick struct {
+	classes [256]int32
+	stride  int       // number of byte classes
+	next    []int32   // next[state+class], states premultiplied by stride
+	accept  int32     // the lowest state where a pattern ends
+	outputs [][]int32 // ids of the patterns ending at each state, by state/stride
+}
+
+// newAhoCorasick builds the automaton for the non-empty patterns listed in ids.
+func newAhoCorasick(patterns [][]byte, ids []int) *ahoCorasick {
+	ac := &ahoCorasick{stride: 1}
+	for _, id := range ids {
+		for _, c := range patterns[id] {
+			if ac.classes[c] == 0 {
+				ac.classes[c] = int32(ac.stride)
+				ac.stride++
+			}
+		}
+	}
+
+	// Build the trie, with -1 for missing edges
+	var next []int32
+	var outputs [][]int32
+	newState := func() int32 {
+		outputs = append(outputs, nil)
+		for c := 0; c < ac.stride; c++ {
+			next = append(next, -1)
+		}
+		return int32(len(outputs) - 1)
+	}
+	newState()
+	for _, id := range ids {
+		s := int32(0)
+		for _, c := range patterns[id] {
+			edge := int(s)*ac.stride + int(ac.classes[c])
+			if next[edge] < 0 {
+				child := newState()
+				next[edge] = child
+			}
+			s = next[edge]
+		}
+		outputs[s] = append(outputs[s], int32(id))
+	}
+
+	// Fill the missing edges from the failure links, breadth first so a
+	// state's failure target is complete before the state itself
+	fail := make([]int32, len(outputs))
+	queue := make([]int32, 0, len(outputs))
+	for c := 0; c < ac.stride; c++ {
+		if child := next[c]; child > 0 {
+			queue = append(queue, child)
+		} else {
+			next[c] = 0
+		}
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		if out := outputs[fail[s]]; len(out) > 0 {
+			outputs[s] = append(outputs[s], out...)
+		}
+		for c := 0; c < ac.stride; c++ {
+			edge := int(s)*ac.stride + c
+			via := next[int(fail[s])*ac.stride+c]
+			if child := next[edge]; child >= 0 {
+				fail[child] = via
+				queue = append(queue, child)
+			} else {
+				next[edge] = via
+			}
+		}
+	}
+
+	// Renumber the states, accepting ones last; the root accepts nothing and
+	// stays first
+	order := make([]int32, 0, len(outputs))
+	for s, out := range outputs {
+		if out == nil {
+			order = append(order, int32(s))
+		}
+	}
+	ac.accept = int32(len(order) * ac.stride)
+	for s, out := range outputs {
+		if out != nil {
+			order = append(order, int32(s))
+		}
+	}
+	renamed := make([]int32, len(outputs))
+	for i, s := range order {
+		renamed[s] = int32(i * ac.stride)
+	}
+	ac.next = make([]int32, len(next))
+	ac.outputs = make([][]int32, len(outputs))
+	for i, s := range order {
+		for c := 0; c < ac.stride; c++ {
+			ac.next[i*ac.stride+c] = renamed[next[int(s)*ac.stride+c]]
+		}
+		ac.outputs[i] = outputs[s]
+	}
+	return ac
+}
+
+func (ac *ahoCorasick) scan(s []byte, first bool, set []uint64) bool {
+	found := false
+	state := int32(0)
+	for _, c := range s {
+		state = ac.next[state+ac.classes[c]]
+		if state >= ac.accept {
+			for _, id := range ac.outputs[int(state)/ac.stride] {
+				set[id/64] |= 1 << uint(id%64)
+			}
+			if first {
+				return true
+			}
+			found = true
+		}
+	}
+	return found
+}
@@ -0,0 +1,200 @@
+package syndrdbsimd
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// MultiPattern Tests
+// ============================================================================
+
+func TestMultiPattern_HappyPath(t *testing.T) {
+	mp := CompileMultiPattern([]string{"refused", "timeout", "error", "time"})
+	values := []string{
+		"connection refused", "read timeout after 30s: error", "ok", "",
+		"a long line that mentions an error only near the very end: error",
+		"lifetime", "TIMEOUT",
+	}
+
+	checkEqual(t, len(values), "ContainsAny", mp.ContainsAny(values),
+		[]bool{true, true, false, false, true, true, false})
+	checkEqual(t, len(values), "ContainsAnyMask", mp.ContainsAnyMask(values), []uint64{0b011_0011})
+	checkEqual(t, len(values), "FirstMatch", mp.FirstMatch(values), []int{0, 1, -1, -1, 2, 3, -1})
+	checkEqual(t, len(values), "MatchMasks", mp.MatchMasks(values),
+		[]uint64{0b0001, 0b1110, 0, 0, 0b0100, 0b1000, 0})
+
+	if mp.Len() != 4 || mp.Pattern(1) != "timeout" {
+		t.Errorf("Len(), Pattern(1) = %d, %q, want 4, %q", mp.Len(), mp.Pattern(1), "timeout")
+	}
+}
+
+func TestMultiPattern_EdgeCases(t *testing.T) {
+	values := []string{"abc", ""}
+
+	// An empty pattern is in every row, and duplicates keep their own ids
+	mp := CompileMultiPattern([]string{"zz", "", "b", "b"})
+	checkEqual(t, 2, "FirstMatch", mp.FirstMatch(values), []int{1, 1})
+	checkEqual(t, 2, "MatchMasks", mp.MatchMasks(values), []uint64{0b1110, 0b0010})
+
+	mp = CompileMultiPattern(nil)
+	checkEqual(t, 2, "empty set ContainsAny", mp.ContainsAny(values), []bool{false, false})
+	checkEqual(t, 2, "empty set FirstMatch", mp.FirstMatch(values), []int{-1, -1})
+	if got := mp.MatchMasks(values); len(got) != 0 {
+		t.Errorf("empty set MatchMasks = %v, want no words", got)
+	}
+
+	// Ids past 63 spill into further words per row
+	patterns := make([]string, 70)
+	for i := range patterns {
+		patterns[i] = fmt.Sprintf("<%d>", i)
+	}
+	mp = CompileMultiPattern(patterns)
+	checkEqual(t, 2, "wide MatchMasks", mp.MatchMasks([]string{"<3><69>", "<64>"}),
+		[]uint64{1 << 3, 1 << 5, 0, 1 << 0})
+	checkEqual(t, 2, "wide FirstMatch", mp.FirstMatch([]string{"<69><65>", "x"}), []int{65, -1})
+}
+
+// randomPatterns returns n patterns of 1 to maxLen bytes over alphabet.
+func randomPatterns(rng *rand.Rand, n, maxLen int, alphabet string) []string {
+	patterns := make([]string, n)
+	for i := range patterns {
+		b := make([]byte, 1+rng.Intn(maxLen))
+		for j := range b {
+			b[j] = alphabet[rng.Intn(len(alphabet))]
+		}
+		patterns[i] = string(b)
+	}
+	return patterns
+}
+
+// Teddy and Aho-Corasick must both agree with one substring search per pattern,
+// on every ISA and for rows on both sides of the Teddy cutover
+func TestMultiPattern_MatchContains(t *testing.T) {
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(460))
+				values := make([]string, 300)
+				for i := range values {
+					values[i] = randomPatterns(rng, 1, 120, "abcdefgh")[0]
+				}
+
+				for _, n := range []int{1, 2, 7, 8, 9, 32, 33, 100} {
+					for _, maxLen := range []int{1, 2, 3, 8} {
+						patterns := randomPatterns(rng, n, maxLen, "abcdefghij")
+						// Long patterns keep the fingerprint at 3 bytes
+						if maxLen == 8 {
+							for i := range patterns {
+								patterns[i] += "abc"
+							}
+						}
+						mp := CompileMultiPattern(patterns)
+						masks := mp.MatchMasks(values)
+						first := mp.FirstMatch(values)
+						contains := mp.ContainsAny(values)
+						words := mp.words()
+						for i, v := range values {
+							want := -1
+							for id, p := range patterns {
+								has := strings.Contains(v, p)
+								if has && want < 0 {
+									want = id
+								}
+								if got := masks[i*words+id/64]>>uint(id%64)&1 == 1; got != has {
+									t.Fatalf("n=%d: MatchMasks(%q) bit %d (%q) = %v, want %v", n, v, id, p, got, has)
+								}
+							}
+							if first[i] != want || contains[i] != (want >= 0) {
+								t.Fatalf("n=%d: FirstMatch(%q) = %d, ContainsAny = %v, want %d", n, v, first[i], contains[i], want)
+							}
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
+// The kernels must return the generic scan's candidate from any start position,
+// including ones inside the final, overlapping block
+func TestTeddyFind_MatchGeneric(t *testing.T) {
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(461))
+				for trial := 0; trial < 500; trial++ {
+					patterns := randomPatterns(rng, 1+rng.Intn(12), 4, "abcdefghijklmnop")
+					mp := CompileMultiPattern(patterns)
+					s := []byte(randomPatterns(rng, 1, 100, "abcdefghijklmnopqrstuvwxyz")[0])
+					td := mp.teddy
+					for from := 0; from <= len(s)-td.m; from++ {
+						got := teddyFindImpl(currentKernels(), s, &td.masks, td.m, from)
+						want := teddyFindGeneric(s, &td.masks, td.m, from)
+						if got != want {
+							t.Fatalf("teddyFindImpl(currentKernels(), %q, m=%d, from=%d) = %d, want %d", s, td.m, from, got, want)
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+var multiPatternRules = []string{
+	"refused", "timeout", "denied", "segfault", "panic:", "OOMKilled", "unreachable",
+	"certificate", "handshake", "deadlock", "corrupt", "overflow", "throttled",
+	"sql injection", "<script", "../../", "/etc/passwd", "cmd.exe", "base64,",
+	"union select", "drop table", "xp_cmdshell", "wget http", "curl http",
+}
+
+func BenchmarkMultiPatternContainsAny(b *testing.B) {
+	values := logLines(65536)
+	mp := CompileMultiPattern(multiPatternRules)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mp.ContainsAny(values)
+	}
+}
+
+// The same rules as one CmpContainsString pass each
+func BenchmarkMultiPatternContainsEach(b *testing.B) {
+	values := logLines(65536)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range multiPatternRules {
+			CmpContainsStringMask(values, p)
+		}
+	}
+}
+
+// Too many rules for Teddy, so every row goes through the automaton
+func BenchmarkMultiPatternAhoCorasick(b *testing.B) {
+	values := logLines(65536)
+	rules := make([]string, 0, 200)
+	for i := 0; len(rules) < 200; i++ {
+		for _, p := range multiPatternRules {
+			rules = append(rules, fmt.Sprintf("%s#%d", p, i))
+		}
+	}
+	mp := CompileMultiPattern(rules[:200])
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mp.ContainsAny(values)
+	}
+}
@@ -0,0 +1,89 @@
+//go:build amd64
+
+package syndrdbsimd
+
+import "unsafe"
+
+// AVX2 kernels for int8/int16/int32 and their unsigned counterparts.
+// See narrow_amd64.s for the unsigned bias scheme and length requirements.
+
+//go:noescape
+func cmpEqInt8MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+
+//go:noescape
+func cmpGtInt8MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+
+//go:noescape
+func cmpLtInt8MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+
+//go:noescape
+func cmpEqInt16MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+
+//go:noescape
+func cmpGtInt16MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+
+//go:noescape
+func cmpLtInt16MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+
+//go:noescape
+func cmpEqInt32MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+
+//go:noescape
+func cmpGtInt32MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+
+//go:noescape
+func cmpLtInt32MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+
+//go:noescape
+func sumInt8AVX2(values unsafe.Pointer, length int) int64
+
+//go:noescape
+func sumUint8AVX2(values unsafe.Pointer, length int) int64
+
+//go:noescape
+func sumInt16AVX2(values unsafe.Pointer, length int) int64
+
+//go:noescape
+func sumUint16AVX2(values unsafe.Pointer, length int) int64
+
+//go:noescape
+func sumInt32AVX2(values unsafe.Pointer, length int) int64
+
+//go:noescape
+func sumUint32AVX2(values unsafe.Pointer, length int) int64
+
+//go:noescape
+func minInt8AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func maxInt8AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func minUint8AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func maxUint8AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func minInt16AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func maxInt16AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func minUint16AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func maxUint16AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func minInt32AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func maxInt32AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func minUint32AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+
+//go:noescape
+func maxUint32AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
@@ -0,0 +1,273 @@
+#include "textflag.h"
+
+// ============================================================================
+// Narrow integer comparisons (int8/int16/int32 and unsigned counterparts)
+// ============================================================================
+//
+// Every compare kernel writes one bitmap word per 64 elements; length must be a
+// multiple of 64. Only ==, > and < have kernels: the Go side derives !=, <= and >=
+// by inverting the words.
+//
+// AVX2 has signed compares only. Unsigned values are compared by XORing each
+// lane with the sign bit (bias), which maps unsigned order onto signed order.
+// The caller applies the same bias to the threshold; signed callers pass 0.
+//
+// Register usage:
+//   Y0: Broadcast threshold
+//   Y7: Broadcast bias
+//   Y1: Values being compared
+//   SI: values, DI: mask, CX: remaining bitmap words
+
+// Predicates compare the biased values in Y1 against the threshold in Y0.
+// VPCMPGTx a, b, dst computes dst = b > a.
+#define EQ8(dst) VPCMPEQB Y0, Y1, dst
+#define GT8(dst) VPCMPGTB Y0, Y1, dst
+#define LT8(dst) VPCMPGTB Y1, Y0, dst
+#define EQ16(dst) VPCMPEQW Y0, Y1, dst
+#define GT16(dst) VPCMPGTW Y0, Y1, dst
+#define LT16(dst) VPCMPGTW Y1, Y0, dst
+#define EQ32(dst) VPCMPEQD Y0, Y1, dst
+#define GT32(dst) VPCMPGTD Y0, Y1, dst
+#define LT32(dst) VPCMPGTD Y1, Y0, dst
+
+// LOAD_BIASED loads 32 bytes at off(SI) into Y1 and applies the bias.
+#define LOAD_BIASED(off) \
+    VMOVDQU off(SI), Y1 \
+    VPXOR   Y7, Y1, Y1
+
+// CMP_NARROW_MASK_AVX2 emits a kernel whose loop body, step, computes the
+// 64-bit mask for the next 64 elements into AX.
+#define CMP_NARROW_MASK_AVX2(name, broadcast, step) \
+TEXT name(SB), NOSPLIT, $0-32 \
+    MOVQ    values+0(FP), SI \
+    MOVQ    mask+16(FP), DI \
+    MOVQ    length+24(FP), CX \
+    SHRQ    $6, CX \
+    JZ      done \
+    broadcast threshold+8(FP), Y0 \
+    broadcast bias+12(FP), Y7 \
+loop: \
+    step \
+    MOVQ    AX, (DI) \
+    ADDQ    $8, DI \
+    DECQ    CX \
+    JNZ     loop \
+    VZEROUPPER \
+done: \
+    RET
+
+// 8-bit: two vectors of 32 lanes, one VPMOVMSKB each
+#define CMP8_STEP(pred) \
+    LOAD_BIASED(0) \
+    pred(Y2) \
+    VPMOVMSKB Y2, AX \
+    LOAD_BIASED(32) \
+    pred(Y2) \
+    VPMOVMSKB Y2, DX \
+    SHLQ    $32, DX \
+    ORQ     DX, AX \
+    ADDQ    $64, SI
+
+// 16-bit: VPACKSSWB narrows two compare results (all-ones or zero words) to bytes.
+// It packs per 128-bit lane, so VPERMQ $0xD8 restores element order before
+// VPMOVMSKB collects 32 lanes.
+#define CMP16_HALF(pred, off, reg) \
+    LOAD_BIASED(off) \
+    pred(Y2) \
+    LOAD_BIASED(off+32) \
+    pred(Y3) \
+    VPACKSSWB Y3, Y2, Y2 \
+    VPERMQ  $0xD8, Y2, Y2 \
+    VPMOVMSKB Y2, reg
+
+#define CMP16_STEP(pred) \
+    CMP16_HALF(pred, 0, AX) \
+    CMP16_HALF(pred, 64, DX) \
+    SHLQ    $32, DX \
+    ORQ     DX, AX \
+    ADDQ    $128, SI
+
+// 32-bit: eight vectors of 8 lanes, VMOVMSKPS collects the sign bit of each lane
+#define CMP32_PART(pred, off, shift) \
+    LOAD_BIASED(off) \
+    pred(Y2) \
+    VMOVMSKPS Y2, DX \
+    SHLQ    $shift, DX \
+    ORQ     DX, AX
+
+#define CMP32_STEP(pred) \
+    LOAD_BIASED(0) \
+    pred(Y2) \
+    VMOVMSKPS Y2, AX \
+    CMP32_PART(pred, 32, 8) \
+    CMP32_PART(pred, 64, 16) \
+    CMP32_PART(pred, 96, 24) \
+    CMP32_PART(pred, 128, 32) \
+    CMP32_PART(pred, 160, 40) \
+    CMP32_PART(pred, 192, 48) \
+    CMP32_PART(pred, 224, 56) \
+    ADDQ    $256, SI
+
+// func cmpEqInt8MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+CMP_NARROW_MASK_AVX2(·cmpEqInt8MaskAVX2, VPBROADCASTB, CMP8_STEP(EQ8))
+
+// func cmpGtInt8MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+CMP_NARROW_MASK_AVX2(·cmpGtInt8MaskAVX2, VPBROADCASTB, CMP8_STEP(GT8))
+
+// func cmpLtInt8MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+CMP_NARROW_MASK_AVX2(·cmpLtInt8MaskAVX2, VPBROADCASTB, CMP8_STEP(LT8))
+
+// func cmpEqInt16MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+CMP_NARROW_MASK_AVX2(·cmpEqInt16MaskAVX2, VPBROADCASTW, CMP16_STEP(EQ16))
+
+// func cmpGtInt16MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+CMP_NARROW_MASK_AVX2(·cmpGtInt16MaskAVX2, VPBROADCASTW, CMP16_STEP(GT16))
+
+// func cmpLtInt16MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+CMP_NARROW_MASK_AVX2(·cmpLtInt16MaskAVX2, VPBROADCASTW, CMP16_STEP(LT16))
+
+// func cmpEqInt32MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+CMP_NARROW_MASK_AVX2(·cmpEqInt32MaskAVX2, VPBROADCASTD, CMP32_STEP(EQ32))
+
+// func cmpGtInt32MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+CMP_NARROW_MASK_AVX2(·cmpGtInt32MaskAVX2, VPBROADCASTD, CMP32_STEP(GT32))
+
+// func cmpLtInt32MaskAVX2(values unsafe.Pointer, threshold uint32, bias uint32, mask *uint64, length int)
+CMP_NARROW_MASK_AVX2(·cmpLtInt32MaskAVX2, VPBROADCASTD, CMP32_STEP(LT32))
+
+// ============================================================================
+// Narrow integer sums
+// ============================================================================
+//
+// Each kernel widens 4 elements at a time to int64 lanes with VPMOVSX/VPMOVZX
+// straight from memory and accumulates into four independent registers, so no
+// lane can overflow before the scalar total would. length must be a multiple of 16.
+//
+// Register usage:
+//   Y0-Y3: int64 accumulators
+//   Y4-Y7: Widened values
+//   SI: values, CX: remaining 16-element groups
+
+// func sum{T}AVX2(values unsafe.Pointer, length int) int64
+// stride is the size in bytes of 4 elements.
+#define SUM_NARROW_AVX2(name, widen, stride) \
+TEXT name(SB), NOSPLIT, $0-24 \
+    MOVQ    values+0(FP), SI \
+    MOVQ    length+8(FP), CX \
+    XORQ    AX, AX \
+    SHRQ    $4, CX \
+    JZ      done \
+    VPXOR   Y0, Y0, Y0 \
+    VPXOR   Y1, Y1, Y1 \
+    VPXOR   Y2, Y2, Y2 \
+    VPXOR   Y3, Y3, Y3 \
+loop: \
+    widen   0(SI), Y4 \
+    widen   stride(SI), Y5 \
+    widen   (2*stride)(SI), Y6 \
+    widen   (3*stride)(SI), Y7 \
+    VPADDQ  Y4, Y0, Y0 \
+    VPADDQ  Y5, Y1, Y1 \
+    VPADDQ  Y6, Y2, Y2 \
+    VPADDQ  Y7, Y3, Y3 \
+    ADDQ    $(4*stride), SI \
+    DECQ    CX \
+    JNZ     loop \
+    VPADDQ  Y1, Y0, Y0 \
+    VPADDQ  Y3, Y2, Y2 \
+    VPADDQ  Y2, Y0, Y0 \
+    VEXTRACTI128 $1, Y0, X1 \
+    VPADDQ  X1, X0, X0 \
+    VPSHUFD $0x4E, X0, X1 \
+    VPADDQ  X1, X0, X0 \
+    VMOVQ   X0, AX \
+    VZEROUPPER \
+done: \
+    MOVQ    AX, ret+16(FP) \
+    RET
+
+// func sumInt8AVX2(values unsafe.Pointer, length int) int64
+SUM_NARROW_AVX2(·sumInt8AVX2, VPMOVSXBQ, 4)
+
+// func sumUint8AVX2(values unsafe.Pointer, length int) int64
+SUM_NARROW_AVX2(·sumUint8AVX2, VPMOVZXBQ, 4)
+
+// func sumInt16AVX2(values unsafe.Pointer, length int) int64
+SUM_NARROW_AVX2(·sumInt16AVX2, VPMOVSXWQ, 8)
+
+// func sumUint16AVX2(values unsafe.Pointer, length int) int64
+SUM_NARROW_AVX2(·sumUint16AVX2, VPMOVZXWQ, 8)
+
+// func sumInt32AVX2(values unsafe.Pointer, length int) int64
+SUM_NARROW_AVX2(·sumInt32AVX2, VPMOVSXDQ, 16)
+
+// func sumUint32AVX2(values unsafe.Pointer, length int) int64
+SUM_NARROW_AVX2(·sumUint32AVX2, VPMOVZXDQ, 16)
+
+// ============================================================================
+// Narrow integer min/max
+// ============================================================================
+//
+// Each kernel reduces size bytes (a non-zero multiple of 32) to one vector of
+// per-lane minimums or maximums and stores it to acc. The Go side finishes the
+// reduction over the 32/16/8 lanes, which avoids a width-specific shuffle tree.
+//
+// Register usage:
+//   Y0: Running min/max
+//   SI: values, CX: remaining bytes
+
+// func {min,max}{T}AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+#define MINMAX_NARROW_AVX2(name, op) \
+TEXT name(SB), NOSPLIT, $0-24 \
+    MOVQ    values+0(FP), SI \
+    MOVQ    size+8(FP), CX \
+    MOVQ    acc+16(FP), DI \
+    VMOVDQU (SI), Y0 \
+    ADDQ    $32, SI \
+    SUBQ    $32, CX \
+    JZ      store \
+loop: \
+    op      (SI), Y0, Y0 \
+    ADDQ    $32, SI \
+    SUBQ    $32, CX \
+    JNZ     loop \
+store: \
+    VMOVDQU Y0, (DI) \
+    VZEROUPPER \
+    RET
+
+// func minInt8AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·minInt8AVX2, VPMINSB)
+
+// func maxInt8AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·maxInt8AVX2, VPMAXSB)
+
+// func minUint8AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·minUint8AVX2, VPMINUB)
+
+// func maxUint8AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·maxUint8AVX2, VPMAXUB)
+
+// func minInt16AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·minInt16AVX2, VPMINSW)
+
+// func maxInt16AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·maxInt16AVX2, VPMAXSW)
+
+// func minUint16AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·minUint16AVX2, VPMINUW)
+
+// func maxUint16AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·maxUint16AVX2, VPMAXUW)
+
+// func minInt32AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·minInt32AVX2, VPMINSD)
+
+// func maxInt32AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·maxInt32AVX2, VPMAXSD)
+
+// func minUint32AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·minUint32AVX2, VPMINUD)
+
+// func maxUint32AVX2(values unsafe.Pointer, size int, acc unsafe.Pointer)
+MINMAX_NARROW_AVX2(·maxUint32AVX2, VPMAXUD)
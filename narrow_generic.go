@@ -0,0 +1,74 @@
+package syndrdbsimd
+
+// narrowInt is the set of integer element types narrower than 64 bits.
+// Dictionary codes and small-range columns use these to avoid widening to int64.
+type narrowInt interface {
+	int8 | int16 | int32 | uint8 | uint16 | uint32
+}
+
+// signedNarrowInt and unsignedNarrowInt split narrowInt by signedness, which
+// decides whether sums accumulate in int64 or uint64.
+type signedNarrowInt interface {
+	int8 | int16 | int32
+}
+
+type unsignedNarrowInt interface {
+	uint8 | uint16 | uint32
+}
+
+// sumSignedGeneric computes the sum of signed values in an int64 accumulator.
+func sumSignedGeneric[T signedNarrowInt](values []T) int64 {
+	var sum int64
+	for _, v := range values {
+		sum += int64(v)
+	}
+	return sum
+}
+
+// sumUnsignedGeneric computes the sum of unsigned values in a uint64 accumulator.
+func sumUnsignedGeneric[T unsignedNarrowInt](values []T) uint64 {
+	var sum uint64
+	for _, v := range values {
+		sum += uint64(v)
+	}
+	return sum
+}
+
+// minNarrowGeneric finds the minimum value. values must not be empty.
+func minNarrowGeneric[T narrowInt](values []T) T {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
+
+// maxNarrowGeneric finds the maximum value. values must not be empty.
+func maxNarrowGeneric[T narrowInt](values []T) T {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v > result {
+			result = v
+		}
+	}
+	return result
+}
+
+// xxhash64NarrowImpl hashes each value as its int64 widening, so equal keys hash
+// identically whatever the column width. It batches the widening through a stack
+// buffer and reuses the int64 XXHash64 kernels on every architecture.
+func xxhash64NarrowImpl[T narrowInt](ks *kernelSet, values []T, output []uint64) {
+	var widened [256]int64
+	for start := 0; start < len(values); start += len(widened) {
+		chunk := values[start:]
+		if len(chunk) > len(widened) {
+			chunk = chunk[:len(widened)]
+		}
+		for i, v := range chunk {
+			widened[i] = int64(v)
+		}
+		xxhash64Impl(ks, widened[:len(chunk)], output[start:start+len(chunk)])
+	}
+}
@@ -0,0 +1,264 @@
+package syndrdbsimd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// ============================================================================
+// Narrow Integer Comparison Tests
+// ============================================================================
+
+func TestCmpInt8_HappyPath(t *testing.T) {
+	values := []int8{-128, -1, 0, 1, 5, 127}
+
+	checkEqual(t, len(values), "CmpGtInt8", CmpGtInt8(values, 0), []bool{false, false, false, true, true, true})
+	checkEqual(t, len(values), "CmpLeInt8", CmpLeInt8(values, -1), []bool{true, true, false, false, false, false})
+	checkEqual(t, len(values), "CmpEqInt8Mask", CmpEqInt8Mask(values, 127), []uint64{1 << 5})
+}
+
+// Unsigned values above the signed range must order above small values
+func TestCmpUint_HighBitValues(t *testing.T) {
+	u8 := make([]uint8, 100)
+	u16 := make([]uint16, 100)
+	u32 := make([]uint32, 100)
+	for i := range u8 {
+		u8[i] = uint8(i * 3)
+		u16[i] = uint16(i * 700)
+		u32[i] = uint32(i) * 50_000_000
+	}
+
+	checkNarrowCompares(t, u8, 200)
+	checkNarrowCompares(t, u16, 40000)
+	checkNarrowCompares(t, u32, 3_000_000_000)
+}
+
+// checkNarrowCompares checks every operator in both output forms against the
+// generic implementation.
+func checkNarrowCompares[T narrowInt](t *testing.T, values []T, threshold T) {
+	t.Helper()
+	for _, op := range []Op{OpEq, OpNe, OpGt, OpLt, OpGe, OpLe} {
+		checkEqual(t, len(values), "cmpNarrowImpl", cmpNarrowImpl(currentKernels(), values, op, threshold), cmpNumberGeneric(values, op, threshold))
+		checkEqual(t, len(values), "cmpNarrowMaskImpl", cmpNarrowMaskImpl(currentKernels(), values, op, threshold), cmpNumberMaskGeneric(values, op, threshold))
+	}
+}
+
+// checkNarrowAggregates checks min and max against the generic implementations.
+func checkNarrowAggregates[T narrowInt](t *testing.T, values []T) {
+	t.Helper()
+	if got, want := minNarrowImpl(currentKernels(), values), minNarrowGeneric(values); got != want {
+		t.Errorf("size=%d: min = %d, want %d", len(values), got, want)
+	}
+	if got, want := maxNarrowImpl(currentKernels(), values), maxNarrowGeneric(values); got != want {
+		t.Errorf("size=%d: max = %d, want %d", len(values), got, want)
+	}
+}
+
+// randomNarrow fills a slice of T with random bits, so the sign bit is set in
+// about half of the values.
+func randomNarrow[T narrowInt](rng *rand.Rand, size int) []T {
+	values := make([]T, size)
+	for i := range values {
+		values[i] = T(rng.Uint32())
+	}
+	return values
+}
+
+// Every ISA must match the generic implementations, including the SIMD paths
+// for inputs smaller than one kernel iteration
+func TestNarrow_MatchGeneric(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+	cfg := DefaultConfig()
+	cfg.MinNarrow = 1
+	if err := SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	// Lengths chosen to cover scalar tails and the 16/32/64 element group boundaries
+	sizes := []int{1, 15, 16, 17, 31, 32, 33, 63, 64, 65, 127, 128, 129, 1000}
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(32))
+				for _, size := range sizes {
+					i8, u8 := randomNarrow[int8](rng, size), randomNarrow[uint8](rng, size)
+					i16, u16 := randomNarrow[int16](rng, size), randomNarrow[uint16](rng, size)
+					i32, u32 := randomNarrow[int32](rng, size), randomNarrow[uint32](rng, size)
+
+					checkNarrowCompares(t, i8, i8[size/2])
+					checkNarrowCompares(t, u8, u8[size/2])
+					checkNarrowCompares(t, i16, i16[size/2])
+					checkNarrowCompares(t, u16, u16[size/2])
+					checkNarrowCompares(t, i32, i32[size/2])
+					checkNarrowCompares(t, u32, u32[size/2])
+
+					// Thresholds at the type extremes
+					checkNarrowCompares(t, i8, math.MinInt8)
+					checkNarrowCompares(t, u8, math.MaxUint8)
+					checkNarrowCompares(t, i32, math.MaxInt32)
+					checkNarrowCompares(t, u32, 0)
+
+					checkNarrowAggregates(t, i8)
+					checkNarrowAggregates(t, u8)
+					checkNarrowAggregates(t, i16)
+					checkNarrowAggregates(t, u16)
+					checkNarrowAggregates(t, i32)
+					checkNarrowAggregates(t, u32)
+
+					if got, want := sumSignedImpl(currentKernels(), i8), sumSignedGeneric(i8); got != want {
+						t.Errorf("size=%d: SumInt8 = %d, want %d", size, got, want)
+					}
+					if got, want := sumSignedImpl(currentKernels(), i16), sumSignedGeneric(i16); got != want {
+						t.Errorf("size=%d: SumInt16 = %d, want %d", size, got, want)
+					}
+					if got, want := sumSignedImpl(currentKernels(), i32), sumSignedGeneric(i32); got != want {
+						t.Errorf("size=%d: SumInt32 = %d, want %d", size, got, want)
+					}
+					if got, want := sumUnsignedImpl(currentKernels(), u8), sumUnsignedGeneric(u8); got != want {
+						t.Errorf("size=%d: SumUint8 = %d, want %d", size, got, want)
+					}
+					if got, want := sumUnsignedImpl(currentKernels(), u16), sumUnsignedGeneric(u16); got != want {
+						t.Errorf("size=%d: SumUint16 = %d, want %d", size, got, want)
+					}
+					if got, want := sumUnsignedImpl(currentKernels(), u32), sumUnsignedGeneric(u32); got != want {
+						t.Errorf("size=%d: SumUint32 = %d, want %d", size, got, want)
+					}
+				}
+			})
+		})
+	}
+}
+
+// ============================================================================
+// Narrow Integer Aggregation Tests
+// ============================================================================
+
+// Sums accumulate in 64 bits, so a column of maximum values does not wrap
+func TestSumInt32_NoOverflow(t *testing.T) {
+	values := make([]int32, 1000)
+	for i := range values {
+		values[i] = math.MaxInt32
+	}
+	if got, want := SumInt32(values), int64(1000)*math.MaxInt32; got != want {
+		t.Errorf("Expected %d, got %d", want, got)
+	}
+
+	unsigned := make([]uint32, 1000)
+	for i := range unsigned {
+		unsigned[i] = math.MaxUint32
+	}
+	if got, want := SumUint32(unsigned), uint64(1000)*math.MaxUint32; got != want {
+		t.Errorf("Expected %d, got %d", want, got)
+	}
+}
+
+func TestMinMaxNarrow_Extremes(t *testing.T) {
+	values := make([]int16, 300)
+	for i := range values {
+		values[i] = int16(i - 150)
+	}
+	values[17] = math.MinInt16
+	values[299] = math.MaxInt16
+
+	if got := MinInt16(values); got != math.MinInt16 {
+		t.Errorf("MinInt16: expected %d, got %d", math.MinInt16, got)
+	}
+	if got := MaxInt16(values); got != math.MaxInt16 {
+		t.Errorf("MaxInt16: expected %d, got %d", math.MaxInt16, got)
+	}
+
+	// Values above the signed range are the largest unsigned values
+	codes := make([]uint8, 100)
+	for i := range codes {
+		codes[i] = uint8(i)
+	}
+	codes[50] = 200
+	if got := MaxUint8(codes); got != 200 {
+		t.Errorf("MaxUint8: expected 200, got %d", got)
+	}
+	if got := MinUint8(codes); got != 0 {
+		t.Errorf("MinUint8: expected 0, got %d", got)
+	}
+}
+
+func TestNarrow_Empty(t *testing.T) {
+	if len(CmpEqUint16(nil, 1)) != 0 || len(CmpEqUint16Mask(nil, 1)) != 0 {
+		t.Error("Expected empty results for empty input")
+	}
+	if SumInt8(nil) != 0 || SumUint32(nil) != 0 {
+		t.Error("Expected 0 sum for empty input")
+	}
+	if MinInt8(nil) != math.MaxInt8 || MaxInt8(nil) != math.MinInt8 {
+		t.Error("Expected type extremes for empty int8 input")
+	}
+	if MinUint32(nil) != math.MaxUint32 || MaxUint32(nil) != 0 {
+		t.Error("Expected type extremes for empty uint32 input")
+	}
+}
+
+// ============================================================================
+// Narrow Integer Hash Tests
+// ============================================================================
+
+// Equal keys hash identically whatever the column width
+func TestXXHash64Narrow_MatchesInt64(t *testing.T) {
+	wide := make([]int64, 600)
+	i32 := make([]int32, len(wide))
+	u16 := make([]uint16, len(wide))
+	for i := range wide {
+		wide[i] = int64(i * 97)
+		i32[i] = int32(i * 97)
+		u16[i] = uint16(i * 97)
+	}
+
+	expected := make([]uint64, len(wide))
+	XXHash64(wide, expected)
+
+	got := make([]uint64, len(wide))
+	XXHash64Int32(i32, got)
+	checkEqual(t, len(wide), "XXHash64Int32", got, expected)
+
+	got = make([]uint64, len(wide))
+	XXHash64Uint16(u16, got)
+	checkEqual(t, len(wide), "XXHash64Uint16", got, expected)
+
+	// Negative values hash as their sign-extended int64
+	output := make([]uint64, 1)
+	XXHash64Int8([]int8{-5}, output)
+	if output[0] != xxhash64Generic(-5) {
+		t.Errorf("Expected hash of int64(-5), got %x", output[0])
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+func BenchmarkCmpGtUint16Mask(b *testing.B) {
+	values := make([]uint16, 4096)
+	for i := range values {
+		values[i] = uint16(i * 31)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CmpGtUint16Mask(values, 40000)
+	}
+}
+
+func BenchmarkSumInt32(b *testing.B) {
+	values := make([]int32, 4096)
+	for i := range values {
+		values[i] = int32(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SumInt32(values)
+	}
+}
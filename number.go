@@ -0,0 +1,206 @@
+package syndrdbsimd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Number is the set of element types accepted by the generic front-end.
+//
+// Compare, CompareMask and Sum dispatch to the SIMD kernels of the type-specific
+// functions (CmpGtInt64, CmpGtFloat32, SumInt16, ...) for the types that have them,
+// and use a scalar loop for int, uint and uint64.
+type Number interface {
+	int8 | int16 | int32 | int64 | int |
+		uint8 | uint16 | uint32 | uint64 | uint |
+		float32 | float64
+}
+
+// Op identifies a comparison operator, so a query engine can pick the operator at
+// runtime instead of switching over the Cmp* function names.
+type Op int
+
+const (
+	OpEq Op = iota // values[i] == threshold
+	OpNe           // values[i] != threshold
+	OpGt           // values[i] > threshold
+	OpLt           // values[i] < threshold
+	OpGe           // values[i] >= threshold
+	OpLe           // values[i] <= threshold
+)
+
+// String returns the Go operator for op, e.g. ">=".
+func (op Op) String() string {
+	switch op {
+	case OpEq:
+		return "=="
+	case OpNe:
+		return "!="
+	case OpGt:
+		return ">"
+	case OpLt:
+		return "<"
+	case OpGe:
+		return ">="
+	case OpLe:
+		return "<="
+	default:
+		return "Op(" + strconv.Itoa(int(op)) + ")"
+	}
+}
+
+// checkOp panics if op is not one of the defined operators.
+func checkOp(op Op) {
+	if op < OpEq || op > OpLe {
+		panic(fmt.Sprintf("syndrdbsimd: invalid comparison operator %v", op))
+	}
+}
+
+// Compare compares values against a threshold with the operator op.
+// Returns a slice of booleans where result[i] == true if values[i] op threshold.
+//
+// The result is identical to the type-specific function, e.g. Compare(values, OpGt, t)
+// on a []float64 returns the same as CmpGtFloat64(values, t), including its NaN semantics.
+// Panics if op is not a defined operator.
+//
+// This function automatically selects the best implementation:
+//   - The SIMD kernels of the type-specific functions for int8/16/32/64,
+//     uint8/16/32, float32 and float64
+//   - Scalar fallback for int, uint and uint64
+func Compare[T Number](values []T, op Op, threshold T) []bool {
+	checkOp(op)
+	if len(values) == 0 {
+		return []bool{}
+	}
+
+	switch v := any(values).(type) {
+	case []int64:
+		return int64CmpImpls[op](currentKernels(), v, int64(threshold))
+	case []float64:
+		return float64CmpImpls[op](currentKernels(), v, float64(threshold))
+	case []float32:
+		return cmpFloat32Impl(currentKernels(), v, op, float32(threshold))
+	case []int8:
+		return cmpNarrowImpl(currentKernels(), v, op, int8(threshold))
+	case []int16:
+		return cmpNarrowImpl(currentKernels(), v, op, int16(threshold))
+	case []int32:
+		return cmpNarrowImpl(currentKernels(), v, op, int32(threshold))
+	case []uint8:
+		return cmpNarrowImpl(currentKernels(), v, op, uint8(threshold))
+	case []uint16:
+		return cmpNarrowImpl(currentKernels(), v, op, uint16(threshold))
+	case []uint32:
+		return cmpNarrowImpl(currentKernels(), v, op, uint32(threshold))
+	default:
+		return cmpNumberGeneric(values, op, threshold)
+	}
+}
+
+// CompareMask compares values against a threshold with the operator op and returns a bitmask.
+// Returns a slice of uint64 where bit i in result[j] is set if values[j*64+i] op threshold.
+// This is more memory-efficient than Compare for large datasets.
+// Panics if op is not a defined operator.
+func CompareMask[T Number](values []T, op Op, threshold T) []uint64 {
+	checkOp(op)
+	if len(values) == 0 {
+		return []uint64{}
+	}
+
+	switch v := any(values).(type) {
+	case []int64:
+		return int64CmpMaskImpls[op](currentKernels(), v, int64(threshold))
+	case []float64:
+		return float64CmpMaskImpls[op](currentKernels(), v, float64(threshold))
+	case []float32:
+		return cmpFloat32MaskImpl(currentKernels(), v, op, float32(threshold))
+	case []int8:
+		return cmpNarrowMaskImpl(currentKernels(), v, op, int8(threshold))
+	case []int16:
+		return cmpNarrowMaskImpl(currentKernels(), v, op, int16(threshold))
+	case []int32:
+		return cmpNarrowMaskImpl(currentKernels(), v, op, int32(threshold))
+	case []uint8:
+		return cmpNarrowMaskImpl(currentKernels(), v, op, uint8(threshold))
+	case []uint16:
+		return cmpNarrowMaskImpl(currentKernels(), v, op, uint16(threshold))
+	case []uint32:
+		return cmpNarrowMaskImpl(currentKernels(), v, op, uint32(threshold))
+	default:
+		return cmpNumberMaskGeneric(values, op, threshold)
+	}
+}
+
+// Sum computes the sum of all values in the array as a T. Returns 0 for empty arrays.
+//
+// Integer sums wrap on overflow like Go's + operator; use the type-specific
+// functions such as SumInt8 or SumUint32 for a result widened to 64 bits.
+// Float32 sums are accumulated in float64 and rounded once at the end.
+//
+// This function automatically selects the best implementation:
+//   - The SIMD kernels of SumInt64, SumFloat32 and the narrow integer sums
+//   - Scalar fallback for int, uint, uint64 and float64
+func Sum[T Number](values []T) T {
+	if len(values) == 0 {
+		return 0
+	}
+
+	switch v := any(values).(type) {
+	case []int64:
+		return T(sumInt64Impl(currentKernels(), v))
+	case []float32:
+		return T(sumFloat32Impl(currentKernels(), v))
+	case []int8:
+		return T(sumSignedImpl(currentKernels(), v))
+	case []int16:
+		return T(sumSignedImpl(currentKernels(), v))
+	case []int32:
+		return T(sumSignedImpl(currentKernels(), v))
+	case []uint8:
+		return T(sumUnsignedImpl(currentKernels(), v))
+	case []uint16:
+		return T(sumUnsignedImpl(currentKernels(), v))
+	case []uint32:
+		return T(sumUnsignedImpl(currentKernels(), v))
+	default:
+		return sumNumberGeneric(values)
+	}
+}
+
+// int64CmpImpls and the tables below map each Op to the dispatcher of the
+// type-specific function with per-operator kernels.
+var int64CmpImpls = [...]func(*kernelSet, []int64, int64) []bool{
+	OpEq: cmpEqInt64Impl,
+	OpNe: cmpNeInt64Impl,
+	OpGt: cmpGtInt64Impl,
+	OpLt: cmpLtInt64Impl,
+	OpGe: cmpGeInt64Impl,
+	OpLe: cmpLeInt64Impl,
+}
+
+var int64CmpMaskImpls = [...]func(*kernelSet, []int64, int64) []uint64{
+	OpEq: cmpEqInt64MaskImpl,
+	OpNe: cmpNeInt64MaskImpl,
+	OpGt: cmpGtInt64MaskImpl,
+	OpLt: cmpLtInt64MaskImpl,
+	OpGe: cmpGeInt64MaskImpl,
+	OpLe: cmpLeInt64MaskImpl,
+}
+
+var float64CmpImpls = [...]func(*kernelSet, []float64, float64) []bool{
+	OpEq: cmpEqFloat64Impl,
+	OpNe: cmpNeFloat64Impl,
+	OpGt: cmpGtFloat64Impl,
+	OpLt: cmpLtFloat64Impl,
+	OpGe: cmpGeFloat64Impl,
+	OpLe: cmpLeFloat64Impl,
+}
+
+var float64CmpMaskImpls = [...]func(*kernelSet, []float64, float64) []uint64{
+	OpEq: cmpEqFloat64MaskImpl,
+	OpNe: cmpNeFloat64MaskImpl,
+	OpGt: cmpGtFloat64MaskImpl,
+	OpLt: cmpLtFloat64MaskImpl,
+	OpGe: cmpGeFloat64MaskImpl,
+	OpLe: cmpLeFloat64MaskImpl,
+}
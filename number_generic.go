@@ -0,0 +1,78 @@
+package syndrdbsimd
+
+// cmpNumberGeneric performs an element-wise comparison using scalar operations.
+// Returns a slice of booleans where true indicates values[i] op threshold.
+// For floats, NaN comparisons return false per IEEE 754, except != which returns true.
+func cmpNumberGeneric[T Number](values []T, op Op, threshold T) []bool {
+	results := make([]bool, len(values))
+	switch op {
+	case OpEq:
+		for i, v := range values {
+			results[i] = v == threshold
+		}
+	case OpNe:
+		for i, v := range values {
+			results[i] = v != threshold
+		}
+	case OpGt:
+		for i, v := range values {
+			results[i] = v > threshold
+		}
+	case OpLt:
+		for i, v := range values {
+			results[i] = v < threshold
+		}
+	case OpGe:
+		for i, v := range values {
+			results[i] = v >= threshold
+		}
+	case OpLe:
+		for i, v := range values {
+			results[i] = v <= threshold
+		}
+	}
+	return results
+}
+
+// cmpNumberMaskGeneric performs an element-wise comparison using scalar operations.
+// Returns a bitmask where bit i is set if values[i] op threshold.
+func cmpNumberMaskGeneric[T Number](values []T, op Op, threshold T) []uint64 {
+	return boolsToBitmask(cmpNumberGeneric(values, op, threshold))
+}
+
+// cmpNumberTail sets the mask bits for values[start:] using scalar comparisons.
+// The SIMD paths use it for the elements after the last full group, and string
+// predicates of expressions use it for the whole column.
+func cmpNumberTail[T Number | string](values []T, op Op, threshold T, mask []uint64, start int) {
+	for i := start; i < len(values); i++ {
+		v := values[i]
+		var match bool
+		switch op {
+		case OpEq:
+			match = v == threshold
+		case OpNe:
+			match = v != threshold
+		case OpGt:
+			match = v > threshold
+		case OpLt:
+			match = v < threshold
+		case OpGe:
+			match = v >= threshold
+		case OpLe:
+			match = v <= threshold
+		}
+		if match {
+			mask[i/64] |= 1 << uint(i%64)
+		}
+	}
+}
+
+// sumNumberGeneric computes the sum of values in T using scalar operations.
+// Integer sums wrap on overflow like Go's + operator.
+func sumNumberGeneric[T Number](values []T) T {
+	var sum T
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
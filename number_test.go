@@ -0,0 +1,158 @@
+package syndrdbsimd
+
+import (
+	"math"
+	"testing"
+)
+
+// ============================================================================
+// Generic Compare Tests
+// ============================================================================
+
+var allOps = []Op{OpEq, OpNe, OpGt, OpLt, OpGe, OpLe}
+
+// checkCompare checks Compare and CompareMask against the scalar reference for every operator.
+func checkCompare[T Number](t *testing.T, name string, values []T, threshold T) {
+	t.Helper()
+	for _, op := range allOps {
+		checkEqual(t, len(values), name+" Compare "+op.String(), Compare(values, op, threshold), cmpNumberGeneric(values, op, threshold))
+		checkEqual(t, len(values), name+" CompareMask "+op.String(), CompareMask(values, op, threshold), cmpNumberMaskGeneric(values, op, threshold))
+	}
+}
+
+// Sizes above every default threshold so the SIMD paths are taken where they exist
+func TestCompare_AllTypes(t *testing.T) {
+	const size = 300
+	i8, i16, i32, i64, in := make([]int8, size), make([]int16, size), make([]int32, size), make([]int64, size), make([]int, size)
+	u8, u16, u32, u64, un := make([]uint8, size), make([]uint16, size), make([]uint32, size), make([]uint64, size), make([]uint, size)
+	f32, f64 := make([]float32, size), make([]float64, size)
+	for i := 0; i < size; i++ {
+		v := i%50 - 25
+		i8[i], i16[i], i32[i], i64[i], in[i] = int8(v), int16(v), int32(v), int64(v), v
+		u8[i], u16[i], u32[i], u64[i], un[i] = uint8(i), uint16(i), uint32(i), uint64(i), uint(i)
+		f32[i], f64[i] = float32(v)/2, float64(v)/2
+	}
+	f32[7], f64[7] = float32(math.NaN()), math.NaN()
+
+	checkCompare(t, "int8", i8, 3)
+	checkCompare(t, "int16", i16, -3)
+	checkCompare(t, "int32", i32, 0)
+	checkCompare(t, "int64", i64, 10)
+	checkCompare(t, "int", in, 10)
+	checkCompare(t, "uint8", u8, 200)
+	checkCompare(t, "uint16", u16, 150)
+	checkCompare(t, "uint32", u32, 1)
+	checkCompare(t, "uint64", u64, 299)
+	checkCompare(t, "uint", un, 42)
+	checkCompare(t, "float32", f32, 2.5)
+	checkCompare(t, "float64", f64, -2.5)
+}
+
+// The generic front-end returns the same results as the type-specific functions
+func TestCompare_MatchesTypedFunctions(t *testing.T) {
+	values := []float64{1, math.NaN(), 3, math.Inf(1), -2, 3}
+
+	checkEqual(t, len(values), "OpNe", Compare(values, OpNe, 3), CmpNeFloat64(values, 3))
+	checkEqual(t, len(values), "OpGe", Compare(values, OpGe, 3), CmpGeFloat64(values, 3))
+	checkEqual(t, len(values), "OpLt", CompareMask(values, OpLt, 3), CmpLtFloat64Mask(values, 3))
+}
+
+func TestCompare_InvalidOp(t *testing.T) {
+	for _, op := range []Op{-1, OpLe + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Compare with %v: expected panic", op)
+				}
+			}()
+			Compare([]int64{1}, op, 0)
+		}()
+	}
+}
+
+func TestCompare_Empty(t *testing.T) {
+	if len(Compare([]int32{}, OpEq, 1)) != 0 || len(CompareMask[uint64](nil, OpGt, 1)) != 0 {
+		t.Error("Expected empty results for empty input")
+	}
+}
+
+func TestOp_String(t *testing.T) {
+	expected := []string{"==", "!=", ">", "<", ">=", "<="}
+	for i, op := range allOps {
+		if got := op.String(); got != expected[i] {
+			t.Errorf("Op %d: expected %q, got %q", i, expected[i], got)
+		}
+	}
+	if got := Op(9).String(); got != "Op(9)" {
+		t.Errorf("Expected \"Op(9)\", got %q", got)
+	}
+}
+
+// ============================================================================
+// Generic Sum Tests
+// ============================================================================
+
+func TestSum_AllTypes(t *testing.T) {
+	const size = 300
+	i16, i64, u32, u64 := make([]int16, size), make([]int64, size), make([]uint32, size), make([]uint64, size)
+	f32, f64 := make([]float32, size), make([]float64, size)
+	for i := 0; i < size; i++ {
+		i16[i], i64[i], u32[i], u64[i] = int16(i-100), int64(i-100), uint32(i), uint64(i)
+		f32[i], f64[i] = float32(i)/4, float64(i)/4
+	}
+
+	if got, want := Sum(i16), int16(SumInt16(i16)); got != want {
+		t.Errorf("int16: expected %d, got %d", want, got)
+	}
+	if got, want := Sum(i64), SumInt64(i64); got != want {
+		t.Errorf("int64: expected %d, got %d", want, got)
+	}
+	if got, want := Sum(u32), uint32(SumUint32(u32)); got != want {
+		t.Errorf("uint32: expected %d, got %d", want, got)
+	}
+	if got, want := Sum(u64), sumNumberGeneric(u64); got != want {
+		t.Errorf("uint64: expected %d, got %d", want, got)
+	}
+	if got, want := Sum(f32), float32(SumFloat32(f32)); got != want {
+		t.Errorf("float32: expected %v, got %v", want, got)
+	}
+	if got, want := Sum(f64), float64(size*(size-1))/8; got != want {
+		t.Errorf("float64: expected %v, got %v", want, got)
+	}
+	if Sum([]int{}) != 0 {
+		t.Error("Expected 0 sum for empty input")
+	}
+}
+
+// Sum accumulates in T, so narrow integer sums wrap like Go's + operator
+func TestSum_Wraps(t *testing.T) {
+	values := make([]int8, 100)
+	var expected int8
+	for i := range values {
+		values[i] = 100
+		expected += 100
+	}
+
+	if got := Sum(values); got != expected {
+		t.Errorf("Expected %d, got %d", expected, got)
+	}
+	if got := SumInt8(values); got != 10000 {
+		t.Errorf("SumInt8: expected 10000, got %d", got)
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+func BenchmarkCompare_Int64(b *testing.B) {
+	values := make([]int64, 4096)
+	for i := range values {
+		values[i] = int64(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CompareMask(values, OpGt, 2048)
+	}
+}
@@ -0,0 +1,331 @@
+package syndrdbsimd
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// ErrPackedStrings is returned when packed string buffers are inconsistent.
+var ErrPackedStrings = errors.New("invalid packed strings")
+
+// PackedStrings is a string column stored as one contiguous data buffer and
+// uint32 offsets, so predicates and hashes run over it without building a
+// []string or [][]byte header per row.
+//
+// It can also keep the first 12 bytes of each row inline, as Umbra-style string
+// headers do. Equality, ordering and short prefix tests then read only the
+// offsets and the inline prefixes, and touch the data buffer just for rows
+// whose first 12 bytes tie with the operand.
+type PackedStrings struct {
+	offsets  []uint32 // Len()+1 offsets into data
+	data     []byte
+	prefixes []inlinePrefix // nil unless built with inline prefixes
+}
+
+// inlinePrefixLen is the number of leading bytes kept inline per row.
+const inlinePrefixLen = 12
+
+// inlinePrefix holds the first 12 bytes of a string, zero padded and read
+// big-endian, so comparing hi and then lo orders strings by those bytes.
+type inlinePrefix struct {
+	hi uint64
+	lo uint32
+}
+
+func makeInlinePrefix(b []byte) inlinePrefix {
+	var buf [inlinePrefixLen]byte
+	copy(buf[:], b)
+	return inlinePrefix{hi: binary.BigEndian.Uint64(buf[:8]), lo: binary.BigEndian.Uint32(buf[8:])}
+}
+
+// compare orders two prefixes like bytes.Compare orders their bytes.
+func (p inlinePrefix) compare(q inlinePrefix) int {
+	if p.hi != q.hi {
+		return cmp.Compare(p.hi, q.hi)
+	}
+	return cmp.Compare(p.lo, q.lo)
+}
+
+// PackStrings copies values into a PackedStrings, keeping the first 12 bytes of
+// each row inline if inline is set. Panics if the data exceeds 4 GiB.
+func PackStrings(values []string, inline bool) *PackedStrings {
+	total := 0
+	for _, s := range values {
+		total += len(s)
+	}
+	if uint64(total) > 1<<32-1 {
+		panic(fmt.Sprintf("syndrdbsimd: %d bytes of strings do not fit uint32 offsets", total))
+	}
+
+	p := &PackedStrings{offsets: make([]uint32, len(values)+1), data: make([]byte, 0, total)}
+	for i, s := range values {
+		p.data = append(p.data, s...)
+		p.offsets[i+1] = uint32(len(p.data))
+	}
+	if inline {
+		p.buildPrefixes()
+	}
+	return p
+}
+
+// NewPackedStrings returns a PackedStrings over existing buffers, which are
+// shared, not copied: row i is data[offsets[i]:offsets[i+1]]. It keeps the
+// first 12 bytes of each row inline if inline is set.
+//
+// Returns an error wrapping ErrPackedStrings if offsets is empty, decreases or
+// points past data.
+func NewPackedStrings(offsets []uint32, data []byte, inline bool) (*PackedStrings, error) {
+	if len(offsets) == 0 {
+		return nil, fmt.Errorf("%w: no offsets", ErrPackedStrings)
+	}
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] < offsets[i-1] {
+			return nil, fmt.Errorf("%w: offsets decrease at row %d", ErrPackedStrings, i-1)
+		}
+	}
+	if last := offsets[len(offsets)-1]; uint64(last) > uint64(len(data)) {
+		return nil, fmt.Errorf("%w: offset %d past data buffer of %d bytes", ErrPackedStrings, last, len(data))
+	}
+
+	p := &PackedStrings{offsets: offsets, data: data}
+	if inline {
+		p.buildPrefixes()
+	}
+	return p, nil
+}
+
+func (p *PackedStrings) buildPrefixes() {
+	p.prefixes = make([]inlinePrefix, p.Len())
+	for i := range p.prefixes {
+		p.prefixes[i] = makeInlinePrefix(p.Bytes(i))
+	}
+}
+
+// Len returns the number of rows.
+func (p *PackedStrings) Len() int {
+	return len(p.offsets) - 1
+}
+
+// rowLen returns the length of row i from the offsets alone.
+func (p *PackedStrings) rowLen(i int) int {
+	return int(p.offsets[i+1] - p.offsets[i])
+}
+
+// Bytes returns the bytes of row i, sharing the data buffer.
+func (p *PackedStrings) Bytes(i int) []byte {
+	return p.data[p.offsets[i]:p.offsets[i+1]]
+}
+
+// Value returns row i as a string sharing the data buffer.
+func (p *PackedStrings) Value(i int) string {
+	b := p.Bytes(i)
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(&b[0], len(b))
+}
+
+// matchMask returns the bitmask of the rows for which match returns true.
+func (p *PackedStrings) matchMask(match func([]byte) bool) []uint64 {
+	mask := make([]uint64, (p.Len()+63)/64)
+	for i := 0; i < p.Len(); i++ {
+		if match(p.Bytes(i)) {
+			mask[i/64] |= 1 << uint(i%64)
+		}
+	}
+	return mask
+}
+
+// compareRow compares row i with t, whose inline prefix is tp, like bytes.Compare.
+// Rows that differ from t in their first 12 bytes, or are no longer than 12
+// bytes, are decided without reading the data buffer.
+func (p *PackedStrings) compareRow(i int, t []byte, tp inlinePrefix) int {
+	if c := p.prefixes[i].compare(tp); c != 0 {
+		return c
+	}
+	// The first 12 bytes tie; if either side ends within them, it is a prefix
+	// of the other, as the padding bytes compared equal
+	n := p.rowLen(i)
+	if n <= inlinePrefixLen || len(t) <= inlinePrefixLen {
+		return cmp.Compare(n, len(t))
+	}
+	return strCmpImpl(currentKernels(), p.Bytes(i)[inlinePrefixLen:], t[inlinePrefixLen:])
+}
+
+// CompareMask compares each row against threshold with the operator op, ordering
+// strings by bytes as Go does, and returns a bitmask. Panics if op is invalid.
+func (p *PackedStrings) CompareMask(op Op, threshold string) []uint64 {
+	ks := currentKernels()
+	checkOp(op)
+	t := stringToBytes(threshold)
+	mask := make([]uint64, (p.Len()+63)/64)
+
+	switch {
+	case op == OpEq || op == OpNe:
+		// Rows of another length differ without reading either prefix or data
+		eq := op == OpEq
+		tp := makeInlinePrefix(t)
+		for i := 0; i < p.Len(); i++ {
+			match := p.rowLen(i) == len(t)
+			if match && p.prefixes != nil {
+				match = p.prefixes[i] == tp &&
+					(len(t) <= inlinePrefixLen || strEqImpl(ks, p.Bytes(i)[inlinePrefixLen:], t[inlinePrefixLen:]))
+			} else if match {
+				match = strEqImpl(ks, p.Bytes(i), t)
+			}
+			if match == eq {
+				mask[i/64] |= 1 << uint(i%64)
+			}
+		}
+	case p.prefixes != nil:
+		tp := makeInlinePrefix(t)
+		for i := 0; i < p.Len(); i++ {
+			if orderMatches(op, p.compareRow(i, t, tp)) {
+				mask[i/64] |= 1 << uint(i%64)
+			}
+		}
+	default:
+		for i := 0; i < p.Len(); i++ {
+			if orderMatches(op, strCmpImpl(ks, p.Bytes(i), t)) {
+				mask[i/64] |= 1 << uint(i%64)
+			}
+		}
+	}
+	return mask
+}
+
+// BetweenMask returns a bitmask of the rows with lo <= row <= hi, matching SQL's
+// inclusive BETWEEN.
+func (p *PackedStrings) BetweenMask(lo, hi string) []uint64 {
+	ks := currentKernels()
+	l, h := stringToBytes(lo), stringToBytes(hi)
+	if p.prefixes == nil {
+		return p.matchMask(func(b []byte) bool { return strCmpImpl(ks, b, l) >= 0 && strCmpImpl(ks, b, h) <= 0 })
+	}
+
+	lp, hp := makeInlinePrefix(l), makeInlinePrefix(h)
+	mask := make([]uint64, (p.Len()+63)/64)
+	for i := 0; i < p.Len(); i++ {
+		if p.compareRow(i, l, lp) >= 0 && p.compareRow(i, h, hp) <= 0 {
+			mask[i/64] |= 1 << uint(i%64)
+		}
+	}
+	return mask
+}
+
+// HasPrefixMask returns a bitmask of the rows that start with prefix. With
+// inline prefixes, a prefix of up to 12 bytes is tested without reading the
+// data buffer.
+func (p *PackedStrings) HasPrefixMask(prefix string) []uint64 {
+	ks := currentKernels()
+	pre := stringToBytes(prefix)
+	if p.prefixes == nil {
+		return p.matchMask(func(b []byte) bool {
+			return len(b) >= len(pre) && strEqImpl(ks, b[:len(pre)], pre)
+		})
+	}
+
+	// Compare the first min(len(pre), 12) bytes under a mask, then the rest
+	head := min(len(pre), inlinePrefixLen)
+	want := makeInlinePrefix(pre)
+	keep := makeInlinePrefix(bytes.Repeat([]byte{0xFF}, head))
+	mask := make([]uint64, (p.Len()+63)/64)
+	for i := 0; i < p.Len(); i++ {
+		if p.rowLen(i) < len(pre) {
+			continue
+		}
+		rp := p.prefixes[i]
+		if rp.hi&keep.hi != want.hi || rp.lo&keep.lo != want.lo {
+			continue
+		}
+		if head == len(pre) || strEqImpl(ks, p.Bytes(i)[head:len(pre)], pre[head:]) {
+			mask[i/64] |= 1 << uint(i%64)
+		}
+	}
+	return mask
+}
+
+// HasSuffixMask returns a bitmask of the rows that end with suffix.
+func (p *PackedStrings) HasSuffixMask(suffix string) []uint64 {
+	s := stringToBytes(suffix)
+	return p.matchMask(func(b []byte) bool {
+		return len(b) >= len(s) && strEqImpl(currentKernels(), b[len(b)-len(s):], s)
+	})
+}
+
+// ContainsMask returns a bitmask of the rows that contain substr. Like
+// ArrowStringArray.ContainsMask, it searches the data buffer as a whole and
+// maps each occurrence to its row.
+func (p *PackedStrings) ContainsMask(substr string) []uint64 {
+	return containsRows(p.offsets, p.data, stringToBytes(substr))
+}
+
+// LikeMask returns a bitmask of the rows that match the SQL LIKE pattern.
+// Like CmpLikeStringMask, an invalid pattern matches nothing.
+func (p *PackedStrings) LikeMask(pattern string) []uint64 {
+	compiled, err := CompilePatternAuto(pattern)
+	if err != nil {
+		return make([]uint64, (p.Len()+63)/64)
+	}
+
+	switch compiled.Type {
+	case PatternExact:
+		return p.CompareMask(OpEq, string(compiled.Segments[0]))
+	case PatternPrefix:
+		return p.HasPrefixMask(string(compiled.Segments[0]))
+	case PatternSuffix:
+		return p.HasSuffixMask(string(compiled.Segments[0]))
+	case PatternContains:
+		return p.ContainsMask(string(compiled.Segments[0]))
+	default:
+		ks := currentKernels()
+		return p.matchMask(func(b []byte) bool { return compiled.program.match(ks, b) })
+	}
+}
+
+// XXHash64 computes the XXHash64Bytes hash of each row into output. It does
+// nothing unless output has the same length as the column.
+func (p *PackedStrings) XXHash64(output []uint64) {
+	if p.Len() == 0 || len(output) != p.Len() {
+		return
+	}
+	for i := range output {
+		output[i] = xxhash64BytesGeneric(p.Bytes(i))
+	}
+}
+
+// containsRows returns the bitmask of the rows of an offsets and data layout
+// that contain sub, searching the data as a whole and skipping to the next row
+// after each match.
+func containsRows[O int32 | uint32](offsets []O, data, sub []byte) []uint64 {
+	rows := len(offsets) - 1
+	if len(sub) == 0 {
+		return constantMask(rows, true)
+	}
+
+	mask := make([]uint64, (rows+63)/64)
+	end := int(offsets[rows])
+	row := 0
+	for pos := int(offsets[0]); pos < end; {
+		idx := strIndexImpl(currentKernels(), data[pos:end], sub)
+		if idx < 0 {
+			break
+		}
+		start := pos + idx
+		for int(offsets[row+1]) <= start {
+			row++
+		}
+		if start+len(sub) <= int(offsets[row+1]) {
+			mask[row/64] |= 1 << uint(row%64)
+			pos = int(offsets[row+1])
+		} else {
+			// The occurrence spans a row boundary; one inside the row may start later
+			pos = start + 1
+		}
+	}
+	return mask
+}
@@ -0,0 +1,177 @@
+package syndrdbsimd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// PackedStrings Tests
+// ============================================================================
+
+func TestPackedStrings_HappyPath(t *testing.T) {
+	values := []string{"apple", "banana", "", "apricot", "cherry", "apple", "an apple a day keeps"}
+	for _, inline := range []bool{false, true} {
+		p := PackStrings(values, inline)
+		name := fmt.Sprintf("inline=%v ", inline)
+
+		if p.Len() != 7 || p.Value(3) != "apricot" || string(p.Bytes(6)) != values[6] {
+			t.Errorf("%sLen, Value(3) = %d, %q", name, p.Len(), p.Value(3))
+		}
+		checkEqual(t, 7, name+"CompareMask Eq", p.CompareMask(OpEq, "apple"), []uint64{0b010_0001})
+		checkEqual(t, 7, name+"CompareMask Lt", p.CompareMask(OpLt, "b"), []uint64{0b110_1101})
+		checkEqual(t, 7, name+"BetweenMask", p.BetweenMask("apple", "banana"), []uint64{0b010_1011})
+		checkEqual(t, 7, name+"HasPrefixMask", p.HasPrefixMask("ap"), []uint64{0b010_1001})
+		checkEqual(t, 7, name+"HasSuffixMask", p.HasSuffixMask("le"), []uint64{0b010_0001})
+		checkEqual(t, 7, name+"ContainsMask", p.ContainsMask("an"), []uint64{0b100_0010})
+		checkEqual(t, 7, name+"LikeMask", p.LikeMask("%e%"), []uint64{0b111_0001})
+
+		hashes, want := make([]uint64, 7), make([]uint64, 7)
+		p.XXHash64(hashes)
+		StringColumn{Values: values}.XXHash64(want)
+		checkEqual(t, 7, name+"XXHash64", hashes, want)
+	}
+}
+
+// Every mask must match StringColumn's, with and without inline prefixes, for
+// rows and operands on both sides of the 12 inline bytes. Zero bytes check that
+// the padding of short prefixes is told apart from real zeros.
+func TestPackedStrings_MatchStringColumn(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+	cfg := DefaultConfig()
+	cfg.MinStrings = 1
+	if err := SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(480))
+				random := func() string {
+					// Shared heads make ties in the inline bytes common
+					head := strings.Repeat("ab", rng.Intn(8))
+					b := []byte(head)
+					for k := rng.Intn(40); k > 0; k-- {
+						b = append(b, "ab\x00\xff"[rng.Intn(4)])
+					}
+					return string(b[:rng.Intn(len(b)+1)])
+				}
+
+				values := make([]string, 500)
+				for i := range values {
+					values[i] = random()
+				}
+				c := StringColumn{Values: values}
+				n := len(values)
+				plain, inline := PackStrings(values, false), PackStrings(values, true)
+
+				for trial := 0; trial < 100; trial++ {
+					s, s2 := random(), random()
+					for _, p := range []*PackedStrings{plain, inline} {
+						name := fmt.Sprintf("inline=%v %q", p.prefixes != nil, s)
+						for _, op := range allOps {
+							checkEqual(t, n, "CompareMask "+op.String()+" "+name, p.CompareMask(op, s), c.CompareMask(op, s))
+						}
+						checkEqual(t, n, "BetweenMask "+name, p.BetweenMask(s, s2), CmpBetweenStringMask(values, s, s2))
+						checkEqual(t, n, "HasPrefixMask "+name, p.HasPrefixMask(s), c.HasPrefixMask(s))
+						checkEqual(t, n, "HasSuffixMask "+name, p.HasSuffixMask(s), c.HasSuffixMask(s))
+						checkEqual(t, n, "ContainsMask "+name, p.ContainsMask(s), c.ContainsMask(s))
+					}
+				}
+				for _, pattern := range []string{"ab%", "%\x00", "%b\x00%", "a_b%", "abababab%"} {
+					checkEqual(t, n, "LikeMask "+pattern, inline.LikeMask(pattern), c.LikeMask(pattern))
+				}
+			})
+		})
+	}
+}
+
+func TestNewPackedStrings(t *testing.T) {
+	data := []byte("xxhelloworld")
+	p, err := NewPackedStrings([]uint32{2, 7, 7, 12}, data, true)
+	if err != nil {
+		t.Fatalf("NewPackedStrings failed: %v", err)
+	}
+	if p.Len() != 3 || p.Value(0) != "hello" || p.Value(1) != "" || p.Value(2) != "world" {
+		t.Errorf("rows = %q, %q, %q", p.Value(0), p.Value(1), p.Value(2))
+	}
+	if &p.Bytes(2)[0] != &data[7] {
+		t.Error("NewPackedStrings copied the data buffer")
+	}
+	checkEqual(t, 3, "CompareMask", p.CompareMask(OpGe, "hello"), []uint64{0b101})
+
+	tests := []struct {
+		name    string
+		offsets []uint32
+	}{
+		{"no offsets", nil},
+		{"decreasing offsets", []uint32{0, 5, 3}},
+		{"offset past data", []uint32{0, 13}},
+	}
+	for _, tt := range tests {
+		if _, err := NewPackedStrings(tt.offsets, data, false); !errors.Is(err, ErrPackedStrings) {
+			t.Errorf("%s: err = %v, want ErrPackedStrings", tt.name, err)
+		}
+	}
+
+	if p, err := NewPackedStrings([]uint32{0}, nil, true); err != nil || p.Len() != 0 {
+		t.Errorf("empty NewPackedStrings: %v", err)
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+// userAgents returns n strings that share long prefixes, like URLs or user agents.
+func userAgents(n int) []string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = fmt.Sprintf("Mozilla/5.0 (X11; Linux x86_64) build/%d", i%5000)
+	}
+	return values
+}
+
+func BenchmarkPackedStringsEq(b *testing.B) {
+	p := PackStrings(userAgents(65536), true)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.CompareMask(OpEq, "Mozilla/5.0 (X11; Linux x86_64) build/1234")
+	}
+}
+
+func BenchmarkPackedStringsLt(b *testing.B) {
+	p := PackStrings(userAgents(65536), true)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.CompareMask(OpLt, "Mozilla/4")
+	}
+}
+
+// The []string equivalents of the benchmarks above
+func BenchmarkCmpEqStringUserAgents(b *testing.B) {
+	values := userAgents(65536)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CmpEqStringMask(values, "Mozilla/5.0 (X11; Linux x86_64) build/1234")
+	}
+}
+
+func BenchmarkCmpLtStringUserAgents(b *testing.B) {
+	values := userAgents(65536)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CmpLtStringMask(values, "Mozilla/4")
+	}
+}
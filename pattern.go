@@ -1,7 +1,9 @@
 package syndrdbsimd
 
 import (
+	"bytes"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -16,8 +18,8 @@ type CompiledPattern struct {
 	// For PatternWildcard: multiple segments separated by % or _
 	Segments [][]byte
 
-	// WildcardPositions tracks the positions of _ (single char wildcard)
-	// Only used for PatternWildcard type
+	// WildcardPositions holds the byte offsets of each _ (single char wildcard)
+	// in OriginalPattern. Only set for PatternWildcard type
 	WildcardPositions []int
 
 	// HasWildcard indicates if the pattern contains % or _ wildcards
@@ -25,6 +27,16 @@ type CompiledPattern struct {
 
 	// OriginalPattern is the original pattern string (for debugging/logging)
 	OriginalPattern string
+
+	// IgnoreCase makes the pattern match regardless of ASCII case (SQL ILIKE).
+	// Segments then hold lowercase copies of the literals
+	IgnoreCase bool
+
+	// program is the compiled matcher for PatternWildcard
+	program *likeProgram
+
+	// regex is the compiled matcher for PatternRegex
+	regex *regexp.Regexp
 }
 
 // CompilePattern analyzes and compiles a SQL LIKE pattern for efficient matching.
@@ -86,7 +98,7 @@ func CompilePattern(patternType PatternType, pattern string) (*CompiledPattern,
 		// Complex pattern with % and/or _ wildcards
 		// Split by % to get literal segments
 		segments := strings.Split(pattern, "%")
-		
+
 		// Filter out empty segments and convert to []byte
 		for _, seg := range segments {
 			if seg != "" {
@@ -98,17 +110,12 @@ func CompilePattern(patternType PatternType, pattern string) (*CompiledPattern,
 			return nil, fmt.Errorf("wildcard pattern has no literal segments: %q", pattern)
 		}
 
-		// Track positions of _ wildcards within segments
-		// Note: Full _ position tracking across entire pattern would be complex
-		// For now, we handle _ during matching rather than pre-parsing positions
-		// This works well with SyndrDB's constraint of max 3 % characters
-		for _, seg := range compiled.Segments {
-			if strings.Contains(string(seg), "_") {
-				// Mark that we have _ wildcards
-				// Detailed position tracking can be added if needed
-				break
+		for i := 0; i < len(pattern); i++ {
+			if pattern[i] == '_' {
+				compiled.WildcardPositions = append(compiled.WildcardPositions, i)
 			}
 		}
+		compiled.program = compileLikeProgram(parseLikePattern(pattern))
 
 	default:
 		return nil, fmt.Errorf("unsupported pattern type: %v", patternType)
@@ -123,52 +130,413 @@ func DetectPatternType(pattern string) PatternType {
 	if pattern == "" {
 		return PatternExact
 	}
+	return detectLikeElems(parseLikePattern(pattern))
+}
 
-	hasPercent := strings.Contains(pattern, "%")
-	hasUnderscore := strings.Contains(pattern, "_")
+// CompilePatternAuto analyzes and compiles a SQL LIKE pattern with auto-detection.
+// This is a convenience wrapper around CompilePattern that auto-detects the pattern type.
+func CompilePatternAuto(pattern string) (*CompiledPattern, error) {
+	patternType := DetectPatternType(pattern)
+	return CompilePattern(patternType, pattern)
+}
 
-	// No wildcards at all
-	if !hasPercent && !hasUnderscore {
-		return PatternExact
+// CompilePatternEscape compiles a SQL LIKE pattern with an ESCAPE character.
+// The escape character followed by %, _ or itself matches that character
+// literally, so LIKE 'user\_%' ESCAPE '\' is a prefix match for "user_" and
+// takes the same SIMD routes as an unescaped prefix pattern.
+//
+// patternType must describe the unescaped pattern, as DetectPatternTypeEscape
+// reports it; PatternWildcard is accepted for any pattern. Returns an error for
+// an escape character that ends the pattern or precedes any other byte.
+func CompilePatternEscape(patternType PatternType, pattern string, escape byte) (*CompiledPattern, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("pattern is empty")
 	}
 
-	// Complex wildcard pattern
-	if hasUnderscore {
-		return PatternWildcard
+	if patternType < PatternExact || patternType > PatternWildcard {
+		return nil, fmt.Errorf("invalid pattern type: %d", int(patternType))
 	}
 
-	// Only % wildcards - check pattern structure
-	if strings.HasPrefix(pattern, "%") && strings.HasSuffix(pattern, "%") {
-		// %...% pattern
-		if strings.Count(pattern, "%") == 2 {
-			return PatternContains
+	elems, err := parseLikePatternEscape(pattern, escape)
+	if err != nil {
+		return nil, err
+	}
+	if detected := detectLikeElems(elems); patternType != PatternWildcard && patternType != detected {
+		return nil, fmt.Errorf("pattern %q is a %v pattern, not %v", pattern, detected, patternType)
+	}
+	return compileLikeElems(patternType, pattern, elems)
+}
+
+// compileLikeElems compiles a parsed pattern whose type has been checked.
+func compileLikeElems(patternType PatternType, pattern string, elems []likeElem) (*CompiledPattern, error) {
+	compiled := &CompiledPattern{
+		Type:            patternType,
+		OriginalPattern: pattern,
+	}
+	for _, e := range elems {
+		compiled.HasWildcard = compiled.HasWildcard || e.wildcard
+	}
+
+	// Strip the wildcards the type implies; what remains is literal
+	var literal []likeElem
+	switch patternType {
+	case PatternExact:
+		literal = elems
+	case PatternPrefix:
+		literal = elems[:len(elems)-1]
+	case PatternSuffix:
+		literal = elems[1:]
+	case PatternContains:
+		literal = elems[1 : len(elems)-1]
+	case PatternWildcard:
+		for _, part := range splitLikeElems(elems, '%') {
+			if len(part) > 0 {
+				compiled.Segments = append(compiled.Segments, likeLiteral(part))
+			}
 		}
+		if len(compiled.Segments) == 0 {
+			return nil, fmt.Errorf("wildcard pattern has no literal segments: %q", pattern)
+		}
+		for _, e := range elems {
+			if e.is('_') {
+				compiled.WildcardPositions = append(compiled.WildcardPositions, e.pos)
+			}
+		}
+		compiled.program = compileLikeProgram(elems)
+		return compiled, nil
+	}
+
+	if len(literal) == 0 {
+		return nil, fmt.Errorf("%s pattern has no literal part: %q", strings.ToLower(patternType.String()), pattern)
+	}
+	compiled.Segments = [][]byte{likeLiteral(literal)}
+	return compiled, nil
+}
+
+// DetectPatternTypeEscape analyzes a SQL LIKE pattern with an ESCAPE character
+// and returns its type, treating escaped % and _ as literals. A pattern with an
+// invalid escape sequence is reported as PatternWildcard; CompilePatternEscape
+// then returns the error.
+func DetectPatternTypeEscape(pattern string, escape byte) PatternType {
+	if pattern == "" {
+		return PatternExact
+	}
+	elems, err := parseLikePatternEscape(pattern, escape)
+	if err != nil {
 		return PatternWildcard
 	}
+	return detectLikeElems(elems)
+}
+
+// CompilePatternAutoEscape compiles a SQL LIKE pattern with an ESCAPE character
+// and auto-detection.
+func CompilePatternAutoEscape(pattern string, escape byte) (*CompiledPattern, error) {
+	patternType := DetectPatternTypeEscape(pattern, escape)
+	return CompilePatternEscape(patternType, pattern, escape)
+}
+
+// CompilePatternIgnoreCase compiles a SQL ILIKE pattern: like CompilePattern, but
+// ASCII letters match regardless of case.
+func CompilePatternIgnoreCase(patternType PatternType, pattern string) (*CompiledPattern, error) {
+	compiled, err := CompilePattern(patternType, pattern)
+	if err != nil {
+		return nil, err
+	}
 
-	if strings.HasPrefix(pattern, "%") {
-		// %... pattern (suffix match)
-		if strings.Count(pattern, "%") == 1 {
-			return PatternSuffix
+	// The segments alias pattern, so they are lowered into copies
+	compiled.IgnoreCase = true
+	for i, seg := range compiled.Segments {
+		compiled.Segments[i] = lowerASCII(seg)
+	}
+	if compiled.program != nil {
+		compiled.program = compiled.program.lowered()
+	}
+	return compiled, nil
+}
+
+// CompilePatternAutoIgnoreCase compiles a SQL ILIKE pattern with auto-detection.
+func CompilePatternAutoIgnoreCase(pattern string) (*CompiledPattern, error) {
+	patternType := DetectPatternType(pattern)
+	return CompilePatternIgnoreCase(patternType, pattern)
+}
+
+// lowerASCII returns a copy of b with ASCII letters lowercased.
+func lowerASCII(b []byte) []byte {
+	lowered := append([]byte(nil), b...)
+	strToLowerImpl(currentKernels(), lowered)
+	return lowered
+}
+
+// ============================================================================
+// Pattern Parsing
+// ============================================================================
+
+// likeElem is one element of a parsed LIKE pattern: a literal byte, or a % or _
+// wildcard. An escaped % or _ is a literal.
+type likeElem struct {
+	b        byte
+	wildcard bool
+	pos      int // offset in the original pattern
+}
+
+// is reports whether e is the wildcard w.
+func (e likeElem) is(w byte) bool {
+	return e.wildcard && e.b == w
+}
+
+// parseLikePattern parses a LIKE pattern without an escape character.
+func parseLikePattern(pattern string) []likeElem {
+	elems := make([]likeElem, len(pattern))
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		elems[i] = likeElem{b: c, wildcard: c == '%' || c == '_', pos: i}
+	}
+	return elems
+}
+
+// parseLikePatternEscape parses a LIKE pattern in which escape makes the next
+// %, _ or escape character a literal. As in the SQL standard, the escape
+// character must be followed by one of those three.
+func parseLikePatternEscape(pattern string, escape byte) ([]likeElem, error) {
+	elems := make([]likeElem, 0, len(pattern))
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c != escape {
+			elems = append(elems, likeElem{b: c, wildcard: c == '%' || c == '_', pos: i})
+			continue
 		}
-		return PatternWildcard
+		if i+1 == len(pattern) {
+			return nil, fmt.Errorf("pattern ends with escape character: %q", pattern)
+		}
+		if next := pattern[i+1]; next != '%' && next != '_' && next != escape {
+			return nil, fmt.Errorf("invalid escape sequence %q in pattern %q", pattern[i:i+2], pattern)
+		}
+		i++
+		elems = append(elems, likeElem{b: pattern[i], pos: i})
+	}
+	return elems, nil
+}
+
+// splitLikeElems splits elems at each wildcard w, like bytes.Split.
+func splitLikeElems(elems []likeElem, w byte) [][]likeElem {
+	var parts [][]likeElem
+	start := 0
+	for i, e := range elems {
+		if e.is(w) {
+			parts = append(parts, elems[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, elems[start:])
+}
+
+// likeLiteral returns the bytes of elems, with wildcards as their pattern byte.
+func likeLiteral(elems []likeElem) []byte {
+	literal := make([]byte, len(elems))
+	for i, e := range elems {
+		literal[i] = e.b
 	}
+	return literal
+}
 
-	if strings.HasSuffix(pattern, "%") {
-		// ...% pattern (prefix match)
-		if strings.Count(pattern, "%") == 1 {
-			return PatternPrefix
+// detectLikeElems classifies a parsed pattern like DetectPatternType.
+func detectLikeElems(elems []likeElem) PatternType {
+	percents, underscores := 0, 0
+	for _, e := range elems {
+		switch {
+		case e.is('%'):
+			percents++
+		case e.is('_'):
+			underscores++
 		}
+	}
+
+	switch {
+	case percents == 0 && underscores == 0:
+		// No wildcards at all
+		return PatternExact
+	case underscores > 0:
 		return PatternWildcard
 	}
 
-	// % in the middle somewhere - complex pattern
-	return PatternWildcard
+	first, last := elems[0].is('%'), elems[len(elems)-1].is('%')
+	switch {
+	case first && last && percents == 2:
+		return PatternContains
+	case first && !last && percents == 1:
+		return PatternSuffix
+	case last && !first && percents == 1:
+		return PatternPrefix
+	default:
+		return PatternWildcard
+	}
 }
 
-// CompilePatternAuto analyzes and compiles a SQL LIKE pattern with auto-detection.
-// This is a convenience wrapper around CompilePattern that auto-detects the pattern type.
-func CompilePatternAuto(pattern string) (*CompiledPattern, error) {
-	patternType := DetectPatternType(pattern)
-	return CompilePattern(patternType, pattern)
+// ============================================================================
+// Wildcard Match Program
+// ============================================================================
+
+// likeProgram matches a PatternWildcard pattern without backtracking.
+//
+// The pattern is split at each % into segments. The first segment is anchored
+// at the start of the string and the last at its end; the middle segments must
+// then appear in order in between. Matching each middle segment at its leftmost
+// occurrence is always safe: a later occurrence would only leave less room for
+// the segments that follow. A row therefore costs one forward scan, with the
+// segments located by the SIMD substring search.
+//
+// Within a segment, each _ matches exactly one byte, so a segment has a fixed
+// length and is stored as literal runs at fixed offsets.
+type likeProgram struct {
+	head   likeSegment   // anchored at the start; empty if the pattern starts with %
+	tail   likeSegment   // anchored at the end; empty if the pattern ends with %
+	middle []likeSegment // matched in order between head and tail
+	exact  bool          // the pattern has no %, so head must span the whole string
+	minLen int           // total length of all segments
+}
+
+// likeSegment is the part of a pattern between two %.
+type likeSegment struct {
+	length int       // bytes matched, counting each _ as one
+	runs   []likeRun // literal runs between the _ wildcards
+	anchor int       // index of the longest run, located by substring search
+}
+
+// likeRun is a run of literal bytes at a fixed offset within its segment.
+type likeRun struct {
+	offset  int
+	literal []byte
+}
+
+// compileLikeProgram builds the match program for a parsed LIKE pattern.
+func compileLikeProgram(elems []likeElem) *likeProgram {
+	parts := splitLikeElems(elems, '%')
+	prog := &likeProgram{
+		head:  compileLikeSegment(parts[0]),
+		exact: len(parts) == 1,
+	}
+	prog.minLen = prog.head.length
+	if prog.exact {
+		return prog
+	}
+
+	prog.tail = compileLikeSegment(parts[len(parts)-1])
+	prog.minLen += prog.tail.length
+	for _, part := range parts[1 : len(parts)-1] {
+		// %% is the same as %
+		if len(part) > 0 {
+			seg := compileLikeSegment(part)
+			prog.middle = append(prog.middle, seg)
+			prog.minLen += seg.length
+		}
+	}
+	return prog
+}
+
+// compileLikeSegment splits a segment into its literal runs.
+func compileLikeSegment(part []likeElem) likeSegment {
+	seg := likeSegment{length: len(part)}
+	start := 0
+	for i := 0; i <= len(part); i++ {
+		if i < len(part) && !part[i].is('_') {
+			continue
+		}
+		if i > start {
+			seg.runs = append(seg.runs, likeRun{offset: start, literal: likeLiteral(part[start:i])})
+			if i-start > len(seg.runs[seg.anchor].literal) {
+				seg.anchor = len(seg.runs) - 1
+			}
+		}
+		start = i + 1
+	}
+	return seg
+}
+
+// lowered returns a copy of the program with ASCII letters lowercased, for ILIKE.
+func (p *likeProgram) lowered() *likeProgram {
+	lower := func(seg likeSegment) likeSegment {
+		runs := make([]likeRun, len(seg.runs))
+		for i, run := range seg.runs {
+			runs[i] = likeRun{offset: run.offset, literal: lowerASCII(run.literal)}
+		}
+		seg.runs = runs
+		return seg
+	}
+	q := *p
+	q.head, q.tail = lower(p.head), lower(p.tail)
+	q.middle = make([]likeSegment, len(p.middle))
+	for i, seg := range p.middle {
+		q.middle[i] = lower(seg)
+	}
+	return &q
+}
+
+// match reports whether s matches the pattern.
+func (p *likeProgram) match(ks *kernelSet, s []byte) bool {
+	if p.exact {
+		return len(s) == p.head.length && p.head.matchAt(s, 0)
+	}
+	if len(s) < p.minLen || !p.head.matchAt(s, 0) || !p.tail.matchAt(s, len(s)-p.tail.length) {
+		return false
+	}
+
+	pos, end := p.head.length, len(s)-p.tail.length
+	for i := range p.middle {
+		seg := &p.middle[i]
+		idx := seg.index(ks, s[pos:end])
+		if idx < 0 {
+			return false
+		}
+		pos += idx + seg.length
+	}
+	return true
+}
+
+// matchAll matches every value against the pattern.
+func (p *likeProgram) matchAll(ks *kernelSet, values [][]byte) []bool {
+	results := make([]bool, len(values))
+	for i, v := range values {
+		results[i] = p.match(ks, v)
+	}
+	return results
+}
+
+// matchAt reports whether the segment matches s at offset pos. The caller
+// guarantees that the segment fits.
+func (seg *likeSegment) matchAt(s []byte, pos int) bool {
+	for _, run := range seg.runs {
+		if !bytes.Equal(s[pos+run.offset:pos+run.offset+len(run.literal)], run.literal) {
+			return false
+		}
+	}
+	return true
+}
+
+// index returns the offset of the leftmost match of the segment in s, or -1.
+// Candidates come from searching for the anchor run; the other runs are then
+// checked at their fixed offsets.
+func (seg *likeSegment) index(ks *kernelSet, s []byte) int {
+	if len(s) < seg.length {
+		return -1
+	}
+	if len(seg.runs) == 0 {
+		// Only _ wildcards: any position fits
+		return 0
+	}
+
+	anchor := seg.runs[seg.anchor]
+	// The anchor can only start where the whole segment still fits
+	lo, hi := anchor.offset, len(s)-seg.length+anchor.offset+len(anchor.literal)
+	for lo < hi {
+		idx := strIndexImpl(ks, s[lo:hi], anchor.literal)
+		if idx < 0 {
+			return -1
+		}
+		start := lo + idx - anchor.offset
+		if seg.matchAt(s, start) {
+			return start
+		}
+		lo += idx + 1
+	}
+	return -1
 }
@@ -0,0 +1,424 @@
+package syndrdbsimd
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"sync"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// CompiledRegex is a regular expression prepared for filtering string columns,
+// as in WHERE msg ~ 'timeout|refused'. It is safe for concurrent use.
+//
+// Matching runs in two stages. The literals that every match must contain are
+// extracted from the expression, and rows containing none of them are rejected
+// with the SIMD substring search alone. The remaining rows run a lazily built
+// DFA over the compiled program, which costs one table lookup per ASCII byte.
+// When the expression is nothing but those literals, the prefilter is the
+// whole answer and the DFA never runs.
+type CompiledRegex struct {
+	expr     string
+	re       *regexp.Regexp
+	prog     *syntax.Prog
+	literals [][]byte // every match contains one of these; nil if unknown
+	exact    bool     // a row matches iff it contains one of literals
+	dfa      bool     // prog only uses assertions the DFA supports
+	caches   sync.Pool
+}
+
+// CompileRegex compiles a regular expression in the RE2 syntax of package
+// regexp. Like the ~ operator of SQL, a row matches if any substring of it
+// matches, so the expression must use ^ and $ to anchor.
+func CompileRegex(expr string) (*CompiledRegex, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", expr, err)
+	}
+	parsed, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", expr, err)
+	}
+	parsed = parsed.Simplify()
+	prog, err := syntax.Compile(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", expr, err)
+	}
+
+	r := &CompiledRegex{expr: expr, re: re, prog: prog, dfa: dfaSupported(prog)}
+	var lits []string
+	lits, r.exact = requiredLiterals(parsed)
+	for _, lit := range lits {
+		r.literals = append(r.literals, []byte(lit))
+	}
+	r.caches.New = func() any { return newRegexDFA(prog) }
+	return r, nil
+}
+
+// String returns the source text of the expression.
+func (r *CompiledRegex) String() string {
+	return r.expr
+}
+
+// Match reports whether b contains a match of the expression.
+func (r *CompiledRegex) Match(b []byte) bool {
+	if !r.prefilter(b) {
+		return false
+	}
+	if r.exact {
+		return true
+	}
+	if !r.dfa {
+		return r.re.Match(b)
+	}
+	d := r.caches.Get().(*regexDFA)
+	defer r.caches.Put(d)
+	return d.match(b)
+}
+
+// prefilter reports whether b contains one of the required literals.
+func (r *CompiledRegex) prefilter(b []byte) bool {
+	if r.literals == nil {
+		return true
+	}
+	for _, lit := range r.literals {
+		if strIndexImpl(currentKernels(), b, lit) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// CmpRegexString filters string values with a compiled regular expression.
+// Returns a slice of booleans where result[i] == true if values[i] contains a
+// match of re.
+func CmpRegexString(values []string, re *CompiledRegex) []bool {
+	results := make([]bool, len(values))
+	if len(values) == 0 {
+		return results
+	}
+
+	// One DFA cache serves the whole call, so its states are built once
+	var d *regexDFA
+	if re.dfa && !re.exact {
+		d = re.caches.Get().(*regexDFA)
+		defer re.caches.Put(d)
+	}
+	for i, v := range stringsToBytes(values) {
+		switch {
+		case !re.prefilter(v):
+		case re.exact:
+			results[i] = true
+		case d != nil:
+			results[i] = d.match(v)
+		default:
+			results[i] = re.re.Match(v)
+		}
+	}
+	return results
+}
+
+// CmpRegexStringMask filters string values with a compiled regular expression
+// and returns a bitmask.
+func CmpRegexStringMask(values []string, re *CompiledRegex) []uint64 {
+	bools := CmpRegexString(values, re)
+	return boolsToBitmask(bools)
+}
+
+// ============================================================================
+// Literal Extraction
+// ============================================================================
+
+// maxRequiredLiterals bounds the alternatives the prefilter searches for; more
+// substring scans per row would cost more than they save.
+const maxRequiredLiterals = 8
+
+// requiredLiterals returns literals such that every match of re contains at
+// least one of them, or nil if no useful set is known. exact reports that the
+// converse also holds: any string containing one of them matches.
+func requiredLiterals(re *syntax.Regexp) (lits []string, exact bool) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		// U+FFFD also matches any invalid UTF-8 byte, which its encoding does not
+		if re.Flags&syntax.FoldCase != 0 || slices.Contains(re.Rune, utf8.RuneError) {
+			return nil, false
+		}
+		return []string{string(re.Rune)}, true
+
+	case syntax.OpCapture:
+		return requiredLiterals(re.Sub[0])
+
+	case syntax.OpPlus:
+		lits, _ = requiredLiterals(re.Sub[0])
+		return lits, false
+
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return nil, false
+		}
+		lits, _ = requiredLiterals(re.Sub[0])
+		return lits, false
+
+	case syntax.OpAlternate:
+		exact = true
+		for _, sub := range re.Sub {
+			subLits, subExact := requiredLiterals(sub)
+			if subLits == nil {
+				return nil, false
+			}
+			lits = append(lits, subLits...)
+			exact = exact && subExact
+		}
+		if len(lits) > maxRequiredLiterals {
+			return nil, false
+		}
+		return lits, exact
+
+	case syntax.OpConcat:
+		// Any one factor's literals are required; keep the set whose shortest
+		// literal is longest, as it rejects the most rows
+		best := -1
+		for _, sub := range re.Sub {
+			subLits, _ := requiredLiterals(sub)
+			if subLits == nil {
+				continue
+			}
+			shortest := len(subLits[0])
+			for _, lit := range subLits {
+				shortest = min(shortest, len(lit))
+			}
+			if shortest > best {
+				lits, best = subLits, shortest
+			}
+		}
+		return lits, false
+	}
+	return nil, false
+}
+
+// ============================================================================
+// Lazy DFA
+// ============================================================================
+
+// maxDFAStates bounds the states one cache holds. Past it the cache starts over,
+// so pathological expressions cost time rather than memory.
+const maxDFAStates = 4096
+
+// dfaSupported reports whether the DFA can run prog. Of the empty-width
+// assertions it handles only \A and \z (^ and $ without the m flag); word
+// boundaries and line anchors fall back to package regexp.
+func dfaSupported(prog *syntax.Prog) bool {
+	for _, inst := range prog.Inst {
+		if inst.Op == syntax.InstEmptyWidth &&
+			syntax.EmptyOp(inst.Arg)&^(syntax.EmptyBeginText|syntax.EmptyEndText) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// dfaState is a set of program positions, each waiting to consume a rune or at
+// an end-of-text assertion. States are built on first use and then immutable.
+type dfaState struct {
+	pcs      []uint32
+	match    bool // the set reached a match; the row matches
+	endMatch bool // the row matches if the input ends here
+	ascii    [utf8.RuneSelf]*dfaState
+	runes    map[rune]*dfaState
+}
+
+// regexDFA is one cache of DFA states. It is not safe for concurrent use;
+// CompiledRegex hands each caller its own from a pool.
+type regexDFA struct {
+	prog   *syntax.Prog
+	start  *dfaState // state at the start of the input
+	states map[string]*dfaState
+
+	// Scratch for closures
+	seen  []bool
+	stack []uint32
+	set   []uint32
+	key   []byte
+}
+
+func newRegexDFA(prog *syntax.Prog) *regexDFA {
+	d := &regexDFA{prog: prog, seen: make([]bool, len(prog.Inst))}
+	d.reset()
+	return d
+}
+
+// reset drops every state and rebuilds the start state.
+func (d *regexDFA) reset() {
+	d.states = make(map[string]*dfaState)
+	clear(d.seen)
+	d.set = d.closure(d.set[:0], uint32(d.prog.Start), true)
+	d.start = d.intern(d.set, true)
+}
+
+// match reports whether b contains a match.
+func (d *regexDFA) match(b []byte) bool {
+	s := d.start
+	for i := 0; i < len(b); {
+		if s.match {
+			return true
+		}
+		if c := b[i]; c < utf8.RuneSelf {
+			next := s.ascii[c]
+			if next == nil {
+				next = d.step(s, rune(c))
+				s.ascii[c] = next
+			}
+			s = next
+			i++
+			continue
+		}
+		r, width := utf8.DecodeRune(b[i:])
+		next := s.runes[r]
+		if next == nil {
+			next = d.step(s, r)
+			if s.runes == nil {
+				s.runes = make(map[rune]*dfaState)
+			}
+			s.runes[r] = next
+		}
+		s = next
+		i += width
+	}
+	return s.match || s.endMatch
+}
+
+// step returns the state after s consumes r. The search is unanchored, so the
+// program's start is added back at every position.
+func (d *regexDFA) step(s *dfaState, r rune) *dfaState {
+	if len(d.states) >= maxDFAStates {
+		d.reset()
+	}
+	set := d.set[:0]
+	clear(d.seen)
+	for _, pc := range s.pcs {
+		inst := &d.prog.Inst[pc]
+		if consumes(inst, r) {
+			set = d.closure(set, inst.Out, false)
+		}
+	}
+	set = d.closure(set, uint32(d.prog.Start), false)
+	d.set = set
+	return d.intern(set, false)
+}
+
+// consumes reports whether inst consumes r.
+func consumes(inst *syntax.Inst, r rune) bool {
+	switch inst.Op {
+	case syntax.InstRune, syntax.InstRune1:
+		return inst.MatchRune(r)
+	case syntax.InstRuneAny:
+		return true
+	case syntax.InstRuneAnyNotNL:
+		return r != '\n'
+	}
+	return false
+}
+
+// closure appends to set the positions reachable from pc without consuming
+// input, at the start of the text if begin is set. Positions marked in d.seen
+// are skipped, so callers clear it before building a new set.
+func (d *regexDFA) closure(set []uint32, pc uint32, begin bool) []uint32 {
+	stack := append(d.stack[:0], pc)
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if d.seen[pc] {
+			continue
+		}
+		d.seen[pc] = true
+
+		inst := &d.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, inst.Arg, inst.Out)
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, inst.Out)
+		case syntax.InstEmptyWidth:
+			need := syntax.EmptyOp(inst.Arg)
+			if need&syntax.EmptyBeginText != 0 && !begin {
+				continue
+			}
+			if need&syntax.EmptyEndText != 0 {
+				// Resolved by endMatch once the input ends
+				set = append(set, pc)
+				continue
+			}
+			stack = append(stack, inst.Out)
+		case syntax.InstFail:
+		default:
+			// Rune instructions and InstMatch
+			set = append(set, pc)
+		}
+	}
+	d.stack = stack
+	return set
+}
+
+// intern returns the state for set, building it if needed. The start state is
+// kept apart because \A holds only there.
+func (d *regexDFA) intern(set []uint32, begin bool) *dfaState {
+	slices.Sort(set)
+	key := d.key[:0]
+	for _, pc := range set {
+		key = append(key, byte(pc), byte(pc>>8), byte(pc>>16), byte(pc>>24))
+	}
+	d.key = key
+	if !begin {
+		if s, ok := d.states[unsafe.String(unsafe.SliceData(key), len(key))]; ok {
+			return s
+		}
+	}
+
+	s := &dfaState{pcs: slices.Clone(set)}
+	for _, pc := range s.pcs {
+		inst := &d.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstMatch:
+			s.match = true
+		case syntax.InstEmptyWidth:
+			// closure only keeps assertions waiting for the end of the text
+			s.endMatch = s.endMatch || d.matchesEmpty(inst.Out, begin)
+		}
+	}
+	if !begin {
+		d.states[string(key)] = s
+	}
+	return s
+}
+
+// matchesEmpty reports whether the program reaches a match from pc at the end
+// of the text without consuming input.
+func (d *regexDFA) matchesEmpty(pc uint32, begin bool) bool {
+	stack := []uint32{pc}
+	seen := make(map[uint32]bool)
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[pc] {
+			continue
+		}
+		seen[pc] = true
+
+		inst := &d.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstMatch:
+			return true
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, inst.Arg, inst.Out)
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, inst.Out)
+		case syntax.InstEmptyWidth:
+			if syntax.EmptyOp(inst.Arg)&syntax.EmptyBeginText == 0 || begin {
+				stack = append(stack, inst.Out)
+			}
+		}
+	}
+	return false
+}
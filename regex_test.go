@@ -0,0 +1,254 @@
+package syndrdbsimd
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Regex Tests
+// ============================================================================
+
+func TestCmpRegexString(t *testing.T) {
+	values := []string{
+		"connection refused", "read timeout after 30s", "ok", "", "Timeout",
+		"error 404: not found", "error: disk full", "naïve café", "line\nbreak",
+	}
+	tests := []struct {
+		expr     string
+		expected []bool
+	}{
+		{"timeout|refused", []bool{true, true, false, false, false, false, false, false, false}},
+		{"(?i)timeout", []bool{false, true, false, false, true, false, false, false, false}},
+		{`error \d+`, []bool{false, false, false, false, false, true, false, false, false}},
+		{"^error", []bool{false, false, false, false, false, true, true, false, false}},
+		{"full$", []bool{false, false, false, false, false, false, true, false, false}},
+		{"^$", []bool{false, false, false, true, false, false, false, false, false}},
+		{"^ok$|^$", []bool{false, false, true, true, false, false, false, false, false}},
+		{"caf.$", []bool{false, false, false, false, false, false, false, true, false}},
+		{"ï", []bool{false, false, false, false, false, false, false, true, false}},
+		{"line.break", []bool{false, false, false, false, false, false, false, false, false}},
+		{"(?s)line.break", []bool{false, false, false, false, false, false, false, false, true}},
+		{`\btime`, []bool{false, true, false, false, false, false, false, false, false}},
+		{"", []bool{true, true, true, true, true, true, true, true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			re, err := CompileRegex(tt.expr)
+			if err != nil {
+				t.Fatalf("CompileRegex failed: %v", err)
+			}
+			checkEqual(t, len(values), "CmpRegexString", CmpRegexString(values, re), tt.expected)
+			checkEqual(t, len(values), "CmpRegexStringMask", CmpRegexStringMask(values, re), boolsToBitmask(tt.expected))
+			for i, v := range values {
+				if got := re.Match([]byte(v)); got != tt.expected[i] {
+					t.Errorf("Match(%q) = %v, want %v", v, got, tt.expected[i])
+				}
+			}
+		})
+	}
+
+	if _, err := CompileRegex("a(b"); err == nil {
+		t.Error("CompileRegex(\"a(b\") did not fail")
+	}
+	re, _ := CompileRegex("x")
+	if got := CmpRegexString(nil, re); len(got) != 0 {
+		t.Errorf("CmpRegexString(nil) = %v, want empty", got)
+	}
+}
+
+func TestCompileRegex_Literals(t *testing.T) {
+	tests := []struct {
+		expr     string
+		literals []string
+		exact    bool
+	}{
+		{"timeout", []string{"timeout"}, true},
+		{"timeout|refused", []string{"timeout", "refused"}, true},
+		{"(timeout|refused)", []string{"timeout", "refused"}, true},
+		{`upstream error \d+: (timeout|refused)`, []string{"upstream error "}, false},
+		{`\d+ (timeout|refused)`, []string{"timeout", "refused"}, false},
+		{"^timeout", []string{"timeout"}, false},
+		{"(ab)+c", []string{"ab"}, false},
+		{"timeout|.*", nil, false},
+		{"(?i)timeout", nil, false},
+		{"x?", nil, false},
+		{`\w+`, nil, false},
+		{"a|b|c|d|e|f|g|h|i", nil, false},
+		{"\uFFFD", nil, false},
+		{"x\uFFFD|y", nil, false},
+	}
+	for _, tt := range tests {
+		re, err := CompileRegex(tt.expr)
+		if err != nil {
+			t.Fatalf("CompileRegex(%q) failed: %v", tt.expr, err)
+		}
+		var got []string
+		for _, lit := range re.literals {
+			got = append(got, string(lit))
+		}
+		if !slices.Equal(got, tt.literals) || re.exact != tt.exact {
+			t.Errorf("CompileRegex(%q): literals %q, exact %v, want %q, %v", tt.expr, got, re.exact, tt.literals, tt.exact)
+		}
+	}
+}
+
+// U+FFFD matches any invalid UTF-8 byte in package regexp, which its encoding
+// EF BF BD as a literal would not find
+func TestCompiledRegex_ReplacementChar(t *testing.T) {
+	re, err := CompileRegex("\uFFFD")
+	if err != nil {
+		t.Fatalf("CompileRegex failed: %v", err)
+	}
+	for _, v := range []string{"\xff", "a\x80b", "\uFFFD"} {
+		if !re.Match([]byte(v)) {
+			t.Errorf("Match(%q) = false, want true", v)
+		}
+	}
+	if re.Match([]byte("abc")) {
+		t.Error("Match(\"abc\") = true, want false")
+	}
+}
+
+// randomRegex returns an expression over a and b, built from the operators the
+// DFA and the literal extraction handle differently.
+func randomRegex(rng *rand.Rand, depth int) string {
+	if depth == 0 || rng.Intn(3) == 0 {
+		return []string{"a", "b", "ab", "ba", "aab", ".", "[ab]", "[^a]", "é", "\uFFFD", "^", "$", `\b`}[rng.Intn(13)]
+	}
+	x := randomRegex(rng, depth-1)
+	switch rng.Intn(8) {
+	case 0:
+		return "(?:" + x + ")*"
+	case 1:
+		return "(?:" + x + ")+"
+	case 2:
+		return "(?:" + x + ")?"
+	case 3:
+		return fmt.Sprintf("(?:%s){%d,%d}", x, rng.Intn(2), 2+rng.Intn(2))
+	case 4:
+		return "(" + x + "|" + randomRegex(rng, depth-1) + ")"
+	case 5:
+		return "(?i:" + x + ")"
+	default:
+		return x + randomRegex(rng, depth-1)
+	}
+}
+
+// Every ISA must agree with package regexp, whichever of the prefilter, the DFA
+// and the fallback decides the row
+func TestCompiledRegex_MatchRegexp(t *testing.T) {
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(450))
+				values := make([]string, 200)
+				for i := range values {
+					var sb strings.Builder
+					for j := rng.Intn(48); j > 0; j-- {
+						sb.WriteString([]string{"a", "b", "A", "é", "\n", "\xff", "\uFFFD"}[rng.Intn(7)])
+					}
+					values[i] = sb.String()
+				}
+
+				for trial := 0; trial < 300; trial++ {
+					expr := randomRegex(rng, 4)
+					re, err := CompileRegex(expr)
+					if err != nil {
+						t.Fatalf("CompileRegex(%q) failed: %v", expr, err)
+					}
+					want := regexp.MustCompile(expr)
+					got := CmpRegexString(values, re)
+					for i, v := range values {
+						if got[i] != want.MatchString(v) {
+							t.Errorf("CmpRegexString(%q, %q) = %v, want %v", v, expr, got[i], !got[i])
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
+// A DFA cache that overflows starts over; the answers must not change
+func TestCompiledRegex_StateLimit(t *testing.T) {
+	// Finding a[ab]{12}$ tracks which of the last 13 bytes were a, in 2^13 states
+	expr := "a[ab]{12}$"
+	re, err := CompileRegex(expr)
+	if err != nil {
+		t.Fatalf("CompileRegex failed: %v", err)
+	}
+	rng := rand.New(rand.NewSource(451))
+	values := make([]string, 64)
+	for i := range values {
+		b := make([]byte, 2000)
+		for j := range b {
+			b[j] = "ab"[rng.Intn(2)]
+		}
+		values[i] = string(b)
+	}
+	want := regexp.MustCompile(expr)
+	got := CmpRegexString(values, re)
+	for i, v := range values {
+		if got[i] != want.MatchString(v) {
+			t.Errorf("row %d: got %v, want %v", i, got[i], !got[i])
+		}
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+// logLines returns n log messages, one in a hundred of them an error.
+func logLines(n int) []string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = fmt.Sprintf("2026-10-18 12:%02d:%02d INFO request %d served in %dms", i%60, i%57, i, i%300)
+		if i%100 == 0 {
+			values[i] = fmt.Sprintf("2026-10-18 12:%02d:%02d ERROR upstream %d: connection refused", i%60, i%57, i)
+		}
+	}
+	return values
+}
+
+func BenchmarkCmpRegexString(b *testing.B) {
+	values := logLines(65536)
+	re, _ := CompileRegex(`ERROR upstream \d+: (timeout|connection refused)`)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CmpRegexString(values, re)
+	}
+}
+
+// The DFA without a prefilter: every row has a literal the expression needs
+func BenchmarkCmpRegexStringDFA(b *testing.B) {
+	values := logLines(65536)
+	re, _ := CompileRegex(`served in \d\d+ms$`)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CmpRegexString(values, re)
+	}
+}
+
+func BenchmarkRegexpMatchString(b *testing.B) {
+	values := logLines(65536)
+	re := regexp.MustCompile(`ERROR upstream \d+: (timeout|connection refused)`)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range values {
+			re.MatchString(v)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package syndrdbsimd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// CompileSimilarTo compiles a SQL SIMILAR TO pattern, with backslash as the
+// escape character as in PostgreSQL.
+func CompileSimilarTo(pattern string) (*CompiledPattern, error) {
+	return CompileSimilarToEscape(pattern, '\\')
+}
+
+// CompileSimilarToEscape compiles a SQL SIMILAR TO pattern with the given ESCAPE
+// character. The result is used with CmpLikeStringCompiled and
+// CmpNotLikeStringCompiled like any compiled LIKE pattern.
+//
+// SIMILAR TO combines the LIKE wildcards % and _ with the regular expression
+// operators | * + ? {m,n}, grouping with ( ) and bracket expressions [...], and
+// must match the whole string. The escape character makes the next character,
+// however many bytes encode it, a literal.
+//
+// A pattern made only of literals and % is classified and routed exactly like
+// the equivalent LIKE pattern, so the SIMD equality, prefix, suffix, substring
+// and segment matchers apply. Any other pattern compiles to a regular
+// expression (PatternRegex), in which _ matches one UTF-8 character.
+func CompileSimilarToEscape(pattern string, escape byte) (*CompiledPattern, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("pattern is empty")
+	}
+
+	if elems, ok := similarToLikeElems(pattern, escape); ok {
+		if compiled, err := compileLikeElems(detectLikeElems(elems), pattern, elems); err == nil {
+			return compiled, nil
+		}
+		// Only % wildcards, such as "%", have no literal part for the LIKE routes
+	}
+
+	expr, err := translateSimilarTo(pattern, escape)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SIMILAR TO pattern %q: %v", pattern, err)
+	}
+
+	return &CompiledPattern{
+		Type:            PatternRegex,
+		OriginalPattern: pattern,
+		HasWildcard:     true,
+		regex:           re,
+	}, nil
+}
+
+// similarMeta holds the SIMILAR TO operators that only a regular expression can
+// match, and _, whose one-character meaning differs from the one byte of LIKE.
+const similarMeta = "_|*+?{}()[]"
+
+// similarToLikeElems parses a SIMILAR TO pattern as a LIKE pattern. ok is false
+// if the pattern uses any operator in similarMeta or ends with the escape
+// character.
+func similarToLikeElems(pattern string, escape byte) (elems []likeElem, ok bool) {
+	elems = make([]likeElem, 0, len(pattern))
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == escape:
+			if i+1 == len(pattern) {
+				return nil, false
+			}
+			// The whole escaped character is literal, not just its first byte
+			_, size := utf8.DecodeRuneInString(pattern[i+1:])
+			for j := i + 1; j <= i+size; j++ {
+				elems = append(elems, likeElem{b: pattern[j], pos: j})
+			}
+			i += size
+		case strings.IndexByte(similarMeta, c) >= 0:
+			return nil, false
+		default:
+			elems = append(elems, likeElem{b: c, wildcard: c == '%', pos: i})
+		}
+	}
+	return elems, true
+}
+
+// posixClassAt returns the POSIX character class, such as [:alpha:] or
+// [:^digit:], that s starts with, or "" if there is none. Unknown class names
+// are returned too; regexp.Compile rejects them.
+func posixClassAt(s string) string {
+	if !strings.HasPrefix(s, "[:") {
+		return ""
+	}
+	name := strings.TrimPrefix(s[2:], "^")
+	n := 0
+	for n < len(name) && 'a' <= name[n] && name[n] <= 'z' {
+		n++
+	}
+	if n == 0 || !strings.HasPrefix(name[n:], ":]") {
+		return ""
+	}
+	return s[:len(s)-len(name)+n+2]
+}
+
+// translateSimilarTo rewrites a SIMILAR TO pattern as an anchored RE2 regular
+// expression. Characters that are literal in SIMILAR TO but special in RE2,
+// such as . and ^, are quoted.
+func translateSimilarTo(pattern string, escape byte) (string, error) {
+	var b strings.Builder
+	b.WriteString(`^(?:`)
+	inBracket := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c == escape {
+			if i+1 == len(pattern) {
+				return "", fmt.Errorf("pattern ends with escape character: %q", pattern)
+			}
+			_, size := utf8.DecodeRuneInString(pattern[i+1:])
+			b.WriteString(regexp.QuoteMeta(pattern[i+1 : i+1+size]))
+			i += size
+			continue
+		}
+
+		if inBracket {
+			switch c {
+			case ']':
+				inBracket = false
+				b.WriteByte(c)
+			case '[':
+				// POSIX classes such as [:alpha:] mean the same in RE2
+				if class := posixClassAt(pattern[i:]); class != "" {
+					b.WriteString(class)
+					i += len(class) - 1
+				} else {
+					b.WriteString(`\[`)
+				}
+			case '\\':
+				b.WriteString(`\\`)
+			default:
+				b.WriteByte(c)
+			}
+			continue
+		}
+
+		switch c {
+		case '%':
+			b.WriteString(`(?s:.*)`)
+		case '_':
+			b.WriteString(`(?s:.)`)
+		case '(':
+			b.WriteString(`(?:`)
+		case ')', '|', '*', '+', '?', '{', '}':
+			b.WriteByte(c)
+		case '[':
+			inBracket = true
+			b.WriteByte(c)
+			if i+1 < len(pattern) && pattern[i+1] == '^' {
+				b.WriteByte('^')
+				i++
+			}
+			// A ] right after the opening bracket is a member, not the end
+			if i+1 < len(pattern) && pattern[i+1] == ']' {
+				b.WriteString(`\]`)
+				i++
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	if inBracket {
+		return "", fmt.Errorf("unterminated bracket expression in pattern %q", pattern)
+	}
+	b.WriteString(`)$`)
+	return b.String(), nil
+}
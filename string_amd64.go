@@ -15,13 +15,58 @@ func strEqAVX2(a, b *byte, length int) int
 func strPrefixCmpAVX2(str, prefix *byte, strLen, prefixLen int) int
 
 // strToLowerAVX2 converts ASCII string to lowercase using AVX2.
-// Modifies the string in-place.
+// Modifies the string in-place. Requires length >= 32.
 //
 //go:noescape
 func strToLowerAVX2(s *byte, length int)
 
 // strToUpperAVX2 converts ASCII string to uppercase using AVX2.
-// Modifies the string in-place.
+// Modifies the string in-place. Requires length >= 32.
 //
 //go:noescape
 func strToUpperAVX2(s *byte, length int)
+
+// strEqFoldAVX2 compares two strings for equality ignoring ASCII case using AVX2.
+// Returns 1 if equal, 0 if not equal. Requires length >= 32.
+//
+//go:noescape
+func strEqFoldAVX2(a, b *byte, length int) int
+
+// strIndexAVX2 finds the first occurrence of sub in s using AVX2.
+// Returns its index, or -1 if sub is not present.
+// Requires m >= 2 and n-m+1 >= 32.
+//
+//go:noescape
+func strIndexAVX2(s *byte, n int, sub *byte, m int) int
+
+// teddyAVX2 finds the first start position >= from where a Teddy fingerprint
+// matches, using AVX2. Returns the position, or -1 if there is none.
+// Requires 1 <= m <= 3, n-m+1 >= 32 and from <= n-m.
+//
+//go:noescape
+func teddyAVX2(s *byte, n int, masks *[3][2][16]byte, m int, from int) int
+
+// strDiffAVX2 finds the first byte where a and b differ using AVX2.
+// Returns its index, or n if the first n bytes are equal. Requires n >= 32.
+//
+//go:noescape
+func strDiffAVX2(a, b *byte, n int) int
+
+// asciiPrefixAVX2 finds the first non-ASCII byte using AVX2.
+// Returns its index, or n if all n bytes are ASCII. Requires n >= 32.
+//
+//go:noescape
+func asciiPrefixAVX2(s *byte, n int) int
+
+// utf8ValidAVX2 validates UTF-8 using AVX2 lookup tables. Returns 1 if s[:n]
+// holds no invalid sequence, except one cut off at the end, and 0 otherwise.
+// Requires n to be a multiple of 32.
+//
+//go:noescape
+func utf8ValidAVX2(s *byte, n int) int
+
+// charLengthAVX2 counts the bytes that are not UTF-8 continuation bytes using
+// AVX2. Requires n to be a multiple of 32.
+//
+//go:noescape
+func charLengthAVX2(s *byte, n int) int
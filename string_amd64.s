@@ -138,125 +138,572 @@ no_match:
 	VZEROUPPER
 	RET
 
+// ASCII case folding. A byte c is in the letter range [lo, lo+25] iff the
+// wrapping difference c-lo is at most 25 as an unsigned byte, which VPMINUB can
+// test without the signed compares of VPCMPGTB. Flipping bit 5 (0x20) of the
+// letters in range changes their case.
+//
+// CASE_SETUP(lo) loads Y6 = lo, Y7 = 25 and Y8 = 0x20 in every byte.
+// CASE_FLIP(x, t1, t2) flips the case of the bytes of x in [lo, lo+25].
+#define CASE_SETUP(lo) \
+	MOVL $(lo*0x01010101), AX \
+	VMOVD AX, X6 \
+	VPBROADCASTD X6, Y6 \
+	MOVL $0x19191919, AX \
+	VMOVD AX, X7 \
+	VPBROADCASTD X7, Y7 \
+	MOVL $0x20202020, AX \
+	VMOVD AX, X8 \
+	VPBROADCASTD X8, Y8
+
+#define CASE_FLIP(x, t1, t2) \
+	VPSUBB Y6, x, t1 \
+	VPMINUB Y7, t1, t2 \
+	VPCMPEQB t1, t2, t1 \
+	VPAND Y8, t1, t1 \
+	VPXOR t1, x, x
+
 // func strToLowerAVX2(s *byte, length int)
 // Converts ASCII string to lowercase using AVX2
+// Requires length >= 32; the last block overlaps the previous one, which is
+// harmless because lowering is idempotent.
 TEXT ·strToLowerAVX2(SB), NOSPLIT, $0-16
 	MOVQ s+0(FP), SI         // SI = &s[0]
-	MOVQ length+8(FP), CX    // CX = length
-
-	// Prepare constants
-	VPCMPEQB Y5, Y5, Y5      // Y5 = all 1s
-	VPSRLW $8, Y5, Y5
-	VPSLLDQ $1, Y5, Y5       // Y5 = 0xFF00FF00... (mask for odd bytes)
-	
-	// ASCII 'A' = 65, 'Z' = 90
-	// To convert to lowercase: add 32 if in range [A-Z]
-	MOVQ $65, AX
-	VPBROADCASTB AX, Y6      // Y6 = 'A' repeated
-	MOVQ $90, AX
-	VPBROADCASTB AX, Y7      // Y7 = 'Z' repeated
-	MOVQ $32, AX
-	VPBROADCASTB AX, Y8      // Y8 = 32 repeated
+	MOVQ length+8(FP), DX    // DX = length
+	CASE_SETUP(65)           // 'A'
+	LEAQ -32(SI)(DX*1), DX   // DX = start of the last block
 
 loop_lower_32:
-	CMPQ CX, $32
-	JL remainder_lower
-	
-	// Load 32 bytes
 	VMOVDQU (SI), Y0
-	
-	// Check if >= 'A' and <= 'Z'
-	VPCMPGTB Y6, Y0, Y1      // Y1 = (char > 'A' - 1) ? 0xFF : 0
-	VPCMPGTB Y0, Y7, Y2      // Y2 = (char < 'Z' + 1) ? 0xFF : 0
-	VPANDN Y1, Y2, Y3        // Y3 = in range [A-Z]
-	VPAND Y8, Y3, Y4         // Y4 = 32 where uppercase, 0 elsewhere
-	VPADDB Y4, Y0, Y0        // Add 32 to uppercase chars
-	
-	// Store result
+	CASE_FLIP(Y0, Y1, Y2)
 	VMOVDQU Y0, (SI)
-	
+	CMPQ SI, DX
+	JEQ done_lower
 	ADDQ $32, SI
-	SUBQ $32, CX
+	CMPQ SI, DX
+	JLS loop_lower_32
+	MOVQ DX, SI              // Final, overlapping block
 	JMP loop_lower_32
 
-remainder_lower:
-	TESTQ CX, CX
-	JZ done_lower
-	
-	// Handle remainder byte-by-byte
-scalar_lower:
-	MOVB (SI), AL
-	CMPB AL, $65
-	JL skip_lower
-	CMPB AL, $90
-	JG skip_lower
-	ADDB $32, AL
-	MOVB AL, (SI)
-
-skip_lower:
-	INCQ SI
-	DECQ CX
-	JNZ scalar_lower
-
 done_lower:
 	VZEROUPPER
 	RET
 
 // func strToUpperAVX2(s *byte, length int)
 // Converts ASCII string to uppercase using AVX2
+// Requires length >= 32, like strToLowerAVX2.
 TEXT ·strToUpperAVX2(SB), NOSPLIT, $0-16
 	MOVQ s+0(FP), SI         // SI = &s[0]
-	MOVQ length+8(FP), CX    // CX = length
-
-	// ASCII 'a' = 97, 'z' = 122
-	// To convert to uppercase: subtract 32 if in range [a-z]
-	MOVQ $97, AX
-	VPBROADCASTB AX, Y6      // Y6 = 'a' repeated
-	MOVQ $122, AX
-	VPBROADCASTB AX, Y7      // Y7 = 'z' repeated
-	MOVQ $32, AX
-	VPBROADCASTB AX, Y8      // Y8 = 32 repeated
+	MOVQ length+8(FP), DX    // DX = length
+	CASE_SETUP(97)           // 'a'
+	LEAQ -32(SI)(DX*1), DX   // DX = start of the last block
 
 loop_upper_32:
-	CMPQ CX, $32
-	JL remainder_upper
-	
-	// Load 32 bytes
 	VMOVDQU (SI), Y0
-	
-	// Check if >= 'a' and <= 'z'
-	VPCMPGTB Y6, Y0, Y1      // Y1 = (char > 'a' - 1) ? 0xFF : 0
-	VPCMPGTB Y0, Y7, Y2      // Y2 = (char < 'z' + 1) ? 0xFF : 0
-	VPANDN Y1, Y2, Y3        // Y3 = in range [a-z]
-	VPAND Y8, Y3, Y4         // Y4 = 32 where lowercase, 0 elsewhere
-	VPSUBB Y4, Y0, Y0        // Subtract 32 from lowercase chars
-	
-	// Store result
+	CASE_FLIP(Y0, Y1, Y2)
 	VMOVDQU Y0, (SI)
-	
+	CMPQ SI, DX
+	JEQ done_upper
 	ADDQ $32, SI
-	SUBQ $32, CX
+	CMPQ SI, DX
+	JLS loop_upper_32
+	MOVQ DX, SI              // Final, overlapping block
 	JMP loop_upper_32
 
-remainder_upper:
+done_upper:
+	VZEROUPPER
+	RET
+
+// func strEqFoldAVX2(a, b *byte, length int) int
+// Compares two strings for equality ignoring ASCII case using AVX2.
+// Returns 1 if equal, 0 if not equal. Requires length >= 32.
+//
+// Both inputs are lowered with CASE_FLIP before the compare, so only ASCII
+// letters fold; other bytes must match exactly.
+TEXT ·strEqFoldAVX2(SB), NOSPLIT, $0-32
+	MOVQ a+0(FP), SI         // SI = &a[0]
+	MOVQ b+8(FP), DI         // DI = &b[0]
+	MOVQ length+16(FP), DX   // DX = length
+	CASE_SETUP(65)           // 'A'
+	SUBQ $32, DX             // DX = start of the last block
+	XORQ CX, CX              // CX = offset
+
+loop_fold_32:
+	VMOVDQU (SI)(CX*1), Y0
+	VMOVDQU (DI)(CX*1), Y3
+	CASE_FLIP(Y0, Y1, Y2)
+	CASE_FLIP(Y3, Y4, Y5)
+	VPCMPEQB Y0, Y3, Y0
+	VPMOVMSKB Y0, BX
+	CMPL BX, $0xFFFFFFFF
+	JNE fold_not_equal
+	CMPQ CX, DX
+	JEQ fold_equal
+	ADDQ $32, CX
+	CMPQ CX, DX
+	JLE loop_fold_32
+	MOVQ DX, CX              // Final, overlapping block
+	JMP loop_fold_32
+
+fold_equal:
+	MOVQ $1, ret+24(FP)
+	VZEROUPPER
+	RET
+
+fold_not_equal:
+	MOVQ $0, ret+24(FP)
+	VZEROUPPER
+	RET
+
+// func strIndexAVX2(s *byte, n int, sub *byte, m int) int
+// Returns the index of the first occurrence of sub in s, or -1.
+// Requires 2 <= m and n-m+1 >= 32, so at least one full block of start positions.
+//
+// For each block of 32 start positions i, compares s[i] with the first byte of
+// sub and s[i+m-1] with the last byte, and verifies the middle bytes only where
+// both match. The final block overlaps the previous one; positions already
+// checked have no match, so the first match found is still the first overall.
+TEXT ·strIndexAVX2(SB), NOSPLIT, $0-40
+	MOVQ s+0(FP), SI          // SI = &s[0]
+	MOVQ n+8(FP), R10         // R10 = n
+	MOVQ sub+16(FP), DI       // DI = &sub[0]
+	MOVQ m+24(FP), R9         // R9 = m
+
+	// Broadcast the first and last bytes of sub
+	VPBROADCASTB (DI), Y0     // Y0 = first byte
+	DECQ R9                   // R9 = m-1, distance from first to last byte
+	VPBROADCASTB (DI)(R9*1), Y1 // Y1 = last byte
+
+	SUBQ R9, R10
+	SUBQ $32, R10             // R10 = n-m-31, start of the last block
+	XORQ CX, CX               // CX = i
+
+index_block:
+	LEAQ (SI)(CX*1), R11      // R11 = &s[i]
+	VPCMPEQB (R11), Y0, Y2
+	VPCMPEQB (R11)(R9*1), Y1, Y3
+	VPAND Y2, Y3, Y2
+	VPMOVMSKB Y2, DX          // DX = candidate start positions
+	TESTL DX, DX
+	JZ index_next
+
+index_candidate:
+	BSFL DX, R13              // R13 = candidate offset in block
+	LEAQ (R11)(R13*1), R12    // R12 = &s[i+offset]
+	MOVQ $1, BX               // BX = byte index k in sub
+
+	// Verify sub[1:m-1], 8 bytes at a time while they fit
+index_verify8:
+	LEAQ 8(BX), AX
+	CMPQ AX, R9
+	JG index_verify1
+	MOVQ (R12)(BX*1), AX
+	CMPQ AX, (DI)(BX*1)
+	JNE index_mismatch
+	ADDQ $8, BX
+	JMP index_verify8
+
+index_verify1:
+	CMPQ BX, R9
+	JGE index_found
+	MOVBLZX (R12)(BX*1), AX
+	CMPB AL, (DI)(BX*1)
+	JNE index_mismatch
+	INCQ BX
+	JMP index_verify1
+
+index_found:
+	ADDQ CX, R13
+	MOVQ R13, ret+32(FP)
+	VZEROUPPER
+	RET
+
+index_mismatch:
+	// Clear the lowest candidate bit
+	LEAL -1(DX), AX
+	ANDL AX, DX
+	JNZ index_candidate
+
+index_next:
+	CMPQ CX, R10
+	JEQ index_not_found
+	ADDQ $32, CX
+	CMPQ CX, R10
+	JLE index_block
+	MOVQ R10, CX              // Final, overlapping block
+	JMP index_block
+
+index_not_found:
+	MOVQ $-1, ret+32(FP)
+	VZEROUPPER
+	RET
+
+// func teddyAVX2(s *byte, n int, masks *[3][2][16]byte, m int, from int) int
+// Returns the first start position p >= from at which the Teddy fingerprint of
+// some bucket matches, or -1. Requires 1 <= m <= 3, n-m+1 >= 32 and from <= n-m.
+//
+// masks[k] maps the low and high nibble of byte k of a pattern to the buckets
+// holding it. VPSHUFB looks up both nibbles of 32 bytes at once, and the AND
+// over the m fingerprint bytes, loaded at offsets 0 to m-1, leaves the buckets
+// that may match at each start position. The final block overlaps the previous
+// one; positions before from are cleared from its candidates.
+TEXT ·teddyAVX2(SB), NOSPLIT, $0-48
+	MOVQ s+0(FP), SI          // SI = &s[0]
+	MOVQ n+8(FP), R10         // R10 = n
+	MOVQ masks+16(FP), DI     // DI = &masks
+	MOVQ m+24(FP), R9         // R9 = m
+	MOVQ from+32(FP), R8      // R8 = from
+
+	// Copy each 16-byte nibble table to both lanes
+	VBROADCASTI128 0(DI), Y8  // Y8/Y9 = byte 0 low/high nibble tables
+	VBROADCASTI128 16(DI), Y9
+	VBROADCASTI128 32(DI), Y10 // Y10/Y11 = byte 1
+	VBROADCASTI128 48(DI), Y11
+	VBROADCASTI128 64(DI), Y12 // Y12/Y13 = byte 2
+	VBROADCASTI128 80(DI), Y13
+	VPXOR Y14, Y14, Y14       // Y14 = 0
+	MOVL $0x0F0F0F0F, AX
+	VMOVD AX, X15
+	VPBROADCASTD X15, Y15     // Y15 = nibble mask
+
+	SUBQ R9, R10
+	SUBQ $31, R10             // R10 = n-m-31, start of the last block
+	MOVQ R8, BX               // BX = i
+	CMPQ BX, R10
+	JLE teddy_block
+	MOVQ R10, BX
+
+teddy_block:
+	LEAQ (SI)(BX*1), R11      // R11 = &s[i]
+	VMOVDQU (R11), Y0
+	VPSRLW $4, Y0, Y1
+	VPAND Y15, Y0, Y0
+	VPAND Y15, Y1, Y1
+	VPSHUFB Y0, Y8, Y2
+	VPSHUFB Y1, Y9, Y3
+	VPAND Y3, Y2, Y2          // Y2 = buckets matching byte 0
+	CMPQ R9, $2
+	JLT teddy_mask
+
+	VMOVDQU 1(R11), Y0
+	VPSRLW $4, Y0, Y1
+	VPAND Y15, Y0, Y0
+	VPAND Y15, Y1, Y1
+	VPSHUFB Y0, Y10, Y3
+	VPSHUFB Y1, Y11, Y4
+	VPAND Y4, Y3, Y3
+	VPAND Y3, Y2, Y2          // Y2 &= buckets matching byte 1
+	CMPQ R9, $3
+	JLT teddy_mask
+
+	VMOVDQU 2(R11), Y0
+	VPSRLW $4, Y0, Y1
+	VPAND Y15, Y0, Y0
+	VPAND Y15, Y1, Y1
+	VPSHUFB Y0, Y12, Y3
+	VPSHUFB Y1, Y13, Y4
+	VPAND Y4, Y3, Y3
+	VPAND Y3, Y2, Y2          // Y2 &= buckets matching byte 2
+
+teddy_mask:
+	VPCMPEQB Y14, Y2, Y2
+	VPMOVMSKB Y2, DX
+	NOTL DX                   // DX = candidate start positions
+
+	// Clear the positions before from
+	MOVQ R8, CX
+	SUBQ BX, CX
+	JLE teddy_test
+	SHRL CL, DX
+	SHLL CL, DX
+
+teddy_test:
+	TESTL DX, DX
+	JZ teddy_next
+	BSFL DX, DX
+	ADDQ BX, DX
+	MOVQ DX, ret+40(FP)
+	VZEROUPPER
+	RET
+
+teddy_next:
+	CMPQ BX, R10
+	JGE teddy_not_found
+	ADDQ $32, BX
+	CMPQ BX, R10
+	JLE teddy_block
+	MOVQ R10, BX              // Final, overlapping block
+	JMP teddy_block
+
+teddy_not_found:
+	MOVQ $-1, ret+40(FP)
+	VZEROUPPER
+	RET
+
+// func strDiffAVX2(a, b *byte, n int) int
+// Returns the index of the first byte where a[:n] and b[:n] differ, or n if they
+// are equal. Requires n >= 32.
+//
+// Compares 64 bytes per iteration, then 32-byte blocks. The final block overlaps
+// the previous one; the bytes compared twice are equal, so the first difference
+// found is still the first overall.
+TEXT ·strDiffAVX2(SB), NOSPLIT, $0-32
+	MOVQ a+0(FP), SI          // SI = &a[0]
+	MOVQ b+8(FP), DI          // DI = &b[0]
+	MOVQ n+16(FP), R11        // R11 = n
+	LEAQ -32(R11), R10        // R10 = n-32, start of the last block
+	XORQ CX, CX               // CX = i
+
+diff_loop64:
+	LEAQ 64(CX), AX
+	CMPQ AX, R11
+	JG diff_tail
+	VMOVDQU (SI)(CX*1), Y0
+	VMOVDQU 32(SI)(CX*1), Y1
+	VPCMPEQB (DI)(CX*1), Y0, Y0
+	VPCMPEQB 32(DI)(CX*1), Y1, Y1
+	VPAND Y0, Y1, Y2
+	VPMOVMSKB Y2, DX
+	INCL DX                   // Zero only if all 32 bits were set
+	JNZ diff_found64
+	MOVQ AX, CX
+	JMP diff_loop64
+
+diff_found64:
+	VPMOVMSKB Y0, DX
+	NOTL DX
+	TESTL DX, DX
+	JNZ diff_found
+	VPMOVMSKB Y1, DX
+	NOTL DX
+	ADDQ $32, CX
+	JMP diff_found
+
+diff_tail:
+	CMPQ CX, R10
+	JLE diff_block
+	MOVQ R10, CX              // Final, overlapping block
+
+diff_block:
+	VMOVDQU (SI)(CX*1), Y0
+	VPCMPEQB (DI)(CX*1), Y0, Y0
+	VPMOVMSKB Y0, DX
+	NOTL DX                   // DX = differing bytes
+	TESTL DX, DX
+	JNZ diff_found
+	CMPQ CX, R10
+	JEQ diff_equal
+	ADDQ $32, CX
+	JMP diff_tail
+
+diff_found:
+	BSFL DX, DX
+	ADDQ CX, DX
+	MOVQ DX, ret+24(FP)
+	VZEROUPPER
+	RET
+
+diff_equal:
+	MOVQ R11, ret+24(FP)
+	VZEROUPPER
+	RET
+
+// func asciiPrefixAVX2(s *byte, n int) int
+// Returns the index of the first byte of s[:n] with the high bit set, or n if
+// all are ASCII. Requires n >= 32.
+//
+// VPMOVMSKB gathers the high bit of each byte, so no compare is needed. Tests
+// 64 bytes per iteration, then 32-byte blocks with an overlapping final block
+// as in strDiffAVX2.
+TEXT ·asciiPrefixAVX2(SB), NOSPLIT, $0-24
+	MOVQ s+0(FP), SI          // SI = &s[0]
+	MOVQ n+8(FP), R11         // R11 = n
+	LEAQ -32(R11), R10        // R10 = n-32, start of the last block
+	XORQ CX, CX               // CX = i
+
+ascii_loop64:
+	LEAQ 64(CX), AX
+	CMPQ AX, R11
+	JG ascii_tail
+	VMOVDQU (SI)(CX*1), Y0
+	VMOVDQU 32(SI)(CX*1), Y1
+	VPOR Y0, Y1, Y2
+	VPMOVMSKB Y2, DX
+	TESTL DX, DX
+	JNZ ascii_found64
+	MOVQ AX, CX
+	JMP ascii_loop64
+
+ascii_found64:
+	VPMOVMSKB Y0, DX
+	TESTL DX, DX
+	JNZ ascii_found
+	VPMOVMSKB Y1, DX
+	ADDQ $32, CX
+	JMP ascii_found
+
+ascii_tail:
+	CMPQ CX, R10
+	JLE ascii_block
+	MOVQ R10, CX              // Final, overlapping block
+
+ascii_block:
+	VMOVDQU (SI)(CX*1), Y0
+	VPMOVMSKB Y0, DX          // DX = non-ASCII bytes
+	TESTL DX, DX
+	JNZ ascii_found
+	CMPQ CX, R10
+	JEQ ascii_all
+	ADDQ $32, CX
+	JMP ascii_tail
+
+ascii_found:
+	BSFL DX, DX
+	ADDQ CX, DX
+	MOVQ DX, ret+16(FP)
+	VZEROUPPER
+	RET
+
+ascii_all:
+	MOVQ R11, ret+16(FP)
+	VZEROUPPER
+	RET
+
+// Lookup tables of the UTF-8 validation by Keiser and Lemire ("Validating
+// UTF-8 In Less Than One Instruction Per Byte"). Each maps a nibble to the
+// errors that bytes with that nibble may take part in:
+//
+//	bit 0 TOO_SHORT   lead byte not followed by enough continuations
+//	bit 1 TOO_LONG    ASCII byte followed by a continuation
+//	bit 2 OVERLONG_3  E0 followed by 80..9F
+//	bit 3 TOO_LARGE   F4 followed by 90..BF, or F5..FF
+//	bit 4 SURROGATE   ED followed by A0..BF
+//	bit 5 OVERLONG_2  C0 or C1
+//	bit 6 TOO_LARGE_1000 / OVERLONG_4, F0 followed by 80..8F
+//	bit 7 TWO_CONTS   continuation after a continuation that ends a sequence
+//
+// A byte is in error where the tables of the high and low nibble of the
+// previous byte and of the high nibble of the byte itself share a bit. Bit 7
+// is expected instead for the third and fourth bytes of 3 and 4-byte
+// sequences.
+DATA utf8Byte1High<>+0(SB)/8, $0x0202020202020202
+DATA utf8Byte1High<>+8(SB)/8, $0x4915012180808080
+GLOBL utf8Byte1High<>(SB), RODATA|NOPTR, $16
+
+DATA utf8Byte1Low<>+0(SB)/8, $0xcbcbcb8b8383a3e7
+DATA utf8Byte1Low<>+8(SB)/8, $0xcbcbdbcbcbcbcbcb
+GLOBL utf8Byte1Low<>(SB), RODATA|NOPTR, $16
+
+DATA utf8Byte2High<>+0(SB)/8, $0x0101010101010101
+DATA utf8Byte2High<>+8(SB)/8, $0x01010101babaaee6
+GLOBL utf8Byte2High<>(SB), RODATA|NOPTR, $16
+
+// func utf8ValidAVX2(s *byte, n int) int
+// Returns 1 if s[:n] holds no invalid UTF-8, not counting a sequence that the
+// end of s[:n] cuts off, and 0 otherwise. Requires n to be a multiple of 32.
+//
+// The bytes before each one come from VPALIGNR over the current block and the
+// previous one. Blocks that are ASCII after an ASCII block cannot be in error
+// and skip the lookups.
+TEXT ·utf8ValidAVX2(SB), NOSPLIT, $0-24
+	MOVQ s+0(FP), SI          // SI = &s[0]
+	MOVQ n+8(FP), CX          // CX = bytes left
+
+	VBROADCASTI128 utf8Byte1High<>(SB), Y14
+	VBROADCASTI128 utf8Byte1Low<>(SB), Y13
+	VBROADCASTI128 utf8Byte2High<>(SB), Y12
+	MOVL $0x0f0f0f0f, AX
+	VMOVD AX, X15
+	VPBROADCASTD X15, Y15     // Y15 = low nibble mask
+	MOVL $0x60606060, AX
+	VMOVD AX, X11
+	VPBROADCASTD X11, Y11     // Y11 = 0xE0-0x80
+	MOVL $0x70707070, AX
+	VMOVD AX, X10
+	VPBROADCASTD X10, Y10     // Y10 = 0xF0-0x80
+	MOVL $0x80808080, AX
+	VMOVD AX, X9
+	VPBROADCASTD X9, Y9       // Y9 = 0x80
+
+	VPXOR Y8, Y8, Y8          // Y8 = previous block
+	VPXOR Y7, Y7, Y7          // Y7 = errors
+	XORL R9, R9               // R9 = non-ASCII bytes of the previous block
+
+utf8_loop:
 	TESTQ CX, CX
-	JZ done_upper
-	
-	// Handle remainder byte-by-byte
-scalar_upper:
-	MOVB (SI), AL
-	CMPB AL, $97
-	JL skip_upper
-	CMPB AL, $122
-	JG skip_upper
-	SUBB $32, AL
-	MOVB AL, (SI)
-
-skip_upper:
-	INCQ SI
-	DECQ CX
-	JNZ scalar_upper
+	JZ utf8_done
+	VMOVDQU (SI), Y0
+	VPMOVMSKB Y0, AX
+	MOVL AX, R10
+	ORL R9, R10
+	MOVL AX, R9
+	JZ utf8_next
 
-done_upper:
+	VPERM2I128 $0x21, Y0, Y8, Y1  // Y1 = previous block high lane, current low lane
+	VPALIGNR $15, Y1, Y0, Y2      // Y2 = prev1, the byte before each
+	VPALIGNR $14, Y1, Y0, Y3      // Y3 = prev2
+	VPALIGNR $13, Y1, Y0, Y4      // Y4 = prev3
+
+	VPSRLW $4, Y2, Y5
+	VPAND Y15, Y5, Y5
+	VPSHUFB Y5, Y14, Y5           // Y5 = byte 1 high nibble errors
+	VPAND Y15, Y2, Y6
+	VPSHUFB Y6, Y13, Y6           // Y6 = byte 1 low nibble errors
+	VPAND Y6, Y5, Y5
+	VPSRLW $4, Y0, Y6
+	VPAND Y15, Y6, Y6
+	VPSHUFB Y6, Y12, Y6           // Y6 = byte 2 high nibble errors
+	VPAND Y6, Y5, Y5              // Y5 = special cases
+
+	// Third and fourth bytes must be continuations, flagged as TWO_CONTS
+	VPSUBUSB Y11, Y3, Y3          // >= 0x80 where prev2 >= 0xE0
+	VPSUBUSB Y10, Y4, Y4          // >= 0x80 where prev3 >= 0xF0
+	VPOR Y4, Y3, Y3
+	VPAND Y9, Y3, Y3
+	VPXOR Y5, Y3, Y3
+	VPOR Y3, Y7, Y7
+
+utf8_next:
+	VMOVDQA Y0, Y8
+	ADDQ $32, SI
+	SUBQ $32, CX
+	JMP utf8_loop
+
+utf8_done:
+	XORQ AX, AX
+	VPTEST Y7, Y7
+	SETEQ AL
+	MOVQ AX, ret+16(FP)
+	VZEROUPPER
+	RET
+
+// func charLengthAVX2(s *byte, n int) int
+// Returns the number of bytes of s[:n] that are not UTF-8 continuation bytes
+// (0x80..0xBF), that is the number of characters of valid UTF-8. Requires n
+// to be a multiple of 32.
+//
+// As signed bytes, continuation bytes are exactly those below -64.
+TEXT ·charLengthAVX2(SB), NOSPLIT, $0-24
+	MOVQ s+0(FP), SI          // SI = &s[0]
+	MOVQ n+8(FP), CX          // CX = bytes left
+	MOVL $0xbfbfbfbf, AX
+	VMOVD AX, X15
+	VPBROADCASTD X15, Y15     // Y15 = -65
+	XORQ DX, DX               // DX = count
+
+count_loop:
+	TESTQ CX, CX
+	JZ count_done
+	VMOVDQU (SI), Y0
+	VPCMPGTB Y15, Y0, Y0      // 0xFF where the byte starts a character
+	VPMOVMSKB Y0, AX
+	POPCNTL AX, AX
+	ADDQ AX, DX
+	ADDQ $32, SI
+	SUBQ $32, CX
+	JMP count_loop
+
+count_done:
+	MOVQ DX, ret+16(FP)
 	VZEROUPPER
 	RET
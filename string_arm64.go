@@ -15,6 +15,59 @@ func strEqNEON(a, b *byte, length int) int
 //go:noescape
 func strPrefixCmpNEON(str, prefix *byte, strLen, prefixLen int) int
 
-// Note: strToLowerNEON and strToUpperNEON are disabled
-// Go's ARM64 assembler doesn't support vector comparison instructions (VCMGE, VCMGT)
-// needed for case conversion range checks. Using generic implementations instead.
+// strIndexNEON finds the first occurrence of sub in s using NEON.
+// Returns its index, or -1 if sub is not present.
+// Requires m >= 2 and n-m+1 >= 16.
+//
+//go:noescape
+func strIndexNEON(s *byte, n int, sub *byte, m int) int
+
+// strToLowerNEON converts ASCII string to lowercase using NEON.
+// Modifies the string in-place. Requires length >= 16.
+//
+//go:noescape
+func strToLowerNEON(s *byte, length int)
+
+// strToUpperNEON converts ASCII string to uppercase using NEON.
+// Modifies the string in-place. Requires length >= 16.
+//
+//go:noescape
+func strToUpperNEON(s *byte, length int)
+
+// strEqFoldNEON compares two strings for equality ignoring ASCII case using NEON.
+// Returns 1 if equal, 0 if not equal. Requires length >= 16.
+//
+//go:noescape
+func strEqFoldNEON(a, b *byte, length int) int
+
+// teddyNEON finds the first start position >= from where a Teddy fingerprint
+// matches, using NEON. Returns the position, or -1 if there is none.
+// Requires 1 <= m <= 3, n-m+1 >= 16 and from <= n-m.
+//
+//go:noescape
+func teddyNEON(s *byte, n int, masks *[3][2][16]byte, m int, from int) int
+
+// strDiffNEON finds the first byte where a and b differ using NEON.
+// Returns its index, or n if the first n bytes are equal. Requires n >= 16.
+//
+//go:noescape
+func strDiffNEON(a, b *byte, n int) int
+
+// asciiPrefixNEON finds the first non-ASCII byte using NEON.
+// Returns its index, or n if all n bytes are ASCII. Requires n >= 16.
+//
+//go:noescape
+func asciiPrefixNEON(s *byte, n int) int
+
+// utf8ValidNEON validates UTF-8 using NEON lookup tables. Returns 1 if s[:n]
+// holds no invalid sequence, except one cut off at the end, and 0 otherwise.
+// Requires n to be a multiple of 16.
+//
+//go:noescape
+func utf8ValidNEON(s *byte, n int) int
+
+// charLengthNEON counts the bytes that are not UTF-8 continuation bytes using
+// NEON. Requires n to be a multiple of 16.
+//
+//go:noescape
+func charLengthNEON(s *byte, n int) int
@@ -138,3 +138,489 @@ no_match:
 // Note: strToLowerNEON and strToUpperNEON are disabled
 // Go's ARM64 assembler doesn't support vector comparison instructions (VCMGE, VCMGT)
 // needed for case conversion range checks. Using generic implementations instead.
+
+// ASCII case folding, as in string_amd64.s: a byte c is in the letter range
+// [lo, lo+25] iff the wrapping difference c-lo is at most 25 unsigned, which
+// VUMIN tests without the signed compares the assembler lacks. Flipping bit 5
+// (0x20) of the letters in range changes their case.
+//
+// CASE_SETUP(lo) loads V6 = lo, V7 = 25 and V8 = 0x20 in every byte.
+// CASE_FLIP(x, t1, t2) flips the case of the bytes of x in [lo, lo+25].
+#define CASE_SETUP(lo) \
+	VMOVI $lo, V6.B16 \
+	VMOVI $25, V7.B16 \
+	VMOVI $32, V8.B16
+
+#define CASE_FLIP(x, t1, t2) \
+	VSUB V6.B16, x.B16, t1.B16 \
+	VUMIN V7.B16, t1.B16, t2.B16 \
+	VCMEQ t1.B16, t2.B16, t1.B16 \
+	VAND V8.B16, t1.B16, t1.B16 \
+	VEOR t1.B16, x.B16, x.B16
+
+// func strToLowerNEON(s *byte, length int)
+// Converts ASCII string to lowercase using NEON
+// Requires length >= 16; the last block overlaps the previous one, which is
+// harmless because lowering is idempotent.
+TEXT ·strToLowerNEON(SB), NOSPLIT, $0-16
+	MOVD s+0(FP), R0          // R0 = &s[0]
+	MOVD length+8(FP), R1     // R1 = length
+	CASE_SETUP(65)            // 'A'
+	ADD R0, R1, R2
+	SUB $16, R2, R2           // R2 = start of the last block
+
+loop_lower_16:
+	VLD1 (R0), [V0.B16]
+	CASE_FLIP(V0, V1, V2)
+	VST1 [V0.B16], (R0)
+	CMP R2, R0
+	BEQ done_lower
+	ADD $16, R0, R0
+	CMP R2, R0
+	BLS loop_lower_16
+	MOVD R2, R0               // Final, overlapping block
+	B loop_lower_16
+
+done_lower:
+	RET
+
+// func strToUpperNEON(s *byte, length int)
+// Converts ASCII string to uppercase using NEON
+// Requires length >= 16, like strToLowerNEON.
+TEXT ·strToUpperNEON(SB), NOSPLIT, $0-16
+	MOVD s+0(FP), R0          // R0 = &s[0]
+	MOVD length+8(FP), R1     // R1 = length
+	CASE_SETUP(97)            // 'a'
+	ADD R0, R1, R2
+	SUB $16, R2, R2           // R2 = start of the last block
+
+loop_upper_16:
+	VLD1 (R0), [V0.B16]
+	CASE_FLIP(V0, V1, V2)
+	VST1 [V0.B16], (R0)
+	CMP R2, R0
+	BEQ done_upper
+	ADD $16, R0, R0
+	CMP R2, R0
+	BLS loop_upper_16
+	MOVD R2, R0               // Final, overlapping block
+	B loop_upper_16
+
+done_upper:
+	RET
+
+// func strEqFoldNEON(a, b *byte, length int) int
+// Compares two strings for equality ignoring ASCII case using NEON.
+// Returns 1 if equal, 0 if not equal. Requires length >= 16.
+TEXT ·strEqFoldNEON(SB), NOSPLIT, $0-32
+	MOVD a+0(FP), R0          // R0 = &a[0]
+	MOVD b+8(FP), R1          // R1 = &b[0]
+	MOVD length+16(FP), R2    // R2 = length
+	CASE_SETUP(65)            // 'A'
+	SUB $16, R2, R2           // R2 = start of the last block
+	MOVD $0, R3               // R3 = offset
+
+loop_fold_16:
+	ADD R0, R3, R4
+	VLD1 (R4), [V0.B16]
+	ADD R1, R3, R5
+	VLD1 (R5), [V3.B16]
+	CASE_FLIP(V0, V1, V2)
+	CASE_FLIP(V3, V4, V5)
+	VEOR V0.B16, V3.B16, V0.B16
+	VMOV V0.D[0], R6
+	VMOV V0.D[1], R7
+	ORR R6, R7, R6
+	CBNZ R6, fold_not_equal
+	CMP R2, R3
+	BEQ fold_equal
+	ADD $16, R3, R3
+	CMP R2, R3
+	BLE loop_fold_16
+	MOVD R2, R3               // Final, overlapping block
+	B loop_fold_16
+
+fold_equal:
+	MOVD $1, R6
+	MOVD R6, ret+24(FP)
+	RET
+
+fold_not_equal:
+	MOVD $0, R6
+	MOVD R6, ret+24(FP)
+	RET
+
+// func strIndexNEON(s *byte, n int, sub *byte, m int) int
+// Returns the index of the first occurrence of sub in s, or -1.
+// Requires 2 <= m and n-m+1 >= 16, so at least one full block of start positions.
+//
+// Same first/last byte filter as strIndexAVX2, 16 start positions per block.
+// NEON has no byte movemask, so SHRN narrows the compare result to 4 bits per
+// position; it is emitted as a WORD directive.
+TEXT ·strIndexNEON(SB), NOSPLIT, $0-40
+	MOVD s+0(FP), R0          // R0 = &s[0]
+	MOVD n+8(FP), R6          // R6 = n
+	MOVD sub+16(FP), R2       // R2 = &sub[0]
+	MOVD m+24(FP), R5         // R5 = m
+
+	// Broadcast the first and last bytes of sub
+	MOVBU (R2), R4
+	VDUP R4, V0.B16           // V0 = first byte
+	SUB $1, R5, R5            // R5 = m-1, distance from first to last byte
+	MOVBU (R2)(R5), R4
+	VDUP R4, V1.B16           // V1 = last byte
+
+	SUB R5, R6, R6
+	SUB $16, R6, R6           // R6 = n-m-15, start of the last block
+	MOVD $0, R7               // R7 = i
+
+index_block:
+	ADD R0, R7, R8            // R8 = &s[i]
+	VLD1 (R8), [V2.B16]
+	ADD R8, R5, R9
+	VLD1 (R9), [V3.B16]
+	VCMEQ V0.B16, V2.B16, V2.B16
+	VCMEQ V1.B16, V3.B16, V3.B16
+	VAND V2.B16, V3.B16, V2.B16
+	WORD $0x0f0c8442          // shrn v2.8b, v2.8h, #4
+	VMOV V2.D[0], R10         // R10 = 4 bits per candidate start position
+	CBZ R10, index_next
+
+index_candidate:
+	RBIT R10, R11
+	CLZ R11, R11              // R11 = 4 * candidate offset in block
+	LSR $2, R11, R12          // R12 = candidate offset
+	ADD R8, R12, R13          // R13 = &s[i+offset]
+	MOVD $1, R14              // R14 = byte index k in sub
+
+	// Verify sub[1:m-1], 8 bytes at a time while they fit
+index_verify8:
+	ADD $8, R14, R15
+	CMP R5, R15
+	BGT index_verify1
+	MOVD (R13)(R14), R16
+	MOVD (R2)(R14), R17
+	CMP R16, R17
+	BNE index_mismatch
+	MOVD R15, R14
+	B index_verify8
+
+index_verify1:
+	CMP R5, R14
+	BGE index_found
+	MOVBU (R13)(R14), R16
+	MOVBU (R2)(R14), R17
+	CMP R16, R17
+	BNE index_mismatch
+	ADD $1, R14, R14
+	B index_verify1
+
+index_found:
+	ADD R7, R12, R12
+	MOVD R12, ret+32(FP)
+	RET
+
+index_mismatch:
+	// Clear the 4 bits of this candidate
+	MOVD $15, R16
+	LSL R11, R16, R16
+	BIC R16, R10, R10
+	CBNZ R10, index_candidate
+
+index_next:
+	CMP R6, R7
+	BEQ index_not_found
+	ADD $16, R7, R7
+	CMP R6, R7
+	BLE index_block
+	MOVD R6, R7               // Final, overlapping block
+	B index_block
+
+index_not_found:
+	MOVD $-1, R12
+	MOVD R12, ret+32(FP)
+	RET
+
+// func teddyNEON(s *byte, n int, masks *[3][2][16]byte, m int, from int) int
+// Returns the first start position p >= from at which the Teddy fingerprint of
+// some bucket matches, or -1. Requires 1 <= m <= 3, n-m+1 >= 16 and from <= n-m.
+//
+// Same fingerprint as teddyAVX2, 16 start positions per block, with TBL looking
+// up the nibbles. Positions with no bucket compare equal to zero, so the
+// inverted SHRN mask holds 4 set bits per candidate.
+TEXT ·teddyNEON(SB), NOSPLIT, $0-48
+	MOVD s+0(FP), R0          // R0 = &s[0]
+	MOVD n+8(FP), R6          // R6 = n
+	MOVD masks+16(FP), R2     // R2 = &masks
+	MOVD m+24(FP), R5         // R5 = m
+	MOVD from+32(FP), R3      // R3 = from
+
+	VLD1.P 64(R2), [V8.B16, V9.B16, V10.B16, V11.B16] // V8/V9, V10/V11 = bytes 0, 1
+	VLD1 (R2), [V12.B16, V13.B16] // V12/V13 = byte 2
+	VEOR V14.B16, V14.B16, V14.B16 // V14 = 0
+	VMOVI $15, V15.B16        // V15 = nibble mask
+
+	SUB R5, R6, R6
+	SUB $15, R6, R6           // R6 = n-m-15, start of the last block
+	MOVD R3, R7               // R7 = i
+	CMP R6, R7
+	BLE teddy_block
+	MOVD R6, R7
+
+teddy_block:
+	ADD R0, R7, R8            // R8 = &s[i]
+	VLD1 (R8), [V0.B16]
+	VUSHR $4, V0.B16, V1.B16
+	VAND V15.B16, V0.B16, V0.B16
+	VTBL V0.B16, [V8.B16], V2.B16
+	VTBL V1.B16, [V9.B16], V3.B16
+	VAND V3.B16, V2.B16, V2.B16 // V2 = buckets matching byte 0
+	CMP $2, R5
+	BLT teddy_mask
+
+	ADD $1, R8, R9
+	VLD1 (R9), [V0.B16]
+	VUSHR $4, V0.B16, V1.B16
+	VAND V15.B16, V0.B16, V0.B16
+	VTBL V0.B16, [V10.B16], V3.B16
+	VTBL V1.B16, [V11.B16], V4.B16
+	VAND V4.B16, V3.B16, V3.B16
+	VAND V3.B16, V2.B16, V2.B16 // V2 &= buckets matching byte 1
+	CMP $3, R5
+	BLT teddy_mask
+
+	ADD $2, R8, R9
+	VLD1 (R9), [V0.B16]
+	VUSHR $4, V0.B16, V1.B16
+	VAND V15.B16, V0.B16, V0.B16
+	VTBL V0.B16, [V12.B16], V3.B16
+	VTBL V1.B16, [V13.B16], V4.B16
+	VAND V4.B16, V3.B16, V3.B16
+	VAND V3.B16, V2.B16, V2.B16 // V2 &= buckets matching byte 2
+
+teddy_mask:
+	VCMEQ V14.B16, V2.B16, V2.B16
+	WORD $0x0f0c8442          // shrn v2.8b, v2.8h, #4
+	VMOV V2.D[0], R10
+	MVN R10, R10              // R10 = 4 bits per candidate start position
+
+	// Clear the positions before from
+	SUBS R7, R3, R11
+	BLE teddy_test
+	LSL $2, R11, R11
+	LSR R11, R10, R10
+	LSL R11, R10, R10
+
+teddy_test:
+	CBZ R10, teddy_next
+	RBIT R10, R11
+	CLZ R11, R11
+	LSR $2, R11, R11
+	ADD R7, R11, R11
+	MOVD R11, ret+40(FP)
+	RET
+
+teddy_next:
+	CMP R6, R7
+	BGE teddy_not_found
+	ADD $16, R7, R7
+	CMP R6, R7
+	BLE teddy_block
+	MOVD R6, R7               // Final, overlapping block
+	B teddy_block
+
+teddy_not_found:
+	MOVD $-1, R11
+	MOVD R11, ret+40(FP)
+	RET
+
+// func strDiffNEON(a, b *byte, n int) int
+// Returns the index of the first byte where a[:n] and b[:n] differ, or n if they
+// are equal. Requires n >= 16.
+//
+// Same overlapping final block as strDiffAVX2. The inverted SHRN mask of the
+// compare holds 4 set bits per differing byte.
+TEXT ·strDiffNEON(SB), NOSPLIT, $0-32
+	MOVD a+0(FP), R0          // R0 = &a[0]
+	MOVD b+8(FP), R1          // R1 = &b[0]
+	MOVD n+16(FP), R6
+	SUB $16, R6, R6           // R6 = n-16, start of the last block
+	MOVD $0, R7               // R7 = i
+
+diff_block:
+	ADD R0, R7, R8
+	ADD R1, R7, R9
+	VLD1 (R8), [V0.B16]
+	VLD1 (R9), [V1.B16]
+	VCMEQ V0.B16, V1.B16, V2.B16
+	WORD $0x0f0c8442          // shrn v2.8b, v2.8h, #4
+	VMOV V2.D[0], R10
+	MVN R10, R10              // R10 = 4 bits per differing byte
+	CBNZ R10, diff_found
+	CMP R6, R7
+	BEQ diff_equal
+	ADD $16, R7, R7
+	CMP R6, R7
+	BLE diff_block
+	MOVD R6, R7               // Final, overlapping block
+	B diff_block
+
+diff_found:
+	RBIT R10, R11
+	CLZ R11, R11
+	LSR $2, R11, R11
+	ADD R7, R11, R11
+	MOVD R11, ret+24(FP)
+	RET
+
+diff_equal:
+	MOVD n+16(FP), R11
+	MOVD R11, ret+24(FP)
+	RET
+
+// func asciiPrefixNEON(s *byte, n int) int
+// Returns the index of the first byte of s[:n] with the high bit set, or n if
+// all are ASCII. Requires n >= 16.
+//
+// Shifting each byte right by 7 leaves its high bit; comparing that with zero
+// and inverting the SHRN mask gives 4 set bits per non-ASCII byte. Same
+// overlapping final block as strDiffNEON.
+TEXT ·asciiPrefixNEON(SB), NOSPLIT, $0-24
+	MOVD s+0(FP), R0          // R0 = &s[0]
+	MOVD n+8(FP), R6
+	SUB $16, R6, R6           // R6 = n-16, start of the last block
+	MOVD $0, R7               // R7 = i
+	VEOR V3.B16, V3.B16, V3.B16
+
+ascii_block:
+	ADD R0, R7, R8
+	VLD1 (R8), [V0.B16]
+	VUSHR $7, V0.B16, V1.B16
+	VCMEQ V1.B16, V3.B16, V2.B16
+	WORD $0x0f0c8442          // shrn v2.8b, v2.8h, #4
+	VMOV V2.D[0], R10
+	MVN R10, R10              // R10 = 4 bits per non-ASCII byte
+	CBNZ R10, ascii_found
+	CMP R6, R7
+	BEQ ascii_all
+	ADD $16, R7, R7
+	CMP R6, R7
+	BLE ascii_block
+	MOVD R6, R7               // Final, overlapping block
+	B ascii_block
+
+ascii_found:
+	RBIT R10, R11
+	CLZ R11, R11
+	LSR $2, R11, R11
+	ADD R7, R11, R11
+	MOVD R11, ret+16(FP)
+	RET
+
+ascii_all:
+	MOVD n+8(FP), R11
+	MOVD R11, ret+16(FP)
+	RET
+
+// Lookup tables of the UTF-8 validation by Keiser and Lemire, as documented
+// with utf8ValidAVX2 in string_amd64.s.
+DATA utf8Byte1High<>+0(SB)/8, $0x0202020202020202
+DATA utf8Byte1High<>+8(SB)/8, $0x4915012180808080
+GLOBL utf8Byte1High<>(SB), RODATA|NOPTR, $16
+
+DATA utf8Byte1Low<>+0(SB)/8, $0xcbcbcb8b8383a3e7
+DATA utf8Byte1Low<>+8(SB)/8, $0xcbcbdbcbcbcbcbcb
+GLOBL utf8Byte1Low<>(SB), RODATA|NOPTR, $16
+
+DATA utf8Byte2High<>+0(SB)/8, $0x0101010101010101
+DATA utf8Byte2High<>+8(SB)/8, $0x01010101babaaee6
+GLOBL utf8Byte2High<>(SB), RODATA|NOPTR, $16
+
+// func utf8ValidNEON(s *byte, n int) int
+// Returns 1 if s[:n] holds no invalid UTF-8, not counting a sequence that the
+// end of s[:n] cuts off, and 0 otherwise. Requires n to be a multiple of 16.
+//
+// EXT over the previous and current block gives the bytes before each one,
+// and TBL does the nibble lookups. Without an unsigned compare, prev2 >= 0xE0
+// and prev3 >= 0xF0 are tested as prev2>>5 == 7 and prev3>>4 == 15.
+TEXT ·utf8ValidNEON(SB), NOSPLIT, $0-24
+	MOVD s+0(FP), R0          // R0 = &s[0]
+	MOVD n+8(FP), R1          // R1 = bytes left
+
+	MOVD $utf8Byte1High<>(SB), R2
+	VLD1 (R2), [V20.B16]
+	MOVD $utf8Byte1Low<>(SB), R2
+	VLD1 (R2), [V21.B16]
+	MOVD $utf8Byte2High<>(SB), R2
+	VLD1 (R2), [V22.B16]
+	VMOVI $15, V19.B16        // V19 = low nibble mask
+	VMOVI $7, V18.B16
+	VMOVI $128, V17.B16
+	VEOR V8.B16, V8.B16, V8.B16   // V8 = previous block
+	VEOR V7.B16, V7.B16, V7.B16   // V7 = errors
+
+utf8_loop:
+	CBZ R1, utf8_done
+	VLD1.P 16(R0), [V0.B16]
+	VEXT $15, V0.B16, V8.B16, V2.B16  // V2 = prev1, the byte before each
+	VEXT $14, V0.B16, V8.B16, V3.B16  // V3 = prev2
+	VEXT $13, V0.B16, V8.B16, V4.B16  // V4 = prev3
+
+	VUSHR $4, V2.B16, V5.B16
+	VTBL V5.B16, [V20.B16], V5.B16    // V5 = byte 1 high nibble errors
+	VAND V19.B16, V2.B16, V6.B16
+	VTBL V6.B16, [V21.B16], V6.B16    // V6 = byte 1 low nibble errors
+	VAND V6.B16, V5.B16, V5.B16
+	VUSHR $4, V0.B16, V6.B16
+	VTBL V6.B16, [V22.B16], V6.B16    // V6 = byte 2 high nibble errors
+	VAND V6.B16, V5.B16, V5.B16       // V5 = special cases
+
+	// Third and fourth bytes must be continuations, flagged as TWO_CONTS
+	VUSHR $5, V3.B16, V3.B16
+	VCMEQ V3.B16, V18.B16, V3.B16     // 0xFF where prev2 >= 0xE0
+	VUSHR $4, V4.B16, V4.B16
+	VCMEQ V4.B16, V19.B16, V4.B16     // 0xFF where prev3 >= 0xF0
+	VORR V4.B16, V3.B16, V3.B16
+	VAND V17.B16, V3.B16, V3.B16
+	VEOR V5.B16, V3.B16, V3.B16
+	VORR V3.B16, V7.B16, V7.B16
+
+	VORR V0.B16, V0.B16, V8.B16
+	SUB $16, R1, R1
+	B utf8_loop
+
+utf8_done:
+	VMOV V7.D[0], R2
+	VMOV V7.D[1], R3
+	ORR R3, R2, R2
+	CMP $0, R2
+	CSET EQ, R4
+	MOVD R4, ret+16(FP)
+	RET
+
+// func charLengthNEON(s *byte, n int) int
+// Returns the number of bytes of s[:n] that are not UTF-8 continuation bytes
+// (0x80..0xBF), that is the number of characters of valid UTF-8. Requires n
+// to be a multiple of 16.
+TEXT ·charLengthNEON(SB), NOSPLIT, $0-24
+	MOVD s+0(FP), R0          // R0 = &s[0]
+	MOVD n+8(FP), R1          // R1 = bytes left
+	MOVD R1, R6               // R6 = count
+	VMOVI $192, V1.B16
+	VMOVI $128, V2.B16
+
+count_loop:
+	CBZ R1, count_done
+	VLD1.P 16(R0), [V0.B16]
+	VAND V1.B16, V0.B16, V3.B16
+	VCMEQ V3.B16, V2.B16, V3.B16      // 0xFF where the byte is a continuation
+	VUSHR $7, V3.B16, V3.B16
+	VUADDLV V3.B16, V4
+	VMOV V4.H[0], R5
+	SUB R5, R6, R6
+	SUB $16, R1, R1
+	B count_loop
+
+count_done:
+	MOVD R6, ret+16(FP)
+	RET
@@ -2,6 +2,9 @@ package syndrdbsimd
 
 import (
 	"bytes"
+	"encoding/binary"
+	"math/bits"
+	"unicode/utf8"
 )
 
 // strCmpGeneric compares two byte slices lexicographically.
@@ -66,6 +69,12 @@ func strContainsSliceGeneric(strings [][]byte, substr []byte, output []bool) {
 	}
 }
 
+// strIndexGeneric returns the index of the first occurrence of 'substr' in
+// 'str', or -1 if it is not present.
+func strIndexGeneric(str, substr []byte) int {
+	return bytes.Index(str, substr)
+}
+
 // strEqGeneric checks if two byte slices are equal.
 // Returns true if equal, false otherwise.
 func strEqGeneric(a, b []byte) bool {
@@ -122,3 +131,58 @@ func strEqIgnoreCaseGeneric(a, b []byte) bool {
 	}
 	return true
 }
+
+// teddyFindGeneric returns the first start position p >= from at which the
+// Teddy fingerprint of some bucket matches, or -1.
+func teddyFindGeneric(s []byte, masks *[3][2][16]byte, m, from int) int {
+	for p := from; p <= len(s)-m; p++ {
+		if teddyBuckets(s[p:], masks, m) != 0 {
+			return p
+		}
+	}
+	return -1
+}
+
+// asciiPrefixGeneric returns the index of the first non-ASCII byte of s, or
+// len(s) if there is none. It tests 8 bytes at a time.
+func asciiPrefixGeneric(s []byte) int {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		if binary.LittleEndian.Uint64(s[i:])&0x8080808080808080 != 0 {
+			break
+		}
+	}
+	for ; i < len(s) && s[i] < utf8.RuneSelf; i++ {
+	}
+	return i
+}
+
+// utf8TailStart returns where to resume validating s after a kernel checked
+// s[:m]: at the last lead byte among the final 3, whose sequence may run past
+// m, or else at m.
+func utf8TailStart(s []byte, m int) int {
+	for i := m - 1; i >= max(0, m-3); i-- {
+		if s[i] >= 0xC0 {
+			return i
+		}
+	}
+	return m
+}
+
+// charLengthGeneric returns the number of bytes of s that are not UTF-8
+// continuation bytes, testing 8 bytes at a time: a continuation byte has its
+// high bit set and the next bit clear.
+func charLengthGeneric(s []byte) int {
+	conts := 0
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		x := binary.LittleEndian.Uint64(s[i:])
+		conts += bits.OnesCount64(x &^ (x << 1) & 0x8080808080808080)
+	}
+	for ; i < len(s); i++ {
+		if s[i]&0xC0 == 0x80 {
+			conts++
+		}
+	}
+	return len(s) - conts
+}
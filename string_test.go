@@ -2,6 +2,7 @@ package syndrdbsimd
 
 import (
 	"bytes"
+	"math/rand"
 	"strings"
 	"testing"
 )
@@ -121,6 +122,117 @@ func TestStrContains(t *testing.T) {
 	}
 }
 
+// The kernels must agree with bytes.Compare wherever the first difference
+// falls, including bytes above 0x7F, which order as unsigned
+func TestStrCmp_MatchBytesCompare(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+	cfg := DefaultConfig()
+	cfg.MinStrings = 1
+	if err := SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(470))
+				for n := 0; n <= 100; n++ {
+					for trial := 0; trial < 40; trial++ {
+						a := make([]byte, n)
+						for i := range a {
+							a[i] = byte(rng.Intn(256))
+						}
+						// b shares a prefix of a and may differ after it
+						b := append([]byte{}, a[:rng.Intn(n+1)]...)
+						for k := rng.Intn(3); k > 0; k-- {
+							b = append(b, byte(rng.Intn(256)))
+						}
+						if got, want := StrCmp(a, b), bytes.Compare(a, b); got != want {
+							t.Fatalf("StrCmp(%x, %x) = %d, want %d", a, b, got, want)
+						}
+						if got, want := StrCmp(b, a), bytes.Compare(b, a); got != want {
+							t.Fatalf("StrCmp(%x, %x) = %d, want %d", b, a, got, want)
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
+// TestStrContains_MatchBytesIndex checks the first/last byte filter against
+// bytes.Index on small alphabets, where most candidates fail verification
+func TestStrContains_MatchBytesIndex(t *testing.T) {
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+	cfg := DefaultConfig()
+	cfg.MinStrings = 1
+	if err := SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(410))
+				random := func(n int, alphabet string) []byte {
+					b := make([]byte, n)
+					for i := range b {
+						b[i] = alphabet[rng.Intn(len(alphabet))]
+					}
+					return b
+				}
+
+				for n := 0; n <= 100; n++ {
+					for trial := 0; trial < 40; trial++ {
+						alphabet := []string{"ab", "abc", "abcdefgh"}[trial%3]
+						str := random(n, alphabet)
+						sub := random(rng.Intn(20), alphabet)
+						// Plant the substring near the end to hit the overlapping final block
+						if trial%4 == 0 && len(sub) <= n {
+							copy(str[n-len(sub)-rng.Intn(n-len(sub)+1):], sub)
+						}
+						if got, want := strIndexImpl(currentKernels(), str, sub), bytes.Index(str, sub); got != want {
+							t.Fatalf("strIndexImpl(currentKernels(), %q, %q) = %d, want %d", str, sub, got, want)
+						}
+						if got, want := StrContains(str, sub), bytes.Contains(str, sub); got != want {
+							t.Fatalf("StrContains(%q, %q) = %v, want %v", str, sub, got, want)
+						}
+					}
+				}
+
+				// Needles longer than the 8-byte verify step, and a match only in the last position
+				str := append(bytes.Repeat([]byte("a"), 300), 'b')
+				for _, m := range []int{2, 9, 17, 33, 64} {
+					sub := append(bytes.Repeat([]byte("a"), m-1), 'b')
+					if got, want := strIndexImpl(currentKernels(), str, sub), len(str)-m; got != want {
+						t.Errorf("strIndexImpl(currentKernels(), a*300 b, a*%d b) = %d, want %d", m-1, got, want)
+					}
+					if got := strIndexImpl(currentKernels(), str[:300], sub); got != -1 {
+						t.Errorf("strIndexImpl(currentKernels(), a*300, a*%d b) = %d, want -1", m-1, got)
+					}
+				}
+
+				values := make([]string, 50)
+				for i := range values {
+					values[i] = string(random(rng.Intn(80), "abc"))
+				}
+				got := CmpContainsString(values, "abca")
+				for i, v := range values {
+					if got[i] != strings.Contains(v, "abca") {
+						t.Errorf("CmpContainsString(%q, %q) = %v", v, "abca", got[i])
+					}
+				}
+			})
+		})
+	}
+}
+
 // TestStrEq tests string equality
 func TestStrEq(t *testing.T) {
 	tests := []struct {
@@ -240,6 +352,51 @@ func TestStrEqIgnoreCase(t *testing.T) {
 	}
 }
 
+// TestStrCaseFold_MatchScalar checks the SIMD case kernels against the scalar
+// loops over every byte value, including the neighbours of the letter ranges
+// and the non-letters that differ from each other only in bit 5
+func TestStrCaseFold_MatchScalar(t *testing.T) {
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(421))
+				for n := 0; n <= 130; n++ {
+					for trial := 0; trial < 10; trial++ {
+						s := make([]byte, n)
+						for i := range s {
+							s[i] = byte(rng.Intn(256))
+						}
+
+						lower, upper := bytes.Clone(s), bytes.Clone(s)
+						wantLower, wantUpper := bytes.Clone(s), bytes.Clone(s)
+						StrToLower(lower)
+						StrToUpper(upper)
+						strToLowerGeneric(wantLower)
+						strToUpperGeneric(wantUpper)
+						if !bytes.Equal(lower, wantLower) || !bytes.Equal(upper, wantUpper) {
+							t.Fatalf("StrToLower/StrToUpper(%q) = %q, %q, want %q, %q", s, lower, upper, wantLower, wantUpper)
+						}
+
+						// Flip bit 5 of one byte: equal only if that byte is a letter
+						other := bytes.Clone(upper)
+						if n > 0 {
+							other[rng.Intn(n)] ^= 0x20
+						}
+						for _, b := range [][]byte{lower, upper, other} {
+							if got, want := StrEqIgnoreCase(s, b), strEqIgnoreCaseGeneric(s, b); got != want {
+								t.Fatalf("StrEqIgnoreCase(%q, %q) = %v, want %v", s, b, got, want)
+							}
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
 // Benchmark tests for string operations
 
 func BenchmarkStrEq(b *testing.B) {
@@ -269,6 +426,38 @@ func BenchmarkStrContains(b *testing.B) {
 	}
 }
 
+func BenchmarkStrContainsLong(b *testing.B) {
+	str := []byte(strings.Repeat("the quick brown fox jumps over the lazy dog ", 100))
+	substr := []byte("lazy cat")
+	b.SetBytes(int64(len(str)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		StrContains(str, substr)
+	}
+}
+
+func BenchmarkStrContainsLongBytes(b *testing.B) {
+	str := []byte(strings.Repeat("the quick brown fox jumps over the lazy dog ", 100))
+	substr := []byte("lazy cat")
+	b.SetBytes(int64(len(str)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bytes.Contains(str, substr)
+	}
+}
+
+// Strings that differ only in their last byte
+func BenchmarkStrCmpLong(b *testing.B) {
+	a := []byte(strings.Repeat("the quick brown fox jumps over the lazy dog ", 100))
+	c := append([]byte{}, a...)
+	c[len(c)-1] = '!'
+	b.SetBytes(int64(len(a)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		StrCmp(a, c)
+	}
+}
+
 func BenchmarkStrToLower(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		s := []byte(strings.Repeat("HELLO WORLD ", 10))
@@ -27,3 +27,13 @@ func stringsToBytes(strs []string) [][]byte {
 	}
 	return result
 }
+
+// float64sAsInt64s reinterprets a []float64 as []int64 without copying.
+// Used by kernels that only move bits around (compress, gather) so that float64
+// columns can share the int64 implementation.
+func float64sAsInt64s(f []float64) []int64 {
+	if len(f) == 0 {
+		return []int64{}
+	}
+	return unsafe.Slice((*int64)(unsafe.Pointer(&f[0])), len(f))
+}
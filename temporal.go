@@ -0,0 +1,178 @@
+package syndrdbsimd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimeUnit is the resolution of an int64 timestamp column.
+// Timestamps count units since the Unix epoch, 1970-01-01T00:00:00Z.
+type TimeUnit int
+
+const (
+	UnixMicro TimeUnit = iota // microseconds since the epoch, as time.Time.UnixMicro
+	UnixNano                  // nanoseconds since the epoch, as time.Time.UnixNano
+)
+
+// perDay returns the number of units in a day, panicking for an invalid unit.
+func (u TimeUnit) perDay() int64 {
+	switch u {
+	case UnixMicro:
+		return 86_400_000_000
+	case UnixNano:
+		return 86_400_000_000_000
+	default:
+		panic(fmt.Sprintf("syndrdbsimd: invalid time unit %d", int(u)))
+	}
+}
+
+// FromTime converts t to a timestamp in unit u, for building range filter bounds.
+func (u TimeUnit) FromTime(t time.Time) int64 {
+	switch u {
+	case UnixMicro:
+		return t.UnixMicro()
+	case UnixNano:
+		return t.UnixNano()
+	default:
+		panic(fmt.Sprintf("syndrdbsimd: invalid time unit %d", int(u)))
+	}
+}
+
+// DatePart selects the field for DateTrunc and DateExtract, as in SQL
+// DATE_TRUNC('month', ts) and EXTRACT(DOW FROM ts).
+// All calendar computations use the proleptic Gregorian calendar in UTC.
+type DatePart int
+
+const (
+	DatePartSecond    DatePart = iota // seconds within the minute, 0-59
+	DatePartMinute                    // minutes within the hour, 0-59
+	DatePartHour                      // hours within the day, 0-23
+	DatePartDay                       // day of the month, 1-31
+	DatePartWeek                      // DateTrunc only: weeks start on Monday, as ISO 8601
+	DatePartMonth                     // month of the year, 1-12
+	DatePartQuarter                   // quarter of the year, 1-4
+	DatePartYear                      // the year, e.g. 2024
+	DatePartDayOfWeek                 // DateExtract only: 0 (Sunday) to 6 (Saturday), as SQL DOW
+	DatePartDayOfYear                 // DateExtract only: 1-366
+)
+
+var datePartNames = [...]string{
+	DatePartSecond:    "second",
+	DatePartMinute:    "minute",
+	DatePartHour:      "hour",
+	DatePartDay:       "day",
+	DatePartWeek:      "week",
+	DatePartMonth:     "month",
+	DatePartQuarter:   "quarter",
+	DatePartYear:      "year",
+	DatePartDayOfWeek: "dow",
+	DatePartDayOfYear: "doy",
+}
+
+// String returns the SQL name of the part, e.g. "month" or "dow".
+func (p DatePart) String() string {
+	if p < 0 || int(p) >= len(datePartNames) {
+		return "DatePart(" + strconv.Itoa(int(p)) + ")"
+	}
+	return datePartNames[p]
+}
+
+// DateTrunc rounds each timestamp in src down to the start of its part and writes
+// the result to dst, like SQL DATE_TRUNC. dst may be src to truncate in place.
+// Converts min(len(dst), len(src)) values and returns that count.
+//
+// Timestamps before the epoch round down, not toward zero. A timestamp whose
+// truncation falls below the int64 range, such as math.MinInt64 truncated to
+// the day, saturates at math.MinInt64.
+// Panics if unit is invalid or part is DatePartDayOfWeek or DatePartDayOfYear.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 or NEON fixed-width division for second, minute, hour and day, and for
+//     the days since the epoch that feed the civil calendar conversion
+//   - Scalar civil calendar conversion for week, month, quarter and year
+//   - Scalar fallback for other platforms
+func DateTrunc(dst, src []int64, unit TimeUnit, part DatePart) int {
+	perDay := unit.perDay()
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+
+	ks := currentKernels()
+	switch part {
+	case DatePartSecond:
+		truncFixedImpl(ks, dst, src, perDay/86400)
+	case DatePartMinute:
+		truncFixedImpl(ks, dst, src, perDay/1440)
+	case DatePartHour:
+		truncFixedImpl(ks, dst, src, perDay/24)
+	case DatePartDay:
+		truncFixedImpl(ks, dst, src, perDay)
+	case DatePartWeek, DatePartMonth, DatePartQuarter, DatePartYear:
+		var days, tod [dateChunk]int64
+		for len(src) > 0 {
+			c := min(len(src), dateChunk)
+			floorDivModImpl(ks, days[:c], tod[:c], src[:c], perDay)
+			truncCalendarGeneric(dst[:c], days[:c], perDay, part)
+			dst, src = dst[c:], src[c:]
+		}
+	default:
+		panic(fmt.Sprintf("syndrdbsimd: DateTrunc does not support %v", part))
+	}
+	return n
+}
+
+// dateChunk is the number of timestamps split into days and time of day at a
+// time, sized so the split buffers stay on the stack.
+const dateChunk = 256
+
+// DateExtract extracts one field of each timestamp in src into dst, like SQL EXTRACT.
+// Converts min(len(dst), len(src)) values and returns that count.
+//
+// The int32 output can be filtered with the int32 comparison kernels,
+// e.g. CmpEqInt32Mask(dst, 6) for rows that fall on a Saturday.
+// Panics if unit is invalid or part is DatePartWeek.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 or NEON division splitting each timestamp into days and time of day
+//   - Scalar field extraction and civil calendar conversion
+//   - Scalar fallback for other platforms
+func DateExtract(dst []int32, src []int64, unit TimeUnit, part DatePart) int {
+	perDay := unit.perDay()
+	if part < DatePartSecond || part > DatePartDayOfYear || part == DatePartWeek {
+		panic(fmt.Sprintf("syndrdbsimd: DateExtract does not support %v", part))
+	}
+
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+
+	ks := currentKernels()
+	var days, tod [dateChunk]int64
+	for len(src) > 0 {
+		c := min(len(src), dateChunk)
+		floorDivModImpl(ks, days[:c], tod[:c], src[:c], perDay)
+		extractGeneric(dst[:c], days[:c], tod[:c], perDay/86400, part)
+		dst, src = dst[c:], src[c:]
+	}
+	return n
+}
+
+// TimeRange reports which timestamps fall in the half-open window [start, end).
+// Returns a slice of booleans where result[i] == true if start <= values[i] < end.
+//
+// This function automatically selects the best implementation:
+//   - The int64 comparison kernels (AVX-512, AVX2, NEON) combined with AndBitmap
+//   - Scalar fallback for other platforms
+func TimeRange(values []int64, start, end int64) []bool {
+	return bitmaskToBools(TimeRangeMask(values, start, end), len(values))
+}
+
+// TimeRangeMask reports which timestamps fall in the half-open window [start, end)
+// as a bitmask. Bit i in result[j] is set if start <= values[j*64+i] < end.
+// Bounds are usually built with TimeUnit.FromTime.
+func TimeRangeMask(values []int64, start, end int64) []uint64 {
+	if len(values) == 0 {
+		return []uint64{}
+	}
+	ks := currentKernels()
+	return andBitmapImpl(ks, cmpGeInt64MaskImpl(ks, values, start), cmpLtInt64MaskImpl(ks, values, end))
+}
@@ -0,0 +1,12 @@
+//go:build amd64
+
+package syndrdbsimd
+
+// AVX2 kernels for fixed-width timestamp division.
+// See temporal_amd64.s for the quotient estimate and length requirements.
+
+//go:noescape
+func truncFixedAVX2(dst, src *int64, length int, width int64, inv float64)
+
+//go:noescape
+func floorDivModAVX2(quo, rem, src *int64, length int, width int64, inv float64)
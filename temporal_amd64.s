@@ -0,0 +1,127 @@
+#include "textflag.h"
+
+// ============================================================================
+// Fixed-width timestamp division
+// ============================================================================
+//
+// AVX2 has no 64-bit integer division, and no conversion between int64 and
+// float64 either. Each kernel estimates q = floor(v / width) in float64 and
+// corrects it with exact integer arithmetic:
+//
+//   1. v is converted to float64 as hi*2^32 + lo. Each half is placed in the
+//      mantissa of a double with a fixed exponent and the exponent's value is
+//      subtracted, which is exact; only the final addition rounds.
+//   2. q = floor(v * inv), with inv = 1/width. For width >= 2^12 the estimate is
+//      within one of the true quotient and below 2^51 in magnitude, so adding
+//      1.5*2^52 leaves q in the low mantissa bits as a two's complement integer.
+//   3. r = v - q*width, with the product's low 64 bits built from three
+//      VPMULUDQ. The wrapped arithmetic is exact because r lies in (-width, 2*width),
+//      and one step moves q and r onto floorDiv and floorMod.
+//
+// Every kernel processes 4 elements per iteration; length must be a multiple of 4.
+//
+// Register usage:
+//   SI: src, DI: dst or quo, DX: rem, CX: remaining iterations
+//   Y0: v, Y1: q, Y2: r, Y3-Y4: scratch
+//   Y7: zero, Y8: width-1, Y9: width>>32, Y10: width, Y11: 1.5*2^52,
+//   Y12: inv, Y13: 2^52, Y14: math.MinInt64, Y15: 2^84 + 2^63
+
+// DIV_SETUP broadcasts the constants from the width and inv arguments at the
+// given frame offsets.
+#define DIV_SETUP(width, inv) \
+    VPBROADCASTQ width, Y10 \
+    VBROADCASTSD inv, Y12 \
+    VPSRLQ  $32, Y10, Y9 \
+    VPXOR   Y7, Y7, Y7 \
+    VPCMPEQQ Y8, Y8, Y8 \
+    VPADDQ  Y8, Y10, Y8                 /* width - 1 */ \
+    MOVQ    $0x4530000080000000, AX \
+    VMOVQ   AX, X15 \
+    VPBROADCASTQ X15, Y15 \
+    MOVQ    $0x4330000000000000, AX \
+    VMOVQ   AX, X13 \
+    VPBROADCASTQ X13, Y13 \
+    MOVQ    $0x4338000000000000, AX \
+    VMOVQ   AX, X11 \
+    VPBROADCASTQ X11, Y11 \
+    MOVQ    $0x8000000000000000, AX \
+    VMOVQ   AX, X14 \
+    VPBROADCASTQ X14, Y14
+
+// DIV_STEP loads 4 timestamps from SI into Y0 and leaves floorDiv(v, width) in
+// Y1 and floorMod(v, width) in Y2.
+#define DIV_STEP \
+    VMOVDQU (SI), Y0 \
+    VPSRLQ  $32, Y0, Y1 \
+    VPXOR   Y15, Y1, Y1                 /* 2^84 + (hi + 2^31) * 2^32 */ \
+    VSUBPD  Y15, Y1, Y1                 /* hi * 2^32 */ \
+    VPBLENDD $0xAA, Y13, Y0, Y2         /* 2^52 + lo */ \
+    VSUBPD  Y13, Y2, Y2                 /* lo */ \
+    VADDPD  Y2, Y1, Y1 \
+    VMULPD  Y12, Y1, Y1 \
+    VROUNDPD $1, Y1, Y1                 /* floor */ \
+    VADDPD  Y11, Y1, Y1 \
+    VPSUBQ  Y11, Y1, Y1                 /* q estimate as int64 */ \
+    VPMULUDQ Y10, Y1, Y2                /* q.lo * width.lo */ \
+    VPSRLQ  $32, Y1, Y3 \
+    VPMULUDQ Y10, Y3, Y3                /* q.hi * width.lo */ \
+    VPMULUDQ Y9, Y1, Y4                 /* q.lo * width.hi */ \
+    VPADDQ  Y4, Y3, Y3 \
+    VPSLLQ  $32, Y3, Y3 \
+    VPADDQ  Y3, Y2, Y2 \
+    VPSUBQ  Y2, Y0, Y2                  /* r = v - q*width */ \
+    VPCMPGTQ Y2, Y7, Y3                 /* r < 0: q--, r += width */ \
+    VPADDQ  Y3, Y1, Y1 \
+    VPAND   Y10, Y3, Y3 \
+    VPADDQ  Y3, Y2, Y2 \
+    VPCMPGTQ Y8, Y2, Y3                 /* r >= width: q++, r -= width */ \
+    VPSUBQ  Y3, Y1, Y1 \
+    VPAND   Y10, Y3, Y3 \
+    VPSUBQ  Y3, Y2, Y2
+
+// func truncFixedAVX2(dst, src *int64, length int, width int64, inv float64)
+//
+// Writes v - floorMod(v, width), saturating at math.MinInt64 where the
+// subtraction wraps.
+TEXT ·truncFixedAVX2(SB), NOSPLIT, $0-40
+    MOVQ    dst+0(FP), DI
+    MOVQ    src+8(FP), SI
+    MOVQ    length+16(FP), CX
+    DIV_SETUP(width+24(FP), inv+32(FP))
+    SHRQ    $2, CX
+    JZ      done
+loop:
+    DIV_STEP
+    VPSUBQ  Y2, Y0, Y3                  /* v - r */
+    VPCMPGTQ Y0, Y3, Y4                 /* wrapped above v */
+    VPBLENDVB Y4, Y14, Y3, Y3
+    VMOVDQU Y3, (DI)
+    ADDQ    $32, SI
+    ADDQ    $32, DI
+    DECQ    CX
+    JNZ     loop
+done:
+    VZEROUPPER
+    RET
+
+// func floorDivModAVX2(quo, rem, src *int64, length int, width int64, inv float64)
+TEXT ·floorDivModAVX2(SB), NOSPLIT, $0-48
+    MOVQ    quo+0(FP), DI
+    MOVQ    rem+8(FP), DX
+    MOVQ    src+16(FP), SI
+    MOVQ    length+24(FP), CX
+    DIV_SETUP(width+32(FP), inv+40(FP))
+    SHRQ    $2, CX
+    JZ      done
+loop:
+    DIV_STEP
+    VMOVDQU Y1, (DI)
+    VMOVDQU Y2, (DX)
+    ADDQ    $32, SI
+    ADDQ    $32, DI
+    ADDQ    $32, DX
+    DECQ    CX
+    JNZ     loop
+done:
+    VZEROUPPER
+    RET
@@ -0,0 +1,12 @@
+//go:build arm64
+
+package syndrdbsimd
+
+// NEON kernels for fixed-width timestamp division.
+// See temporal_arm64.s for the quotient estimate and length requirements.
+
+//go:noescape
+func truncFixedNEON(dst, src *int64, length int, width int64, inv float64)
+
+//go:noescape
+func floorDivModNEON(quo, rem, src *int64, length int, width int64, inv float64)
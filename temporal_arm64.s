@@ -0,0 +1,104 @@
+#include "textflag.h"
+
+// Vector conversions, multiplies and comparisons are emitted as WORD directives
+// because older Go ARM64 assemblers have no mnemonics for them. Each is annotated
+// with its disassembly.
+
+// ============================================================================
+// Fixed-width timestamp division
+// ============================================================================
+//
+// NEON has no 64-bit integer division or lane multiply. Each kernel estimates
+// q = floor(v / width) with SCVTF, a multiply by inv = 1/width and FCVTMS, which
+// for width >= 2^12 is within one of the true quotient. It then computes
+// r = v - q*width exactly, with the product's low 64 bits built from 32-bit
+// multiplies, and one step moves q and r onto floorDiv and floorMod: r lies in
+// (-width, 2*width) before the step.
+//
+// Every kernel processes 2 elements per iteration; length must be a multiple of 2.
+//
+// Register usage:
+//   R0: dst or quo, R1: rem, R2: src, R3: remaining iterations
+//   V0: v, V1: q, V2: r, V3-V4: scratch
+//   V25: math.MinInt64, V26: width, V27: width-1, V28: width.lo, V29: width.hi,
+//   V30: inv, V31: zero
+
+// DIV_SETUP broadcasts the constants from the width and inv arguments at the
+// given frame offsets.
+#define DIV_SETUP(width, inv) \
+    MOVD    width, R4 \
+    VDUP    R4, V26.D2 \
+    SUB     $1, R4, R5 \
+    VDUP    R5, V27.D2 \
+    VDUP    R4, V28.S4 \
+    LSR     $32, R4, R5 \
+    VDUP    R5, V29.S4 \
+    MOVD    inv, R5 \
+    VDUP    R5, V30.D2 \
+    MOVD    $0x8000000000000000, R5 \
+    VDUP    R5, V25.D2 \
+    VEOR    V31.B16, V31.B16, V31.B16
+
+// DIV_STEP loads 2 timestamps from R2 into V0 and leaves floorDiv(v, width) in
+// V1 and floorMod(v, width) in V2.
+#define DIV_STEP \
+    VLD1.P  16(R2), [V0.D2] \
+    WORD    $0x4e61d801                 /* scvtf  v1.2d, v0.2d */ \
+    WORD    $0x6e7edc21                 /* fmul   v1.2d, v1.2d, v30.2d */ \
+    WORD    $0x4e61b821                 /* fcvtms v1.2d, v1.2d */ \
+    WORD    $0x0ea12822                 /* xtn    v2.2s, v1.2d; q.lo */ \
+    WORD    $0x0f208423                 /* shrn   v3.2s, v1.2d, #32; q.hi */ \
+    WORD    $0x2ebcc044                 /* umull  v4.2d, v2.2s, v28.2s; q.lo * width.lo */ \
+    WORD    $0x0ebc9c63                 /* mul    v3.2s, v3.2s, v28.2s; q.hi * width.lo */ \
+    WORD    $0x0ebd9443                 /* mla    v3.2s, v2.2s, v29.2s; + q.lo * width.hi */ \
+    WORD    $0x4e833be3                 /* zip1   v3.4s, v31.4s, v3.4s; shift left by 32 */ \
+    VADD    V3.D2, V4.D2, V4.D2 \
+    VSUB    V4.D2, V0.D2, V2.D2         /* r = v - q*width */ \
+    WORD    $0x4ee0a843                 /* cmlt   v3.2d, v2.2d, #0; r < 0: q--, r += width */ \
+    VADD    V3.D2, V1.D2, V1.D2 \
+    VAND    V26.B16, V3.B16, V3.B16 \
+    VADD    V3.D2, V2.D2, V2.D2 \
+    WORD    $0x4efb3443                 /* cmgt   v3.2d, v2.2d, v27.2d; r >= width: q++, r -= width */ \
+    VSUB    V3.D2, V1.D2, V1.D2 \
+    VAND    V26.B16, V3.B16, V3.B16 \
+    VSUB    V3.D2, V2.D2, V2.D2
+
+// func truncFixedNEON(dst, src *int64, length int, width int64, inv float64)
+//
+// Writes v - floorMod(v, width), saturating at math.MinInt64 where the
+// subtraction wraps.
+TEXT ·truncFixedNEON(SB), NOSPLIT, $0-40
+    MOVD    dst+0(FP), R0
+    MOVD    src+8(FP), R2
+    MOVD    length+16(FP), R3
+    DIV_SETUP(width+24(FP), inv+32(FP))
+    LSR     $1, R3, R3
+    CBZ     R3, done
+loop:
+    DIV_STEP
+    VSUB    V2.D2, V0.D2, V3.D2         // v - r
+    WORD    $0x4ee03464                 // cmgt   v4.2d, v3.2d, v0.2d; wrapped above v
+    WORD    $0x6ea41f23                 // bit    v3.16b, v25.16b, v4.16b
+    VST1.P  [V3.D2], 16(R0)
+    SUB     $1, R3, R3
+    CBNZ    R3, loop
+done:
+    RET
+
+// func floorDivModNEON(quo, rem, src *int64, length int, width int64, inv float64)
+TEXT ·floorDivModNEON(SB), NOSPLIT, $0-48
+    MOVD    quo+0(FP), R0
+    MOVD    rem+8(FP), R1
+    MOVD    src+16(FP), R2
+    MOVD    length+24(FP), R3
+    DIV_SETUP(width+32(FP), inv+40(FP))
+    LSR     $1, R3, R3
+    CBZ     R3, done
+loop:
+    DIV_STEP
+    VST1.P  [V1.D2], 16(R0)
+    VST1.P  [V2.D2], 16(R1)
+    SUB     $1, R3, R3
+    CBNZ    R3, loop
+done:
+    RET
@@ -0,0 +1,152 @@
+package syndrdbsimd
+
+import "math"
+
+// Civil calendar conversions use the proleptic Gregorian calendar in UTC,
+// following Howard Hinnant's days_from_civil / civil_from_days algorithms.
+
+// floorDiv divides a by b rounding toward negative infinity, so timestamps
+// before 1970 truncate down rather than toward zero.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// floorMod returns a - floorDiv(a, b)*b for b > 0, in [0, b), without the
+// overflow of the multiplication for a near math.MinInt64.
+func floorMod(a, b int64) int64 {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
+
+// scaleDown returns q*width for width > 0, saturating at math.MinInt64 when the
+// product is below it. Truncating a timestamp near math.MinInt64 can round
+// down past the int64 range.
+func scaleDown(q, width int64) int64 {
+	if q < math.MinInt64/width {
+		return math.MinInt64
+	}
+	return q * width
+}
+
+// civilFromDays converts days since 1970-01-01 to a year, month (1-12) and day (1-31).
+func civilFromDays(days int64) (year, month, day int64) {
+	z := days + 719468
+	era := floorDiv(z, 146097)
+	doe := z - era*146097
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+
+	day = doy - (153*mp+2)/5 + 1
+	month = mp + 3
+	if mp >= 10 {
+		month = mp - 9
+	}
+	year = yoe + era*400
+	if month <= 2 {
+		year++
+	}
+	return year, month, day
+}
+
+// daysFromCivil converts a year, month (1-12) and day (1-31) to days since 1970-01-01.
+func daysFromCivil(year, month, day int64) int64 {
+	if month <= 2 {
+		year--
+	}
+	era := floorDiv(year, 400)
+	yoe := year - era*400
+	mp := month - 3
+	if month <= 2 {
+		mp = month + 9
+	}
+	doy := (153*mp+2)/5 + day - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
+
+// truncFixedGeneric rounds each timestamp down to a multiple of width.
+func truncFixedGeneric(dst, src []int64, width int64) {
+	for i, v := range src {
+		dst[i] = scaleDown(floorDiv(v, width), width)
+	}
+}
+
+// floorDivModGeneric splits each timestamp into floorDiv(v, width) and
+// floorMod(v, width), the days since the epoch and time of day for width perDay.
+func floorDivModGeneric(quo, rem, src []int64, width int64) {
+	for i, v := range src {
+		quo[i] = floorDiv(v, width)
+		rem[i] = floorMod(v, width)
+	}
+}
+
+// truncCalendarGeneric rounds each day since the epoch down to the start of its
+// week, month, quarter or year and scales it back to a timestamp with perDay
+// units in a day.
+func truncCalendarGeneric(dst, days []int64, perDay int64, part DatePart) {
+	for i, d := range days {
+		switch part {
+		case DatePartWeek:
+			// 1970-01-01 was a Thursday; weeks start on Monday
+			d -= (d%7 + 7 + 3) % 7
+		default:
+			year, month, _ := civilFromDays(d)
+			switch part {
+			case DatePartMonth:
+			case DatePartQuarter:
+				month = (month-1)/3*3 + 1
+			default:
+				month = 1
+			}
+			d = daysFromCivil(year, month, 1)
+		}
+		dst[i] = scaleDown(d, perDay)
+	}
+}
+
+// extractGeneric extracts one calendar or clock field from each timestamp, split
+// into days since the epoch and time of day by floorDivModGeneric. perSecond is
+// the number of timestamp units in a second.
+func extractGeneric(dst []int32, days, tod []int64, perSecond int64, part DatePart) {
+	for i, d := range days {
+		if part <= DatePartHour {
+			seconds := tod[i] / perSecond
+			switch part {
+			case DatePartSecond:
+				dst[i] = int32(seconds % 60)
+			case DatePartMinute:
+				dst[i] = int32(seconds / 60 % 60)
+			default:
+				dst[i] = int32(seconds / 3600)
+			}
+			continue
+		}
+		if part == DatePartDayOfWeek {
+			// 0 is Sunday; 1970-01-01 was a Thursday
+			dst[i] = int32((d%7 + 7 + 4) % 7)
+			continue
+		}
+
+		year, month, day := civilFromDays(d)
+		switch part {
+		case DatePartDay:
+			dst[i] = int32(day)
+		case DatePartMonth:
+			dst[i] = int32(month)
+		case DatePartQuarter:
+			dst[i] = int32((month-1)/3 + 1)
+		case DatePartYear:
+			dst[i] = int32(year)
+		default: // DatePartDayOfYear
+			dst[i] = int32(d - daysFromCivil(year, 1, 1) + 1)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+package syndrdbsimd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// DATE_TRUNC Tests
+// ============================================================================
+
+// timeReference truncates t with the time package, the reference for DateTrunc.
+func timeReference(t time.Time, part DatePart) time.Time {
+	y, m, d := t.Date()
+	switch part {
+	case DatePartSecond:
+		return t.Truncate(time.Second)
+	case DatePartMinute:
+		return t.Truncate(time.Minute)
+	case DatePartHour:
+		return t.Truncate(time.Hour)
+	case DatePartDay:
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	case DatePartWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, time.UTC)
+	case DatePartMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	case DatePartQuarter:
+		return time.Date(y, (m-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// randomTimes returns times spread over 1700-2200, so pre-epoch values and
+// leap years are covered.
+func randomTimes(rng *rand.Rand, size int) []time.Time {
+	lo := time.Date(1700, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	hi := time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	times := make([]time.Time, size)
+	for i := range times {
+		times[i] = time.Unix(lo+rng.Int63n(hi-lo), rng.Int63n(1e9)).UTC()
+	}
+	times[0] = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	times[1] = time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC)
+	times[2] = time.Date(2000, 2, 29, 12, 0, 0, 0, time.UTC)
+	return times
+}
+
+func TestDateTrunc_MatchesTimePackage(t *testing.T) {
+	times := randomTimes(rand.New(rand.NewSource(36)), 2000)
+	parts := []DatePart{DatePartSecond, DatePartMinute, DatePartHour, DatePartDay,
+		DatePartWeek, DatePartMonth, DatePartQuarter, DatePartYear}
+
+	for _, unit := range []TimeUnit{UnixMicro, UnixNano} {
+		src := make([]int64, len(times))
+		for i, tm := range times {
+			src[i] = unit.FromTime(tm)
+		}
+		for _, part := range parts {
+			dst := make([]int64, len(src))
+			if n := DateTrunc(dst, src, unit, part); n != len(src) {
+				t.Fatalf("%v: expected %d values, got %d", part, len(src), n)
+			}
+			for i, tm := range times {
+				if want := unit.FromTime(timeReference(tm, part)); dst[i] != want {
+					t.Errorf("unit=%d %v(%v) = %d, want %d", unit, part, tm, dst[i], want)
+					break
+				}
+			}
+		}
+	}
+}
+
+// unitTime converts a timestamp in unit back to a time.Time, for values that
+// time.Time's own constructors reach.
+func unitTime(unit TimeUnit, v int64) time.Time {
+	if unit == UnixMicro {
+		return time.UnixMicro(v).UTC()
+	}
+	return time.Unix(0, v).UTC()
+}
+
+// Truncation must not wrap at the ends of the int64 range; below it, results
+// saturate at math.MinInt64
+func TestDateTrunc_Int64Bounds(t *testing.T) {
+	src := []int64{math.MinInt64, math.MinInt64 + 1, math.MaxInt64 - 1, math.MaxInt64}
+	parts := []DatePart{DatePartSecond, DatePartMinute, DatePartHour, DatePartDay,
+		DatePartWeek, DatePartMonth, DatePartQuarter, DatePartYear}
+
+	for _, unit := range []TimeUnit{UnixMicro, UnixNano} {
+		lowest := unitTime(unit, math.MinInt64)
+		for _, part := range parts {
+			dst := make([]int64, len(src))
+			DateTrunc(dst, src, unit, part)
+			for i, v := range src {
+				ref := timeReference(unitTime(unit, v), part)
+				want := int64(math.MinInt64)
+				if !ref.Before(lowest) {
+					want = unit.FromTime(ref)
+				}
+				if dst[i] != want {
+					t.Errorf("unit=%d %v(%d) = %d, want %d", unit, part, v, dst[i], want)
+				}
+			}
+		}
+	}
+}
+
+// randomTimestamps returns values over the whole int64 range, the ends of the
+// range and values next to multiples of every fixed width, where a quotient
+// estimate that is off by one shows.
+func randomTimestamps(rng *rand.Rand, size int) []int64 {
+	values := []int64{math.MinInt64, math.MinInt64 + 1, math.MaxInt64 - 1, math.MaxInt64, 0, -1, 1}
+	for _, unit := range []TimeUnit{UnixMicro, UnixNano} {
+		perDay := unit.perDay()
+		for _, width := range []int64{perDay / 86400, perDay / 1440, perDay / 24, perDay} {
+			ks := []int64{math.MinInt64 / width, math.MaxInt64 / width}
+			for range 16 {
+				ks = append(ks, rng.Int63n(math.MaxInt64/width)-rng.Int63n(math.MaxInt64/width))
+			}
+			for _, k := range ks {
+				values = append(values, k*width-1, k*width, k*width+1)
+			}
+		}
+	}
+	for len(values) < size {
+		values = append(values, int64(rng.Uint64()))
+	}
+	rng.Shuffle(len(values), func(i, j int) { values[i], values[j] = values[j], values[i] })
+	return values
+}
+
+// The SIMD division kernels must agree with the scalar path for every value
+func TestDateTrunc_MatchesGeneric(t *testing.T) {
+	src := randomTimestamps(rand.New(rand.NewSource(3600)), 1003)
+	parts := []DatePart{DatePartSecond, DatePartMinute, DatePartHour, DatePartDay,
+		DatePartWeek, DatePartMonth, DatePartQuarter, DatePartYear}
+
+	want := make(map[TimeUnit][][]int64)
+	WithGlobalISA(ISAGeneric, func() {
+		for _, unit := range []TimeUnit{UnixMicro, UnixNano} {
+			for _, part := range parts {
+				dst := make([]int64, len(src))
+				DateTrunc(dst, src, unit, part)
+				want[unit] = append(want[unit], dst)
+			}
+		}
+	})
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				for _, unit := range []TimeUnit{UnixMicro, UnixNano} {
+					for j, part := range parts {
+						dst := make([]int64, len(src))
+						DateTrunc(dst, src, unit, part)
+						for i := range dst {
+							if dst[i] != want[unit][j][i] {
+								t.Errorf("unit=%d %v(%d) = %d, want %d", unit, part, src[i], dst[i], want[unit][j][i])
+								break
+							}
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestDateTrunc_InPlace(t *testing.T) {
+	values := []int64{UnixMicro.FromTime(time.Date(2024, 5, 17, 13, 45, 0, 0, time.UTC))}
+	DateTrunc(values, values, UnixMicro, DatePartMonth)
+
+	if want := UnixMicro.FromTime(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)); values[0] != want {
+		t.Errorf("Expected %d, got %d", want, values[0])
+	}
+}
+
+func TestDateTrunc_InvalidPart(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for DatePartDayOfWeek")
+		}
+	}()
+	DateTrunc(make([]int64, 1), make([]int64, 1), UnixMicro, DatePartDayOfWeek)
+}
+
+// ============================================================================
+// EXTRACT Tests
+// ============================================================================
+
+func TestDateExtract_MatchesTimePackage(t *testing.T) {
+	times := randomTimes(rand.New(rand.NewSource(360)), 2000)
+	fields := map[DatePart]func(time.Time) int{
+		DatePartSecond:    time.Time.Second,
+		DatePartMinute:    time.Time.Minute,
+		DatePartHour:      time.Time.Hour,
+		DatePartDay:       time.Time.Day,
+		DatePartMonth:     func(t time.Time) int { return int(t.Month()) },
+		DatePartQuarter:   func(t time.Time) int { return (int(t.Month())-1)/3 + 1 },
+		DatePartYear:      time.Time.Year,
+		DatePartDayOfWeek: func(t time.Time) int { return int(t.Weekday()) },
+		DatePartDayOfYear: time.Time.YearDay,
+	}
+
+	for _, unit := range []TimeUnit{UnixMicro, UnixNano} {
+		src := make([]int64, len(times))
+		for i, tm := range times {
+			src[i] = unit.FromTime(tm)
+		}
+		for part, field := range fields {
+			dst := make([]int32, len(src))
+			DateExtract(dst, src, unit, part)
+			for i, tm := range times {
+				if want := int32(field(tm)); dst[i] != want {
+					t.Errorf("unit=%d %v(%v) = %d, want %d", unit, part, tm, dst[i], want)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestDateExtract_Int64Bounds(t *testing.T) {
+	src := []int64{math.MinInt64, math.MinInt64 + 1, math.MaxInt64 - 1, math.MaxInt64}
+	fields := map[DatePart]func(time.Time) int{
+		DatePartSecond:    time.Time.Second,
+		DatePartMinute:    time.Time.Minute,
+		DatePartHour:      time.Time.Hour,
+		DatePartDay:       time.Time.Day,
+		DatePartYear:      time.Time.Year,
+		DatePartDayOfWeek: func(t time.Time) int { return int(t.Weekday()) },
+		DatePartDayOfYear: time.Time.YearDay,
+	}
+
+	for _, unit := range []TimeUnit{UnixMicro, UnixNano} {
+		for part, field := range fields {
+			dst := make([]int32, len(src))
+			DateExtract(dst, src, unit, part)
+			for i, v := range src {
+				if want := int32(field(unitTime(unit, v))); dst[i] != want {
+					t.Errorf("unit=%d %v(%d) = %d, want %d", unit, part, v, dst[i], want)
+				}
+			}
+		}
+	}
+}
+
+func TestDateExtract_MatchesGeneric(t *testing.T) {
+	src := randomTimestamps(rand.New(rand.NewSource(3601)), 1003)
+	parts := []DatePart{DatePartSecond, DatePartMinute, DatePartHour, DatePartDay, DatePartMonth,
+		DatePartQuarter, DatePartYear, DatePartDayOfWeek, DatePartDayOfYear}
+
+	want := make(map[TimeUnit][][]int32)
+	WithGlobalISA(ISAGeneric, func() {
+		for _, unit := range []TimeUnit{UnixMicro, UnixNano} {
+			for _, part := range parts {
+				dst := make([]int32, len(src))
+				DateExtract(dst, src, unit, part)
+				want[unit] = append(want[unit], dst)
+			}
+		}
+	})
+
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				for _, unit := range []TimeUnit{UnixMicro, UnixNano} {
+					for j, part := range parts {
+						dst := make([]int32, len(src))
+						DateExtract(dst, src, unit, part)
+						for i := range dst {
+							if dst[i] != want[unit][j][i] {
+								t.Errorf("unit=%d %v(%d) = %d, want %d", unit, part, src[i], dst[i], want[unit][j][i])
+								break
+							}
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
+// Extracted fields feed straight into the int32 comparison kernels
+func TestDateExtract_FilterWeekend(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC) // a Monday
+	src := make([]int64, 14)
+	for i := range src {
+		src[i] = UnixMicro.FromTime(start.AddDate(0, 0, i))
+	}
+
+	dow := make([]int32, len(src))
+	DateExtract(dow, src, UnixMicro, DatePartDayOfWeek)
+	weekend := OrBitmap(CmpEqInt32Mask(dow, 0), CmpEqInt32Mask(dow, 6))
+
+	if weekend[0] != 0b11000001100000 {
+		t.Errorf("Expected weekend mask %b, got %b", 0b11000001100000, weekend[0])
+	}
+}
+
+func TestDatePart_String(t *testing.T) {
+	if DatePartMonth.String() != "month" || DatePartDayOfWeek.String() != "dow" {
+		t.Errorf("Unexpected names %q, %q", DatePartMonth, DatePartDayOfWeek)
+	}
+	if got := DatePart(42).String(); got != "DatePart(42)" {
+		t.Errorf("Expected \"DatePart(42)\", got %q", got)
+	}
+}
+
+// ============================================================================
+// Range Filter Tests
+// ============================================================================
+
+func TestTimeRange(t *testing.T) {
+	base := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	values := make([]int64, 200)
+	for i := range values {
+		values[i] = UnixNano.FromTime(base.Add(time.Duration(i) * time.Hour))
+	}
+	start := UnixNano.FromTime(base.Add(24 * time.Hour))
+	end := UnixNano.FromTime(base.Add(48 * time.Hour))
+
+	expected := make([]bool, len(values))
+	for i := range expected {
+		expected[i] = i >= 24 && i < 48
+	}
+
+	checkEqual(t, len(values), "TimeRange", TimeRange(values, start, end), expected)
+	checkEqual(t, len(values), "TimeRangeMask", TimeRangeMask(values, start, end), BoolsToBitmask(expected))
+	if len(TimeRangeMask(nil, start, end)) != 0 {
+		t.Error("Expected empty result for empty input")
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+func BenchmarkDateTrunc_Day(b *testing.B) {
+	src := make([]int64, 4096)
+	for i := range src {
+		src[i] = int64(i) * 3_600_000_000
+	}
+	dst := make([]int64, len(src))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DateTrunc(dst, src, UnixMicro, DatePartDay)
+	}
+}
+
+func BenchmarkDateExtract_Year(b *testing.B) {
+	src := make([]int64, 4096)
+	for i := range src {
+		src[i] = int64(i) * 86_400_000_000
+	}
+	dst := make([]int32, len(src))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DateExtract(dst, src, UnixMicro, DatePartYear)
+	}
+}
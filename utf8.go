@@ -0,0 +1,53 @@
+package syndrdbsimd
+
+// ValidUTF8 reports whether s is valid UTF-8, as utf8.Valid does: no overlong
+// encodings, surrogates, code points past U+10FFFF or truncated sequences.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (32 bytes per operation)
+//   - NEON on ARM64 processors (16 bytes per operation)
+//   - Scalar fallback on other architectures
+//
+// The SIMD kernels use the lookup-table validation of Keiser and Lemire: three
+// nibble lookups per byte classify it against the byte before it, and all
+// blocks are checked before a single test for errors. Blocks of ASCII after
+// ASCII skip the lookups on AVX2.
+func ValidUTF8(s []byte) bool {
+	return utf8ValidImpl(currentKernels(), s)
+}
+
+// ValidUTF8Strings returns a bitmask of the values that are valid UTF-8, as
+// ValidUTF8 decides, so rows to reject on ingest are the zero bits.
+func ValidUTF8Strings(values []string) []uint64 {
+	mask := make([]uint64, (len(values)+63)/64)
+	for i, v := range values {
+		if utf8ValidImpl(currentKernels(), stringToBytes(v)) {
+			mask[i/64] |= 1 << uint(i%64)
+		}
+	}
+	return mask
+}
+
+// CharLength returns the number of characters of the UTF-8 text s, SQL's
+// CHAR_LENGTH, where StrLen returns the number of bytes. It counts the bytes
+// that are not continuation bytes (0x80..0xBF), which for valid UTF-8 is
+// utf8.RuneCount(s). Invalid input should be rejected with ValidUTF8 first:
+// for it the count may differ from utf8.RuneCount, which counts each invalid
+// byte as a rune.
+//
+// This function automatically selects the best implementation:
+//   - AVX2 on x86-64 processors (32 bytes per operation)
+//   - NEON on ARM64 processors (16 bytes per operation)
+//   - Scalar fallback on other architectures
+func CharLength(s []byte) int {
+	return charLengthImpl(currentKernels(), s)
+}
+
+// CharLengthStrings returns the CharLength of each value.
+func CharLengthStrings(values []string) []int64 {
+	results := make([]int64, len(values))
+	for i, v := range values {
+		results[i] = int64(charLengthImpl(currentKernels(), stringToBytes(v)))
+	}
+	return results
+}
@@ -0,0 +1,176 @@
+package syndrdbsimd
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// ============================================================================
+// UTF-8 Validation Tests
+// ============================================================================
+
+func TestValidUTF8(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"empty", "", true},
+		{"ASCII", "hello", true},
+		{"2 to 4 bytes", "ä € 😀", true},
+		{"max code point", "\U0010FFFF", true},
+		{"stray continuation", "a\x80", false},
+		{"truncated 2 bytes", "\xc3", false},
+		{"truncated 4 bytes", "\xf0\x9f\x98", false},
+		{"lead after lead", "\xc3\xc3\xa4", false},
+		{"overlong 2 bytes", "\xc0\xaf", false},
+		{"overlong 3 bytes", "\xe0\x80\xaf", false},
+		{"overlong 4 bytes", "\xf0\x80\x80\xaf", false},
+		{"surrogate", "\xed\xa0\x80", false},
+		{"past U+10FFFF", "\xf4\x90\x80\x80", false},
+		{"F5 lead", "\xf5\x80\x80\x80", false},
+		{"FF", "\xff", false},
+		{"too many continuations", "\xe2\x82\xac\x80", false},
+	}
+
+	for _, tt := range tests {
+		// Also place each case where SIMD blocks split it, and after long runs
+		for _, pad := range []int{0, 13, 29, 31, 62, 100} {
+			s := []byte(strings.Repeat("x", pad) + tt.input + strings.Repeat("y", 40))
+			if got := ValidUTF8(s); got != tt.expected {
+				t.Errorf("%s at %d: ValidUTF8(%q) = %v, want %v", tt.name, pad, s, got, tt.expected)
+			}
+		}
+		if got := ValidUTF8([]byte(tt.input)); got != tt.expected {
+			t.Errorf("%s: ValidUTF8(%q) = %v, want %v", tt.name, tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestUTF8Strings(t *testing.T) {
+	values := []string{"Köln", "", "caf\xe9", "naïve café", strings.Repeat("€", 40), "\xed\xa0\x80"}
+
+	checkEqual(t, len(values), "ValidUTF8Strings", ValidUTF8Strings(values), []uint64{0b01_1011})
+	checkEqual(t, len(values), "CharLengthStrings", CharLengthStrings(values), []int64{4, 0, 4, 10, 40, 1})
+
+	if got := CharLength([]byte("Straße")); got != 6 {
+		t.Errorf("CharLength(%q) = %d, want 6", "Straße", got)
+	}
+}
+
+// utf8TestBytes returns n bytes mixing valid characters of every length with,
+// if corrupt is set, bytes that break them.
+func utf8TestBytes(rng *rand.Rand, n int, corrupt bool) []byte {
+	pieces := []string{"a", "z", " ", "ä", "ß", "€", "한", "😀", "\U0010FFFF", "߿", "ࠀ", "￿", "\U00010000"}
+	var b []byte
+	for len(b) < n {
+		b = append(b, pieces[rng.Intn(len(pieces))]...)
+	}
+	b = b[:n]
+	if corrupt && n > 0 {
+		for k := 1 + rng.Intn(2); k > 0; k-- {
+			b[rng.Intn(n)] = []byte{0x80, 0xbf, 0xc0, 0xc2, 0xe0, 0xed, 0xf0, 0xf4, 0xf5, 0xff, 'a'}[rng.Intn(11)]
+		}
+	}
+	return b
+}
+
+// The kernels must agree with utf8.Valid and utf8.RuneCount for errors and
+// sequences anywhere in or across blocks, and in the scalar tail
+func TestUTF8_MatchUnicodeUTF8(t *testing.T) {
+	for _, isa := range []ISA{ISAGeneric, ISASSE42, ISAAVX2, ISAAVX512, ISANEON, ISASVE} {
+		if !isaSupported(isa) {
+			continue
+		}
+		t.Run(isa.String(), func(t *testing.T) {
+			WithGlobalISA(isa, func() {
+				rng := rand.New(rand.NewSource(500))
+				for trial := 0; trial < 5000; trial++ {
+					s := utf8TestBytes(rng, rng.Intn(150), rng.Intn(2) == 0)
+					if got, want := ValidUTF8(s), utf8.Valid(s); got != want {
+						t.Fatalf("ValidUTF8(%x) = %v, want %v", s, got, want)
+					}
+					if got, want := CharLength(s), charLengthGeneric(s); got != want {
+						t.Fatalf("CharLength(%x) = %d, want %d", s, got, want)
+					}
+					if utf8.Valid(s) && CharLength(s) != utf8.RuneCount(s) {
+						t.Fatalf("CharLength(%x) = %d, want %d", s, CharLength(s), utf8.RuneCount(s))
+					}
+				}
+
+				// Every pair of bytes after a lead byte, at each offset
+				// around the first block boundary
+				buf := make([]byte, 72)
+				for lead := 0xc0; lead <= 0xff; lead += 1 + rng.Intn(3) {
+					for b1 := 0x70; b1 <= 0xd0; b1++ {
+						b2 := []byte{0x80, 0xa0, 0xbf, 'a'}[rng.Intn(4)]
+						for off := 26; off < 36; off++ {
+							for i := range buf {
+								buf[i] = 'x'
+							}
+							buf[off], buf[off+1], buf[off+2], buf[off+3] = byte(lead), byte(b1), b2, 0x80
+							for _, s := range [][]byte{buf, buf[:off+1], buf[:off+2], buf[:off+4]} {
+								if got, want := ValidUTF8(s), utf8.Valid(s); got != want {
+									t.Fatalf("ValidUTF8(%x) = %v, want %v", s, got, want)
+								}
+							}
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+// utf8BenchText returns 64 KiB of text that is mostly ASCII, with accented
+// words and the occasional symbol.
+func utf8BenchText() []byte {
+	line := "Müller & Söhne GmbH, Köln: Lieferung über 200 € am Straßenrand. "
+	return []byte(strings.Repeat(line, 65536/len(line)))
+}
+
+func BenchmarkValidUTF8(b *testing.B) {
+	s := utf8BenchText()
+	b.SetBytes(int64(len(s)))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ValidUTF8(s)
+	}
+}
+
+func BenchmarkUTF8Valid(b *testing.B) {
+	s := utf8BenchText()
+	b.SetBytes(int64(len(s)))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		utf8.Valid(s)
+	}
+}
+
+func BenchmarkCharLength(b *testing.B) {
+	s := utf8BenchText()
+	b.SetBytes(int64(len(s)))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CharLength(s)
+	}
+}
+
+func BenchmarkUTF8RuneCount(b *testing.B) {
+	s := utf8BenchText()
+	b.SetBytes(int64(len(s)))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		utf8.RuneCount(s)
+	}
+}